@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"gio-redux-example/counterstore"
+)
+
+// defaultAutoIncrementEvery is how often the demo bot increments unless
+// -auto-increment-every says otherwise
+const defaultAutoIncrementEvery = 500 * time.Millisecond
+
+// StartAutoIncrement starts the demo bot incrementing the count every
+// interval on the view model's clock, replacing a bot already running. The
+// bot is one of the store's tasks while it runs.
+func (v *ViewModel) StartAutoIncrement(every time.Duration) {
+	v.StopAutoIncrement()
+	bot := counterstore.NewBurst(v.clock, every, func() {
+		v.store.Dispatch(counterstore.IncrementAction{})
+	})
+	_, done := v.store.RegisterTask("demo bot", func() { v.stopBot(bot) })
+	v.mu.Lock()
+	v.autoIncrement = bot
+	v.autoIncrementDone = done
+	v.mu.Unlock()
+
+	bot.Start()
+}
+
+// StopAutoIncrement stops the demo bot; once it returns the bot dispatches
+// nothing further beyond an increment already under way
+func (v *ViewModel) StopAutoIncrement() {
+	v.mu.Lock()
+	bot := v.autoIncrement
+	v.mu.Unlock()
+	if bot != nil {
+		v.stopBot(bot)
+	}
+}
+
+// stopBot stops bot, and forgets it if it is still the current one
+func (v *ViewModel) stopBot(bot *counterstore.Burst) {
+	bot.Close()
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.autoIncrement == bot {
+		v.autoIncrement = nil
+		v.autoIncrementDone()
+		v.autoIncrementDone = nil
+	}
+}
+
+// TaskCount is the number of tasks registered with the store
+func (v *ViewModel) TaskCount() int {
+	return len(v.store.Tasks())
+}
+
+// StopEverything cancels all the work registered with the store: the demo
+// bot, a running countdown or stopwatch and debounced actions
+func (v *ViewModel) StopEverything() {
+	v.store.CancelAllTasks()
+}
+
+// AutoIncrementing reports whether the demo bot is running
+func (v *ViewModel) AutoIncrementing() bool {
+	v.mu.Lock()
+	bot := v.autoIncrement
+	v.mu.Unlock()
+	return bot != nil && bot.Running()
+}
+
+// AutoIncrementPanel has the buttons starting and stopping the demo bot and
+// cancelling all background work
+type AutoIncrementPanel struct {
+	theme        *material.Theme
+	toggleButton widget.Clickable
+	stopButton   widget.Clickable
+}
+
+func (p *AutoIncrementPanel) Layout(gtx layout.Context, vm *ViewModel) layout.Dimensions {
+	if p.toggleButton.Clicked(gtx) {
+		if vm.AutoIncrementing() {
+			vm.StopAutoIncrement()
+		} else {
+			vm.StartAutoIncrement(vm.autoIncrementEvery)
+		}
+	}
+	if p.stopButton.Clicked(gtx) {
+		vm.StopEverything()
+	}
+	label := "Start demo bot"
+	if vm.AutoIncrementing() {
+		label = "Stop demo bot"
+	}
+
+	return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+		layout.Rigid(material.Body1(p.theme, fmt.Sprintf("+1 every %v", vm.autoIncrementEvery)).Layout),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(6)}.Layout),
+		layout.Rigid(material.Button(p.theme, &p.toggleButton, label).Layout),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(6)}.Layout),
+		layout.Rigid(material.Button(p.theme, &p.stopButton, fmt.Sprintf("Stop everything (%d)", vm.TaskCount())).Layout),
+	)
+}
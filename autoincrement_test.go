@@ -0,0 +1,60 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"gio-redux-example/counterstore"
+)
+
+func TestAutoIncrement(t *testing.T) {
+	vm, store := newTestViewModel(counterstore.State{})
+	clock := counterstore.NewFakeClock(time.Unix(0, 0))
+	vm.clock = clock
+
+	vm.StartAutoIncrement(100 * time.Millisecond)
+	if !vm.AutoIncrementing() || vm.TaskCount() != 1 {
+		t.Fatalf("bot running %v with %d tasks, want running as one task", vm.AutoIncrementing(), vm.TaskCount())
+	}
+	clock.Advance(99 * time.Millisecond)
+	if got := store.GetState().Count; got != 0 {
+		t.Fatalf("Count = %d before the first interval", got)
+	}
+	clock.Advance(time.Second - 99*time.Millisecond)
+	if got := store.GetState().Count; got != 10 {
+		t.Errorf("Count = %d after a second at 100ms, want 10", got)
+	}
+
+	vm.StopAutoIncrement()
+	clock.Advance(time.Second)
+	if got := store.GetState().Count; got != 10 {
+		t.Errorf("Count = %d after stopping, want 10", got)
+	}
+	if vm.AutoIncrementing() || vm.TaskCount() != 0 || clock.Pending() != 0 {
+		t.Errorf("after stopping: running %v, %d tasks, %d timers pending", vm.AutoIncrementing(), vm.TaskCount(), clock.Pending())
+	}
+	vm.StopAutoIncrement()
+}
+
+func TestAutoIncrementRestartAndStopEverything(t *testing.T) {
+	vm, store := newTestViewModel(counterstore.State{})
+	clock := counterstore.NewFakeClock(time.Unix(0, 0))
+	vm.clock = clock
+
+	// Starting again replaces the running bot
+	vm.StartAutoIncrement(100 * time.Millisecond)
+	vm.StartAutoIncrement(250 * time.Millisecond)
+	clock.Advance(time.Second)
+	if got := store.GetState().Count; got != 4 {
+		t.Errorf("Count = %d after a second at 250ms, want 4", got)
+	}
+	if vm.TaskCount() != 1 {
+		t.Errorf("%d tasks after restarting the bot, want 1", vm.TaskCount())
+	}
+
+	vm.StopEverything()
+	clock.Advance(time.Second)
+	if got := store.GetState().Count; got != 4 || vm.AutoIncrementing() || vm.TaskCount() != 0 {
+		t.Errorf("after stopping everything: Count %d, running %v, %d tasks", got, vm.AutoIncrementing(), vm.TaskCount())
+	}
+}
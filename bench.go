@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"gio-redux-example/counterstore"
+)
+
+// benchSubscribers is the number of listeners each -bench run attaches,
+// roughly what the app itself subscribes
+const benchSubscribers = 4
+
+// runBenchmarks dispatches n actions through a bare store and through the
+// logging and profiling stacks, printing each one's throughput. The logging
+// run writes to io.Discard so it measures formatting, not the terminal.
+func runBenchmarks(n int) {
+	logOutput := log.Writer()
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(logOutput)
+
+	configs := []counterstore.BenchmarkConfig{
+		{Name: "bare"},
+		{Name: "logging", Middleware: []counterstore.Middleware[counterstore.State, counterstore.AppAction]{
+			counterstore.LoggingMiddleware[counterstore.State, counterstore.AppAction],
+		}},
+		{Name: "profiling", Middleware: []counterstore.Middleware[counterstore.State, counterstore.AppAction]{
+			counterstore.ProfilerMiddleware[counterstore.State, counterstore.AppAction](counterstore.NewProfiler(nil)),
+		}},
+		{Name: "logging+profiling", Middleware: []counterstore.Middleware[counterstore.State, counterstore.AppAction]{
+			counterstore.LoggingMiddleware[counterstore.State, counterstore.AppAction],
+			counterstore.ProfilerMiddleware[counterstore.State, counterstore.AppAction](counterstore.NewProfiler(nil)),
+		}},
+	}
+	for _, cfg := range configs {
+		cfg.Subscribers = benchSubscribers
+		cfg.Actions = n
+		fmt.Fprintln(os.Stdout, counterstore.Benchmark(cfg))
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+)
+
+// syncDecision is whether a CountBinding should copy the state into its editor
+type syncDecision int
+
+const (
+	syncSkip syncDecision = iota
+	syncUpdate
+)
+
+// decideSync decides whether the editor should be overwritten with
+// stateText. lastSynced is the text the binding last put in the editor;
+// while the editor is focused and its text differs from that, the user is
+// typing and an external update must not clobber the edit.
+func decideSync(focused bool, editorText, lastSynced, stateText string) syncDecision {
+	switch {
+	case editorText == stateText:
+		return syncSkip
+	case focused && editorText != lastSynced:
+		return syncSkip
+	}
+	return syncUpdate
+}
+
+// CountBinding binds an editor to the count both ways: state changes show up
+// in the editor unless the user is mid-edit, and an edit is dispatched as a
+// SetCountAction when submitted or when the editor loses focus. Text that is
+// not a number is discarded in favour of the current count.
+type CountBinding struct {
+	theme      *material.Theme
+	editor     widget.Editor
+	lastSynced string
+	focused    bool
+}
+
+func NewCountBinding(theme *material.Theme) *CountBinding {
+	return &CountBinding{
+		theme:  theme,
+		editor: widget.Editor{SingleLine: true, Submit: true, Filter: "-0123456789"},
+	}
+}
+
+func (b *CountBinding) Layout(gtx layout.Context, vm *ViewModel) layout.Dimensions {
+	submitted := false
+	for {
+		e, ok := b.editor.Update(gtx)
+		if !ok {
+			break
+		}
+		if _, ok := e.(widget.SubmitEvent); ok {
+			submitted = true
+		}
+	}
+
+	focused := gtx.Focused(&b.editor)
+	if submitted || (b.focused && !focused) {
+		b.commit(vm)
+	}
+	b.focused = focused
+
+	stateText := vm.CountLabel()
+	if decideSync(focused, b.editor.Text(), b.lastSynced, stateText) == syncUpdate {
+		b.editor.SetText(stateText)
+		b.lastSynced = stateText
+	}
+
+	return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+		layout.Rigid(material.Body2(b.theme, "Count:").Layout),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(8)}.Layout),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			gtx.Constraints.Min.X = gtx.Dp(unit.Dp(80))
+			gtx.Constraints.Max.X = gtx.Constraints.Min.X
+			return material.Editor(b.theme, &b.editor, "").Layout(gtx)
+		}),
+	)
+}
+
+// commit dispatches the edited text, or drops it when it does not parse so
+// the next sync restores the count
+func (b *CountBinding) commit(vm *ViewModel) {
+	text := b.editor.Text()
+	if text == b.lastSynced {
+		return
+	}
+	if value, err := parseCountInput(text); err == nil {
+		vm.SetCount(value)
+	}
+	b.lastSynced = ""
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"testing"
+
+	"gioui.org/widget/material"
+
+	"gio-redux-example/counterstore"
+)
+
+func TestDecideSync(t *testing.T) {
+	tests := []struct {
+		name                              string
+		focused                           bool
+		editorText, lastSynced, stateText string
+		want                              syncDecision
+	}{
+		{"in sync", false, "5", "5", "5", syncSkip},
+		{"in sync while focused", true, "5", "5", "5", syncSkip},
+		{"external change, unfocused", false, "5", "5", "6", syncUpdate},
+		{"external change, focused but untouched", true, "5", "5", "6", syncUpdate},
+		{"external change while typing", true, "12", "5", "6", syncSkip},
+		{"unfocused with a stale edit", false, "12", "5", "6", syncUpdate},
+		{"first sync", false, "", "", "0", syncUpdate},
+		{"typed the new value already", true, "6", "5", "6", syncSkip},
+	}
+	for _, tt := range tests {
+		if got := decideSync(tt.focused, tt.editorText, tt.lastSynced, tt.stateText); got != tt.want {
+			t.Errorf("%s: %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCountBindingCommit(t *testing.T) {
+	vm, store := newTestViewModel(counterstore.State{Count: 5})
+	b := NewCountBinding(material.NewTheme())
+	b.editor.SetText("5")
+	b.lastSynced = "5"
+
+	// Unchanged text dispatches nothing
+	b.commit(vm)
+	if store.HistoryLen() != 0 {
+		t.Fatal("committing the synced text dispatched")
+	}
+
+	b.editor.SetText("-42")
+	b.commit(vm)
+	if got := store.GetState().Count; got != -42 {
+		t.Errorf("Count after commit = %d, want -42", got)
+	}
+	if b.lastSynced != "" {
+		t.Errorf("lastSynced = %q after commit, want it cleared for the next sync", b.lastSynced)
+	}
+
+	b.editor.SetText("-")
+	b.lastSynced = "-42"
+	b.commit(vm)
+	if got := store.GetState().Count; got != -42 {
+		t.Errorf("Count after committing %q = %d, want it unchanged", "-", got)
+	}
+	if got := decideSync(false, b.editor.Text(), b.lastSynced, vm.CountLabel()); got != syncUpdate {
+		t.Error("an invalid edit is not replaced by the count on the next sync")
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"gio-redux-example/counterstore"
+)
+
+// Bookmarks returns the saved bookmark names in order
+func (v *ViewModel) Bookmarks() []string {
+	bookmarks := v.store.GetStateFast().Bookmarks
+	names := make([]string, 0, len(bookmarks))
+	for name := range bookmarks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Bookmark returns the count saved under name
+func (v *ViewModel) Bookmark(name string) (int, bool) {
+	count, ok := v.store.GetStateFast().Bookmarks[name]
+	return count, ok
+}
+
+// SaveBookmark saves the current count under name
+func (v *ViewModel) SaveBookmark(name string) error {
+	return v.store.Dispatch(counterstore.SaveBookmarkAction{Name: name})
+}
+
+// GotoBookmark sets the count to the one saved under name
+func (v *ViewModel) GotoBookmark(name string) error {
+	return v.store.Dispatch(counterstore.GotoBookmarkAction{Name: name})
+}
+
+// BookmarkPanel saves the count under a typed name and lists the saved
+// bookmarks as buttons jumping back to them
+type BookmarkPanel struct {
+	theme      *material.Theme
+	name       widget.Editor
+	saveButton widget.Clickable
+	jump       map[string]*widget.Clickable
+}
+
+func NewBookmarkPanel(theme *material.Theme) *BookmarkPanel {
+	return &BookmarkPanel{
+		theme: theme,
+		name:  widget.Editor{SingleLine: true, Submit: true},
+		jump:  map[string]*widget.Clickable{},
+	}
+}
+
+func (p *BookmarkPanel) Layout(gtx layout.Context, vm *ViewModel) layout.Dimensions {
+	save := p.saveButton.Clicked(gtx)
+	for {
+		ev, ok := p.name.Update(gtx)
+		if !ok {
+			break
+		}
+		if _, ok := ev.(widget.SubmitEvent); ok {
+			save = true
+		}
+	}
+	if save {
+		if err := vm.SaveBookmark(strings.TrimSpace(p.name.Text())); err == nil {
+			p.name.SetText("")
+		}
+	}
+
+	names := vm.Bookmarks()
+	children := []layout.FlexChild{
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			gtx.Constraints.Min.X = gtx.Dp(unit.Dp(80))
+			gtx.Constraints.Max.X = gtx.Constraints.Min.X
+			return material.Editor(p.theme, &p.name, "Bookmark").Layout(gtx)
+		}),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(6)}.Layout),
+		layout.Rigid(material.Button(p.theme, &p.saveButton, "Save").Layout),
+	}
+	for _, name := range names {
+		button, ok := p.jump[name]
+		if !ok {
+			button = new(widget.Clickable)
+			p.jump[name] = button
+		}
+		if button.Clicked(gtx) {
+			vm.GotoBookmark(name)
+		}
+		count, _ := vm.Bookmark(name)
+		children = append(children,
+			layout.Rigid(layout.Spacer{Width: unit.Dp(6)}.Layout),
+			layout.Rigid(material.Button(p.theme, button, fmt.Sprintf("%s (%d)", name, count)).Layout),
+		)
+	}
+	return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx, children...)
+}
@@ -0,0 +1,20 @@
+package main
+
+import "time"
+
+// burstInterval is how often holding space increments the count
+const burstInterval = 20 * time.Millisecond
+
+// StartBurst starts incrementing the count rapidly, until StopBurst
+func (v *ViewModel) StartBurst() {
+	if v.burst != nil {
+		v.burst.Start()
+	}
+}
+
+// StopBurst stops a burst started by StartBurst
+func (v *ViewModel) StopBurst() {
+	if v.burst != nil {
+		v.burst.Stop()
+	}
+}
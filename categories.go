@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"gio-redux-example/counterstore"
+)
+
+// Categories returns the category names in order
+func (v *ViewModel) Categories() []string {
+	categories := v.store.GetStateFast().Categories
+	names := make([]string, 0, len(categories))
+	for name := range categories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Category returns the count of the category name
+func (v *ViewModel) Category(name string) int {
+	return v.store.GetStateFast().Categories[name]
+}
+
+// CategoryTotal returns the sum of all categories
+func (v *ViewModel) CategoryTotal() int {
+	return v.store.GetStateFast().Total()
+}
+
+// AddCategory creates the category name at 0
+func (v *ViewModel) AddCategory(name string) error {
+	return v.store.Dispatch(counterstore.AddCategoryAction{Name: name})
+}
+
+// StepCategory increments the category name for a positive delta and
+// decrements it otherwise
+func (v *ViewModel) StepCategory(name string, delta int) {
+	if delta > 0 {
+		v.store.Dispatch(counterstore.IncCategoryAction{Name: name})
+		return
+	}
+	v.store.Dispatch(counterstore.DecCategoryAction{Name: name})
+}
+
+// categoryButtons are the +/- buttons of one category row
+type categoryButtons struct {
+	inc, dec widget.Clickable
+}
+
+// CategoryPanel lists the categories, each with +/- buttons, under a field
+// adding a new one, and ends with their total
+type CategoryPanel struct {
+	theme     *material.Theme
+	name      widget.Editor
+	addButton widget.Clickable
+	rows      map[string]*categoryButtons
+}
+
+func NewCategoryPanel(theme *material.Theme) *CategoryPanel {
+	return &CategoryPanel{
+		theme: theme,
+		name:  widget.Editor{SingleLine: true, Submit: true},
+		rows:  map[string]*categoryButtons{},
+	}
+}
+
+func (p *CategoryPanel) Layout(gtx layout.Context, vm *ViewModel) layout.Dimensions {
+	add := p.addButton.Clicked(gtx)
+	for {
+		ev, ok := p.name.Update(gtx)
+		if !ok {
+			break
+		}
+		if _, ok := ev.(widget.SubmitEvent); ok {
+			add = true
+		}
+	}
+	if add {
+		if err := vm.AddCategory(strings.TrimSpace(p.name.Text())); err == nil {
+			p.name.SetText("")
+		}
+	}
+
+	children := []layout.FlexChild{
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+					gtx.Constraints.Min.X = gtx.Dp(unit.Dp(80))
+					gtx.Constraints.Max.X = gtx.Constraints.Min.X
+					return material.Editor(p.theme, &p.name, "Category").Layout(gtx)
+				}),
+				layout.Rigid(layout.Spacer{Width: unit.Dp(6)}.Layout),
+				layout.Rigid(material.Button(p.theme, &p.addButton, "Add").Layout),
+			)
+		}),
+	}
+	for _, name := range vm.Categories() {
+		children = append(children, layout.Rigid(p.row(vm, name)))
+	}
+	children = append(children, layout.Rigid(
+		material.Body1(p.theme, fmt.Sprintf("Total: %d", vm.CategoryTotal())).Layout,
+	))
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+}
+
+// row lays out the category name with its count and +/- buttons
+func (p *CategoryPanel) row(vm *ViewModel, name string) layout.Widget {
+	buttons, ok := p.rows[name]
+	if !ok {
+		buttons = new(categoryButtons)
+		p.rows[name] = buttons
+	}
+	return func(gtx layout.Context) layout.Dimensions {
+		if buttons.inc.Clicked(gtx) {
+			vm.StepCategory(name, 1)
+		}
+		if buttons.dec.Clicked(gtx) {
+			vm.StepCategory(name, -1)
+		}
+		return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+			layout.Rigid(material.Body1(p.theme, fmt.Sprintf("%s: %d", name, vm.Category(name))).Layout),
+			layout.Rigid(layout.Spacer{Width: unit.Dp(6)}.Layout),
+			layout.Rigid(material.Button(p.theme, &buttons.inc, "+").Layout),
+			layout.Rigid(layout.Spacer{Width: unit.Dp(6)}.Layout),
+			layout.Rigid(material.Button(p.theme, &buttons.dec, "−").Layout),
+		)
+	}
+}
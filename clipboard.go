@@ -0,0 +1,128 @@
+package main
+
+import (
+	"io"
+	"strings"
+	"time"
+
+	"gioui.org/io/clipboard"
+	"gioui.org/io/transfer"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+)
+
+// copiedFeedback is how long the Copy button reads "Copied" after a click
+const copiedFeedback = 1500 * time.Millisecond
+
+// copyButtonLabel returns the Copy button's label at now, given when the
+// "Copied" feedback expires
+func copyButtonLabel(now, copiedUntil time.Time) string {
+	if now.Before(copiedUntil) {
+		return "Copied"
+	}
+	return "Copy"
+}
+
+// PasteMachine tracks a clipboard read. Gio answers a read request in a
+// later frame, so the request and its result are separate transitions:
+//
+//	idle --Request--> pending --Receive(valid)--> idle
+//	pending --Receive(invalid)--> idle (with Err set)
+type PasteMachine struct {
+	Pending bool
+	Err     string
+}
+
+// Request starts a read; it returns false if one is already pending
+func (m *PasteMachine) Request() bool {
+	if m.Pending {
+		return false
+	}
+	m.Pending = true
+	m.Err = ""
+	return true
+}
+
+// Receive completes the pending read with the clipboard text, returning the
+// parsed count on success. Text arriving with no read pending is ignored.
+func (m *PasteMachine) Receive(text string) (int, bool) {
+	if !m.Pending {
+		return 0, false
+	}
+	m.Pending = false
+	value, err := parseCountInput(text)
+	if err != nil {
+		m.Err = "clipboard: " + err.Error()
+		return 0, false
+	}
+	return value, true
+}
+
+// ClipboardButtons is the View component copying the count to and pasting
+// it from the system clipboard. Gio queues clipboard writes as commands and
+// reports nothing back, so the command being executed is treated as success.
+type ClipboardButtons struct {
+	theme       *material.Theme
+	copyButton  widget.Clickable
+	pasteButton widget.Clickable
+	copiedUntil time.Time
+	paste       PasteMachine
+}
+
+func (c *ClipboardButtons) Layout(gtx layout.Context, vm *ViewModel) layout.Dimensions {
+	if c.copyButton.Clicked(gtx) {
+		gtx.Execute(clipboard.WriteCmd{
+			Type: "application/text",
+			Data: io.NopCloser(strings.NewReader(vm.ClipboardText())),
+		})
+		c.copiedUntil = gtx.Now.Add(copiedFeedback)
+	}
+	if gtx.Now.Before(c.copiedUntil) {
+		gtx.Execute(op.InvalidateCmd{At: c.copiedUntil})
+	}
+
+	if c.pasteButton.Clicked(gtx) && c.paste.Request() {
+		gtx.Execute(clipboard.ReadCmd{Tag: &c.paste})
+	}
+	for {
+		e, ok := gtx.Event(transfer.TargetFilter{Target: &c.paste, Type: "application/text"})
+		if !ok {
+			break
+		}
+		if de, ok := e.(transfer.DataEvent); ok {
+			if value, ok := c.paste.Receive(readClipboard(de)); ok {
+				vm.SetCount(value)
+			}
+		}
+	}
+
+	return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+		layout.Rigid(material.Button(c.theme, &c.copyButton, copyButtonLabel(gtx.Now, c.copiedUntil)).Layout),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(8)}.Layout),
+		layout.Rigid(material.Button(c.theme, &c.pasteButton, "Paste").Layout),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(8)}.Layout),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if c.paste.Err == "" {
+				return layout.Dimensions{}
+			}
+			lbl := material.Body2(c.theme, c.paste.Err)
+			lbl.Color = vm.ErrorColor()
+			return lbl.Layout(gtx)
+		}),
+	)
+}
+
+// readClipboard returns the text carried by a clipboard data event, or ""
+// if it cannot be read, which then fails to parse like any other bad paste
+func readClipboard(e transfer.DataEvent) string {
+	r := e.Open()
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"gio-redux-example/counterstore"
+)
+
+func TestClipboardText(t *testing.T) {
+	vm, store := newTestViewModel(counterstore.State{Count: -12})
+	if got := vm.ClipboardText(); got != "-12" {
+		t.Errorf("ClipboardText = %q, want -12", got)
+	}
+	store.Dispatch(counterstore.IncrementAction{})
+	if got := vm.ClipboardText(); got != vm.CountLabel() {
+		t.Errorf("ClipboardText = %q, want the count label %q", got, vm.CountLabel())
+	}
+}
+
+func TestCopyButtonLabel(t *testing.T) {
+	now := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	copiedUntil := now.Add(copiedFeedback)
+	tests := []struct {
+		at   time.Time
+		want string
+	}{
+		{now, "Copied"},
+		{copiedUntil.Add(-time.Millisecond), "Copied"},
+		{copiedUntil, "Copy"},
+	}
+	for _, tt := range tests {
+		if got := copyButtonLabel(tt.at, copiedUntil); got != tt.want {
+			t.Errorf("label at %v = %q, want %q", tt.at.Sub(now), got, tt.want)
+		}
+	}
+	if got := copyButtonLabel(now, time.Time{}); got != "Copy" {
+		t.Errorf("label before any copy = %q, want Copy", got)
+	}
+}
+
+func TestPasteMachine(t *testing.T) {
+	var m PasteMachine
+	if _, ok := m.Receive("5"); ok {
+		t.Fatal("Receive with no read pending succeeded")
+	}
+	if !m.Request() {
+		t.Fatal("Request from idle failed")
+	}
+	if m.Request() {
+		t.Error("second Request while pending succeeded")
+	}
+
+	value, ok := m.Receive(" 17\n")
+	if !ok || value != 17 {
+		t.Fatalf("Receive = %d, %v; want 17, true", value, ok)
+	}
+	if m.Pending || m.Err != "" {
+		t.Errorf("after a valid read: %+v", m)
+	}
+}
+
+func TestPasteMachineInvalidContent(t *testing.T) {
+	var m PasteMachine
+	m.Request()
+	if _, ok := m.Receive("twelve"); ok {
+		t.Fatal("Receive of non-number succeeded")
+	}
+	if m.Pending || m.Err != "clipboard: "+errNotANumber.Error() {
+		t.Fatalf("after an invalid read: %+v", m)
+	}
+
+	// A new request clears the error
+	m.Request()
+	if m.Err != "" {
+		t.Errorf("Err = %q after a new Request", m.Err)
+	}
+}
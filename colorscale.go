@@ -0,0 +1,33 @@
+package main
+
+import (
+	"image/color"
+	"math"
+)
+
+// ColorRange is the span of counts the label's colour scale covers
+type ColorRange struct {
+	Min, Max int
+}
+
+// defaultColorRange is the colour scale used unless configured otherwise
+var defaultColorRange = ColorRange{Min: -100, Max: 100}
+
+// scaleColor maps count onto the gradient from p.Cold at r.Min to p.Hot at
+// r.Max. Counts outside the range are clamped to its ends; an empty or
+// inverted range paints everything at its midpoint.
+func scaleColor(count int, r ColorRange, p palette) color.NRGBA {
+	if r.Max <= r.Min {
+		return lerpColor(p.Cold, p.Hot, 0.5)
+	}
+	t := (float64(count) - float64(r.Min)) / (float64(r.Max) - float64(r.Min))
+	return lerpColor(p.Cold, p.Hot, math.Max(0, math.Min(1, t)))
+}
+
+// lerpColor interpolates each channel from a at t=0 to b at t=1
+func lerpColor(a, b color.NRGBA, t float64) color.NRGBA {
+	mix := func(x, y uint8) uint8 {
+		return uint8(math.Round(float64(x) + (float64(y)-float64(x))*t))
+	}
+	return color.NRGBA{R: mix(a.R, b.R), G: mix(a.G, b.G), B: mix(a.B, b.B), A: mix(a.A, b.A)}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"image/color"
+	"testing"
+
+	"gio-redux-example/counterstore"
+)
+
+var testPalette = palette{
+	Cold: color.NRGBA{R: 0, G: 100, B: 200, A: 255},
+	Hot:  color.NRGBA{R: 200, G: 0, B: 100, A: 255},
+}
+
+func TestLerpColor(t *testing.T) {
+	tests := []struct {
+		t    float64
+		want color.NRGBA
+	}{
+		{0, testPalette.Cold},
+		{0.5, color.NRGBA{R: 100, G: 50, B: 150, A: 255}},
+		{1, testPalette.Hot},
+	}
+	for _, tt := range tests {
+		if got := lerpColor(testPalette.Cold, testPalette.Hot, tt.t); got != tt.want {
+			t.Errorf("lerpColor(t=%v) = %v, want %v", tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestScaleColor(t *testing.T) {
+	mid := color.NRGBA{R: 100, G: 50, B: 150, A: 255}
+	r := ColorRange{Min: -10, Max: 10}
+	tests := []struct {
+		name  string
+		count int
+		r     ColorRange
+		want  color.NRGBA
+	}{
+		{"min", -10, r, testPalette.Cold},
+		{"midpoint", 0, r, mid},
+		{"max", 10, r, testPalette.Hot},
+		{"below range", -500, r, testPalette.Cold},
+		{"above range", 500, r, testPalette.Hot},
+		{"empty range", 3, ColorRange{Min: 4, Max: 4}, mid},
+		{"inverted range", 3, ColorRange{Min: 10, Max: -10}, mid},
+	}
+	for _, tt := range tests {
+		if got := scaleColor(tt.count, tt.r, testPalette); got != tt.want {
+			t.Errorf("%s: scaleColor(%d, %+v) = %v, want %v", tt.name, tt.count, tt.r, got, tt.want)
+		}
+	}
+}
+
+func TestViewModelLabelColor(t *testing.T) {
+	vm, store := newTestViewModel(counterstore.State{Count: 5})
+	colors := paletteFor(counterstore.PaletteDefault)
+	if got, want := vm.LabelColor(), scaleColor(5, defaultColorRange, colors); got != want {
+		t.Errorf("LabelColor on the default range = %v, want %v", got, want)
+	}
+
+	vm.SetColorRange(ColorRange{Min: 0, Max: 5})
+	if got := vm.LabelColor(); got != colors.Hot {
+		t.Errorf("LabelColor at the configured Max = %v, want %v", got, colors.Hot)
+	}
+	store.Dispatch(counterstore.SetCountAction{Value: -20})
+	if got := vm.LabelColor(); got != colors.Cold {
+		t.Errorf("LabelColor below the configured Min = %v, want %v", got, colors.Cold)
+	}
+
+	vm.SetColorBlind(true)
+	if got, want := vm.LabelColor(), paletteFor(counterstore.PaletteColorBlind).Cold; got != want {
+		t.Errorf("LabelColor in the colour-blind palette = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+)
+
+// ResetConfirmation is the modal asking whether a requested reset should go
+// ahead, shown while State.UI.ConfirmPending is set
+type ResetConfirmation struct {
+	theme     *material.Theme
+	yesButton widget.Clickable
+	noButton  widget.Clickable
+}
+
+func (c *ResetConfirmation) Layout(gtx layout.Context, vm *ViewModel) layout.Dimensions {
+	if !vm.ConfirmPending() {
+		return layout.Dimensions{}
+	}
+	if c.yesButton.Clicked(gtx) {
+		vm.ConfirmReset()
+	}
+	if c.noButton.Clicked(gtx) {
+		vm.CancelReset()
+	}
+	return layoutModal(gtx, c.theme, c, func(gtx layout.Context) layout.Dimensions {
+		return layout.Flex{Axis: layout.Vertical, Alignment: layout.Middle}.Layout(gtx,
+			layout.Rigid(material.Subtitle1(c.theme, "Reset the counter to zero?").Layout),
+			layout.Rigid(layout.Spacer{Height: unit.Dp(12)}.Layout),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+					layout.Rigid(material.Button(c.theme, &c.yesButton, "Yes").Layout),
+					layout.Rigid(layout.Spacer{Width: unit.Dp(12)}.Layout),
+					layout.Rigid(material.Button(c.theme, &c.noButton, "No").Layout),
+				)
+			}),
+		)
+	})
+}
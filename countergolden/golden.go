@@ -0,0 +1,76 @@
+// Package countergolden compares counter states against golden files in
+// tests. It lives apart from counterstore so the testing package and its
+// -update flag are only linked into test binaries that ask for them.
+package countergolden
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gio-redux-example/counterstore"
+)
+
+// update makes AssertMatchesGolden rewrite golden files instead of
+// comparing against them, as in go test ./... -update
+var update = flag.Bool("update", false, "rewrite golden files with the states the tests produce")
+
+// AssertMatchesGolden fails t unless states, serialized as indented JSON,
+// match the golden file at path. Run the tests with -update to write the
+// file from states instead, after checking the change is intended; a
+// missing file fails with a hint to do so. On a mismatch it reports the
+// first state that differs; a file differing only in layout still matches.
+func AssertMatchesGolden(t testing.TB, path string, states []counterstore.State) {
+	t.Helper()
+
+	got, err := json.MarshalIndent(states, "", "  ")
+	if err != nil {
+		t.Fatalf("golden %s: encode states: %v", path, err)
+	}
+	got = append(got, '\n')
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("golden %s: %v", path, err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("golden %s: %v", path, err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("golden %s does not exist; run the test with -update to create it", path)
+	}
+	if err != nil {
+		t.Fatalf("golden %s: %v", path, err)
+	}
+	if bytes.Equal(got, want) {
+		return
+	}
+
+	var wantStates []json.RawMessage
+	if err := json.Unmarshal(want, &wantStates); err != nil {
+		t.Fatalf("golden %s: %v; run the test with -update to rewrite it", path, err)
+	}
+	for i, state := range states {
+		gotState, _ := json.Marshal(state)
+		if i >= len(wantStates) {
+			t.Fatalf("golden %s: state %d is extra: %s", path, i, gotState)
+		}
+		var compact bytes.Buffer
+		json.Compact(&compact, wantStates[i])
+		if !bytes.Equal(gotState, compact.Bytes()) {
+			t.Fatalf("golden %s: state %d differs\n got: %s\nwant: %s", path, i, gotState, compact.Bytes())
+		}
+	}
+	if len(states) != len(wantStates) {
+		t.Fatalf("golden %s: got %d states, want %d", path, len(states), len(wantStates))
+	}
+}
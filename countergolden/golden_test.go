@@ -0,0 +1,106 @@
+package countergolden
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"gio-redux-example/counterstore"
+)
+
+// representativeStates dispatches a sequence touching the counting, target,
+// flag, bookmark and confirmation reducers and returns each state committed
+func representativeStates(t *testing.T) []counterstore.State {
+	t.Helper()
+	store := counterstore.NewStore(counterstore.Reduce, counterstore.State{})
+	var states []counterstore.State
+	store.Subscribe(func() { states = append(states, store.GetState()) })
+
+	for _, action := range []counterstore.AppAction{
+		counterstore.IncrementAction{},
+		counterstore.AddAmountAction{Amount: 4},
+		counterstore.SetTargetAction{Target: 8},
+		counterstore.ApplyExprAction{Expr: "x+3"},
+		counterstore.SaveBookmarkAction{Name: "peak"},
+		counterstore.SetFlagAction{Name: counterstore.FlagNoNegatives, Enabled: true},
+		counterstore.NegateAction{},
+		counterstore.RequestResetAction{},
+		counterstore.ConfirmResetAction{},
+		counterstore.GotoBookmarkAction{Name: "peak"},
+	} {
+		store.Dispatch(action)
+	}
+	return states
+}
+
+func TestRepresentativeSequence(t *testing.T) {
+	AssertMatchesGolden(t, filepath.Join("testdata", "sequence.golden.json"), representativeStates(t))
+}
+
+// fatalRecorder stands in for a test, capturing the failure
+// AssertMatchesGolden reports
+type fatalRecorder struct {
+	testing.TB
+	failed string
+}
+
+func (r *fatalRecorder) Helper() {}
+
+func (r *fatalRecorder) Fatalf(format string, args ...any) {
+	r.failed = fmt.Sprintf(format, args...)
+	runtime.Goexit()
+}
+
+// goldenFailure runs AssertMatchesGolden against path and returns how it
+// failed, or "" if it passed
+func goldenFailure(t *testing.T, path string, states []counterstore.State) string {
+	t.Helper()
+	r := &fatalRecorder{TB: t}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		AssertMatchesGolden(r, path, states)
+	}()
+	<-done
+	return r.failed
+}
+
+func TestAssertMatchesGoldenFailures(t *testing.T) {
+	if *update {
+		t.Skip("nothing is compared while updating")
+	}
+	dir := t.TempDir()
+	golden := []counterstore.State{{Count: 1}, {Count: 2}}
+	path := filepath.Join(dir, "golden.json")
+	// Compact JSON differs from what AssertMatchesGolden writes only in layout
+	data, err := json.Marshal(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		path   string
+		states []counterstore.State
+		want   string
+	}{
+		{"same states, other layout", path, golden, ""},
+		{"missing file", filepath.Join(dir, "missing.json"), golden, "run the test with -update to create it"},
+		{"changed state", path, []counterstore.State{{Count: 1}, {Count: 3}}, "state 1 differs"},
+		{"extra state", path, append(golden, counterstore.State{}), "state 2 is extra"},
+		{"missing state", path, golden[:1], "got 1 states, want 2"},
+	}
+	for _, tt := range tests {
+		got := goldenFailure(t, tt.path, tt.states)
+		if tt.want == "" && got != "" || !strings.Contains(got, tt.want) {
+			t.Errorf("%s: failure %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+// Package counterotel traces counter store dispatches with OpenTelemetry. It
+// lives apart from counterstore so only programs that want tracing pull in
+// the OpenTelemetry dependency.
+package counterotel
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"gio-redux-example/counterstore"
+)
+
+// OtelMiddleware starts a span per dispatch, named after the action, that
+// records the count before and after and ends once next returns. A dispatch
+// error is recorded on the span and sets its status to Error.
+func OtelMiddleware(tracer trace.Tracer) counterstore.Middleware[counterstore.State, counterstore.AppAction] {
+	return func(store *counterstore.Store[counterstore.State, counterstore.AppAction], next counterstore.Dispatch[counterstore.AppAction]) counterstore.Dispatch[counterstore.AppAction] {
+		return func(action counterstore.AppAction) error {
+			_, span := tracer.Start(context.Background(), counterstore.ActionName(action),
+				trace.WithAttributes(
+					attribute.String("action.type", fmt.Sprintf("%T", action)),
+					attribute.Int("counter.prev", store.GetState().Count),
+				),
+			)
+			defer span.End()
+
+			err := next(action)
+			span.SetAttributes(attribute.Int("counter.new", store.GetState().Count))
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			return err
+		}
+	}
+}
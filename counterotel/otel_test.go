@@ -0,0 +1,92 @@
+package counterotel
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/embedded"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"gio-redux-example/counterstore"
+)
+
+// recordedSpan is what recordingTracer keeps of a span
+type recordedSpan struct {
+	noop.Span
+	name   string
+	attrs  map[attribute.Key]attribute.Value
+	errs   []error
+	status codes.Code
+	ended  bool
+}
+
+func (s *recordedSpan) SetAttributes(kv ...attribute.KeyValue) {
+	for _, a := range kv {
+		s.attrs[a.Key] = a.Value
+	}
+}
+
+func (s *recordedSpan) RecordError(err error, _ ...trace.EventOption) { s.errs = append(s.errs, err) }
+func (s *recordedSpan) SetStatus(code codes.Code, _ string)           { s.status = code }
+func (s *recordedSpan) End(...trace.SpanEndOption)                    { s.ended = true }
+
+// recordingTracer is an in-memory tracer keeping every span it starts
+type recordingTracer struct {
+	embedded.Tracer
+	spans []*recordedSpan
+}
+
+func (t *recordingTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	span := &recordedSpan{name: name, attrs: map[attribute.Key]attribute.Value{}}
+	cfg := trace.NewSpanStartConfig(opts...)
+	span.SetAttributes(cfg.Attributes()...)
+	t.spans = append(t.spans, span)
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+func TestOtelMiddleware(t *testing.T) {
+	tracer := &recordingTracer{}
+	store := counterstore.NewStore(counterstore.Reduce,
+		counterstore.State{Count: 1, Flags: map[string]bool{counterstore.FlagNoNegatives: true}},
+		OtelMiddleware(tracer))
+
+	store.Dispatch(counterstore.AddAmountAction{Amount: 4})
+	store.Dispatch(counterstore.IncrementAction{})
+	if err := store.Dispatch(counterstore.AddAmountAction{Amount: -50}); err == nil {
+		t.Fatal("rejected action succeeded")
+	}
+
+	want := []struct {
+		name      string
+		prev, new int64
+		failed    bool
+	}{
+		{"AddAmount", 1, 5, false},
+		{"Increment", 5, 6, false},
+		{"AddAmount", 6, 6, true},
+	}
+	if len(tracer.spans) != len(want) {
+		t.Fatalf("%d spans, want one per action (%d)", len(tracer.spans), len(want))
+	}
+	for i, w := range want {
+		span := tracer.spans[i]
+		if span.name != w.name || !span.ended {
+			t.Errorf("span %d: name %q, ended %v", i, span.name, span.ended)
+		}
+		if got := span.attrs["counter.prev"].AsInt64(); got != w.prev {
+			t.Errorf("span %d: counter.prev = %d, want %d", i, got, w.prev)
+		}
+		if got := span.attrs["counter.new"].AsInt64(); got != w.new {
+			t.Errorf("span %d: counter.new = %d, want %d", i, got, w.new)
+		}
+		if failed := span.status == codes.Error && len(span.errs) == 1; failed != w.failed {
+			t.Errorf("span %d: status %v with errors %v, want failed %v", i, span.status, span.errs, w.failed)
+		}
+	}
+	if got := tracer.spans[1].attrs["action.type"].AsString(); got != "counterstore.IncrementAction" {
+		t.Errorf("action.type = %q", got)
+	}
+}
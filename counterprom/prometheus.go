@@ -0,0 +1,39 @@
+// Package counterprom exports counter store dispatch metrics to Prometheus.
+// It lives apart from counterstore so only programs that want metrics pull
+// in the Prometheus client.
+package counterprom
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"gio-redux-example/counterstore"
+)
+
+// PrometheusMiddleware registers with reg a counter of dispatches by action
+// name, counter_dispatches_total, and a histogram of how long they take,
+// counter_dispatch_duration_seconds, and updates both on every dispatch. It
+// panics if the metrics are already registered with reg.
+func PrometheusMiddleware(reg prometheus.Registerer) counterstore.Middleware[counterstore.State, counterstore.AppAction] {
+	dispatches := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "counter_dispatches_total",
+		Help: "Actions dispatched to the counter store, by action name.",
+	}, []string{"action"})
+	duration := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "counter_dispatch_duration_seconds",
+		Help:    "Time taken by counter store dispatches.",
+		Buckets: prometheus.ExponentialBuckets(1e-6, 4, 10),
+	})
+	reg.MustRegister(dispatches, duration)
+
+	return func(store *counterstore.Store[counterstore.State, counterstore.AppAction], next counterstore.Dispatch[counterstore.AppAction]) counterstore.Dispatch[counterstore.AppAction] {
+		return func(action counterstore.AppAction) error {
+			start := time.Now()
+			err := next(action)
+			duration.Observe(time.Since(start).Seconds())
+			dispatches.WithLabelValues(counterstore.ActionName(action)).Inc()
+			return err
+		}
+	}
+}
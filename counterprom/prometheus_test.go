@@ -0,0 +1,61 @@
+package counterprom
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"gio-redux-example/counterstore"
+)
+
+func TestPrometheusMiddleware(t *testing.T) {
+	reg := prometheus.NewPedanticRegistry()
+	store := counterstore.NewStore(counterstore.Reduce,
+		counterstore.State{Flags: map[string]bool{counterstore.FlagNoNegatives: true}},
+		PrometheusMiddleware(reg))
+
+	store.Dispatch(counterstore.IncrementAction{})
+	store.Dispatch(counterstore.IncrementAction{})
+	store.Dispatch(counterstore.AddAmountAction{Amount: 3})
+	store.Dispatch(counterstore.SetCountAction{Value: 0})
+	store.Dispatch(counterstore.DecrementAction{}) // rejected, but still dispatched
+
+	want := `
+# HELP counter_dispatches_total Actions dispatched to the counter store, by action name.
+# TYPE counter_dispatches_total counter
+counter_dispatches_total{action="AddAmount"} 1
+counter_dispatches_total{action="Decrement"} 1
+counter_dispatches_total{action="Increment"} 2
+counter_dispatches_total{action="SetCount"} 1
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(want), "counter_dispatches_total"); err != nil {
+		t.Error(err)
+	}
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var observed uint64
+	for _, family := range families {
+		if family.GetName() == "counter_dispatch_duration_seconds" {
+			observed = family.GetMetric()[0].GetHistogram().GetSampleCount()
+		}
+	}
+	if observed != 5 {
+		t.Errorf("histogram observed %d dispatches, want 5", observed)
+	}
+}
+
+func TestPrometheusMiddlewareRegistersOnce(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	PrometheusMiddleware(reg)
+	defer func() {
+		if recover() == nil {
+			t.Error("registering the metrics twice did not panic")
+		}
+	}()
+	PrometheusMiddleware(reg)
+}
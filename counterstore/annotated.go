@@ -0,0 +1,39 @@
+package counterstore
+
+// Annotated wraps an action with free-form metadata, such as its source or a
+// correlation ID, without changing the action's type. The reducer applies
+// the inner action; middleware can read Meta.
+type Annotated struct {
+	AppAction
+	Meta map[string]string
+}
+
+// WithMeta annotates action with a copy of meta
+func WithMeta(action AppAction, meta map[string]string) Annotated {
+	copied := make(map[string]string, len(meta))
+	for k, v := range meta {
+		copied[k] = v
+	}
+	return Annotated{AppAction: action, Meta: copied}
+}
+
+// Metadata is implemented by actions carrying metadata, for middleware that
+// report it
+type Metadata interface {
+	Metadata() map[string]string
+}
+
+func (a Annotated) Metadata() map[string]string {
+	return a.Meta
+}
+
+func (a Annotated) Validate(s State) error {
+	if v, ok := a.AppAction.(Validator[State]); ok {
+		return v.Validate(s)
+	}
+	return nil
+}
+
+func (a Annotated) Undoable() bool {
+	return isUndoable(a.AppAction)
+}
@@ -0,0 +1,51 @@
+package counterstore
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestAnnotatedAppliesInnerAction(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 1, Flags: map[string]bool{FlagNoNegatives: true}})
+	store.Dispatch(WithMeta(AddAmountAction{Amount: 4}, map[string]string{"source": "test"}))
+	if got := store.GetState().Count; got != 5 {
+		t.Errorf("Count = %d, want the inner action applied", got)
+	}
+	err := store.Dispatch(WithMeta(AddAmountAction{Amount: -9}, nil))
+	if !errors.Is(err, ErrNegativeCount) {
+		t.Errorf("Dispatch error = %v, want the inner action's validation", err)
+	}
+	if !store.Undo() || store.GetState().Count != 1 {
+		t.Errorf("the annotated action was not an undo step")
+	}
+}
+
+func TestWithMetaCopies(t *testing.T) {
+	meta := map[string]string{"correlation": "abc"}
+	annotated := WithMeta(IncrementAction{}, meta)
+	meta["correlation"] = "changed"
+	if got := annotated.Metadata()["correlation"]; got != "abc" {
+		t.Errorf("Metadata after editing the caller's map = %q, want abc", got)
+	}
+}
+
+func TestLoggingShowsMetadata(t *testing.T) {
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+
+	store := NewStore(Reduce, State{}, LoggingMiddleware[State, AppAction])
+	store.Dispatch(WithMeta(IncrementAction{}, map[string]string{"source": "keyboard"}))
+	if !strings.Contains(logged.String(), "Meta: map[source:keyboard]") {
+		t.Errorf("log does not show the metadata:\n%s", logged.String())
+	}
+	logged.Reset()
+	store.Dispatch(IncrementAction{})
+	if strings.Contains(logged.String(), "Meta:") {
+		t.Errorf("log shows metadata for a plain action:\n%s", logged.String())
+	}
+}
@@ -0,0 +1,74 @@
+package counterstore
+
+import "errors"
+
+// asyncQueueSize is how many async dispatches may wait for the worker before
+// DispatchAsync blocks
+const asyncQueueSize = 64
+
+// ErrAsyncClosed is the error of async dispatches made after CloseAsync
+var ErrAsyncClosed = errors.New("async dispatch worker is closed")
+
+// AsyncResult is the outcome of an async dispatch. State is the state the
+// action's commit produced, taken as DispatchReturning takes it, or the
+// current state if it committed nothing; Err is the error Dispatch returned.
+type AsyncResult[S any] struct {
+	State S
+	Err   error
+}
+
+type asyncJob[S StateProvider[S], A Action[S]] struct {
+	action A
+	result chan AsyncResult[S]
+}
+
+// DispatchAsync queues action for the store's dispatch worker and returns a
+// channel receiving its result. One worker runs every async dispatch through
+// the full middleware chain in the order they were queued, so subscribers
+// fire on the worker. It is started on first use and runs until CloseAsync.
+func (s *Store[S, A]) DispatchAsync(action A) <-chan AsyncResult[S] {
+	result := make(chan AsyncResult[S], 1)
+
+	s.asyncMu.Lock()
+	defer s.asyncMu.Unlock()
+	if s.asyncClosed {
+		result <- AsyncResult[S]{State: s.GetState(), Err: ErrAsyncClosed}
+		return result
+	}
+	if s.asyncQueue == nil {
+		s.asyncQueue = make(chan asyncJob[S, A], asyncQueueSize)
+		s.asyncDone = make(chan struct{})
+		go s.asyncWorker(s.asyncQueue, s.asyncDone)
+	}
+	s.asyncQueue <- asyncJob[S, A]{action: action, result: result}
+	return result
+}
+
+// CloseAsync stops the dispatch worker once it has run everything already
+// queued, and waits for it. Later async dispatches fail with ErrAsyncClosed.
+// It must not be called from a subscriber running on the worker.
+func (s *Store[S, A]) CloseAsync() {
+	s.asyncMu.Lock()
+	if s.asyncClosed {
+		s.asyncMu.Unlock()
+		return
+	}
+	s.asyncClosed = true
+	queue, done := s.asyncQueue, s.asyncDone
+	if queue != nil {
+		close(queue)
+	}
+	s.asyncMu.Unlock()
+
+	if done != nil {
+		<-done
+	}
+}
+
+func (s *Store[S, A]) asyncWorker(queue <-chan asyncJob[S, A], done chan<- struct{}) {
+	defer close(done)
+	for job := range queue {
+		capture, err := s.dispatchCaptured(job.action)
+		job.result <- AsyncResult[S]{State: capture.next, Err: err}
+	}
+}
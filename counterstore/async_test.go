@@ -0,0 +1,94 @@
+package counterstore
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDispatchAsyncOrdered(t *testing.T) {
+	store := NewStore(Reduce, State{})
+	defer store.CloseAsync()
+
+	var results []<-chan AsyncResult[State]
+	for i := 0; i < 10; i++ {
+		results = append(results, store.DispatchAsync(IncrementAction{}))
+	}
+	for i, result := range results {
+		r := <-result
+		if r.Err != nil || r.State.Count != i+1 {
+			t.Errorf("dispatch %d: Count %d, err %v; want %d, nil", i, r.State.Count, r.Err, i+1)
+		}
+	}
+	if got := store.GetState().Count; got != 10 {
+		t.Errorf("final Count = %d, want 10", got)
+	}
+}
+
+func TestDispatchAsyncResultIsItsOwnCommit(t *testing.T) {
+	store := NewStore(Reduce, State{})
+	defer store.CloseAsync()
+
+	// A subscriber dispatching from the worker commits again before the
+	// first dispatch returns; the result is still the first commit's
+	store.Subscribe(func() {
+		if store.GetState().Count == 5 {
+			store.Dispatch(IncrementAction{})
+		}
+	})
+	r := <-store.DispatchAsync(SetCountAction{Value: 5})
+	if r.State.Count != 5 {
+		t.Errorf("result Count = %d, want the action's own 5", r.State.Count)
+	}
+	if got := store.GetState().Count; got != 6 {
+		t.Errorf("final Count = %d, want 6", got)
+	}
+}
+
+func TestDispatchAsyncError(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 1, Flags: map[string]bool{FlagNoNegatives: true}})
+	defer store.CloseAsync()
+
+	first := store.DispatchAsync(AddAmountAction{Amount: -5})
+	second := store.DispatchAsync(DecrementAction{})
+	if r := <-first; !errors.Is(r.Err, ErrNegativeCount) || r.State.Count != 1 {
+		t.Errorf("rejected dispatch: %+v, want ErrNegativeCount with Count 1", r)
+	}
+	if r := <-second; r.Err != nil || r.State.Count != 0 {
+		t.Errorf("following dispatch: %+v, want Count 0", r)
+	}
+}
+
+func TestCloseAsync(t *testing.T) {
+	store := NewStore(Reduce, State{})
+	var queued []<-chan AsyncResult[State]
+	for i := 0; i < 5; i++ {
+		queued = append(queued, store.DispatchAsync(IncrementAction{}))
+	}
+	store.CloseAsync()
+
+	// Everything queued before Close ran
+	if got := store.GetState().Count; got != 5 {
+		t.Errorf("Count after CloseAsync = %d, want 5", got)
+	}
+	for _, result := range queued {
+		if r := <-result; r.Err != nil {
+			t.Errorf("queued dispatch failed: %v", r.Err)
+		}
+	}
+
+	if r := <-store.DispatchAsync(IncrementAction{}); !errors.Is(r.Err, ErrAsyncClosed) {
+		t.Errorf("dispatch after CloseAsync: err %v, want ErrAsyncClosed", r.Err)
+	}
+	if got := store.GetState().Count; got != 5 {
+		t.Errorf("Count = %d, want a closed worker to apply nothing", got)
+	}
+	store.CloseAsync()
+}
+
+func TestCloseAsyncUnused(t *testing.T) {
+	store := NewStore(Reduce, State{})
+	store.CloseAsync()
+	if r := <-store.DispatchAsync(IncrementAction{}); !errors.Is(r.Err, ErrAsyncClosed) {
+		t.Errorf("err = %v, want ErrAsyncClosed", r.Err)
+	}
+}
@@ -0,0 +1,81 @@
+package counterstore
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// AutoReset resets the counter after a period with no dispatches. Its
+// middleware restarts the inactivity timer on every action; when the timer
+// fires with a non-zero count, a ResetAction is dispatched.
+type AutoReset struct {
+	mu      sync.Mutex
+	clock   Clock
+	timeout time.Duration
+	store   *Store[State, AppAction]
+	timer   Timer
+	closed  bool
+}
+
+// NewAutoReset creates an auto-reset firing after timeout of inactivity, read
+// from clock or the wall clock when clock is nil. A timeout of zero or less
+// disables it.
+func NewAutoReset(clock Clock, timeout time.Duration) *AutoReset {
+	return &AutoReset{clock: orRealClock(clock), timeout: timeout}
+}
+
+// Middleware returns the middleware that watches the store for activity
+func (a *AutoReset) Middleware() Middleware[State, AppAction] {
+	return func(store *Store[State, AppAction], next Dispatch[AppAction]) Dispatch[AppAction] {
+		a.mu.Lock()
+		a.store = store
+		a.restart()
+		a.mu.Unlock()
+
+		return func(action AppAction) error {
+			err := next(action)
+			a.mu.Lock()
+			a.restart()
+			a.mu.Unlock()
+			return err
+		}
+	}
+}
+
+// restart rearms the inactivity timer. Callers must hold a.mu.
+func (a *AutoReset) restart() {
+	if a.timer != nil {
+		a.timer.Stop()
+		a.timer = nil
+	}
+	if a.closed || a.timeout <= 0 || a.store == nil {
+		return
+	}
+	a.timer = a.clock.AfterFunc(a.timeout, a.expire)
+}
+
+func (a *AutoReset) expire() {
+	a.mu.Lock()
+	store := a.store
+	a.timer = nil
+	if a.closed {
+		store = nil
+	}
+	a.mu.Unlock()
+
+	if store == nil || store.GetState().Count == 0 {
+		return
+	}
+	if err := store.Dispatch(ResetAction{}); err != nil {
+		log.Printf("auto-reset: %v", err)
+	}
+}
+
+// Close stops the inactivity timer for good
+func (a *AutoReset) Close() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.closed = true
+	a.restart()
+}
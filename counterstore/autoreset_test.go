@@ -0,0 +1,66 @@
+package counterstore
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAutoReset(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	auto := NewAutoReset(clock, 10*time.Second)
+	defer auto.Close()
+	store := NewStore(Reduce, State{}, auto.Middleware())
+
+	store.Dispatch(AddAmountAction{Amount: 4})
+	clock.Advance(9 * time.Second)
+	if got := store.GetState().Count; got != 4 {
+		t.Fatalf("Count = %d before the timeout, want 4", got)
+	}
+
+	// A dispatch restarts the timer
+	store.Dispatch(IncrementAction{})
+	clock.Advance(9 * time.Second)
+	if got := store.GetState().Count; got != 5 {
+		t.Fatalf("Count = %d, want the dispatch to have restarted the timer", got)
+	}
+
+	clock.Advance(time.Second)
+	if got := store.GetState().Count; got != 0 {
+		t.Errorf("Count = %d after the timeout, want the counter reset", got)
+	}
+	// The reset is itself a dispatch, rearming the timer; at zero it does
+	// nothing more
+	clock.Advance(time.Minute)
+	if got := store.HistoryLen(); got != 3 {
+		t.Errorf("HistoryLen = %d, want one reset only", got)
+	}
+}
+
+func TestAutoResetClose(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	auto := NewAutoReset(clock, time.Second)
+	store := NewStore(Reduce, State{}, auto.Middleware())
+	store.Dispatch(IncrementAction{})
+
+	auto.Close()
+	if got := clock.Pending(); got != 0 {
+		t.Errorf("Pending = %d after Close, want the timer stopped", got)
+	}
+	clock.Advance(time.Minute)
+	store.Dispatch(IncrementAction{})
+	clock.Advance(time.Minute)
+	if got := store.GetState().Count; got != 2 {
+		t.Errorf("Count = %d, want no reset after Close", got)
+	}
+}
+
+func TestAutoResetDisabled(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	auto := NewAutoReset(clock, 0)
+	store := NewStore(Reduce, State{}, auto.Middleware())
+	store.Dispatch(IncrementAction{})
+	clock.Advance(time.Hour)
+	if got := store.GetState().Count; got != 1 || clock.Pending() != 0 {
+		t.Errorf("Count = %d, pending %d; want a disabled auto-reset to do nothing", got, clock.Pending())
+	}
+}
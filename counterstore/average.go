@@ -0,0 +1,58 @@
+package counterstore
+
+import "sync"
+
+// RunningAverage accumulates values for a mean
+type RunningAverage struct {
+	Sum float64
+	N   int
+}
+
+// Add returns the average with value included
+func (a RunningAverage) Add(value int) RunningAverage {
+	return RunningAverage{Sum: a.Sum + float64(value), N: a.N + 1}
+}
+
+// Mean returns the average of the values added, or 0 when there are none
+func (a RunningAverage) Mean() float64 {
+	if a.N == 0 {
+		return 0
+	}
+	return a.Sum / float64(a.N)
+}
+
+// SetAverage is auxiliary state holding the running average of the values
+// set through SetCountAction
+type SetAverage struct {
+	mu  sync.Mutex
+	avg RunningAverage
+}
+
+func (s *SetAverage) Average() RunningAverage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.avg
+}
+
+func (s *SetAverage) add(value int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.avg = s.avg.Add(value)
+}
+
+// Average Middleware adds the value of every SetCountAction it forwards to
+// avg, also when it is wrapped in an annotation or redaction; other actions
+// leave the average alone
+func AverageMiddleware(avg *SetAverage) Middleware[State, AppAction] {
+	return func(store *Store[State, AppAction], next Dispatch[AppAction]) Dispatch[AppAction] {
+		return func(action AppAction) error {
+			if err := next(action); err != nil {
+				return err
+			}
+			if set, ok := plainAction(action).(SetCountAction); ok {
+				avg.add(set.Value)
+			}
+			return nil
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package counterstore
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRunningAverage(t *testing.T) {
+	var avg RunningAverage
+	if got := avg.Mean(); got != 0 {
+		t.Errorf("Mean of nothing = %v, want 0", got)
+	}
+	next := avg.Add(3)
+	if avg.N != 0 {
+		t.Error("Add changed its receiver")
+	}
+	next = next.Add(4).Add(-1)
+	if next.N != 3 || next.Mean() != 2 {
+		t.Errorf("after 3, 4, -1: %+v, Mean %v; want N 3, Mean 2", next, next.Mean())
+	}
+}
+
+func TestAverageMiddleware(t *testing.T) {
+	var avg SetAverage
+	store := NewStoreWithOptions(Reduce, State{},
+		WithMiddleware(AverageMiddleware(&avg)),
+		WithInvariant[State, AppAction](func(s State) error {
+			if s.Count > 50 {
+				return errors.New("too big")
+			}
+			return nil
+		}, nil),
+	)
+
+	store.Dispatch(SetCountAction{Value: 10})
+	store.Dispatch(IncrementAction{})
+	store.Dispatch(SetCountAction{Value: 5})
+	store.Dispatch(AddAmountAction{Amount: 10})
+	store.Dispatch(SetCountAction{Value: 0})
+	store.Dispatch(SetCountAction{Value: 99}) // rejected
+
+	got := avg.Average()
+	if got.N != 3 || got.Mean() != 5 {
+		t.Errorf("average %+v, Mean %v; want N 3, Mean 5", got, got.Mean())
+	}
+}
+
+func TestAverageMiddlewareWrapped(t *testing.T) {
+	var avg SetAverage
+	store := NewStore(Reduce, State{},
+		TraceMiddleware(sequentialIDs()),
+		RedactMiddleware(func(action AppAction) AppAction {
+			if _, ok := action.(SetCountAction); ok {
+				return SetCountAction{}
+			}
+			return action
+		}),
+		AverageMiddleware(&avg))
+
+	store.Dispatch(SetCountAction{Value: 4})
+	store.Dispatch(SetCountAction{Value: 8})
+	store.Dispatch(IncrementAction{})
+	if got := avg.Average(); got.N != 2 || got.Mean() != 6 {
+		t.Errorf("average %+v over traced, redacted sets; want N 2, Mean 6", got)
+	}
+}
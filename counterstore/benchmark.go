@@ -0,0 +1,52 @@
+package counterstore
+
+import (
+	"fmt"
+	"time"
+)
+
+// BenchmarkConfig describes a headless throughput run: a store with
+// Middleware and Subscribers listeners, each reading the state on every
+// notification, receiving Actions dispatches
+type BenchmarkConfig struct {
+	Name        string
+	Middleware  []Middleware[State, AppAction]
+	Subscribers int
+	Actions     int
+}
+
+// BenchmarkResult is the outcome of Benchmark
+type BenchmarkResult struct {
+	Name    string
+	Actions int
+	Elapsed time.Duration
+}
+
+// PerSecond returns the measured throughput in actions per second
+func (r BenchmarkResult) PerSecond() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(r.Actions) / r.Elapsed.Seconds()
+}
+
+func (r BenchmarkResult) String() string {
+	return fmt.Sprintf("%-24s %8d actions in %10v  %12.0f actions/s", r.Name, r.Actions, r.Elapsed.Round(time.Microsecond), r.PerSecond())
+}
+
+// Benchmark measures end-to-end dispatch throughput, middleware, reducer and
+// subscribers included, alternating increments and decrements. History is
+// left on, as in the app.
+func Benchmark(cfg BenchmarkConfig) BenchmarkResult {
+	store := NewStore(Reduce, State{}, cfg.Middleware...)
+	for i := 0; i < cfg.Subscribers; i++ {
+		store.Subscribe(func() { _ = store.GetStateFast() })
+	}
+
+	actions := []AppAction{IncrementAction{}, DecrementAction{}}
+	start := time.Now()
+	for i := 0; i < cfg.Actions; i++ {
+		store.Dispatch(actions[i%len(actions)])
+	}
+	return BenchmarkResult{Name: cfg.Name, Actions: cfg.Actions, Elapsed: time.Since(start)}
+}
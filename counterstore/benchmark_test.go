@@ -0,0 +1,67 @@
+package counterstore
+
+import (
+	"io"
+	"log"
+	"strings"
+	"testing"
+	"time"
+)
+
+// BenchmarkStack runs Benchmark over b.N actions for each stack main's -bench
+// flag measures, so the cost of logging and profiling shows up in go test
+// -bench as well
+func BenchmarkStack(b *testing.B) {
+	logOutput := log.Writer()
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(logOutput)
+
+	for _, cfg := range []BenchmarkConfig{
+		{Name: "bare"},
+		{Name: "logging", Middleware: []Middleware[State, AppAction]{LoggingMiddleware[State, AppAction]}},
+		{Name: "profiling", Middleware: []Middleware[State, AppAction]{ProfilerMiddleware[State, AppAction](NewProfiler(nil))}},
+		{Name: "logging+profiling", Middleware: []Middleware[State, AppAction]{
+			LoggingMiddleware[State, AppAction],
+			ProfilerMiddleware[State, AppAction](NewProfiler(nil)),
+		}},
+	} {
+		b.Run(cfg.Name, func(b *testing.B) {
+			cfg.Subscribers = 4
+			cfg.Actions = b.N
+			b.ReportAllocs()
+			b.ResetTimer()
+			result := Benchmark(cfg)
+			b.ReportMetric(result.PerSecond(), "actions/s")
+		})
+	}
+}
+
+func TestBenchmarkRunsEveryAction(t *testing.T) {
+	dispatched := 0
+	counting := func(store *Store[State, AppAction], next Dispatch[AppAction]) Dispatch[AppAction] {
+		return func(action AppAction) error {
+			dispatched++
+			return next(action)
+		}
+	}
+	result := Benchmark(BenchmarkConfig{Name: "counting", Middleware: []Middleware[State, AppAction]{counting}, Subscribers: 2, Actions: 11})
+	if dispatched != 11 || result.Actions != 11 || result.Name != "counting" {
+		t.Errorf("dispatched %d, result %+v; want 11 actions", dispatched, result)
+	}
+	if result.Elapsed <= 0 {
+		t.Errorf("Elapsed = %v", result.Elapsed)
+	}
+}
+
+func TestBenchmarkResultPerSecond(t *testing.T) {
+	r := BenchmarkResult{Name: "bare", Actions: 500, Elapsed: 250 * time.Millisecond}
+	if got := r.PerSecond(); got != 2000 {
+		t.Errorf("PerSecond = %v, want 2000", got)
+	}
+	if !strings.Contains(r.String(), "2000 actions/s") {
+		t.Errorf("String = %q", r.String())
+	}
+	if got := (BenchmarkResult{Actions: 5}).PerSecond(); got != 0 {
+		t.Errorf("PerSecond with no elapsed time = %v, want 0", got)
+	}
+}
@@ -0,0 +1,55 @@
+package counterstore
+
+import "math/big"
+
+// BigState is the state for the arbitrary-precision counter, for demos that
+// would overflow an int
+type BigState struct {
+	Count *big.Int
+}
+
+// Copy deep-copies Count so snapshots never share a big.Int with the live
+// state
+func (s BigState) Copy() BigState {
+	count := new(big.Int)
+	if s.Count != nil {
+		count.Set(s.Count)
+	}
+	return BigState{Count: count}
+}
+
+// BigAction is an action on BigState
+type BigAction interface {
+	Action[BigState]
+}
+
+// BigIncrementAction
+type BigIncrementAction struct{}
+
+func (a BigIncrementAction) Apply(s BigState) BigState {
+	state := s.Copy()
+	state.Count.Add(state.Count, big.NewInt(1))
+	return state
+}
+
+// BigAddAction
+type BigAddAction struct {
+	Amount *big.Int
+}
+
+func (a BigAddAction) Apply(s BigState) BigState {
+	state := s.Copy()
+	if a.Amount != nil {
+		state.Count.Add(state.Count, a.Amount)
+	}
+	return state
+}
+
+func reduceBig(state BigState, action BigAction) BigState {
+	return action.Apply(state)
+}
+
+// NewBigStore creates an arbitrary-precision counter store starting at zero
+func NewBigStore(middleware ...Middleware[BigState, BigAction]) *Store[BigState, BigAction] {
+	return NewStore(reduceBig, BigState{Count: new(big.Int)}, middleware...)
+}
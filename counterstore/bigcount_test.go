@@ -0,0 +1,62 @@
+package counterstore
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestBigCountPastMaxInt64(t *testing.T) {
+	store := NewBigStore()
+	store.Dispatch(BigAddAction{Amount: big.NewInt(math.MaxInt64)})
+	store.Dispatch(BigIncrementAction{})
+	store.Dispatch(BigIncrementAction{})
+
+	want, _ := new(big.Int).SetString("9223372036854775809", 10)
+	if got := store.GetState().Count; got.Cmp(want) != 0 {
+		t.Errorf("Count = %s, want %s", got, want)
+	}
+
+	store.Dispatch(BigAddAction{Amount: new(big.Int).Neg(want)})
+	if got := store.GetState().Count; got.Sign() != 0 {
+		t.Errorf("Count = %s, want 0", got)
+	}
+	store.Dispatch(BigAddAction{})
+	if got := store.GetState().Count; got.Sign() != 0 {
+		t.Errorf("a nil amount changed Count to %s", got)
+	}
+}
+
+func TestBigCountHistoryDoesNotShare(t *testing.T) {
+	store := NewBigStore()
+	store.Dispatch(BigAddAction{Amount: big.NewInt(5)})
+	snapshot := store.GetState()
+	store.Dispatch(BigIncrementAction{})
+
+	if snapshot.Count.Int64() != 5 {
+		t.Errorf("a later dispatch changed an earlier state's count to %s", snapshot.Count)
+	}
+	if !store.Undo() || store.GetState().Count.Int64() != 5 {
+		t.Errorf("Count after Undo = %s, want 5", store.GetState().Count)
+	}
+
+	// Mutating the amount after dispatch does not reach the state
+	amount := big.NewInt(1)
+	store.Dispatch(BigAddAction{Amount: amount})
+	amount.SetInt64(1000)
+	if got := store.GetState().Count.Int64(); got != 6 {
+		t.Errorf("Count = %d, want 6", got)
+	}
+}
+
+func TestBigStateCopy(t *testing.T) {
+	orig := BigState{Count: big.NewInt(3)}
+	copied := orig.Copy()
+	copied.Count.SetInt64(4)
+	if orig.Count.Int64() != 3 {
+		t.Error("Copy shares its big.Int")
+	}
+	if (BigState{}).Copy().Count == nil {
+		t.Error("Copy of the zero state has a nil count")
+	}
+}
@@ -0,0 +1,71 @@
+package counterstore
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrBookmarkName rejects saving a bookmark without a name
+	ErrBookmarkName = errors.New("bookmark name is empty")
+	// ErrNoBookmark rejects jumping to a bookmark that was never saved
+	ErrNoBookmark = errors.New("no such bookmark")
+)
+
+// SaveBookmarkAction saves the current count under Name, replacing any
+// bookmark of that name
+type SaveBookmarkAction struct {
+	Name string
+}
+
+func (a SaveBookmarkAction) Apply(s State) State {
+	state := s.Copy()
+	if state.Bookmarks == nil {
+		state.Bookmarks = map[string]int{}
+	}
+	state.Bookmarks[a.Name] = state.Count
+	return state
+}
+
+func (a SaveBookmarkAction) Validate(s State) error {
+	if a.Name == "" {
+		return ErrBookmarkName
+	}
+	return nil
+}
+
+// GotoBookmarkAction sets the count to the one saved under Name. A missing
+// bookmark is rejected with ErrNoBookmark; reaching the reducer anyway, it
+// does nothing.
+type GotoBookmarkAction struct {
+	Name string
+}
+
+func (a GotoBookmarkAction) Apply(s State) State {
+	count, ok := s.Bookmarks[a.Name]
+	if !ok {
+		return s
+	}
+	state := s.Copy()
+	state.Count = count
+	return state
+}
+
+func (a GotoBookmarkAction) Validate(s State) error {
+	if _, ok := s.Bookmarks[a.Name]; !ok {
+		return fmt.Errorf("%w: %q", ErrNoBookmark, a.Name)
+	}
+	return nil
+}
+
+// copyBookmarks returns an independent copy of bookmarks, keeping nil as nil
+func copyBookmarks(bookmarks map[string]int) map[string]int {
+	if bookmarks == nil {
+		return nil
+	}
+	out := make(map[string]int, len(bookmarks))
+	for name, count := range bookmarks {
+		out[name] = count
+	}
+	return out
+}
@@ -0,0 +1,66 @@
+package counterstore
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSaveBookmark(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 4})
+	if err := store.Dispatch(SaveBookmarkAction{Name: "start"}); err != nil {
+		t.Fatal(err)
+	}
+	store.Dispatch(AddAmountAction{Amount: 6})
+	store.Dispatch(SaveBookmarkAction{Name: "peak"})
+	// Saving under a name already taken replaces it
+	store.Dispatch(IncrementAction{})
+	store.Dispatch(SaveBookmarkAction{Name: "peak"})
+
+	got := store.GetState().Bookmarks
+	if len(got) != 2 || got["start"] != 4 || got["peak"] != 11 {
+		t.Errorf("Bookmarks = %v, want start 4 and peak 11", got)
+	}
+
+	if err := store.Dispatch(SaveBookmarkAction{}); !errors.Is(err, ErrBookmarkName) {
+		t.Errorf("saving without a name = %v, want ErrBookmarkName", err)
+	}
+}
+
+func TestGotoBookmark(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 2})
+	store.Dispatch(SaveBookmarkAction{Name: "two"})
+	store.Dispatch(AddAmountAction{Amount: 30})
+
+	if err := store.Dispatch(GotoBookmarkAction{Name: "two"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := store.GetState().Count; got != 2 {
+		t.Errorf("Count after jumping = %d, want 2", got)
+	}
+
+	store.Dispatch(IncrementAction{})
+	version := store.Version()
+	if err := store.Dispatch(GotoBookmarkAction{Name: "missing"}); !errors.Is(err, ErrNoBookmark) {
+		t.Errorf("jumping to a missing bookmark = %v, want ErrNoBookmark", err)
+	}
+	if store.Version() != version || store.GetState().Count != 3 {
+		t.Errorf("a rejected jump changed the state: %+v", store.GetState())
+	}
+
+	// Reaching the reducer without validation, a missing bookmark does nothing
+	state := State{Count: 5, Bookmarks: map[string]int{"two": 2}}
+	if got := (GotoBookmarkAction{Name: "missing"}).Apply(state); got.Count != 5 {
+		t.Errorf("Apply of a missing bookmark = Count %d, want 5", got.Count)
+	}
+}
+
+func TestSaveBookmarkLeavesPreviousState(t *testing.T) {
+	prev := State{Count: 1, Bookmarks: map[string]int{"a": 0}}
+	next := SaveBookmarkAction{Name: "b"}.Apply(prev)
+	if _, ok := prev.Bookmarks["b"]; ok {
+		t.Error("saving a bookmark wrote into the previous state's map")
+	}
+	if next.Bookmarks["a"] != 0 || next.Bookmarks["b"] != 1 {
+		t.Errorf("Bookmarks = %v, want a 0 and b 1", next.Bookmarks)
+	}
+}
@@ -0,0 +1,81 @@
+package counterstore
+
+import (
+	"sync"
+	"time"
+)
+
+// Burst calls fire every interval on its clock between Start and Stop, for
+// repeating an action while a key is held. Start while running and Stop
+// while stopped do nothing, so key repeat can call Start again freely.
+type Burst struct {
+	mu       sync.Mutex
+	clock    Clock
+	interval time.Duration
+	fire     func()
+	timer    Timer
+	run      int
+	closed   bool
+}
+
+// NewBurst creates a stopped burst firing every interval on clock, or the
+// wall clock when it is nil
+func NewBurst(clock Clock, interval time.Duration, fire func()) *Burst {
+	return &Burst{clock: orRealClock(clock), interval: interval, fire: fire}
+}
+
+// Running reports whether the burst is firing
+func (b *Burst) Running() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.timer != nil
+}
+
+// Start begins firing, the first time one interval from now
+func (b *Burst) Start() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.timer != nil || b.closed || b.interval <= 0 {
+		return
+	}
+	b.run++
+	run := b.run
+	b.timer = b.clock.AfterFunc(b.interval, func() { b.tick(run) })
+}
+
+// Stop stops firing; a call already under way finishes
+func (b *Burst) Stop() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+}
+
+// Close stops the burst for good
+func (b *Burst) Close() {
+	b.mu.Lock()
+	b.closed = true
+	b.mu.Unlock()
+	b.Stop()
+}
+
+// tick fires for the run started as run, and reschedules itself unless that
+// run has been stopped, possibly with another started since
+func (b *Burst) tick(run int) {
+	b.mu.Lock()
+	current := b.timer != nil && b.run == run
+	b.mu.Unlock()
+	if !current {
+		return
+	}
+
+	b.fire()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.timer != nil && b.run == run {
+		b.timer = b.clock.AfterFunc(b.interval, func() { b.tick(run) })
+	}
+}
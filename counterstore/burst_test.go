@@ -0,0 +1,80 @@
+package counterstore
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBurstHeld(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	store := NewStore(Reduce, State{})
+	burst := NewBurst(clock, 20*time.Millisecond, func() { store.Dispatch(IncrementAction{}) })
+	defer burst.Close()
+
+	burst.Start()
+	// Key repeat starting it again must not add a second ticker
+	clock.Advance(10 * time.Millisecond)
+	burst.Start()
+	clock.Advance(990 * time.Millisecond)
+	if got := store.GetState().Count; got != 50 {
+		t.Errorf("Count after holding 1s = %d, want 50", got)
+	}
+	if !burst.Running() {
+		t.Error("not running while held")
+	}
+
+	burst.Stop()
+	clock.Advance(time.Second)
+	if got := store.GetState().Count; got != 50 || burst.Running() || clock.Pending() != 0 {
+		t.Errorf("after release: Count %d, running %v, %d timers; want 50, stopped, none",
+			got, burst.Running(), clock.Pending())
+	}
+
+	// Holding again starts a fresh interval
+	clock.Advance(5 * time.Millisecond)
+	burst.Start()
+	clock.Advance(19 * time.Millisecond)
+	if got := store.GetState().Count; got != 50 {
+		t.Errorf("Count = %d before the first interval of a new hold, want 50", got)
+	}
+	clock.Advance(41 * time.Millisecond)
+	if got := store.GetState().Count; got != 53 {
+		t.Errorf("Count after holding 60ms more = %d, want 53", got)
+	}
+}
+
+func TestBurstStopFromFire(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	var burst *Burst
+	fired := 0
+	burst = NewBurst(clock, time.Second, func() {
+		fired++
+		if fired == 3 {
+			burst.Stop()
+		}
+	})
+	burst.Start()
+	clock.Advance(time.Minute)
+	if fired != 3 || burst.Running() {
+		t.Errorf("fired %d times, running %v; want 3 and stopped", fired, burst.Running())
+	}
+}
+
+func TestBurstClose(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	fired := 0
+	burst := NewBurst(clock, time.Second, func() { fired++ })
+	burst.Start()
+	clock.Advance(2 * time.Second)
+	burst.Close()
+	burst.Start()
+	clock.Advance(time.Minute)
+	if fired != 2 || burst.Running() || clock.Pending() != 0 {
+		t.Errorf("fired %d times, running %v; want 2 and stopped for good", fired, burst.Running())
+	}
+
+	NewBurst(clock, 0, func() { fired++ }).Start()
+	if clock.Pending() != 0 {
+		t.Error("a burst without an interval started")
+	}
+}
@@ -0,0 +1,92 @@
+package counterstore
+
+import "errors"
+
+// ErrCategoryName rejects a category action without a name
+var ErrCategoryName = errors.New("category name is empty")
+
+// AddCategoryAction creates the category Name at 0, leaving an existing one
+// as it is
+type AddCategoryAction struct {
+	Name string
+}
+
+func (a AddCategoryAction) Apply(s State) State {
+	if _, ok := s.Categories[a.Name]; ok {
+		return s
+	}
+	return stepCategory(s, a.Name, 0)
+}
+
+func (a AddCategoryAction) Validate(s State) error {
+	return validateCategory(s, a.Name, 0)
+}
+
+// IncCategoryAction increments the category Name, creating it at 0 first if
+// it is new
+type IncCategoryAction struct {
+	Name string
+}
+
+func (a IncCategoryAction) Apply(s State) State {
+	return stepCategory(s, a.Name, 1)
+}
+
+func (a IncCategoryAction) Validate(s State) error {
+	return validateCategory(s, a.Name, 1)
+}
+
+// DecCategoryAction decrements the category Name, creating it at 0 first if
+// it is new
+type DecCategoryAction struct {
+	Name string
+}
+
+func (a DecCategoryAction) Apply(s State) State {
+	return stepCategory(s, a.Name, -1)
+}
+
+func (a DecCategoryAction) Validate(s State) error {
+	return validateCategory(s, a.Name, -1)
+}
+
+// stepCategory returns s with delta added to the category name under the
+// overflow mode, creating it at 0 first if it is new
+func stepCategory(s State, name string, delta int) State {
+	state := s.Copy()
+	if state.Categories == nil {
+		state.Categories = map[string]int{}
+	}
+	state.Categories[name], _ = addCount(state.Categories[name], delta, state.Overflow)
+	return state
+}
+
+func validateCategory(s State, name string, delta int) error {
+	if name == "" {
+		return ErrCategoryName
+	}
+	_, err := addCount(s.Categories[name], delta, s.Overflow)
+	return err
+}
+
+// Total sums the category counters
+func (s State) Total() int {
+	total := 0
+	for _, count := range s.Categories {
+		total += count
+	}
+	return total
+}
+
+// copyCategories returns an independent copy of categories, keeping nil as
+// nil
+func copyCategories(categories map[string]int) map[string]int {
+	if categories == nil {
+		return nil
+	}
+	out := make(map[string]int, len(categories))
+	for name, count := range categories {
+		out[name] = count
+	}
+	return out
+}
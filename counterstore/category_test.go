@@ -0,0 +1,65 @@
+package counterstore
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestCategoryActions(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 7})
+	actions := []AppAction{
+		IncCategoryAction{Name: "apples"}, // created at 0, then incremented
+		IncCategoryAction{Name: "apples"},
+		DecCategoryAction{Name: "pears"},
+		AddCategoryAction{Name: "plums"},
+		IncCategoryAction{Name: "plums"},
+		AddCategoryAction{Name: "plums"}, // leaves an existing category alone
+	}
+	for _, action := range actions {
+		if err := store.Dispatch(action); err != nil {
+			t.Fatalf("Dispatch(%+v): %v", action, err)
+		}
+	}
+
+	got := store.GetState()
+	if want := map[string]int{"apples": 2, "pears": -1, "plums": 1}; !reflect.DeepEqual(got.Categories, want) {
+		t.Errorf("Categories = %v, want %v", got.Categories, want)
+	}
+	if got.Count != 7 {
+		t.Errorf("Count = %d, want the main count untouched", got.Count)
+	}
+
+	for _, action := range []AppAction{IncCategoryAction{}, DecCategoryAction{}, AddCategoryAction{}} {
+		if err := store.Dispatch(action); !errors.Is(err, ErrCategoryName) {
+			t.Errorf("%T without a name = %v, want ErrCategoryName", action, err)
+		}
+	}
+}
+
+func TestCategoryOverflow(t *testing.T) {
+	store := NewStore(Reduce, State{Overflow: OverflowError, Categories: map[string]int{"big": math.MaxInt}})
+	if err := store.Dispatch(IncCategoryAction{Name: "big"}); !errors.Is(err, ErrCountOverflow) {
+		t.Errorf("overflowing a category = %v, want ErrCountOverflow", err)
+	}
+	if got := store.GetState().Categories["big"]; got != math.MaxInt {
+		t.Errorf("category = %d, want it unchanged", got)
+	}
+}
+
+func TestTotal(t *testing.T) {
+	tests := []struct {
+		categories map[string]int
+		want       int
+	}{
+		{nil, 0},
+		{map[string]int{"a": 3}, 3},
+		{map[string]int{"a": 3, "b": 4, "c": -2}, 5},
+	}
+	for _, tt := range tests {
+		if got := (State{Categories: tt.categories}).Total(); got != tt.want {
+			t.Errorf("Total of %v = %d, want %d", tt.categories, got, tt.want)
+		}
+	}
+}
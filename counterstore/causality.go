@@ -0,0 +1,102 @@
+package counterstore
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"sync"
+)
+
+// CausalNode is one dispatch in the causality tree, with the dispatches made
+// while it was running as its children
+type CausalNode struct {
+	ID       int
+	Action   any
+	Children []*CausalNode
+}
+
+func (n *CausalNode) clone() *CausalNode {
+	c := &CausalNode{ID: n.ID, Action: n.Action}
+	for _, child := range n.Children {
+		c.Children = append(c.Children, child.clone())
+	}
+	return c
+}
+
+// Causality records which dispatch triggered which, tracking the action in
+// flight separately for every goroutine
+type Causality struct {
+	mu      sync.Mutex
+	nextID  int
+	roots   []*CausalNode
+	current map[uint64][]*CausalNode
+}
+
+func NewCausality() *Causality {
+	return &Causality{current: map[uint64][]*CausalNode{}}
+}
+
+// CausalityTree returns a copy of the recorded top-level dispatches and their
+// descendants, in dispatch order
+func (c *Causality) CausalityTree() []*CausalNode {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	roots := make([]*CausalNode, 0, len(c.roots))
+	for _, root := range c.roots {
+		roots = append(roots, root.clone())
+	}
+	return roots
+}
+
+func (c *Causality) enter(gid uint64, action any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nextID++
+	node := &CausalNode{ID: c.nextID, Action: action}
+	stack := c.current[gid]
+	if len(stack) == 0 {
+		c.roots = append(c.roots, node)
+	} else {
+		parent := stack[len(stack)-1]
+		parent.Children = append(parent.Children, node)
+	}
+	c.current[gid] = append(stack, node)
+}
+
+func (c *Causality) leave(gid uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stack := c.current[gid]
+	if len(stack) <= 1 {
+		delete(c.current, gid)
+		return
+	}
+	c.current[gid] = stack[:len(stack)-1]
+}
+
+// goroutineID parses the current goroutine's ID from its stack header. Go
+// offers no supported way to get it; this is good enough for a debug aid.
+func goroutineID() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+	if i := bytes.IndexByte(buf, ' '); i >= 0 {
+		buf = buf[:i]
+	}
+	id, _ := strconv.ParseUint(string(buf), 10, 64)
+	return id
+}
+
+// Causality Middleware records each dispatch as a child of the dispatch
+// running on the same goroutine, if any. Install it outermost so dispatches
+// made by thunks are seen while their parent is still in flight.
+func CausalityMiddleware[S StateProvider[S], A Action[S]](causality *Causality) Middleware[S, A] {
+	return func(store *Store[S, A], next Dispatch[A]) Dispatch[A] {
+		return func(action A) error {
+			gid := goroutineID()
+			causality.enter(gid, action)
+			defer causality.leave(gid)
+			return next(action)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package counterstore
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestCausalityTree(t *testing.T) {
+	causality := NewCausality()
+	store := NewStore(Reduce, State{}, CausalityMiddleware[State, AppAction](causality), ThunkMiddleware[State, AppAction])
+
+	thunk := Thunk[State, AppAction]{Run: func(dispatch Dispatch[AppAction], getState func() State) {
+		dispatch(IncrementAction{})
+		dispatch(AddAmountAction{Amount: 2})
+	}}
+	store.Dispatch(thunk)
+	store.Dispatch(ResetAction{})
+
+	roots := causality.CausalityTree()
+	if len(roots) != 2 {
+		t.Fatalf("%d roots, want 2", len(roots))
+	}
+	if _, ok := roots[0].Action.(Thunk[State, AppAction]); !ok {
+		t.Errorf("first root is %T, want the thunk", roots[0].Action)
+	}
+	children := roots[0].Children
+	if len(children) != 2 {
+		t.Fatalf("thunk has %d children, want 2", len(children))
+	}
+	if children[0].Action != (IncrementAction{}) || children[1].Action != (AddAmountAction{Amount: 2}) {
+		t.Errorf("children are %v and %v", children[0].Action, children[1].Action)
+	}
+	if roots[1].Action != (ResetAction{}) || len(roots[1].Children) != 0 {
+		t.Errorf("second root = %+v, want a childless reset", roots[1])
+	}
+	if got := store.GetState().Count; got != 0 {
+		t.Errorf("Count = %d, want 0", got)
+	}
+}
+
+func TestCausalityPerGoroutine(t *testing.T) {
+	causality := NewCausality()
+	store := NewStore(Reduce, State{}, CausalityMiddleware[State, AppAction](causality))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			store.Dispatch(IncrementAction{})
+		}()
+	}
+	wg.Wait()
+
+	// Concurrent dispatches on other goroutines are not each other's children
+	roots := causality.CausalityTree()
+	if len(roots) != 4 {
+		t.Fatalf("%d roots, want 4", len(roots))
+	}
+	for _, root := range roots {
+		if len(root.Children) != 0 {
+			t.Errorf("dispatch %d has children %v", root.ID, root.Children)
+		}
+	}
+}
+
+func TestCausalityTreeIsACopy(t *testing.T) {
+	causality := NewCausality()
+	store := NewStore(Reduce, State{}, CausalityMiddleware[State, AppAction](causality))
+	store.Dispatch(IncrementAction{})
+
+	causality.CausalityTree()[0].Children = []*CausalNode{{ID: 42}}
+	if got := causality.CausalityTree()[0].Children; len(got) != 0 {
+		t.Errorf("CausalityTree shares nodes with the recording: %v", got)
+	}
+}
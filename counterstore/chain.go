@@ -0,0 +1,16 @@
+package counterstore
+
+// ChainReducers combines reducers that each see the whole state into one that
+// runs them in order for every action: each receives the state the one
+// before it returned, and the last one's result is the new state. A reducer
+// can therefore post-process its predecessors, e.g. a clamp after the core
+// reducer and a statistics reducer last so it sees the clamped count. With
+// no reducers the state is returned unchanged.
+func ChainReducers[S any, A Action[S]](reducers ...Reducer[S, A]) Reducer[S, A] {
+	return func(state S, action A) S {
+		for _, reducer := range reducers {
+			state = reducer(state, action)
+		}
+		return state
+	}
+}
@@ -0,0 +1,51 @@
+package counterstore
+
+import "testing"
+
+// clampReducer keeps the count within ±10
+func clampReducer(state State, action AppAction) State {
+	state.Count = max(-10, min(10, state.Count))
+	return state
+}
+
+// peakReducer records the highest count seen in Target, standing in for a
+// statistics reducer
+func peakReducer(state State, action AppAction) State {
+	if state.Count > state.Target {
+		state.Target = state.Count
+	}
+	return state
+}
+
+func TestChainReducers(t *testing.T) {
+	store := NewStore(ChainReducers(Reduce, clampReducer, peakReducer), State{})
+
+	store.Dispatch(AddAmountAction{Amount: 7})
+	if got := store.GetState(); got.Count != 7 || got.Target != 7 {
+		t.Fatalf("after +7: Count %d, peak %d; want 7, 7", got.Count, got.Target)
+	}
+	// The clamp runs before the statistics, so the peak never passes 10
+	store.Dispatch(AddAmountAction{Amount: 50})
+	if got := store.GetState(); got.Count != 10 || got.Target != 10 {
+		t.Errorf("after +50: Count %d, peak %d; want clamped 10, 10", got.Count, got.Target)
+	}
+	store.Dispatch(AddAmountAction{Amount: -100})
+	if got := store.GetState(); got.Count != -10 || got.Target != 10 {
+		t.Errorf("after -100: Count %d, peak %d; want -10, 10", got.Count, got.Target)
+	}
+}
+
+func TestChainReducersOrder(t *testing.T) {
+	// Statistics before the clamp see the unclamped count
+	reducer := ChainReducers(Reduce, peakReducer, clampReducer)
+	if got := reducer(State{}, AddAmountAction{Amount: 50}); got.Count != 10 || got.Target != 50 {
+		t.Errorf("peak then clamp: Count %d, peak %d; want 10, 50", got.Count, got.Target)
+	}
+}
+
+func TestChainReducersEmpty(t *testing.T) {
+	reducer := ChainReducers[State, AppAction]()
+	if got := reducer(State{Count: 3}, IncrementAction{}); got.Count != 3 {
+		t.Errorf("empty chain changed Count to %d", got.Count)
+	}
+}
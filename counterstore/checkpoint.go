@@ -0,0 +1,87 @@
+package counterstore
+
+import (
+	"sync"
+	"time"
+)
+
+// checkpointLimit is the number of periodic checkpoints kept; the oldest are
+// dropped first
+const checkpointLimit = 60
+
+// StartPeriodicCheckpoint copies the state into the store's checkpoint list
+// every period, read from the store's clock, until the returned function is
+// called or its task is cancelled. Checkpoints are coarse recovery points
+// kept apart from undo history, so undoing or redoing never touches them.
+func (s *Store[S, A]) StartPeriodicCheckpoint(every time.Duration) (stop func()) {
+	if every <= 0 {
+		return func() {}
+	}
+
+	var mu sync.Mutex
+	var timer Timer
+	stopped := false
+	halt := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		stopped = true
+		timer.Stop()
+	}
+
+	var tick func()
+	tick = func() {
+		s.mu.Lock()
+		s.checkpoints = append(s.checkpoints, s.state.Copy())
+		if len(s.checkpoints) > checkpointLimit {
+			s.checkpoints = s.checkpoints[len(s.checkpoints)-checkpointLimit:]
+		}
+		s.mu.Unlock()
+
+		mu.Lock()
+		defer mu.Unlock()
+		if !stopped {
+			timer = s.clock.AfterFunc(every, tick)
+		}
+	}
+
+	mu.Lock()
+	timer = s.clock.AfterFunc(every, tick)
+	mu.Unlock()
+	_, done := s.RegisterTask("periodic checkpoint", halt)
+
+	return func() {
+		halt()
+		done()
+	}
+}
+
+// Checkpoints returns copies of the periodic checkpoints, oldest first
+func (s *Store[S, A]) Checkpoints() []S {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	checkpoints := make([]S, len(s.checkpoints))
+	for i, state := range s.checkpoints {
+		checkpoints[i] = state.Copy()
+	}
+	return checkpoints
+}
+
+// RestoreCheckpoint reverts the state to checkpoint i, as indexed in
+// Checkpoints. The restore is itself an undo step. It returns false when i is
+// out of range.
+func (s *Store[S, A]) RestoreCheckpoint(i int) bool {
+	s.mu.Lock()
+	if i < 0 || i >= len(s.checkpoints) {
+		s.mu.Unlock()
+		return false
+	}
+	if prev, ok := s.historySnapshot(); ok {
+		s.pushHistory(prev)
+	}
+	s.setState(s.checkpoints[i].Copy())
+	s.mu.Unlock()
+
+	s.changed()
+	s.settle()
+	return true
+}
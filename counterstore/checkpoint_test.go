@@ -0,0 +1,112 @@
+package counterstore
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPeriodicCheckpoints(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	store := NewStoreWithOptions(Reduce, State{}, WithClock[State, AppAction](clock))
+	stop := store.StartPeriodicCheckpoint(time.Minute)
+
+	for _, value := range []int{10, 20, 30} {
+		store.Dispatch(SetCountAction{Value: value})
+		clock.Advance(time.Minute)
+	}
+	checkpoints := store.Checkpoints()
+	if len(checkpoints) != 3 || checkpoints[0].Count != 10 || checkpoints[2].Count != 30 {
+		t.Fatalf("checkpoints = %+v, want counts 10, 20, 30", checkpoints)
+	}
+
+	// Returned checkpoints are copies
+	checkpoints[0].Count = 99
+	if store.Checkpoints()[0].Count != 10 {
+		t.Error("editing a returned checkpoint changed the store's")
+	}
+
+	stop()
+	clock.Advance(time.Hour)
+	if got := len(store.Checkpoints()); got != 3 {
+		t.Errorf("%d checkpoints after stop, want 3", got)
+	}
+	if got := clock.Pending(); got != 0 {
+		t.Errorf("Pending = %d after stop, want 0", got)
+	}
+}
+
+func TestPeriodicCheckpointTask(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	store := NewStoreWithOptions(Reduce, State{}, WithClock[State, AppAction](clock))
+	stop := store.StartPeriodicCheckpoint(time.Minute)
+
+	tasks := store.Tasks()
+	if len(tasks) != 1 || tasks[0].Name != "periodic checkpoint" {
+		t.Fatalf("Tasks = %+v, want the periodic checkpoint", tasks)
+	}
+	// Cancelling the task stops the checkpoints
+	if !store.CancelTask(tasks[0].ID) {
+		t.Fatal("CancelTask did not find the checkpoint task")
+	}
+	clock.Advance(time.Hour)
+	if got := len(store.Checkpoints()); got != 0 {
+		t.Errorf("%d checkpoints after cancelling, want none", got)
+	}
+	if got := clock.Pending(); got != 0 {
+		t.Errorf("Pending = %d after cancelling, want 0", got)
+	}
+	stop()
+
+	// and stopping it ends the task
+	store.StartPeriodicCheckpoint(time.Minute)()
+	if got := store.Tasks(); len(got) != 0 {
+		t.Errorf("Tasks after stop = %+v", got)
+	}
+}
+
+func TestRestoreCheckpoint(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	store := NewStoreWithOptions(Reduce, State{}, WithClock[State, AppAction](clock))
+	defer store.StartPeriodicCheckpoint(time.Second)()
+
+	store.Dispatch(SetCountAction{Value: 4})
+	clock.Advance(time.Second)
+	store.Dispatch(SetCountAction{Value: 8})
+	undoSteps := store.HistoryLen()
+
+	if !store.RestoreCheckpoint(0) {
+		t.Fatal("RestoreCheckpoint(0) returned false")
+	}
+	if got := store.GetState().Count; got != 4 {
+		t.Errorf("Count after restore = %d, want 4", got)
+	}
+	if store.RestoreCheckpoint(1) || store.RestoreCheckpoint(-1) {
+		t.Error("an out-of-range restore succeeded")
+	}
+
+	// The restore is an undo step, and undoing never touches the checkpoints
+	if got := store.HistoryLen(); got != undoSteps+1 {
+		t.Errorf("HistoryLen = %d, want %d", got, undoSteps+1)
+	}
+	store.Undo()
+	if got := store.GetState().Count; got != 8 {
+		t.Errorf("Count after undoing the restore = %d, want 8", got)
+	}
+	if got := len(store.Checkpoints()); got != 1 {
+		t.Errorf("%d checkpoints after Undo, want 1", got)
+	}
+}
+
+func TestCheckpointLimit(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	store := NewStoreWithOptions(Reduce, State{}, WithClock[State, AppAction](clock))
+	defer store.StartPeriodicCheckpoint(time.Second)()
+	for i := 0; i < checkpointLimit+5; i++ {
+		store.Dispatch(IncrementAction{})
+		clock.Advance(time.Second)
+	}
+	checkpoints := store.Checkpoints()
+	if len(checkpoints) != checkpointLimit || checkpoints[0].Count != 6 {
+		t.Errorf("%d checkpoints starting at %d, want %d starting at 6", len(checkpoints), checkpoints[0].Count, checkpointLimit)
+	}
+}
@@ -0,0 +1,175 @@
+package counterstore
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock is the source of time for every timer-based feature, so tests can
+// substitute a FakeClock for the wall clock
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+	AfterFunc(d time.Duration, f func()) Timer
+	Tick(d time.Duration) Ticker
+}
+
+// Timer is a pending AfterFunc call
+type Timer interface {
+	Stop() bool
+}
+
+// Ticker delivers the time on C every period until stopped
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// orRealClock returns clock, or RealClock when it is nil
+func orRealClock(clock Clock) Clock {
+	if clock == nil {
+		return RealClock{}
+	}
+	return clock
+}
+
+// RealClock is the wall clock
+type RealClock struct{}
+
+func (RealClock) Now() time.Time                         { return time.Now() }
+func (RealClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (RealClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+func (RealClock) Tick(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+type realTicker struct{ t *time.Ticker }
+
+func (t realTicker) C() <-chan time.Time { return t.t.C }
+func (t realTicker) Stop()               { t.t.Stop() }
+
+// FakeClock is a manually advanced clock. Timers and tickers fire only from
+// Advance, in time order, on the goroutine calling it: AfterFunc callbacks
+// run synchronously and channel sends are dropped if the reader lags, like
+// a real ticker.
+type FakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	seq    int
+	timers []*fakeTimer
+}
+
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start}
+}
+
+type fakeTimer struct {
+	clock  *FakeClock
+	seq    int
+	when   time.Time
+	period time.Duration
+	fn     func()
+	ch     chan time.Time
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	return c.add(d, 0, nil).ch
+}
+
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) Timer {
+	return c.add(d, 0, f)
+}
+
+func (c *FakeClock) Tick(d time.Duration) Ticker {
+	return fakeTicker{c.add(d, d, nil)}
+}
+
+func (c *FakeClock) add(d, period time.Duration, fn func()) *fakeTimer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.seq++
+	t := &fakeTimer{clock: c, seq: c.seq, when: c.now.Add(d), period: period, fn: fn}
+	if fn == nil {
+		t.ch = make(chan time.Time, 1)
+	}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+// Pending returns the number of timers and tickers waiting to fire
+func (c *FakeClock) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.timers)
+}
+
+// Advance moves the clock forward by d, firing everything that falls due on
+// the way at its scheduled time
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	target := c.now.Add(d)
+	c.mu.Unlock()
+
+	for {
+		c.mu.Lock()
+		sort.SliceStable(c.timers, func(i, j int) bool {
+			a, b := c.timers[i], c.timers[j]
+			if !a.when.Equal(b.when) {
+				return a.when.Before(b.when)
+			}
+			return a.seq < b.seq
+		})
+		if len(c.timers) == 0 || c.timers[0].when.After(target) {
+			c.now = target
+			c.mu.Unlock()
+			return
+		}
+
+		t := c.timers[0]
+		c.now = t.when
+		if t.period > 0 {
+			t.when = t.when.Add(t.period)
+		} else {
+			c.timers = c.timers[1:]
+		}
+		now := c.now
+		c.mu.Unlock()
+
+		if t.fn != nil {
+			t.fn()
+		} else {
+			select {
+			case t.ch <- now:
+			default:
+			}
+		}
+	}
+}
+
+func (c *FakeClock) remove(t *fakeTimer) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, other := range c.timers {
+		if other == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (t *fakeTimer) Stop() bool { return t.clock.remove(t) }
+
+type fakeTicker struct{ t *fakeTimer }
+
+func (t fakeTicker) C() <-chan time.Time { return t.t.ch }
+func (t fakeTicker) Stop()               { t.t.clock.remove(t.t) }
@@ -0,0 +1,65 @@
+package counterstore
+
+import (
+	"testing"
+	"time"
+)
+
+var epoch = time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+
+func TestFakeClockAdvance(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	var fired []string
+	clock.AfterFunc(2*time.Second, func() { fired = append(fired, "b") })
+	clock.AfterFunc(time.Second, func() { fired = append(fired, "a") })
+	stopped := clock.AfterFunc(time.Second, func() { fired = append(fired, "stopped") })
+	if !stopped.Stop() {
+		t.Fatal("Stop of a pending timer returned false")
+	}
+	after := clock.After(3 * time.Second)
+
+	clock.Advance(1500 * time.Millisecond)
+	if len(fired) != 1 || fired[0] != "a" {
+		t.Fatalf("after 1.5s fired %v, want [a]", fired)
+	}
+	if got := clock.Now(); !got.Equal(epoch.Add(1500 * time.Millisecond)) {
+		t.Errorf("Now = %v", got)
+	}
+
+	clock.Advance(2 * time.Second)
+	if len(fired) != 2 || fired[1] != "b" {
+		t.Errorf("after 3.5s fired %v, want [a b]", fired)
+	}
+	select {
+	case at := <-after:
+		if !at.Equal(epoch.Add(3 * time.Second)) {
+			t.Errorf("After delivered %v, want its due time", at)
+		}
+	default:
+		t.Error("After channel did not fire")
+	}
+	if got := clock.Pending(); got != 0 {
+		t.Errorf("Pending = %d, want 0", got)
+	}
+}
+
+func TestFakeClockTick(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	ticker := clock.Tick(time.Second)
+	ticks := 0
+	for i := 0; i < 3; i++ {
+		clock.Advance(time.Second)
+		select {
+		case <-ticker.C():
+			ticks++
+		default:
+		}
+	}
+	if ticks != 3 {
+		t.Errorf("%d ticks in 3s, want 3", ticks)
+	}
+	ticker.Stop()
+	if got := clock.Pending(); got != 0 {
+		t.Errorf("Pending after Stop = %d, want 0", got)
+	}
+}
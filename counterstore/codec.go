@@ -0,0 +1,117 @@
+package counterstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// actionEnvelope is the wire form of an action: its registered name plus
+// the JSON encoding of its fields
+type actionEnvelope struct {
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+var (
+	actionTypesMu sync.RWMutex
+	actionTypes   = map[string]reflect.Type{}
+)
+
+func init() {
+	RegisterAction(IncrementAction{})
+	RegisterAction(DecrementAction{})
+	RegisterAction(AddAmountAction{})
+	RegisterAction(AddCentsAction{})
+	RegisterAction(ResetAction{})
+	RegisterAction(NegateAction{})
+	RegisterAction(RequestResetAction{})
+	RegisterAction(ConfirmResetAction{})
+	RegisterAction(CancelResetAction{})
+	RegisterAction(ToggleCountdownAction{})
+	RegisterAction(TickAction{})
+	RegisterAction(SetCountAction{})
+	RegisterAction(ApplyExprAction{})
+	RegisterAction(SetTargetAction{})
+	RegisterAction(SetPercentAction{})
+	RegisterAction(SetPresentationModeAction{})
+	RegisterAction(ToggleAlwaysOnTopAction{})
+	RegisterAction(ToggleMinimalAction{})
+	RegisterAction(SetHelpAction{})
+	RegisterAction(SetFlagAction{})
+	RegisterAction(SetOverflowModeAction{})
+	RegisterAction(SetPaletteAction{})
+	RegisterAction(ShowToastAction{})
+	RegisterAction(ExpireToastsAction{})
+	RegisterAction(ApplyPatchAction{})
+	RegisterAction(SetStatusAction{})
+	RegisterAction(ScoreAction{})
+	RegisterAction(PassTurnAction{})
+	RegisterAction(SaveBookmarkAction{})
+	RegisterAction(GotoBookmarkAction{})
+	RegisterAction(SetIdleAction{})
+	RegisterAction(SetNoteAction{})
+	RegisterAction(StartStopwatchAction{})
+	RegisterAction(StopStopwatchAction{})
+	RegisterAction(StopwatchTickAction{})
+	RegisterAction(ImportCountsAction{})
+	RegisterAction(SequenceAction{})
+	RegisterAction(AddCategoryAction{})
+	RegisterAction(IncCategoryAction{})
+	RegisterAction(DecCategoryAction{})
+	RegisterAction(CyclePresetAction{})
+	RegisterAction(SetStepSizeAction{})
+	RegisterAction(RecordDwellAction{})
+	RegisterAction(RollAction{})
+	RegisterAction(scopeRestoreAction{})
+}
+
+// RegisterAction makes the sample's concrete type known to the codec under
+// its action name, so it can be decoded again
+func RegisterAction(sample AppAction) {
+	actionTypesMu.Lock()
+	defer actionTypesMu.Unlock()
+	actionTypes[ActionName(sample)] = reflect.TypeOf(sample)
+}
+
+// EncodeAction serializes a registered action to JSON
+func EncodeAction(action AppAction) ([]byte, error) {
+	name := ActionName(action)
+
+	actionTypesMu.RLock()
+	_, ok := actionTypes[name]
+	actionTypesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("encode action: %T is not registered", action)
+	}
+
+	payload, err := json.Marshal(action)
+	if err != nil {
+		return nil, fmt.Errorf("encode action %s: %w", name, err)
+	}
+	return json.Marshal(actionEnvelope{Type: name, Payload: payload})
+}
+
+// DecodeAction parses an action produced by EncodeAction
+func DecodeAction(data []byte) (AppAction, error) {
+	var env actionEnvelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("decode action: %w", err)
+	}
+
+	actionTypesMu.RLock()
+	t, ok := actionTypes[env.Type]
+	actionTypesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("decode action: unknown type %q", env.Type)
+	}
+
+	ptr := reflect.New(t)
+	if len(env.Payload) > 0 {
+		if err := json.Unmarshal(env.Payload, ptr.Interface()); err != nil {
+			return nil, fmt.Errorf("decode action %s: %w", env.Type, err)
+		}
+	}
+	return ptr.Elem().Interface().(AppAction), nil
+}
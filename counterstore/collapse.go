@@ -0,0 +1,67 @@
+package counterstore
+
+import (
+	"sync"
+	"time"
+)
+
+// countDelta is how far action moves the count, for the actions that move it
+// by a fixed amount, also inside an annotation or other carrier
+func countDelta(action AppAction) (int, bool) {
+	switch a := plainAction(action).(type) {
+	case IncrementAction:
+		return 1, true
+	case DecrementAction:
+		return -1, true
+	case AddAmountAction:
+		return a.Amount, true
+	}
+	return 0, false
+}
+
+// CollapseInverse Middleware keeps undo history free of moves that cancel
+// out: when an increment, decrement or AddAmountAction is undone by the very
+// next action, within window of it on clock, both undo steps are dropped. The
+// state is untouched, so undoing afterwards goes straight to before the
+// pair. Only a pair that really left the count where it was collapses, as
+// saturation can keep an inverse from cancelling. A nil clock means the
+// wall clock.
+func CollapseInverseMiddleware(clock Clock, window time.Duration) Middleware[State, AppAction] {
+	clock = orRealClock(clock)
+	return func(store *Store[State, AppAction], next Dispatch[AppAction]) Dispatch[AppAction] {
+		var mu sync.Mutex
+		var last struct {
+			delta   int
+			at      time.Time
+			count   int
+			history int
+			ok      bool
+		}
+
+		return func(action AppAction) error {
+			before := store.GetState().Count
+			if err := next(action); err != nil {
+				return err
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			delta, ok := countDelta(action)
+			if !ok {
+				last.ok = false
+				return nil
+			}
+			now := clock.Now()
+			history := store.HistoryLen()
+			if last.ok && delta == -last.delta && now.Sub(last.at) <= window &&
+				history == last.history+1 && store.GetState().Count == last.count {
+				if store.dropHistory(2) {
+					last.ok = false
+					return nil
+				}
+			}
+			last.delta, last.at, last.count, last.history, last.ok = delta, now, before, history, true
+			return nil
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package counterstore
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCollapseInverseMiddleware(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	store := NewStore(Reduce, State{}, CollapseInverseMiddleware(clock, time.Second))
+
+	store.Dispatch(AddAmountAction{Amount: 5})
+	store.Dispatch(IncrementAction{})
+	store.Dispatch(DecrementAction{})
+	if got := store.HistoryLen(); got != 1 {
+		t.Fatalf("HistoryLen after an inc/dec pair = %d, want the pair dropped (1)", got)
+	}
+	if got := store.GetState().Count; got != 5 {
+		t.Fatalf("Count = %d, want 5", got)
+	}
+
+	store.Dispatch(AddAmountAction{Amount: 3})
+	store.Dispatch(AddAmountAction{Amount: -3})
+	if got := store.HistoryLen(); got != 1 {
+		t.Errorf("HistoryLen after an AddAmount pair = %d, want 1", got)
+	}
+
+	// Undo goes straight to before the collapsed pairs
+	store.Undo()
+	if got := store.GetState().Count; got != 0 {
+		t.Errorf("Count after Undo = %d, want 0", got)
+	}
+}
+
+func TestCollapseInverseMiddlewareAnnotated(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	store := NewStore(Reduce, State{}, CollapseInverseMiddleware(clock, time.Second))
+	meta := map[string]string{"source": "keyboard"}
+
+	store.Dispatch(WithMeta(IncrementAction{}, meta))
+	store.Dispatch(WithMeta(AddAmountAction{Amount: -1}, meta))
+	if got := store.HistoryLen(); got != 0 {
+		t.Errorf("HistoryLen after an annotated pair = %d, want the pair dropped", got)
+	}
+}
+
+func TestCollapseInverseMiddlewareKeeps(t *testing.T) {
+	tests := []struct {
+		name    string
+		initial State
+		steps   func(store *Store[State, AppAction], clock *FakeClock)
+		history int
+	}{
+		{"outside the window", State{}, func(store *Store[State, AppAction], clock *FakeClock) {
+			store.Dispatch(IncrementAction{})
+			clock.Advance(2 * time.Second)
+			store.Dispatch(DecrementAction{})
+		}, 2},
+		{"not inverse", State{}, func(store *Store[State, AppAction], clock *FakeClock) {
+			store.Dispatch(IncrementAction{})
+			store.Dispatch(AddAmountAction{Amount: -2})
+		}, 2},
+		{"interrupted", State{}, func(store *Store[State, AppAction], clock *FakeClock) {
+			store.Dispatch(IncrementAction{})
+			store.Dispatch(SetTargetAction{Target: 4})
+			store.Dispatch(DecrementAction{})
+		}, 3},
+		{"saturated", State{Count: math.MaxInt, Overflow: OverflowSaturate}, func(store *Store[State, AppAction], clock *FakeClock) {
+			store.Dispatch(IncrementAction{})
+			store.Dispatch(DecrementAction{})
+		}, 2},
+		{"only one pair of three", State{}, func(store *Store[State, AppAction], clock *FakeClock) {
+			store.Dispatch(IncrementAction{})
+			store.Dispatch(DecrementAction{})
+			store.Dispatch(IncrementAction{})
+		}, 1},
+	}
+	for _, tt := range tests {
+		clock := NewFakeClock(epoch)
+		store := NewStore(Reduce, tt.initial, CollapseInverseMiddleware(clock, time.Second))
+		tt.steps(store, clock)
+		if got := store.HistoryLen(); got != tt.history {
+			t.Errorf("%s: HistoryLen = %d, want %d", tt.name, got, tt.history)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package counterstore
+
+// AddCommitHook registers fn to run on every commit of a new state, from
+// dispatches as well as Undo, Redo, JumpTo and SetState, while the store's
+// lock is still held. Hooks run in registration order, before any subscriber
+// and before another dispatch can start, so they suit side effects that must
+// move in step with the state, such as mirroring it into an atomic. Because
+// the lock is held, a hook must be quick and must not call back into the
+// store: Dispatch, GetState and the like would deadlock. prev and next are
+// the store's own values and must not be modified.
+func (s *Store[S, A]) AddCommitHook(fn func(prev, next S)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.commitHooks = append(s.commitHooks, fn)
+}
+
+// runCommitHooks calls the commit hooks for the move from prev to next.
+// Callers must hold s.mu.
+func (s *Store[S, A]) runCommitHooks(prev, next S) {
+	for _, fn := range s.commitHooks {
+		fn(prev, next)
+	}
+}
+
+// AddApplyHook registers fn to run whenever the reducer's result for an
+// action is committed, with the action as the reducer saw it, after every
+// transform, and the states either side of it. It runs under the store's
+// lock right after the commit hooks and carries the same restrictions. A
+// hook sees only those actions, in commit order; actions that were dropped
+// or rejected never reach it, and neither do commits that bypass the
+// reducer, such as Undo, Redo, JumpTo and SetState.
+func (s *Store[S, A]) AddApplyHook(fn func(action A, prev, next S)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.applyHooks = append(s.applyHooks, fn)
+}
+
+// runApplyHooks calls the apply hooks for action's commit from prev to next.
+// Callers must hold s.mu.
+func (s *Store[S, A]) runApplyHooks(action A, prev, next S) {
+	for _, fn := range s.applyHooks {
+		fn(action, prev, next)
+	}
+}
@@ -0,0 +1,65 @@
+package counterstore
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCommitHookRunsBeforeSubscribers(t *testing.T) {
+	store := NewStore(Reduce, State{})
+	var events []string
+	var mirrored int
+	store.AddCommitHook(func(prev, next State) {
+		if next.Count != prev.Count+1 {
+			t.Errorf("hook saw %d -> %d", prev.Count, next.Count)
+		}
+		mirrored = next.Count
+		events = append(events, "hook")
+	})
+	store.AddCommitHook(func(prev, next State) { events = append(events, "second hook") })
+	store.Subscribe(func() {
+		if mirrored != store.GetState().Count {
+			t.Errorf("subscriber ran with the mirror at %d, state at %d", mirrored, store.GetState().Count)
+		}
+		events = append(events, "subscriber")
+	})
+
+	store.Dispatch(IncrementAction{})
+	store.Dispatch(IncrementAction{})
+	want := []string{"hook", "second hook", "subscriber", "hook", "second hook", "subscriber"}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("events = %v, want %v", events, want)
+	}
+}
+
+func TestCommitHookSeesEveryCommit(t *testing.T) {
+	store := NewStore(Reduce, State{})
+	var commits []int
+	store.AddCommitHook(func(prev, next State) { commits = append(commits, next.Count) })
+
+	store.Dispatch(AddAmountAction{Amount: 5})
+	store.Undo()
+	store.Redo()
+	store.JumpTo(State{Count: 9})
+	store.SetState(State{Count: 4})
+	store.Dispatch(ApplyExprAction{Expr: "x/0"}) // rejected, nothing committed
+
+	if want := []int{5, 0, 5, 9, 4}; !reflect.DeepEqual(commits, want) {
+		t.Errorf("commits = %v, want %v", commits, want)
+	}
+}
+
+func TestApplyHook(t *testing.T) {
+	store := NewStore(Reduce, State{})
+	var applied []AppAction
+	store.AddApplyHook(func(action AppAction, prev, next State) { applied = append(applied, action) })
+
+	store.Dispatch(IncrementAction{})
+	store.Undo()
+	store.SetState(State{Count: 3})
+	store.Dispatch(DecrementAction{})
+
+	if want := []AppAction{IncrementAction{}, DecrementAction{}}; !reflect.DeepEqual(applied, want) {
+		t.Errorf("apply hook saw %v, want only the reduced actions %v", applied, want)
+	}
+}
@@ -0,0 +1,63 @@
+package counterstore
+
+// Confirm Middleware asks confirm before forwarding any action for which
+// needsConfirm reports true, dropping the action if confirmation is refused.
+// confirm is called synchronously on the dispatching goroutine, so a UI
+// must not block it on a modal drawn by that same goroutine.
+func ConfirmMiddleware[S StateProvider[S], A Action[S]](needsConfirm func(A) bool, confirm func(A) bool) Middleware[S, A] {
+	return func(store *Store[S, A], next Dispatch[A]) Dispatch[A] {
+		return func(action A) error {
+			if needsConfirm(action) && !confirm(action) {
+				store.ReportBlocked("not confirmed")
+				return nil
+			}
+			return next(action)
+		}
+	}
+}
+
+// KeepTransient keeps current's UI, so undoing a confirmed reset does not
+// bring its confirmation prompt back, nor undoing dim the window, start or
+// stop the stopwatch or forget dwell recorded
+func (s State) KeepTransient(current State) State {
+	state := s.Copy()
+	state.UI = current.UI.Copy()
+	return state
+}
+
+// RequestResetAction asks for a reset without performing it, setting
+// ConfirmPending until it is confirmed or cancelled
+type RequestResetAction struct{}
+
+func (a RequestResetAction) Undoable() bool { return false }
+
+func (a RequestResetAction) Apply(s State) State {
+	state := s.Copy()
+	state.UI.ConfirmPending = true
+	return state
+}
+
+// ConfirmResetAction performs a requested reset. Without a pending request
+// it does nothing, so a stray confirmation cannot zero the count.
+type ConfirmResetAction struct{}
+
+func (a ConfirmResetAction) Apply(s State) State {
+	if !s.UI.ConfirmPending {
+		return s
+	}
+	state := s.Copy()
+	state.Count = 0
+	state.UI.ConfirmPending = false
+	return state
+}
+
+// CancelResetAction withdraws a requested reset
+type CancelResetAction struct{}
+
+func (a CancelResetAction) Undoable() bool { return false }
+
+func (a CancelResetAction) Apply(s State) State {
+	state := s.Copy()
+	state.UI.ConfirmPending = false
+	return state
+}
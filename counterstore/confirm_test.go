@@ -0,0 +1,42 @@
+package counterstore
+
+import "testing"
+
+func TestConfirmResetFlow(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 7})
+
+	store.Dispatch(RequestResetAction{})
+	if got := store.GetState(); !got.UI.ConfirmPending || got.Count != 7 {
+		t.Fatalf("after request: %+v, want pending with the count kept", got)
+	}
+	store.Dispatch(ConfirmResetAction{})
+	if got := store.GetState(); got.UI.ConfirmPending || got.Count != 0 {
+		t.Fatalf("after confirm: %+v, want the count zeroed", got)
+	}
+
+	// Undoing the reset restores the count without the prompt
+	if !store.Undo() {
+		t.Fatal("the confirmed reset is not an undo step")
+	}
+	if got := store.GetState(); got.Count != 7 || got.UI.ConfirmPending {
+		t.Errorf("after Undo: %+v, want 7 with nothing pending", got)
+	}
+}
+
+func TestCancelResetFlow(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 7})
+	store.Dispatch(RequestResetAction{})
+	store.Dispatch(CancelResetAction{})
+	if got := store.GetState(); got.UI.ConfirmPending || got.Count != 7 {
+		t.Fatalf("after cancel: %+v, want 7 with nothing pending", got)
+	}
+	if store.CanUndo() {
+		t.Error("request and cancel became undo steps")
+	}
+
+	// Only a pending request can be confirmed
+	store.Dispatch(ConfirmResetAction{})
+	if got := store.GetState().Count; got != 7 {
+		t.Errorf("a stray confirmation zeroed the count to %d", got)
+	}
+}
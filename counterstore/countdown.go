@@ -0,0 +1,134 @@
+package counterstore
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// countdownInterval is how often a running countdown ticks
+const countdownInterval = time.Second
+
+// ToggleCountdownAction turns countdown mode on or off
+type ToggleCountdownAction struct{}
+
+func (a ToggleCountdownAction) Undoable() bool { return false }
+
+func (a ToggleCountdownAction) Apply(s State) State {
+	state := s.Copy()
+	state.Countdown = !state.Countdown
+	return state
+}
+
+// TickAction is one second of a countdown: it moves a positive count one
+// step towards zero, and ends countdown mode once the count is at zero. It
+// does nothing outside countdown mode.
+type TickAction struct{}
+
+func (a TickAction) Undoable() bool { return false }
+
+func (a TickAction) Apply(s State) State {
+	if !s.Countdown {
+		return s
+	}
+	state := s.Copy()
+	if state.Count > 0 {
+		state.Count--
+	}
+	if state.Count <= 0 {
+		state.Countdown = false
+	}
+	return state
+}
+
+// Countdown drives countdown mode: while State.Countdown is set it
+// dispatches a TickAction every second on its clock, and calls onZero when
+// a tick brings the count to zero.
+type Countdown struct {
+	mu     sync.Mutex
+	clock  Clock
+	onZero func()
+	store  *Store[State, AppAction]
+	timer  Timer
+	done   func()
+	closed bool
+}
+
+// NewCountdown creates a countdown driver ticking on clock, or the wall clock
+// when it is nil. onZero may be nil.
+func NewCountdown(clock Clock, onZero func()) *Countdown {
+	return &Countdown{clock: orRealClock(clock), onZero: onZero}
+}
+
+// Middleware returns the middleware that starts and stops the ticker as
+// countdown mode is toggled
+func (c *Countdown) Middleware() Middleware[State, AppAction] {
+	return func(store *Store[State, AppAction], next Dispatch[AppAction]) Dispatch[AppAction] {
+		c.mu.Lock()
+		c.store = store
+		c.mu.Unlock()
+		c.sync()
+
+		return func(action AppAction) error {
+			err := next(action)
+			c.sync()
+			return err
+		}
+	}
+}
+
+// sync arms the ticker while the store is in countdown mode and disarms it
+// otherwise. The store lists countdown mode as a task; cancelling it ends
+// countdown mode.
+func (c *Countdown) sync() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.store == nil {
+		return
+	}
+	running := c.store.GetState().Countdown && !c.closed
+	switch store := c.store; {
+	case running && c.done == nil:
+		_, c.done = store.RegisterTask("countdown", func() {
+			if store.GetState().Countdown {
+				store.Dispatch(ToggleCountdownAction{})
+			}
+		})
+	case !running && c.done != nil:
+		c.done()
+		c.done = nil
+	}
+	switch {
+	case running && c.timer == nil:
+		c.timer = c.clock.AfterFunc(countdownInterval, c.tick)
+	case !running && c.timer != nil:
+		c.timer.Stop()
+		c.timer = nil
+	}
+}
+
+func (c *Countdown) tick() {
+	c.mu.Lock()
+	c.timer = nil
+	store, closed := c.store, c.closed
+	c.mu.Unlock()
+	if closed {
+		return
+	}
+
+	before := store.GetState().Count
+	if err := store.Dispatch(TickAction{}); err != nil {
+		log.Printf("countdown: %v", err)
+	}
+	if before > 0 && store.GetState().Count == 0 && c.onZero != nil {
+		c.onZero()
+	}
+}
+
+// Close stops the ticker for good
+func (c *Countdown) Close() {
+	c.mu.Lock()
+	c.closed = true
+	c.mu.Unlock()
+	c.sync()
+}
@@ -0,0 +1,88 @@
+package counterstore
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTickAction(t *testing.T) {
+	tests := []struct {
+		name  string
+		state State
+		want  State
+	}{
+		{"counts down", State{Count: 3, Countdown: true}, State{Count: 2, Countdown: true}},
+		{"stops at zero", State{Count: 1, Countdown: true}, State{Count: 0}},
+		{"ends at zero", State{Count: 0, Countdown: true}, State{Count: 0}},
+		{"leaves negatives", State{Count: -4, Countdown: true}, State{Count: -4}},
+		{"ignored outside countdown", State{Count: 3}, State{Count: 3}},
+	}
+	for _, tt := range tests {
+		got := TickAction{}.Apply(tt.state)
+		if got.Count != tt.want.Count || got.Countdown != tt.want.Countdown {
+			t.Errorf("%s: Tick(%+v) = Count %d Countdown %v, want %d %v",
+				tt.name, tt.state, got.Count, got.Countdown, tt.want.Count, tt.want.Countdown)
+		}
+	}
+}
+
+func TestCountdownTicksToZero(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	zeros := 0
+	countdown := NewCountdown(clock, func() { zeros++ })
+	defer countdown.Close()
+	store := NewStore(Reduce, State{Count: 3}, countdown.Middleware())
+
+	clock.Advance(5 * time.Second)
+	if got := store.GetState().Count; got != 3 {
+		t.Fatalf("Count = %d before countdown mode, want 3", got)
+	}
+
+	store.Dispatch(ToggleCountdownAction{})
+	if got := len(store.Tasks()); got != 1 {
+		t.Errorf("%d tasks in countdown mode, want 1", got)
+	}
+	for want := 2; want >= 0; want-- {
+		clock.Advance(countdownInterval)
+		if got := store.GetState().Count; got != want {
+			t.Fatalf("Count after a tick = %d, want %d", got, want)
+		}
+	}
+	if zeros != 1 {
+		t.Errorf("onZero fired %d times, want 1", zeros)
+	}
+	if got := store.GetState(); got.Countdown {
+		t.Error("countdown mode still on at zero")
+	}
+
+	clock.Advance(time.Minute)
+	if got := store.GetState().Count; got != 0 || zeros != 1 || clock.Pending() != 0 || len(store.Tasks()) != 0 {
+		t.Errorf("after stopping: Count %d, %d zeros, %d timers, %d tasks", got, zeros, clock.Pending(), len(store.Tasks()))
+	}
+}
+
+func TestCountdownToggleOffAndCancel(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	countdown := NewCountdown(clock, nil)
+	defer countdown.Close()
+	store := NewStore(Reduce, State{Count: 10}, countdown.Middleware())
+
+	store.Dispatch(ToggleCountdownAction{})
+	clock.Advance(2 * countdownInterval)
+	store.Dispatch(ToggleCountdownAction{})
+	clock.Advance(time.Minute)
+	if got := store.GetState().Count; got != 8 {
+		t.Errorf("Count after toggling off = %d, want 8", got)
+	}
+
+	// Cancelling the task ends countdown mode too
+	store.Dispatch(ToggleCountdownAction{})
+	tasks := store.Tasks()
+	if len(tasks) != 1 || !store.CancelTask(tasks[0].ID) {
+		t.Fatalf("could not cancel the countdown task: %v", tasks)
+	}
+	clock.Advance(time.Minute)
+	if got := store.GetState(); got.Countdown || got.Count != 8 {
+		t.Errorf("after cancelling: %+v, want countdown off at 8", got)
+	}
+}
@@ -0,0 +1,73 @@
+package counterstore
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// Debounce Middleware holds back each action until no action of the same type
+// has been dispatched for d, then forwards only the latest one. Forwarded
+// actions run from the clock's timer, after Dispatch has returned, so their
+// errors are logged rather than returned. Each held action is one of the
+// store's tasks until it is forwarded; cancelling it drops the action. A
+// wrapped action, such as an annotated one, goes by the type it wraps. A nil
+// clock means the wall clock.
+func DebounceMiddleware[S StateProvider[S], A Action[S]](clock Clock, d time.Duration) Middleware[S, A] {
+	clock = orRealClock(clock)
+	return func(store *Store[S, A], next Dispatch[A]) Dispatch[A] {
+		// held is the action waiting under a key, known by its task ID
+		type held struct {
+			id    uint64
+			timer Timer
+			done  func()
+		}
+		var mu sync.Mutex
+		waiting := map[string]held{}
+
+		// take removes and returns the action held under key, if it is the
+		// one registered as id
+		take := func(key string, id uint64) (held, bool) {
+			mu.Lock()
+			defer mu.Unlock()
+			h, ok := waiting[key]
+			if !ok || h.id != id {
+				return held{}, false
+			}
+			delete(waiting, key)
+			return h, true
+		}
+
+		return func(action A) error {
+			key := actionKey(action)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if h, ok := waiting[key]; ok {
+				h.timer.Stop()
+				h.done()
+			}
+			var id uint64
+			id, done := store.RegisterTask("debounced "+key, func() {
+				if cancelled, ok := take(key, id); ok {
+					cancelled.timer.Stop()
+				}
+			})
+			timer := clock.AfterFunc(d, func() {
+				fired, ok := take(key, id)
+				if !ok {
+					return
+				}
+				fired.done()
+
+				if err := next(action); err != nil {
+					log.Printf("Debounced action failed: %T: %v", action, err)
+				}
+				store.settle()
+			})
+			waiting[key] = held{id: id, timer: timer, done: done}
+			store.ReportBlocked("debounced")
+			return nil
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package counterstore
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDebounceMiddleware(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	store := NewStore(Reduce, State{}, DebounceMiddleware[State, AppAction](clock, 100*time.Millisecond))
+
+	store.Dispatch(AddAmountAction{Amount: 1})
+	clock.Advance(60 * time.Millisecond)
+	store.Dispatch(AddAmountAction{Amount: 2})
+	clock.Advance(60 * time.Millisecond)
+	if got := store.GetState().Count; got != 0 {
+		t.Fatalf("Count = %d before the quiet period ended, want 0", got)
+	}
+	if got := len(store.Tasks()); got != 1 {
+		t.Errorf("%d tasks, want the one held action", got)
+	}
+
+	clock.Advance(40 * time.Millisecond)
+	if got := store.GetState().Count; got != 2 {
+		t.Errorf("Count = %d, want only the latest action (2) forwarded", got)
+	}
+	if got := len(store.Tasks()); got != 0 {
+		t.Errorf("%d tasks after forwarding, want 0", got)
+	}
+}
+
+func TestDebounceMiddlewareKeysByType(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	store := NewStore(Reduce, State{}, DebounceMiddleware[State, AppAction](clock, 100*time.Millisecond))
+
+	store.Dispatch(SetTargetAction{Target: 7})
+	store.Dispatch(IncrementAction{})
+	clock.Advance(100 * time.Millisecond)
+	if got := store.GetState(); got.Count != 1 || got.Target != 7 {
+		t.Errorf("state = %+v, want both action types forwarded", got)
+	}
+}
+
+func TestDebounceMiddlewareCancel(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	store := NewStore(Reduce, State{}, DebounceMiddleware[State, AppAction](clock, 100*time.Millisecond))
+
+	store.Dispatch(IncrementAction{})
+	tasks := store.Tasks()
+	if len(tasks) != 1 || !store.CancelTask(tasks[0].ID) {
+		t.Fatalf("could not cancel the held action: %v", tasks)
+	}
+	clock.Advance(time.Second)
+	if got := store.GetState().Count; got != 0 {
+		t.Errorf("Count = %d, want the cancelled action dropped", got)
+	}
+	if got := clock.Pending(); got != 0 {
+		t.Errorf("Pending = %d, want the timer stopped", got)
+	}
+}
@@ -0,0 +1,27 @@
+package counterstore
+
+import "time"
+
+// Delay Middleware slows dispatch down for live demos, waiting d on clock
+// before forwarding each action, or the entry in overrides keyed by the
+// action's name (as ActionName gives it, e.g. "Increment") when there is
+// one. A zero or negative delay forwards at once; a nil clock means the wall
+// clock.
+//
+// The wait blocks the dispatching goroutine, and with it the UI when the
+// UI dispatches, so it is for demos and debugging only.
+func DelayMiddleware[S StateProvider[S], A Action[S]](clock Clock, d time.Duration, overrides map[string]time.Duration) Middleware[S, A] {
+	clock = orRealClock(clock)
+	return func(store *Store[S, A], next Dispatch[A]) Dispatch[A] {
+		return func(action A) error {
+			delay := d
+			if override, ok := overrides[ActionName(action)]; ok {
+				delay = override
+			}
+			if delay > 0 {
+				<-clock.After(delay)
+			}
+			return next(action)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package counterstore
+
+import (
+	"testing"
+	"time"
+)
+
+// dispatchDelayed dispatches action on a goroutine of its own and returns
+// once the middleware is waiting on clock, with a channel closed when the
+// dispatch returns
+func dispatchDelayed(t *testing.T, clock *FakeClock, store *Store[State, AppAction], action AppAction) <-chan struct{} {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		store.Dispatch(action)
+		close(done)
+	}()
+	deadline := time.Now().Add(5 * time.Second)
+	for clock.Pending() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("the dispatch never waited on the clock")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return done
+}
+
+func waitDone(t *testing.T, done <-chan struct{}) {
+	t.Helper()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the dispatch did not return after its delay")
+	}
+}
+
+func TestDelayMiddleware(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	overrides := map[string]time.Duration{"SetTarget": 2 * time.Second, "Reset": 0}
+	store := NewStore(Reduce, State{}, DelayMiddleware[State, AppAction](clock, 500*time.Millisecond, overrides))
+
+	tests := []struct {
+		action AppAction
+		delay  time.Duration
+	}{
+		{IncrementAction{}, 500 * time.Millisecond},
+		{SetTargetAction{Target: 9}, 2 * time.Second},
+	}
+	for _, tt := range tests {
+		before := store.Version()
+		done := dispatchDelayed(t, clock, store, tt.action)
+		clock.Advance(tt.delay - time.Millisecond)
+		if store.Version() != before {
+			t.Fatalf("%T forwarded before its %v delay", tt.action, tt.delay)
+		}
+		clock.Advance(time.Millisecond)
+		waitDone(t, done)
+		if store.Version() == before {
+			t.Errorf("%T was not forwarded after %v", tt.action, tt.delay)
+		}
+	}
+
+	// A zero override forwards straight away, without touching the clock
+	if err := store.Dispatch(ResetAction{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := store.GetState().Count; got != 0 || clock.Pending() != 0 {
+		t.Errorf("reset: Count %d with %d timers pending, want 0 and none", got, clock.Pending())
+	}
+}
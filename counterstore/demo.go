@@ -0,0 +1,73 @@
+package counterstore
+
+import (
+	"sync"
+	"time"
+)
+
+// DemoStep is one step of a scripted demo: after Delay it dispatches Action,
+// if any, then shows Caption, if any, on the status line until a later step
+// replaces or clears it
+type DemoStep struct {
+	Action  AppAction
+	Delay   time.Duration
+	Caption string
+}
+
+// RunDemo plays steps against store in the background, timed on the store's
+// clock, for driving the UI through a fixed sequence while a screen
+// recorder captures it. Each step is dispatched like any other action, so
+// subscribers, and with them the window, update between steps. A step's
+// dispatch failing does not stop the demo. done, which may be nil, is
+// called after the last step; calling stop ends the demo early.
+func RunDemo(store *Store[State, AppAction], steps []DemoStep, done func()) (stop func()) {
+	var mu sync.Mutex
+	var timer Timer
+	stopped := false
+
+	var run func(i int)
+	schedule := func(i int) {
+		mu.Lock()
+		if stopped {
+			mu.Unlock()
+			return
+		}
+		if i < len(steps) {
+			timer = store.clock.AfterFunc(steps[i].Delay, func() { run(i) })
+			mu.Unlock()
+			return
+		}
+		stopped = true
+		mu.Unlock()
+		if done != nil {
+			done()
+		}
+	}
+	run = func(i int) {
+		mu.Lock()
+		cancelled := stopped
+		mu.Unlock()
+		if cancelled {
+			return
+		}
+
+		step := steps[i]
+		if step.Action != nil {
+			store.Dispatch(step.Action)
+		}
+		if step.Caption != "" {
+			store.Dispatch(SetStatusAction{Text: step.Caption})
+		}
+		schedule(i + 1)
+	}
+
+	schedule(0)
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		stopped = true
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
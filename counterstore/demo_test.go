@@ -0,0 +1,72 @@
+package counterstore
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRunDemo(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	store := NewStoreWithOptions(Reduce, State{}, WithClock[State, AppAction](clock))
+	var counts []int
+	var times []time.Duration
+	store.Subscribe(func() {
+		counts = append(counts, store.GetState().Count)
+		times = append(times, clock.Now().Sub(epoch))
+	})
+	finished := false
+	RunDemo(store, []DemoStep{
+		{Action: IncrementAction{}, Delay: time.Second},
+		{Action: AddAmountAction{Amount: 5}, Delay: 2 * time.Second, Caption: "Adding five"},
+		{Caption: "That's all", Delay: 500 * time.Millisecond},
+	}, func() { finished = true })
+
+	clock.Advance(999 * time.Millisecond)
+	if len(counts) != 0 {
+		t.Fatalf("a step ran before its delay: %v", counts)
+	}
+	clock.Advance(time.Millisecond)
+	if got := store.GetState().Count; got != 1 {
+		t.Fatalf("Count after the first step = %d, want 1", got)
+	}
+	clock.Advance(2 * time.Second)
+	if got := store.GetState(); got.Count != 6 || got.Status != "Adding five" {
+		t.Fatalf("after the second step: %+v", got)
+	}
+	if finished {
+		t.Error("done called before the last step")
+	}
+	clock.Advance(500 * time.Millisecond)
+	if got := store.GetState(); got.Count != 6 || got.Status != "That's all" {
+		t.Errorf("after the caption step: %+v", got)
+	}
+	if !finished {
+		t.Error("done not called after the last step")
+	}
+
+	// Each dispatch landed at its step's time, in order
+	wantCounts := []int{1, 6, 6, 6}
+	wantTimes := []time.Duration{time.Second, 3 * time.Second, 3 * time.Second, 3500 * time.Millisecond}
+	for i := range wantCounts {
+		if i >= len(counts) || counts[i] != wantCounts[i] || times[i] != wantTimes[i] {
+			t.Fatalf("notifications = %v at %v, want %v at %v", counts, times, wantCounts, wantTimes)
+		}
+	}
+}
+
+func TestRunDemoStop(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	store := NewStoreWithOptions(Reduce, State{}, WithClock[State, AppAction](clock))
+	finished := false
+	stop := RunDemo(store, []DemoStep{
+		{Action: IncrementAction{}, Delay: time.Second},
+		{Action: IncrementAction{}, Delay: time.Second},
+	}, func() { finished = true })
+
+	clock.Advance(time.Second)
+	stop()
+	clock.Advance(time.Minute)
+	if got := store.GetState().Count; got != 1 || finished || clock.Pending() != 0 {
+		t.Errorf("after stop: Count %d, finished %v, %d timers; want 1, false, 0", got, finished, clock.Pending())
+	}
+}
@@ -0,0 +1,53 @@
+package counterstore
+
+import (
+	"go/parser"
+	gotoken "go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestNoGioImports keeps the package usable from servers and CLIs: nothing
+// in it, tests included, may import Gio
+func TestNoGioImports(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fset := gotoken.NewFileSet()
+	for _, name := range files {
+		f, err := parser.ParseFile(fset, name, nil, parser.ImportsOnly)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, imp := range f.Imports {
+			path, _ := strconv.Unquote(imp.Path.Value)
+			if strings.HasPrefix(path, "gioui.org") {
+				t.Errorf("%s imports %s", name, path)
+			}
+		}
+	}
+}
+
+func TestStoreWithoutFrontEnd(t *testing.T) {
+	store := NewStore(Reduce, State{})
+	var counts []int
+	store.Subscribe(func() { counts = append(counts, store.GetState().Count) })
+
+	for _, action := range []AppAction{IncrementAction{}, IncrementAction{}, DecrementAction{}, AddAmountAction{Amount: 10}, NegateAction{}, ResetAction{}} {
+		if err := store.Dispatch(action); err != nil {
+			t.Fatalf("Dispatch(%v): %v", action, err)
+		}
+	}
+	want := []int{1, 2, 1, 11, -11, 0}
+	if len(counts) != len(want) {
+		t.Fatalf("notified with %v, want %v", counts, want)
+	}
+	for i := range want {
+		if counts[i] != want[i] {
+			t.Errorf("notification %d saw %d, want %d", i, counts[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package counterstore
+
+import (
+	"errors"
+	"sync"
+)
+
+// maxRolls is how many rolls State.Rolls keeps; older ones are dropped
+const maxRolls = 100
+
+// ErrRollSides rejects a RollAction for a die with fewer than one side
+var ErrRollSides = errors.New("a die needs at least one side")
+
+// ErrRollValue rejects a RollAction whose Value is not a side of its die,
+// such as one that never passed through RollMiddleware
+var ErrRollValue = errors.New("roll is not a side of the die")
+
+// RollAction rolls a die with Sides sides: the count becomes Value, from 1
+// to Sides, and the roll is appended to State.Rolls. RollMiddleware draws
+// Value when the action is dispatched, so the reducer stays pure and a
+// recorded roll replays as it was thrown. Sides below 1 is rejected with
+// ErrRollSides, and a Value outside the die with ErrRollValue.
+type RollAction struct {
+	Sides int
+	Value int
+}
+
+func (a RollAction) Apply(s State) State {
+	if a.Sides < 1 || a.Value < 1 || a.Value > a.Sides {
+		return s
+	}
+	return applyRoll(s, a.Value)
+}
+
+func (a RollAction) Validate(s State) error {
+	if a.Sides < 1 {
+		return ErrRollSides
+	}
+	if a.Value < 1 || a.Value > a.Sides {
+		return ErrRollValue
+	}
+	return nil
+}
+
+// LastRoll returns the latest roll, and false before the first
+func (s State) LastRoll() (int, bool) {
+	if len(s.Rolls) == 0 {
+		return 0, false
+	}
+	return s.Rolls[len(s.Rolls)-1], true
+}
+
+// Roll Middleware stamps each RollAction without a Value, also inside an
+// annotation, with a roll drawn from intn, which returns a number in [0, n)
+// as rand.Intn does. A nil intn draws from the store's random source, so
+// WithRandSeed makes the rolls repeat. Rolls that already carry a Value,
+// as in a replay, and dice with fewer than one side pass as they are.
+func RollMiddleware(intn func(n int) int) Middleware[State, AppAction] {
+	return func(store *Store[State, AppAction], next Dispatch[AppAction]) Dispatch[AppAction] {
+		draw := store.randIntn
+		if intn != nil {
+			var mu sync.Mutex
+			draw = func(n int) int {
+				mu.Lock()
+				defer mu.Unlock()
+				return intn(n)
+			}
+		}
+		stamp := func(roll RollAction) RollAction {
+			if roll.Value == 0 && roll.Sides >= 1 {
+				roll.Value = 1 + draw(roll.Sides)
+			}
+			return roll
+		}
+
+		return func(action AppAction) error {
+			switch a := action.(type) {
+			case RollAction:
+				action = stamp(a)
+			case Annotated:
+				if roll, ok := a.AppAction.(RollAction); ok {
+					action = Annotated{AppAction: stamp(roll), Meta: a.Meta}
+				}
+			}
+			return next(action)
+		}
+	}
+}
+
+// applyRoll sets the count to value and records it as the latest roll
+func applyRoll(s State, value int) State {
+	state := s.Copy()
+	state.Count = value
+	state.Rolls = append(state.Rolls, value)
+	if len(state.Rolls) > maxRolls {
+		state.Rolls = state.Rolls[len(state.Rolls)-maxRolls:]
+	}
+	return state
+}
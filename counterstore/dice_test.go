@@ -0,0 +1,156 @@
+package counterstore
+
+import (
+	"errors"
+	"reflect"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+// fakeIntn returns draws in turn, recording each n it was asked for
+func fakeIntn(draws ...int) (intn func(int) int, asked *[]int) {
+	asked = new([]int)
+	return func(n int) int {
+		*asked = append(*asked, n)
+		draw := draws[0]
+		draws = draws[1:]
+		return draw
+	}, asked
+}
+
+func TestRollMiddleware(t *testing.T) {
+	intn, asked := fakeIntn(5, 0, 2)
+	store := NewStore(Reduce, State{Count: 40}, RollMiddleware(intn))
+
+	store.Dispatch(RollAction{Sides: 6})
+	if got := store.GetState(); got.Count != 6 || !reflect.DeepEqual(got.Rolls, []int{6}) {
+		t.Fatalf("after one roll: Count %d, rolls %v; want 6 and [6]", got.Count, got.Rolls)
+	}
+	store.Dispatch(WithMeta(RollAction{Sides: 20}, map[string]string{"source": "test"}))
+	store.Dispatch(RollAction{Sides: 3})
+	got := store.GetState()
+	if got.Count != 3 || !reflect.DeepEqual(got.Rolls, []int{6, 1, 3}) {
+		t.Errorf("after three rolls: Count %d, rolls %v; want 3 and [6 1 3]", got.Count, got.Rolls)
+	}
+	if last, ok := got.LastRoll(); !ok || last != 3 {
+		t.Errorf("LastRoll = %d, %v; want 3", last, ok)
+	}
+	if want := []int{6, 20, 3}; !reflect.DeepEqual(*asked, want) {
+		t.Errorf("drew from %v sides, want %v", *asked, want)
+	}
+
+	// Each roll is an undo step
+	store.Undo()
+	if got := store.GetState(); got.Count != 1 || len(got.Rolls) != 2 {
+		t.Errorf("after undo: Count %d, rolls %v", got.Count, got.Rolls)
+	}
+}
+
+func TestRollRejected(t *testing.T) {
+	intn, asked := fakeIntn()
+	store := NewStore(Reduce, State{Count: 7}, RollMiddleware(intn))
+
+	for _, sides := range []int{0, -2} {
+		if err := store.Dispatch(RollAction{Sides: sides}); !errors.Is(err, ErrRollSides) {
+			t.Errorf("rolling %d sides: %v, want ErrRollSides", sides, err)
+		}
+	}
+	if len(*asked) != 0 {
+		t.Errorf("drew for a die with no sides: %v", *asked)
+	}
+	// A roll carrying a value outside its die is refused; none is drawn
+	if err := store.Dispatch(RollAction{Sides: 6, Value: 7}); !errors.Is(err, ErrRollValue) {
+		t.Errorf("a roll of 7 on a d6: %v, want ErrRollValue", err)
+	}
+	if got := store.GetState(); got.Count != 7 || len(got.Rolls) != 0 || store.CanUndo() {
+		t.Errorf("rejected rolls changed the state: %+v", got)
+	}
+
+	// Without the middleware a roll has no value to apply
+	plain := NewStore(Reduce, State{})
+	if err := plain.Dispatch(RollAction{Sides: 6}); !errors.Is(err, ErrRollValue) {
+		t.Errorf("an unstamped roll: %v, want ErrRollValue", err)
+	}
+}
+
+func TestRollReplaysStampedValue(t *testing.T) {
+	intn, asked := fakeIntn()
+	store := NewStore(Reduce, State{}, RollMiddleware(intn))
+	store.Dispatch(RollAction{Sides: 6, Value: 4})
+	if got := store.GetState().Count; got != 4 || len(*asked) != 0 {
+		t.Errorf("Count = %d after %d draws, want the recorded 4 and no draw", got, len(*asked))
+	}
+
+	// The store's own source repeats under the same seed
+	rolls := func() []int {
+		s := NewStoreWithOptions(Reduce, State{}, WithRandSeed[State, AppAction](7),
+			WithMiddleware(RollMiddleware(nil)))
+		for i := 0; i < 5; i++ {
+			s.Dispatch(RollAction{Sides: 6})
+		}
+		return s.GetState().Rolls
+	}
+	first, second := rolls(), rolls()
+	if len(first) != 5 || !reflect.DeepEqual(first, second) {
+		t.Errorf("seeded rolls %v and %v, want the same five", first, second)
+	}
+	for _, roll := range first {
+		if roll < 1 || roll > 6 {
+			t.Errorf("roll %d is not a side of a d6", roll)
+		}
+	}
+}
+
+func TestRollsKeepsLatest(t *testing.T) {
+	state := State{}
+	for i := 0; i < maxRolls+5; i++ {
+		state = RollAction{Sides: 200, Value: i + 1}.Apply(state)
+	}
+	if len(state.Rolls) != maxRolls || state.Rolls[0] != 6 || state.Rolls[maxRolls-1] != maxRolls+5 {
+		t.Errorf("kept %d rolls from %d to %d, want the latest %d", len(state.Rolls), state.Rolls[0],
+			state.Rolls[len(state.Rolls)-1], maxRolls)
+	}
+}
+
+func TestRollsAndThunksShareRandSafely(t *testing.T) {
+	store := NewStoreWithOptions(Reduce, State{},
+		WithMiddleware[State, AppAction](RollMiddleware(nil), ThunkMiddleware[State, AppAction]),
+		WithRandSeed[State, AppAction](7))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				store.Dispatch(RollAction{Sides: 6})
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				store.Dispatch(Thunk[State, AppAction]{RunContext: func(ctx ThunkContext[State, AppAction]) {
+					// Yield between draws, so rolls land among them
+					sum := 0
+					for k := 0; k < 10; k++ {
+						sum += ctx.Rand.Intn(10)
+						runtime.Gosched()
+					}
+					ctx.Dispatch(AddAmountAction{Amount: sum})
+				}})
+			}
+		}()
+	}
+	wg.Wait()
+
+	rolls := store.GetState().Rolls
+	if len(rolls) != maxRolls {
+		t.Fatalf("%d rolls kept, want %d", len(rolls), maxRolls)
+	}
+	for _, roll := range rolls {
+		if roll < 1 || roll > 6 {
+			t.Fatalf("rolled %d on a six-sided die", roll)
+		}
+	}
+}
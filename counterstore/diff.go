@@ -0,0 +1,73 @@
+package counterstore
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Change holds the before and after value of a single state field
+type Change struct {
+	From any
+	To   any
+}
+
+// Diff compares the exported fields of two states and returns the ones that
+// differ, keyed by field name. Non-struct states are compared as a whole
+// under the empty key.
+func Diff[S any](prev, next S) map[string]Change {
+	changes := map[string]Change{}
+
+	pv, nv := reflect.ValueOf(prev), reflect.ValueOf(next)
+	if pv.Kind() != reflect.Struct {
+		if !reflect.DeepEqual(prev, next) {
+			changes[""] = Change{From: prev, To: next}
+		}
+		return changes
+	}
+
+	for i := 0; i < pv.NumField(); i++ {
+		field := pv.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		from, to := pv.Field(i).Interface(), nv.Field(i).Interface()
+		if !reflect.DeepEqual(from, to) {
+			changes[field.Name] = Change{From: from, To: to}
+		}
+	}
+	return changes
+}
+
+// FormatChanges renders a change map as "Field: from → to" lines sorted by
+// field name, or a single "(no change)" line when nothing changed
+func FormatChanges(changes map[string]Change) []string {
+	if len(changes) == 0 {
+		return []string{"(no change)"}
+	}
+
+	names := make([]string, 0, len(changes))
+	for name := range changes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	lines := make([]string, 0, len(names))
+	for _, name := range names {
+		c := changes[name]
+		lines = append(lines, fmt.Sprintf("%s: %v → %v", name, c.From, c.To))
+	}
+	return lines
+}
+
+// Diff Middleware reports the fields changed by each dispatched action
+func DiffMiddleware[S StateProvider[S], A Action[S]](onDiff func(changes map[string]Change)) Middleware[S, A] {
+	return func(store *Store[S, A], next Dispatch[A]) Dispatch[A] {
+		return func(action A) error {
+			prevState := store.GetState()
+			err := next(action)
+			onDiff(Diff(prevState, store.GetState()))
+			return err
+		}
+	}
+}
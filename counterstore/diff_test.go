@@ -0,0 +1,67 @@
+package counterstore
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFormatChanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		changes map[string]Change
+		want    []string
+	}{
+		{"no change", map[string]Change{}, []string{"(no change)"}},
+		{"nil map", nil, []string{"(no change)"}},
+		{"one field", map[string]Change{"Count": {From: 3, To: 4}}, []string{"Count: 3 → 4"}},
+		{
+			"sorted by field",
+			map[string]Change{
+				"Target":  {From: 0, To: 10},
+				"Count":   {From: 3, To: 4},
+				"Minimal": {From: false, To: true},
+			},
+			[]string{"Count: 3 → 4", "Minimal: false → true", "Target: 0 → 10"},
+		},
+		{"whole value", map[string]Change{"": {From: 1, To: 2}}, []string{": 1 → 2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatChanges(tt.changes); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FormatChanges = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	changes := Diff(State{Count: 3, Target: 5}, State{Count: 4, Target: 5})
+	want := map[string]Change{"Count": {From: 3, To: 4}}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("Diff = %v, want %v", changes, want)
+	}
+
+	if changes := Diff(State{Count: 3}, State{Count: 3}); len(changes) != 0 {
+		t.Errorf("Diff of equal states = %v, want none", changes)
+	}
+	if changes := Diff(1, 2); !reflect.DeepEqual(changes, map[string]Change{"": {From: 1, To: 2}}) {
+		t.Errorf("Diff of ints = %v", changes)
+	}
+}
+
+func TestDiffMiddleware(t *testing.T) {
+	var last map[string]Change
+	store := NewStore(Reduce, State{Count: 3}, DiffMiddleware[State, AppAction](func(changes map[string]Change) {
+		last = changes
+	}))
+
+	store.Dispatch(IncrementAction{})
+	if got := FormatChanges(last); !reflect.DeepEqual(got, []string{"Count: 3 → 4"}) {
+		t.Errorf("after increment: %q", got)
+	}
+
+	store.Dispatch(SetCountAction{Value: 4})
+	if got := FormatChanges(last); !reflect.DeepEqual(got, []string{"(no change)"}) {
+		t.Errorf("after setting the same count: %q", got)
+	}
+}
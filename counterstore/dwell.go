@@ -0,0 +1,119 @@
+package counterstore
+
+import (
+	"sync"
+	"time"
+)
+
+// DwellUnit is what DwellMiddleware measures the time at a count in
+type DwellUnit int
+
+const (
+	// DwellDispatches counts the dispatches made while at a count
+	DwellDispatches DwellUnit = iota
+	// DwellMillis counts the milliseconds spent at a count
+	DwellMillis
+)
+
+// AddDwell returns a copy of dwell with amount added to value's entry,
+// leaving dwell itself untouched. A zero amount returns dwell as it is.
+func AddDwell(dwell map[int]int, value, amount int) map[int]int {
+	if amount == 0 {
+		return dwell
+	}
+	out := copyDwell(dwell)
+	if out == nil {
+		out = make(map[int]int, 1)
+	}
+	out[value] += amount
+	return out
+}
+
+// MostVisited returns the count with the most dwell recorded, the lowest
+// such count on a tie, and false when nothing was recorded yet
+func (s State) MostVisited() (int, bool) {
+	best, found := 0, false
+	for value, n := range s.UI.Dwell {
+		if !found || n > s.UI.Dwell[best] || (n == s.UI.Dwell[best] && value < best) {
+			best, found = value, true
+		}
+	}
+	return best, found
+}
+
+// RecordDwellAction adds Amount to the dwell of Value. It is not undoable,
+// and undo keeps the dwell recorded since.
+type RecordDwellAction struct {
+	Value  int
+	Amount int
+}
+
+func (a RecordDwellAction) Undoable() bool { return false }
+
+func (a RecordDwellAction) Apply(s State) State {
+	if a.Amount == 0 {
+		return s
+	}
+	state := s.Copy()
+	state.UI.Dwell = AddDwell(s.UI.Dwell, a.Value, a.Amount)
+	return state
+}
+
+// copyDwell returns an independent copy of dwell, keeping nil as nil
+func copyDwell(dwell map[int]int) map[int]int {
+	if dwell == nil {
+		return nil
+	}
+	out := make(map[int]int, len(dwell))
+	for v, n := range dwell {
+		out[v] = n
+	}
+	return out
+}
+
+// Dwell Middleware keeps State.UI.Dwell, a histogram of how long the count
+// spent at each value: when the count changes, the previous value is
+// credited with the dispatches, this one included, or the milliseconds on
+// clock spent at it, as unit says. The current value's share is recorded
+// once the count leaves it. The record is passed to next as part of the same
+// dispatch, so middleware installed before this one never sees it. A nil
+// clock means the wall clock.
+func DwellMiddleware(unit DwellUnit, clock Clock) Middleware[State, AppAction] {
+	clock = orRealClock(clock)
+	return func(store *Store[State, AppAction], next Dispatch[AppAction]) Dispatch[AppAction] {
+		var mu sync.Mutex
+		value := store.GetState().Count
+		since := clock.Now()
+		dispatches := 0
+
+		return func(action AppAction) error {
+			if _, ok := plainAction(action).(RecordDwellAction); ok {
+				return next(action)
+			}
+			err := next(action)
+
+			mu.Lock()
+			count := store.GetState().Count
+			dispatches++
+			if count == value {
+				mu.Unlock()
+				return err
+			}
+			record := RecordDwellAction{Value: value, Amount: dispatches}
+			if unit == DwellMillis {
+				now := clock.Now()
+				record.Amount = int(now.Sub(since) / time.Millisecond)
+				since = now
+			}
+			value, dispatches = count, 0
+			mu.Unlock()
+
+			// The record goes on down the chain rather than back through
+			// store.Dispatch, so it joins this dispatch's notification
+			if recordErr := next(record); err == nil {
+				err = recordErr
+			}
+			return err
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package counterstore
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAddDwellSequence(t *testing.T) {
+	var dwell map[int]int
+	steps := []struct{ value, amount int }{{0, 2}, {1, 1}, {0, 3}, {2, 0}, {-1, 4}, {1, 1}}
+	history := []map[int]int{}
+	for _, step := range steps {
+		dwell = AddDwell(dwell, step.value, step.amount)
+		history = append(history, dwell)
+	}
+	if want := map[int]int{0: 5, 1: 2, -1: 4}; !reflect.DeepEqual(dwell, want) {
+		t.Errorf("dwell = %v, want %v", dwell, want)
+	}
+	// Earlier results were never modified
+	if want := map[int]int{0: 2}; !reflect.DeepEqual(history[0], want) {
+		t.Errorf("first result became %v, want %v", history[0], want)
+	}
+	if want := map[int]int{0: 5, 1: 1}; !reflect.DeepEqual(history[3], want) {
+		t.Errorf("a zero amount gave %v, want %v unchanged", history[3], want)
+	}
+	if AddDwell(nil, 3, 0) != nil {
+		t.Error("a zero amount on no dwell allocated a map")
+	}
+
+	if value, ok := (State{UI: UIState{Dwell: dwell}}).MostVisited(); !ok || value != 0 {
+		t.Errorf("MostVisited = %d, %v; want 0", value, ok)
+	}
+	if value, _ := (State{UI: UIState{Dwell: map[int]int{4: 2, -3: 2}}}).MostVisited(); value != -3 {
+		t.Errorf("MostVisited on a tie = %d, want the lower count -3", value)
+	}
+	if _, ok := (State{}).MostVisited(); ok {
+		t.Error("MostVisited found a count with nothing recorded")
+	}
+}
+
+func TestDwellMiddlewareDispatches(t *testing.T) {
+	var seen []AppAction
+	record := func(store *Store[State, AppAction], next Dispatch[AppAction]) Dispatch[AppAction] {
+		return func(action AppAction) error {
+			seen = append(seen, action)
+			return next(action)
+		}
+	}
+	store := NewStore(Reduce, State{Target: 100}, record, DwellMiddleware(DwellDispatches, nil))
+	notified := 0
+	store.Subscribe(func() { notified++ })
+
+	for _, action := range []AppAction{
+		SetTargetAction{Target: 50}, IncrementAction{}, // two dispatches at 0
+		IncrementAction{},                                                           // one at 1
+		SetTargetAction{Target: 40}, SetTargetAction{Target: 30}, DecrementAction{}, // three at 2
+		IncrementAction{}, // one back at 1
+	} {
+		store.Dispatch(action)
+	}
+	if got, want := store.GetState().UI.Dwell, map[int]int{0: 2, 1: 2, 2: 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("dwell = %v, want %v", got, want)
+	}
+	// Recording is part of the dispatch that moved the count, so middleware
+	// before DwellMiddleware never sees it
+	if len(seen) != 7 {
+		t.Errorf("outer middleware saw %d actions for 7 dispatches: %v", len(seen), seen)
+	}
+	if notified != 7 {
+		t.Errorf("%d notifications for 7 dispatches", notified)
+	}
+	if store.HistoryLen() != 7 {
+		t.Errorf("%d undo steps, want 7 with no dwell records among them", store.HistoryLen())
+	}
+}
+
+func TestDwellMiddlewareMillis(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	store := NewStore(Reduce, State{}, DwellMiddleware(DwellMillis, clock))
+
+	clock.Advance(1500 * time.Millisecond)
+	store.Dispatch(IncrementAction{})
+	clock.Advance(250 * time.Millisecond)
+	store.Dispatch(SetTargetAction{Target: 9})
+	clock.Advance(250 * time.Millisecond)
+	store.Dispatch(IncrementAction{})
+	if got, want := store.GetState().UI.Dwell, map[int]int{0: 1500, 1: 500}; !reflect.DeepEqual(got, want) {
+		t.Errorf("dwell = %v, want %v", got, want)
+	}
+	store.Undo()
+	if got := store.GetState(); got.Count != 1 || got.UI.Dwell[1] != 500 {
+		t.Errorf("after undo: Count %d, dwell %v; want the dwell kept", got.Count, got.UI.Dwell)
+	}
+}
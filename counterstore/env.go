@@ -0,0 +1,92 @@
+package counterstore
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// DefaultEnvPrefix is the prefix ExportEnv gives every variable
+const DefaultEnvPrefix = "COUNTER_"
+
+// ExportEnv writes s as shell-style KEY=value lines, one per field, for
+// scripts to source or eval. See ExportEnvPrefix.
+func (s State) ExportEnv(w io.Writer) error {
+	return s.ExportEnvPrefix(w, DefaultEnvPrefix)
+}
+
+// ExportEnvPrefix writes s as KEY=value lines named prefix plus the field name
+// in upper snake case, so Count becomes COUNTER_COUNT=7. Maps are flattened
+// into one variable per key, in key order, as in COUNTER_FLAGS_NO_NEGATIVES;
+// slices such as Toasts and the transient UI are skipped. Values a shell
+// would split are quoted.
+func (s State) ExportEnvPrefix(w io.Writer, prefix string) error {
+	v := reflect.ValueOf(s)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Tag.Get("json") == "-" {
+			continue
+		}
+		name := prefix + envName(t.Field(i).Name)
+		field := v.Field(i)
+		switch field.Kind() {
+		case reflect.Slice:
+			continue
+		case reflect.Map:
+			keys := field.MapKeys()
+			sort.Slice(keys, func(i, j int) bool {
+				return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+			})
+			for _, key := range keys {
+				value := field.MapIndex(key).Interface()
+				if err := writeEnv(w, name+"_"+envName(fmt.Sprint(key.Interface())), value); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := writeEnv(w, name, field.Interface()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func writeEnv(w io.Writer, name string, value any) error {
+	_, err := fmt.Fprintf(w, "%s=%s\n", name, envQuote(fmt.Sprint(value)))
+	return err
+}
+
+// envName turns a Go identifier or flag name into an upper snake case
+// variable name: PresentationMode becomes PRESENTATION_MODE and no-negatives
+// becomes NO_NEGATIVES
+func envName(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case unicode.IsUpper(r) && i > 0 && unicode.IsLower(runes[i-1]):
+			b.WriteByte('_')
+			b.WriteRune(r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(unicode.ToUpper(r))
+		default:
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
+
+// envQuote single-quotes value unless it is made only of characters a shell
+// takes literally
+func envQuote(value string) string {
+	if value != "" && strings.IndexFunc(value, func(r rune) bool {
+		return !(r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("-_.,:/+", r)))
+	}) < 0 {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
@@ -0,0 +1,89 @@
+package counterstore
+
+import (
+	"strings"
+	"testing"
+)
+
+func exportEnvLines(t *testing.T, s State, prefix string) map[string]bool {
+	t.Helper()
+	var b strings.Builder
+	if err := s.ExportEnvPrefix(&b, prefix); err != nil {
+		t.Fatalf("ExportEnvPrefix: %v", err)
+	}
+	lines := map[string]bool{}
+	for _, line := range strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n") {
+		lines[line] = true
+	}
+	return lines
+}
+
+func TestExportEnv(t *testing.T) {
+	state := State{
+		Count:     7,
+		Target:    -3,
+		Status:    StatusReset,
+		Flags:     map[string]bool{FlagNoNegatives: true},
+		Bookmarks: map[string]int{"peak": 9, "it's": 1},
+		Toasts:    []Toast{{Text: "hidden"}},
+		UI:        UIState{ShowHelp: true},
+	}
+	var b strings.Builder
+	if err := state.ExportEnv(&b); err != nil {
+		t.Fatal(err)
+	}
+	lines := exportEnvLines(t, state, DefaultEnvPrefix)
+	if len(lines) != strings.Count(b.String(), "\n") {
+		t.Errorf("ExportEnv and ExportEnvPrefix with the default prefix differ:\n%s", b.String())
+	}
+
+	for _, want := range []string{
+		"COUNTER_COUNT=7",
+		"COUNTER_TARGET=-3",
+		"COUNTER_PRESENTATION_MODE=false",
+		"COUNTER_FLAGS_NO_NEGATIVES=true",
+		"COUNTER_STATUS='Reset performed'",
+		"COUNTER_PALETTE=default",
+		"COUNTER_BOOKMARKS_PEAK=9",
+		"COUNTER_BOOKMARKS_IT_S=1",
+	} {
+		if !lines[want] {
+			t.Errorf("missing %s in:\n%s", want, b.String())
+		}
+	}
+	for line := range lines {
+		if strings.Contains(line, "TOAST") || strings.Contains(line, "SHOW_HELP") || strings.HasPrefix(line, "COUNTER_UI") {
+			t.Errorf("exported %s, want slices and the transient UI skipped", line)
+		}
+	}
+}
+
+func TestExportEnvPrefix(t *testing.T) {
+	lines := exportEnvLines(t, State{Count: 2}, "APP_")
+	if !lines["APP_COUNT=2"] {
+		t.Errorf("no APP_COUNT=2 in %v", lines)
+	}
+	for line := range lines {
+		if !strings.HasPrefix(line, "APP_") {
+			t.Errorf("line %q lacks the prefix", line)
+		}
+	}
+}
+
+func TestEnvQuote(t *testing.T) {
+	tests := map[string]string{
+		"7":          "7",
+		"a-b_c.d":    "a-b_c.d",
+		"":           "''",
+		"two words":  "'two words'",
+		"it's":       `'it'\''s'`,
+		"$HOME":      "'$HOME'",
+		"café":       "'café'",
+		"x;rm -rf /": "'x;rm -rf /'",
+	}
+	for in, want := range tests {
+		if got := envQuote(in); got != want {
+			t.Errorf("envQuote(%q) = %s, want %s", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package counterstore
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ActionName returns the action's type name without the "Action" suffix,
+// e.g. "Increment" for IncrementAction
+func ActionName(action any) string {
+	t := reflect.TypeOf(action)
+	if t == nil {
+		return "<nil>"
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return strings.TrimSuffix(t.Name(), "Action")
+}
+
+// actionKey names action for per-type bookkeeping, such as throttling and
+// metrics: the ActionName of the action inside any annotation, redaction or
+// other carrier, so wrapped actions of different types stay apart
+func actionKey(action any) string {
+	if a, ok := action.(AppAction); ok {
+		action = plainAction(a)
+	}
+	return ActionName(action)
+}
+
+// Explain describes what action would do to state, e.g. "Increment: Count 3 → 4".
+// The action is applied to a copy of state directly, so no store is touched
+// and no middleware runs.
+func Explain(state State, action AppAction) string {
+	changes := Diff(state, action.Apply(state.Copy()))
+	if len(changes) == 0 {
+		return ActionName(action) + ": no change"
+	}
+
+	names := make([]string, 0, len(changes))
+	for name := range changes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		c := changes[name]
+		parts = append(parts, fmt.Sprintf("%s %v → %v", name, c.From, c.To))
+	}
+	return ActionName(action) + ": " + strings.Join(parts, ", ")
+}
@@ -0,0 +1,58 @@
+package counterstore
+
+import "testing"
+
+func TestExplain(t *testing.T) {
+	state := State{Count: 3}
+	tests := []struct {
+		action AppAction
+		want   string
+	}{
+		{IncrementAction{}, "Increment: Count 3 → 4"},
+		{DecrementAction{}, "Decrement: Count 3 → 2"},
+		{AddAmountAction{Amount: 5}, "AddAmount: Count 3 → 8"},
+		{ResetAction{}, "Reset: Count 3 → 0"},
+		{NegateAction{}, "Negate: Count 3 → -3"},
+		{SetCountAction{Value: 7}, "SetCount: Count 3 → 7"},
+		{SetCountAction{Value: 3}, "SetCount: no change"},
+		{SetTargetAction{Target: 10}, "SetTarget: Target 0 → 10"},
+		{SetPresentationModeAction{Enabled: true}, "SetPresentationMode: PresentationMode false → true"},
+		{ToggleAlwaysOnTopAction{}, "ToggleAlwaysOnTop: AlwaysOnTop false → true"},
+		{ToggleMinimalAction{}, "ToggleMinimal: Minimal false → true"},
+	}
+	for _, tt := range tests {
+		if got := Explain(state, tt.action); got != tt.want {
+			t.Errorf("Explain(%T) = %q, want %q", tt.action, got, tt.want)
+		}
+	}
+}
+
+func TestExplainLeavesStateAlone(t *testing.T) {
+	state := State{Count: 3, Flags: map[string]bool{}}
+	Explain(state, SetFlagAction{Name: "x", Enabled: true})
+	if len(state.Flags) != 0 || state.Count != 3 {
+		t.Errorf("Explain modified its state: %+v", state)
+	}
+
+	store := NewStore(Reduce, state)
+	Explain(store.GetState(), IncrementAction{})
+	if got := store.GetState().Count; got != 3 {
+		t.Errorf("store Count = %d after Explain, want 3", got)
+	}
+}
+
+func TestActionName(t *testing.T) {
+	tests := []struct {
+		action any
+		want   string
+	}{
+		{IncrementAction{}, "Increment"},
+		{&ResetAction{}, "Reset"},
+		{nil, "<nil>"},
+	}
+	for _, tt := range tests {
+		if got := ActionName(tt.action); got != tt.want {
+			t.Errorf("ActionName(%#v) = %q, want %q", tt.action, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package counterstore
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// ErrDivisionByZero is returned when an expression divides by zero
+var ErrDivisionByZero = errors.New("division by zero")
+
+// ApplyExprAction sets the count to an arithmetic expression of the current
+// count, such as "x*2+3". Expressions use integers, x, + - * /, unary minus
+// and parentheses; / truncates toward zero. They are evaluated exactly, and
+// a result outside the int range is rejected with ErrCountOverflow whatever
+// the overflow mode.
+type ApplyExprAction struct {
+	Expr string
+}
+
+func (a ApplyExprAction) Apply(s State) State {
+	value, err := EvalExpr(a.Expr, s.Count)
+	if err != nil {
+		return s
+	}
+	state := s.Copy()
+	state.Count = value
+	return state
+}
+
+func (a ApplyExprAction) Validate(s State) error {
+	_, err := EvalExpr(a.Expr, s.Count)
+	return err
+}
+
+// EvalExpr evaluates expr with x bound to x
+func EvalExpr(expr string, x int) (int, error) {
+	p := exprParser{src: expr, x: big.NewInt(int64(x))}
+	p.next()
+	value, err := p.parseSum()
+	if err == nil && (p.err != nil || p.tok.kind != tokEOF) {
+		err = p.unexpected()
+	}
+	if err != nil {
+		return 0, fmt.Errorf("expression %q: %w", expr, err)
+	}
+	if !value.IsInt64() || value.Int64() < math.MinInt || value.Int64() > math.MaxInt {
+		return 0, fmt.Errorf("expression %q: %w", expr, ErrCountOverflow)
+	}
+	return int(value.Int64()), nil
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokX
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// exprParser is a recursive-descent parser that evaluates as it parses:
+//
+//	sum     = product { ("+" | "-") product }
+//	product = unary { ("*" | "/") unary }
+//	unary   = "-" unary | primary
+//	primary = number | "x" | "(" sum ")"
+type exprParser struct {
+	src string
+	off int
+	tok token
+	x   *big.Int
+	err error
+}
+
+// next scans the following token into p.tok, recording a scan error in p.err
+func (p *exprParser) next() {
+	for p.off < len(p.src) && (p.src[p.off] == ' ' || p.src[p.off] == '\t') {
+		p.off++
+	}
+	start := p.off
+	if p.off >= len(p.src) {
+		p.tok = token{kind: tokEOF, pos: start}
+		return
+	}
+	switch c := p.src[p.off]; {
+	case c >= '0' && c <= '9':
+		for p.off < len(p.src) && p.src[p.off] >= '0' && p.src[p.off] <= '9' {
+			p.off++
+		}
+		p.tok = token{kind: tokNumber, text: p.src[start:p.off], pos: start}
+	case c == 'x':
+		p.off++
+		p.tok = token{kind: tokX, text: "x", pos: start}
+	case c == '+' || c == '-' || c == '*' || c == '/' || c == '(' || c == ')':
+		p.off++
+		p.tok = token{kind: tokOp, text: string(c), pos: start}
+	default:
+		p.err = fmt.Errorf("invalid character %q at offset %d", c, start)
+		p.tok = token{kind: tokEOF, pos: start}
+	}
+}
+
+func (p *exprParser) unexpected() error {
+	if p.err != nil {
+		return p.err
+	}
+	if p.tok.kind == tokEOF {
+		return errors.New("unexpected end of expression")
+	}
+	return fmt.Errorf("unexpected %q at offset %d", p.tok.text, p.tok.pos)
+}
+
+func (p *exprParser) isOp(op string) bool {
+	return p.tok.kind == tokOp && p.tok.text == op
+}
+
+func (p *exprParser) parseSum() (*big.Int, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("+") || p.isOp("-") {
+		op := p.tok.text
+		p.next()
+		right, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		if op == "+" {
+			left.Add(left, right)
+		} else {
+			left.Sub(left, right)
+		}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseProduct() (*big.Int, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("*") || p.isOp("/") {
+		op := p.tok.text
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if op == "*" {
+			left.Mul(left, right)
+			continue
+		}
+		if right.Sign() == 0 {
+			return nil, ErrDivisionByZero
+		}
+		left.Quo(left, right)
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseUnary() (*big.Int, error) {
+	if p.isOp("-") {
+		p.next()
+		value, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return value.Neg(value), nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *exprParser) parsePrimary() (*big.Int, error) {
+	switch {
+	case p.tok.kind == tokNumber:
+		value, _ := new(big.Int).SetString(p.tok.text, 10)
+		p.next()
+		return value, nil
+	case p.tok.kind == tokX:
+		p.next()
+		return new(big.Int).Set(p.x), nil
+	case p.isOp("("):
+		p.next()
+		value, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		if !p.isOp(")") {
+			return nil, p.unexpected()
+		}
+		p.next()
+		return value, nil
+	}
+	return nil, p.unexpected()
+}
@@ -0,0 +1,90 @@
+package counterstore
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEvalExpr(t *testing.T) {
+	tests := []struct {
+		expr string
+		x    int
+		want int
+	}{
+		{"x*2+3", 5, 13},
+		{"x * (2 + 3)", 4, 20},
+		{"1 + 2 * 3", 0, 7},
+		{"(1 + 2) * 3", 0, 9},
+		{"-x", 6, -6},
+		{"--x", 6, 6},
+		{"x - -1", 6, 7},
+		{"7 / 2", 0, 3},
+		{"-7 / 2", 0, -3},
+		{"10 - 4 - 3", 0, 3},
+		{"x", -12, -12},
+		{"\t42 ", 0, 42},
+		// Intermediate results may leave the int range as long as the result fits
+		{"x * 1000000000000000000000 / 1000000000000000000000", 9, 9},
+	}
+	for _, tt := range tests {
+		got, err := EvalExpr(tt.expr, tt.x)
+		if err != nil || got != tt.want {
+			t.Errorf("EvalExpr(%q, %d) = %d, %v; want %d", tt.expr, tt.x, got, err, tt.want)
+		}
+	}
+}
+
+func TestEvalExprErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		is   error
+	}{
+		{"", nil},
+		{"x +", nil},
+		{"(x + 1", nil},
+		{"x + 1)", nil},
+		{"2x", nil},
+		{"x ^ 2", nil},
+		{"y", nil},
+		{"* 3", nil},
+		{"x / 0", ErrDivisionByZero},
+		{"x / (x - x)", ErrDivisionByZero},
+		{"99999999999999999999", ErrCountOverflow},
+		{"x * x * x * x * x", ErrCountOverflow},
+	}
+	for _, tt := range tests {
+		_, err := EvalExpr(tt.expr, 100000)
+		if err == nil {
+			t.Errorf("EvalExpr(%q) succeeded", tt.expr)
+			continue
+		}
+		if tt.is != nil && !errors.Is(err, tt.is) {
+			t.Errorf("EvalExpr(%q) error = %v, want %v", tt.expr, err, tt.is)
+		}
+	}
+}
+
+func TestApplyExprAction(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 5})
+	if err := store.Dispatch(ApplyExprAction{Expr: "x*2+3"}); err != nil {
+		t.Fatalf("Dispatch: %v", err)
+	}
+	if got := store.GetState().Count; got != 13 {
+		t.Fatalf("Count = %d, want 13", got)
+	}
+
+	// A malformed expression is rejected and leaves the state alone
+	before := store.Version()
+	if err := store.Dispatch(ApplyExprAction{Expr: "x*"}); err == nil {
+		t.Error("malformed expression was accepted")
+	}
+	if err := store.Dispatch(ApplyExprAction{Expr: "x/0"}); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("division by zero error = %v", err)
+	}
+	if got := store.GetState().Count; got != 13 {
+		t.Errorf("Count after rejected expressions = %d, want 13", got)
+	}
+	if store.Version() != before {
+		t.Error("a rejected expression committed a state")
+	}
+}
@@ -0,0 +1,34 @@
+package counterstore
+
+import (
+	"expvar"
+	"time"
+)
+
+// expvarMap returns the published map with the given name, creating it on
+// first use so several stores can share a prefix without expvar panicking
+func expvarMap(name string) *expvar.Map {
+	if v, ok := expvar.Get(name).(*expvar.Map); ok {
+		return v
+	}
+	return expvar.NewMap(name)
+}
+
+// Expvar Middleware publishes per-action dispatch counts and the total time
+// (in nanoseconds) the rest of the chain took, reducer included, as
+// "<prefix>.dispatches" and "<prefix>.dispatch_ns"
+func ExpvarMiddleware[S StateProvider[S], A Action[S]](prefix string) Middleware[S, A] {
+	dispatches := expvarMap(prefix + ".dispatches")
+	dispatchNanos := expvarMap(prefix + ".dispatch_ns")
+
+	return func(store *Store[S, A], next Dispatch[A]) Dispatch[A] {
+		return func(action A) error {
+			key := actionKey(action)
+			start := time.Now()
+			err := next(action)
+			dispatches.Add(key, 1)
+			dispatchNanos.Add(key, int64(time.Since(start)))
+			return err
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package counterstore
+
+import (
+	"expvar"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// expvarRuns numbers the prefixes handed out by expvarPrefix
+var expvarRuns atomic.Int64
+
+// expvarPrefix returns a prefix no earlier test run has published under, as
+// expvar names are global and outlive a run under -count
+func expvarPrefix(t *testing.T) string {
+	return fmt.Sprintf("%s_%d", t.Name(), expvarRuns.Add(1))
+}
+
+func expvarInt(t *testing.T, name, key string) int64 {
+	t.Helper()
+	m, ok := expvar.Get(name).(*expvar.Map)
+	if !ok {
+		t.Fatalf("%s is not published", name)
+	}
+	v, ok := m.Get(key).(*expvar.Int)
+	if !ok {
+		return 0
+	}
+	return v.Value()
+}
+
+func TestExpvarMiddleware(t *testing.T) {
+	prefix := expvarPrefix(t)
+	store := NewStore(Reduce, State{}, ExpvarMiddleware[State, AppAction](prefix))
+	for _, action := range []AppAction{IncrementAction{}, IncrementAction{}, DecrementAction{}, ResetAction{}, IncrementAction{}} {
+		store.Dispatch(action)
+	}
+
+	counts := map[string]int64{
+		"Increment": 3,
+		"Decrement": 1,
+		"Reset":     1,
+		"Negate":    0,
+	}
+	for key, want := range counts {
+		if got := expvarInt(t, prefix+".dispatches", key); got != want {
+			t.Errorf("dispatches[%s] = %d, want %d", key, got, want)
+		}
+	}
+	if got := expvarInt(t, prefix+".dispatch_ns", "Increment"); got <= 0 {
+		t.Errorf("dispatch_ns[IncrementAction] = %d, want > 0", got)
+	}
+}
+
+func TestExpvarMiddlewareConcurrent(t *testing.T) {
+	const goroutines, each = 8, 100
+	prefix := expvarPrefix(t)
+	store := NewStore(Reduce, State{}, ExpvarMiddleware[State, AppAction](prefix))
+
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < each; j++ {
+				store.Dispatch(IncrementAction{})
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := expvarInt(t, prefix+".dispatches", "Increment"); got != goroutines*each {
+		t.Errorf("dispatches = %d, want %d", got, goroutines*each)
+	}
+	if got := store.GetState().Count; got != goroutines*each {
+		t.Errorf("Count = %d, want %d", got, goroutines*each)
+	}
+}
+
+func TestExpvarMiddlewareSharedPrefix(t *testing.T) {
+	prefix := expvarPrefix(t)
+	first := NewStore(Reduce, State{}, ExpvarMiddleware[State, AppAction](prefix))
+	second := NewStore(Reduce, State{}, ExpvarMiddleware[State, AppAction](prefix))
+	first.Dispatch(IncrementAction{})
+	second.Dispatch(IncrementAction{})
+
+	if got := expvarInt(t, prefix+".dispatches", "Increment"); got != 2 {
+		t.Errorf("dispatches = %d, want 2", got)
+	}
+}
@@ -0,0 +1,46 @@
+package counterstore
+
+// setState makes next the current state and commits it: it records a new
+// version, republishes the snapshot GetStateFast reads and runs the commit
+// hooks. In FrameCommitMode the commit is buffered instead, and CommitFrame
+// makes it once for every state set since the last frame. Callers must hold
+// s.mu, and must have finished updating history, so a fast reader never sees
+// a state that is not yet committed.
+func (s *Store[S, A]) setState(next S) {
+	prev := s.state
+	s.captureTransition(prev, next)
+	s.state = next
+	if s.notifyMode == FrameCommitMode {
+		if !s.frameBuffered {
+			s.frameBase, s.frameBuffered = prev, true
+		}
+		return
+	}
+	s.commitLocked(prev, next)
+}
+
+// commitLocked commits the move from prev to next, the store's current
+// state. Callers must hold s.mu.
+func (s *Store[S, A]) commitLocked(prev, next S) {
+	snapshot := Freeze(next)
+	s.fast.Store(&snapshot)
+	s.recordVersion(next)
+	s.runCommitHooks(prev, next)
+}
+
+// committedLocked returns the last committed state, which in FrameCommitMode
+// trails the current one until CommitFrame. Callers must hold s.mu.
+func (s *Store[S, A]) committedLocked() S {
+	if s.frameBuffered {
+		return s.frameBase
+	}
+	return s.state
+}
+
+// GetStateFast returns the current state without taking the store's lock,
+// for readers such as the render loop that run often while other goroutines
+// dispatch. It may trail a dispatch in flight by one commit, and in
+// FrameCommitMode shows the state of the last CommitFrame.
+func (s *Store[S, A]) GetStateFast() S {
+	return s.fast.Load().Get()
+}
@@ -0,0 +1,86 @@
+package counterstore
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// TestGetStateFastConcurrent is meant for go test -race: readers poll the
+// fast snapshot, maps included, while writers dispatch
+func TestGetStateFastConcurrent(t *testing.T) {
+	store := NewStore(Reduce, State{})
+	const writers, perWriter = 4, 200
+
+	stop := make(chan struct{})
+	var readers sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			last := 0
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				state := store.GetStateFast()
+				if state.Count < last {
+					t.Errorf("GetStateFast went back from %d to %d", last, state.Count)
+					return
+				}
+				last = state.Count
+				for name := range state.Flags {
+					_ = state.Flags[name]
+				}
+			}
+		}()
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				store.Dispatch(IncrementAction{})
+				if i%20 == 0 {
+					store.Dispatch(SetFlagAction{Name: fmt.Sprintf("w%d", w), Enabled: i%40 == 0})
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(stop)
+	readers.Wait()
+
+	if got := store.GetStateFast().Count; got != writers*perWriter {
+		t.Errorf("GetStateFast().Count = %d, want %d", got, writers*perWriter)
+	}
+}
+
+func TestGetStateFastFollowsCommits(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 3, Flags: map[string]bool{"x": false}})
+	if got := store.GetStateFast().Count; got != 3 {
+		t.Fatalf("initial GetStateFast().Count = %d, want 3", got)
+	}
+
+	// Inside a subscriber the commit, history included, is already visible
+	store.Subscribe(func() {
+		if fast, locked := store.GetStateFast(), store.GetState(); fast.Count != locked.Count {
+			t.Errorf("subscriber saw fast %d, locked %d", fast.Count, locked.Count)
+		}
+	})
+	store.Dispatch(IncrementAction{})
+	store.Undo()
+	if got := store.GetStateFast().Count; got != 3 {
+		t.Errorf("GetStateFast().Count after Undo = %d, want 3", got)
+	}
+
+	fast := store.GetStateFast()
+	fast.Flags["x"] = true
+	if store.GetStateFast().Flags["x"] {
+		t.Error("changing a returned state reached the snapshot")
+	}
+}
@@ -0,0 +1,60 @@
+package counterstore
+
+import "errors"
+
+const (
+	// FlagPercent gates SetPercentAction in the demo reducer
+	FlagPercent = "percent"
+	// FlagNoNegatives rejects decrements and additions that would take the
+	// count below zero
+	FlagNoNegatives = "no-negatives"
+)
+
+var ErrNegativeCount = errors.New("count would go negative")
+
+// checkNoNegatives rejects next when the no-negatives policy is on and next
+// is below zero
+func checkNoNegatives(s State, next int) error {
+	if s.Flags[FlagNoNegatives] && next < 0 {
+		return ErrNegativeCount
+	}
+	return nil
+}
+
+// SetFlagAction turns the named feature flag on or off
+type SetFlagAction struct {
+	Name    string
+	Enabled bool
+}
+
+func (a SetFlagAction) Apply(s State) State {
+	state := s.Copy()
+	if state.Flags == nil {
+		state.Flags = map[string]bool{}
+	}
+	state.Flags[a.Name] = a.Enabled
+	return state
+}
+
+// GateReducer runs inner only while flag is set in the state, and otherwise
+// returns the state unchanged
+func GateReducer(flag string, inner Reducer[State, AppAction]) Reducer[State, AppAction] {
+	return func(state State, action AppAction) State {
+		if !state.Flags[flag] {
+			return state
+		}
+		return inner(state, action)
+	}
+}
+
+// copyFlags returns an independent copy of flags, keeping nil as nil
+func copyFlags(flags map[string]bool) map[string]bool {
+	if flags == nil {
+		return nil
+	}
+	out := make(map[string]bool, len(flags))
+	for name, enabled := range flags {
+		out[name] = enabled
+	}
+	return out
+}
@@ -0,0 +1,47 @@
+package counterstore
+
+import "testing"
+
+func TestGateReducer(t *testing.T) {
+	inner := func(state State, action AppAction) State {
+		state.Count += 10
+		return state
+	}
+	gated := GateReducer("bonus", inner)
+
+	off := State{Count: 1}
+	if got := gated(off, IncrementAction{}); got.Count != 1 {
+		t.Errorf("gated off: Count = %d, want 1", got.Count)
+	}
+	on := State{Count: 1, Flags: map[string]bool{"bonus": true}}
+	if got := gated(on, IncrementAction{}); got.Count != 11 {
+		t.Errorf("gated on: Count = %d, want 11", got.Count)
+	}
+	other := State{Count: 1, Flags: map[string]bool{"other": true}}
+	if got := gated(other, IncrementAction{}); got.Count != 1 {
+		t.Errorf("another flag opened the gate: Count = %d", got.Count)
+	}
+}
+
+func TestSetFlagAction(t *testing.T) {
+	store := NewStore(Reduce, State{})
+	store.Dispatch(SetFlagAction{Name: FlagPercent, Enabled: true})
+	before := store.GetState()
+	if !before.Flags[FlagPercent] {
+		t.Fatal("flag not set")
+	}
+
+	store.Dispatch(SetFlagAction{Name: FlagPercent, Enabled: false})
+	if store.GetState().Flags[FlagPercent] {
+		t.Error("flag not cleared")
+	}
+	if !before.Flags[FlagPercent] {
+		t.Error("SetFlagAction changed the map of an earlier state")
+	}
+
+	// Undo brings the gate back with the flag
+	store.Undo()
+	if !store.GetState().Flags[FlagPercent] {
+		t.Error("Undo did not restore the flag")
+	}
+}
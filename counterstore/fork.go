@@ -0,0 +1,32 @@
+package counterstore
+
+// Fork returns a sandbox store for trying out a branch of actions: it starts
+// from a deep copy of the current state, with the same reducer, invariant,
+// transforms, clock, random seed and history settings, but empty history,
+// no subscribers and no middleware, since middleware often holds on to the
+// store it was installed in. Nothing dispatched on the fork reaches s until
+// it is merged back with Merge.
+func (s *Store[S, A]) Fork() *Store[S, A] {
+	s.mu.RLock()
+	state := s.state.Copy()
+	opts := []StoreOption[S, A]{
+		WithHistory[S, A](s.historyEnabled),
+		WithHistoryLimit[S, A](s.historyLimit),
+		WithHistoryByteLimit[S, A](s.historyByteLimit),
+		WithClock[S, A](s.clock),
+		WithRandSeed[S, A](s.seed),
+		WithInvariant(s.invariant, s.onInvariantViolation),
+	}
+	transforms := append([]func(A, S) A(nil), s.transforms...)
+	s.mu.RUnlock()
+
+	fork := NewStoreWithOptions(s.reducer, state, opts...)
+	fork.transforms = transforms
+	return fork
+}
+
+// Merge makes fork's current state the state of s with SetState, so it is
+// one undo step on s and is subject to its invariant
+func (s *Store[S, A]) Merge(fork *Store[S, A]) error {
+	return s.SetState(fork.GetState())
+}
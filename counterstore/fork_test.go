@@ -0,0 +1,78 @@
+package counterstore
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestForkStartsEqual(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 3, Flags: map[string]bool{"a": true}, Bookmarks: map[string]int{"x": 1}})
+	store.Dispatch(IncrementAction{})
+	fork := store.Fork()
+
+	if fork.GetState().Hash() != store.GetState().Hash() {
+		t.Errorf("fork starts at %+v, store at %+v", fork.GetState(), store.GetState())
+	}
+	if fork.CanUndo() {
+		t.Error("the fork shares the store's history")
+	}
+}
+
+func TestForkDivergesIndependently(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 3, Flags: map[string]bool{"a": true}})
+	notified := 0
+	store.Subscribe(func() { notified++ })
+	fork := store.Fork()
+
+	fork.Dispatch(AddAmountAction{Amount: 10})
+	fork.Dispatch(SetFlagAction{Name: "a", Enabled: false})
+	store.Dispatch(DecrementAction{})
+
+	if got := fork.GetState(); got.Count != 13 || got.Flags["a"] {
+		t.Errorf("fork = %+v, want Count 13 with flag a cleared", got)
+	}
+	if got := store.GetState(); got.Count != 2 || !got.Flags["a"] {
+		t.Errorf("store = %+v, want Count 2 with flag a set", got)
+	}
+	if notified != 1 {
+		t.Errorf("store subscribers notified %d times, want only for its own dispatch", notified)
+	}
+	if !fork.Undo() || fork.GetState().Flags["a"] != true {
+		t.Error("the fork cannot undo its own steps")
+	}
+}
+
+func TestForkKeepsInvariant(t *testing.T) {
+	store := NewStoreWithOptions(Reduce, State{Count: 1}, WithInvariant[State, AppAction](minZero, nil))
+	fork := store.Fork()
+	var iv *InvariantViolation[State]
+	if err := fork.Dispatch(AddAmountAction{Amount: -5}); !errors.As(err, &iv) {
+		t.Errorf("fork dispatch below zero = %v, want the store's invariant", err)
+	}
+}
+
+func TestMergeAppliesFork(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 3})
+	notified := 0
+	store.Subscribe(func() { notified++ })
+	fork := store.Fork()
+	fork.Dispatch(AddAmountAction{Amount: 4})
+	fork.Dispatch(SetTargetAction{Target: 20})
+
+	if err := store.Merge(fork); err != nil {
+		t.Fatal(err)
+	}
+	if got := store.GetState(); got.Count != 7 || got.Target != 20 || notified != 1 {
+		t.Errorf("after merging: %+v with %d notifications, want Count 7 Target 20 and one", got, notified)
+	}
+	// The merge is one undo step on the store
+	if !store.Undo() || store.GetState().Count != 3 {
+		t.Errorf("Count after undoing the merge = %d, want 3", store.GetState().Count)
+	}
+
+	// Later changes to the fork stay on it
+	fork.Dispatch(IncrementAction{})
+	if got := store.GetState().Count; got != 3 {
+		t.Errorf("store Count = %d after the fork moved on, want 3", got)
+	}
+}
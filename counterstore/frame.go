@@ -0,0 +1,51 @@
+package counterstore
+
+// WithFrameRequest has a store in FrameCommitMode call request when a change
+// is waiting for the next CommitFrame, once per frame, so the window can be
+// asked to draw one
+func WithFrameRequest[S StateProvider[S], A Action[S]](request func()) StoreOption[S, A] {
+	return func(s *Store[S, A]) {
+		s.onFrameRequest = request
+	}
+}
+
+// requestFrameLocked marks a change as waiting for CommitFrame and asks for
+// a frame if none was asked for since the last commit. Callers must hold
+// s.mu, which it releases.
+func (s *Store[S, A]) requestFrameLocked() {
+	request := !s.framePending && s.onFrameRequest != nil
+	s.framePending = true
+	s.mu.Unlock()
+
+	if request {
+		s.onFrameRequest()
+	}
+}
+
+// CommitFrame commits the changes made since the last frame in
+// FrameCommitMode as one: the version moves once, the commit hooks run once
+// from the state of the last frame to the latest, and subscribers are
+// notified once, however many actions were dispatched in between. Call it at
+// the start of every frame. Inside a batch the batch's changes wait for the
+// next frame; in the other notify modes it does nothing.
+func (s *Store[S, A]) CommitFrame() {
+	s.mu.Lock()
+	if s.notifyMode != FrameCommitMode || s.batchDepth > 0 {
+		s.mu.Unlock()
+		return
+	}
+	s.framePending = false
+	if s.frameBuffered {
+		base := s.frameBase
+		var zero S
+		s.frameBase, s.frameBuffered = zero, false
+		s.commitLocked(base, s.state)
+	}
+	dirty := s.dirty
+	s.dirty = false
+	s.mu.Unlock()
+
+	if dirty {
+		s.notify()
+	}
+}
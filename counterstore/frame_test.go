@@ -0,0 +1,127 @@
+package counterstore
+
+import "testing"
+
+// countingStorage counts saves and keeps the last state saved
+type countingStorage struct {
+	saves int
+	last  State
+}
+
+func (c *countingStorage) Save(state State) error {
+	c.saves++
+	c.last = state
+	return nil
+}
+
+func (c *countingStorage) Load() (State, error) { return c.last, nil }
+
+// frameTicker stands in for the window: it records frame requests and runs
+// a frame on demand, as a FrameEvent does
+type frameTicker struct {
+	requested int
+	store     *Store[State, AppAction]
+}
+
+func (f *frameTicker) tick() {
+	f.store.CommitFrame()
+}
+
+func newFrameStore(state State, middleware ...Middleware[State, AppAction]) (*Store[State, AppAction], *frameTicker) {
+	ticker := &frameTicker{}
+	ticker.store = NewStoreWithOptions(Reduce, state,
+		WithNotifyMode[State, AppAction](FrameCommitMode),
+		WithFrameRequest[State, AppAction](func() { ticker.requested++ }),
+		WithMiddleware(middleware...))
+	return ticker.store, ticker
+}
+
+func TestFrameCommitOnePerFrame(t *testing.T) {
+	storage := &countingStorage{}
+	store, ticker := newFrameStore(State{Count: 1}, PersistMiddleware(storage, func(err error) { t.Error(err) }))
+	var notified []int
+	store.Subscribe(func() { notified = append(notified, store.GetStateFast().Count) })
+	var commits [][2]int
+	store.AddCommitHook(func(prev, next State) { commits = append(commits, [2]int{prev.Count, next.Count}) })
+	version := store.Version()
+
+	store.Dispatch(IncrementAction{})
+	store.Dispatch(AddAmountAction{Amount: 5})
+	store.Dispatch(DecrementAction{})
+
+	// Reducers applied every action in turn, but nothing is committed yet
+	if got := store.GetState().Count; got != 6 {
+		t.Errorf("GetState within the frame = %d, want 6", got)
+	}
+	if store.Version() != version || store.GetStateFast().Count != 1 || len(commits) != 0 ||
+		len(notified) != 0 || storage.saves != 0 {
+		t.Fatalf("before the frame: version +%d, fast count %d, %d commits, %d notifications, %d saves; want nothing committed",
+			store.Version()-version, store.GetStateFast().Count, len(commits), len(notified), storage.saves)
+	}
+	if ticker.requested != 1 {
+		t.Errorf("%d frames requested for one frame of changes, want 1", ticker.requested)
+	}
+
+	ticker.tick()
+	if len(notified) != 1 || notified[0] != 6 {
+		t.Errorf("notified with %v, want once with the final 6", notified)
+	}
+	if store.Version() != version+1 || len(commits) != 1 || commits[0] != [2]int{1, 6} {
+		t.Errorf("version +%d with commits %v, want one commit from 1 to 6", store.Version()-version, commits)
+	}
+	if storage.saves != 1 || storage.last.Count != 6 {
+		t.Errorf("%d saves, last Count %d; want one save of 6", storage.saves, storage.last.Count)
+	}
+
+	// A frame with nothing new commits and saves nothing
+	ticker.tick()
+	if len(notified) != 1 || len(commits) != 1 || storage.saves != 1 || store.Version() != version+1 {
+		t.Error("an empty frame committed something")
+	}
+
+	// The next change asks for a frame again
+	store.Dispatch(IncrementAction{})
+	if ticker.requested != 2 {
+		t.Errorf("%d frames requested, want 2", ticker.requested)
+	}
+	ticker.tick()
+	if len(notified) != 2 || notified[1] != 7 || storage.saves != 2 {
+		t.Errorf("second frame: notified %v with %d saves", notified, storage.saves)
+	}
+}
+
+func TestFrameCommitUndoSteps(t *testing.T) {
+	store, ticker := newFrameStore(State{})
+	store.Dispatch(IncrementAction{})
+	store.Dispatch(IncrementAction{})
+	ticker.tick()
+
+	// Every action is still its own undo step, and undo waits for the frame
+	// like any other commit
+	if !store.Undo() || store.GetState().Count != 1 {
+		t.Fatalf("undo gave Count %d, want 1", store.GetState().Count)
+	}
+	if store.GetStateFast().Count != 2 {
+		t.Errorf("fast state %d before the frame, want the committed 2", store.GetStateFast().Count)
+	}
+	ticker.tick()
+	if store.GetStateFast().Count != 1 || store.HistoryLen() != 1 {
+		t.Errorf("after the frame: fast Count %d with %d undo steps, want 1 and 1",
+			store.GetStateFast().Count, store.HistoryLen())
+	}
+}
+
+func TestFrameCommitBatch(t *testing.T) {
+	store, ticker := newFrameStore(State{})
+	notified := 0
+	store.Subscribe(func() { notified++ })
+
+	store.DispatchBatch(IncrementAction{}, IncrementAction{})
+	if !store.DispatchBatchGuarded(func(s State) bool { return s.Count < 10 }, AddAmountAction{Amount: 3}) {
+		t.Fatal("guarded batch rejected")
+	}
+	ticker.tick()
+	if notified != 1 || store.GetStateFast().Count != 5 {
+		t.Errorf("%d notifications with fast Count %d, want one with 5", notified, store.GetStateFast().Count)
+	}
+}
@@ -0,0 +1,21 @@
+package counterstore
+
+// Frozen holds a state nobody else can reach into. The state is copied on
+// the way in and every Get hands out a fresh copy, so reference fields such
+// as State.Flags and State.Toasts are never shared: a holder mutating the
+// map or slice it got changes only its own copy, never the frozen value or
+// another holder's. New reference fields stay covered as long as S's Copy
+// deep-copies them.
+type Frozen[S StateProvider[S]] struct {
+	value S
+}
+
+// Freeze copies state into a Frozen
+func Freeze[S StateProvider[S]](state S) Frozen[S] {
+	return Frozen[S]{value: state.Copy()}
+}
+
+// Get returns a copy of the frozen state for the caller to own
+func (f Frozen[S]) Get() S {
+	return f.value.Copy()
+}
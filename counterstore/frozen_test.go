@@ -0,0 +1,53 @@
+package counterstore
+
+import "testing"
+
+func TestFrozenGetIsACopy(t *testing.T) {
+	original := State{Flags: map[string]bool{"a": true}, Toasts: []Toast{{Text: "hi"}}}
+	f := Freeze(original)
+
+	// Neither the value frozen from nor a copy handed out reaches the frozen state
+	original.Flags["a"] = false
+	got := f.Get()
+	if !got.Flags["a"] {
+		t.Fatal("mutating the original reached the frozen state")
+	}
+	got.Flags["b"] = true
+	got.Toasts[0].Text = "changed"
+	if again := f.Get(); again.Flags["b"] || again.Toasts[0].Text != "hi" {
+		t.Errorf("mutating a copy reached the frozen state: %+v", again)
+	}
+}
+
+func TestSubscriberMutationsStayPrivate(t *testing.T) {
+	store := NewStore(Reduce, State{Flags: map[string]bool{FlagNoNegatives: true}})
+	notified := 0
+	mutate := func(get func() State) func() {
+		return func() {
+			notified++
+			state := get()
+			// Each subscriber sees the committed state, untouched by the one before
+			if !state.Flags[FlagNoNegatives] || state.Flags["corrupt"] || state.Toasts[0].Text != "hello" {
+				t.Errorf("subscriber %d got a corrupted state: %+v", notified, state)
+			}
+			state.Flags[FlagNoNegatives] = false
+			state.Flags["corrupt"] = true
+			state.Toasts = append(state.Toasts[:0], Toast{Text: "corrupt"})
+		}
+	}
+	store.Subscribe(mutate(store.GetState))
+	store.Subscribe(mutate(store.GetStateFast))
+	store.Dispatch(ShowToastAction{Text: "hello"})
+
+	if notified != 2 {
+		t.Fatalf("%d notifications, want 2", notified)
+	}
+	for _, state := range []State{store.GetState(), store.GetStateFast()} {
+		if !state.Flags[FlagNoNegatives] || state.Flags["corrupt"] {
+			t.Errorf("a subscriber corrupted the store's flags: %v", state.Flags)
+		}
+		if len(state.Toasts) != 1 || state.Toasts[0].Text != "hello" {
+			t.Errorf("a subscriber corrupted the store's toasts: %v", state.Toasts)
+		}
+	}
+}
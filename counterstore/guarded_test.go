@@ -0,0 +1,121 @@
+package counterstore
+
+import "testing"
+
+// atMost accepts states whose count is at most n
+func atMost(n int) func(State) bool {
+	return func(s State) bool { return s.Count <= n }
+}
+
+func TestDispatchBatchGuardedCommits(t *testing.T) {
+	for _, mode := range []NotifyMode{NotifyImmediate, NotifyCoalesced} {
+		store := NewStoreWithOptions(Reduce, State{Count: 1}, WithNotifyMode[State, AppAction](mode))
+		var notified []int
+		store.Subscribe(func() { notified = append(notified, store.GetState().Count) })
+		var commits, applied int
+		store.AddCommitHook(func(prev, next State) { commits++ })
+		store.AddApplyHook(func(action AppAction, prev, next State) { applied++ })
+		version := store.Version()
+
+		var judged State
+		ok := store.DispatchBatchGuarded(func(s State) bool {
+			judged = s
+			return s.Count <= 5
+		}, IncrementAction{}, AddAmountAction{Amount: 2}, SetTargetAction{Target: 9})
+		if !ok {
+			t.Fatalf("mode %d: a batch within the guard was rejected", mode)
+		}
+		if judged.Count != 4 || judged.Target != 9 {
+			t.Errorf("mode %d: pred judged %+v, want the batch's final state", mode, judged)
+		}
+		if got := store.GetState(); got.Count != 4 || store.GetStateFast().Count != 4 {
+			t.Errorf("mode %d: Count = %d, want 4", mode, got.Count)
+		}
+		if len(notified) != 1 || notified[0] != 4 {
+			t.Errorf("mode %d: notified with %v, want once with 4", mode, notified)
+		}
+		if store.Version() != version+1 || commits != 1 || applied != 3 {
+			t.Errorf("mode %d: version moved by %d with %d commits and %d applied actions, want 1, 1 and 3",
+				mode, store.Version()-version, commits, applied)
+		}
+		if store.HistoryLen() != 1 || !store.Undo() || store.GetState().Count != 1 || store.GetState().Target != 0 {
+			t.Errorf("mode %d: the batch did not undo as one step", mode)
+		}
+	}
+}
+
+func TestDispatchBatchGuardedRollsBack(t *testing.T) {
+	var seen []AppAction
+	record := func(store *Store[State, AppAction], next Dispatch[AppAction]) Dispatch[AppAction] {
+		return func(action AppAction) error {
+			seen = append(seen, action)
+			return next(action)
+		}
+	}
+	store := NewStore(Reduce, State{Count: 1}, record)
+	store.Dispatch(IncrementAction{})
+	store.Dirty()
+	seen = nil
+
+	notified, commits := 0, 0
+	store.Subscribe(func() { notified++ })
+	store.AddCommitHook(func(prev, next State) { commits++ })
+	version := store.Version()
+
+	if store.DispatchBatchGuarded(atMost(5), IncrementAction{}, AddAmountAction{Amount: 7}) {
+		t.Fatal("a batch breaking the guard was accepted")
+	}
+	if got := store.GetState(); got.Count != 2 || store.GetStateFast().Count != 2 {
+		t.Errorf("Count = %d after the rollback, want 2", got.Count)
+	}
+	if notified != 0 || commits != 0 || store.Version() != version {
+		t.Errorf("rollback notified %d times, ran %d commit hooks and moved the version by %d; want none",
+			notified, commits, store.Version()-version)
+	}
+	if _, dirty := store.Dirty(); dirty {
+		t.Error("a rolled back batch left the store dirty")
+	}
+	if len(seen) != 0 {
+		t.Errorf("middleware saw %v before the guard judged the batch", seen)
+	}
+	if store.HistoryLen() != 1 || store.CanRedo() {
+		t.Errorf("rollback changed the history: %d steps, redo %v", store.HistoryLen(), store.CanRedo())
+	}
+
+	// Only the final state is judged, not the states on the way
+	if !store.DispatchBatchGuarded(atMost(5), AddAmountAction{Amount: 10}, AddAmountAction{Amount: -8}) {
+		t.Error("a batch ending within the guard was rejected")
+	}
+	if got := store.GetState().Count; got != 4 || notified != 1 {
+		t.Errorf("Count = %d with %d notifications, want 4 and one", got, notified)
+	}
+}
+
+func TestDispatchBatchGuardedStepFails(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 1, Flags: map[string]bool{FlagNoNegatives: true}})
+	notified := 0
+	store.Subscribe(func() { notified++ })
+	version := store.Version()
+
+	called := false
+	ok := store.DispatchBatchGuarded(func(State) bool {
+		called = true
+		return true
+	}, IncrementAction{}, AddAmountAction{Amount: -5}, IncrementAction{})
+	if ok || called {
+		t.Errorf("a batch with a rejected action returned %v, pred called %v; want false, not called", ok, called)
+	}
+	if store.GetState().Count != 1 || store.Version() != version || notified != 0 || store.CanUndo() {
+		t.Errorf("a failed step left Count %d, version +%d, %d notifications", store.GetState().Count,
+			store.Version()-version, notified)
+	}
+
+	store.Pause()
+	if store.DispatchBatchGuarded(atMost(10), IncrementAction{}) {
+		t.Error("a guarded batch ran while paused")
+	}
+	store.Resume()
+	if got := store.GetState().Count; got != 1 {
+		t.Errorf("Count = %d after resuming, want nothing queued", got)
+	}
+}
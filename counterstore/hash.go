@@ -0,0 +1,109 @@
+package counterstore
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"io"
+	"sort"
+)
+
+// Hash fingerprints the state with FNV-1a over every field in a fixed order,
+// so equal states hash equally in any process. Maps such as flags are hashed
+// in key order and a nil map hashes like an empty one. The transient UI is
+// left out, so states differing only in it hash equally.
+func (s State) Hash() uint64 {
+	h := fnv.New64a()
+	writeInt(h, int64(s.Count))
+	writeInt(h, int64(s.Target))
+	writeBool(h, s.PresentationMode)
+	writeBool(h, s.AlwaysOnTop)
+
+	names := make([]string, 0, len(s.Flags))
+	for name := range s.Flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	writeInt(h, int64(len(names)))
+	for _, name := range names {
+		writeString(h, name)
+		writeBool(h, s.Flags[name])
+	}
+
+	writeInt(h, int64(s.Overflow))
+	writeInt(h, int64(len(s.Toasts)))
+	for _, t := range s.Toasts {
+		writeString(h, t.Text)
+		writeInt(h, t.ExpiresAt.UnixNano())
+	}
+	writeString(h, s.Status)
+	writeBool(h, s.Countdown)
+	writeInt(h, int64(s.Palette))
+	writeInt(h, int64(s.ScoreA))
+	writeInt(h, int64(s.ScoreB))
+	writeInt(h, int64(s.Turn))
+
+	names = names[:0]
+	for name := range s.Bookmarks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	writeInt(h, int64(len(names)))
+	for _, name := range names {
+		writeString(h, name)
+		writeInt(h, int64(s.Bookmarks[name]))
+	}
+
+	counts := make([]int, 0, len(s.Notes))
+	for count := range s.Notes {
+		counts = append(counts, count)
+	}
+	sort.Ints(counts)
+	writeInt(h, int64(len(counts)))
+	for _, count := range counts {
+		writeInt(h, int64(count))
+		writeString(h, s.Notes[count])
+	}
+	writeInt(h, int64(len(s.Counts)))
+	for _, count := range s.Counts {
+		writeInt(h, int64(count))
+	}
+	writeBool(h, s.Minimal)
+
+	names = names[:0]
+	for name := range s.Categories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	writeInt(h, int64(len(names)))
+	for _, name := range names {
+		writeString(h, name)
+		writeInt(h, int64(s.Categories[name]))
+	}
+	writeInt(h, int64(s.PresetIndex))
+	writeInt(h, int64(s.StepSize))
+	writeInt(h, int64(len(s.Rolls)))
+	for _, roll := range s.Rolls {
+		writeInt(h, int64(roll))
+	}
+	return h.Sum64()
+}
+
+func writeInt(w io.Writer, v int64) {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], uint64(v))
+	w.Write(buf[:])
+}
+
+func writeBool(w io.Writer, v bool) {
+	if v {
+		w.Write([]byte{1})
+	} else {
+		w.Write([]byte{0})
+	}
+}
+
+// writeString length-prefixes s so adjacent strings cannot run together
+func writeString(w io.Writer, s string) {
+	writeInt(w, int64(len(s)))
+	io.WriteString(w, s)
+}
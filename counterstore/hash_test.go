@@ -0,0 +1,94 @@
+package counterstore
+
+import (
+	"testing"
+	"time"
+)
+
+// hashedState has something set in every field Hash covers
+func hashedState() State {
+	return State{
+		Count:            7,
+		Target:           10,
+		PresentationMode: true,
+		Flags:            map[string]bool{FlagPercent: true, FlagNoNegatives: false},
+		Toasts:           []Toast{{Text: "hi", ExpiresAt: time.Unix(5, 0)}},
+		Status:           "ok",
+		Bookmarks:        map[string]int{"a": 1, "b": 2},
+		Notes:            map[int]string{3: "three"},
+		Counts:           []int{1, 2, 3},
+		Categories:       map[string]int{"x": 4},
+		Rolls:            []int{4, 6},
+	}
+}
+
+func TestHashEqualStates(t *testing.T) {
+	a := hashedState()
+	b := a.Copy()
+	// Rebuilt maps have their own iteration order
+	b.Flags = map[string]bool{FlagNoNegatives: false, FlagPercent: true}
+	b.Bookmarks = map[string]int{"b": 2, "a": 1}
+	if a.Hash() != b.Hash() {
+		t.Error("equal states hash differently")
+	}
+	if (State{}).Hash() != (State{Flags: map[string]bool{}, Bookmarks: map[string]int{}}).Hash() {
+		t.Error("a nil map hashes differently from an empty one")
+	}
+}
+
+func TestHashStable(t *testing.T) {
+	// Hashes are compared across processes, so they must not change between
+	// runs or builds for the same content
+	const want = 0xa134ee2a0647769c
+	if got := hashedState().Hash(); got != want {
+		t.Errorf("Hash = %#x, want %#x", got, uint64(want))
+	}
+}
+
+func TestHashFieldChanges(t *testing.T) {
+	base := hashedState()
+	tests := []struct {
+		name   string
+		change func(*State)
+	}{
+		{"Count", func(s *State) { s.Count++ }},
+		{"Target", func(s *State) { s.Target = 0 }},
+		{"PresentationMode", func(s *State) { s.PresentationMode = false }},
+		{"AlwaysOnTop", func(s *State) { s.AlwaysOnTop = true }},
+		{"flag value", func(s *State) { s.Flags[FlagNoNegatives] = true }},
+		{"flag name", func(s *State) { s.Flags["other"] = false }},
+		{"Overflow", func(s *State) { s.Overflow = OverflowError }},
+		{"toast text", func(s *State) { s.Toasts[0].Text = "ho" }},
+		{"toast expiry", func(s *State) { s.Toasts[0].ExpiresAt = time.Unix(6, 0) }},
+		{"Status", func(s *State) { s.Status = "" }},
+		{"Countdown", func(s *State) { s.Countdown = true }},
+		{"Palette", func(s *State) { s.Palette++ }},
+		{"ScoreA", func(s *State) { s.ScoreA = 1 }},
+		{"ScoreB", func(s *State) { s.ScoreB = 1 }},
+		{"Turn", func(s *State) { s.Turn = 1 }},
+		{"bookmark", func(s *State) { s.Bookmarks["a"] = 9 }},
+		{"note", func(s *State) { s.Notes[3] = "drei" }},
+		{"Counts", func(s *State) { s.Counts = s.Counts[:2] }},
+		{"Minimal", func(s *State) { s.Minimal = true }},
+		{"category", func(s *State) { s.Categories["x"] = 5 }},
+		{"PresetIndex", func(s *State) { s.PresetIndex = 1 }},
+		{"StepSize", func(s *State) { s.StepSize = 5 }},
+		{"Rolls", func(s *State) { s.Rolls = append(s.Rolls, 1) }},
+	}
+	for _, tt := range tests {
+		changed := base.Copy()
+		tt.change(&changed)
+		if changed.Hash() == base.Hash() {
+			t.Errorf("changing %s left the hash unchanged", tt.name)
+		}
+	}
+}
+
+func TestHashIgnoresUI(t *testing.T) {
+	base := hashedState()
+	ui := base.Copy()
+	ui.UI = UIState{ShowHelp: true, ConfirmPending: true, Idle: true, Stopwatch: true, Dwell: map[int]int{7: 2}}
+	if ui.Hash() != base.Hash() {
+		t.Error("the transient UI changed the hash")
+	}
+}
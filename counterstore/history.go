@@ -0,0 +1,143 @@
+package counterstore
+
+// defaultHistoryLimit is the number of undo steps kept unless configured
+const defaultHistoryLimit = 100
+
+// Undoable is implemented by actions that may opt out of undo history.
+// Actions without it are undoable; cosmetic, UI-only actions return false so
+// they never become undo steps of their own.
+type Undoable interface {
+	Undoable() bool
+}
+
+func isUndoable(action any) bool {
+	u, ok := action.(Undoable)
+	return !ok || u.Undoable()
+}
+
+// Transient is implemented by states with fields that live outside undo
+// history, such as a pending confirmation prompt. Undo and Redo carry the
+// current values of those fields over into the state they restore.
+type Transient[S any] interface {
+	KeepTransient(current S) S
+}
+
+// restoreHistory returns the history entry restored to replace current
+func restoreHistory[S any](restored, current S) S {
+	if t, ok := any(restored).(Transient[S]); ok {
+		return t.KeepTransient(current)
+	}
+	return restored
+}
+
+func anyUndoable[A any](actions []A) bool {
+	for _, action := range actions {
+		if isUndoable(action) {
+			return true
+		}
+	}
+	return false
+}
+
+// historySnapshot copies the current state for use as an undo step before
+// it is replaced. It reports false, skipping the copy, when history is off or
+// a batch is running (a batch records its starting state once, when it
+// ends). Callers must hold s.mu.
+func (s *Store[S, A]) historySnapshot() (S, bool) {
+	if !s.historyEnabled || s.batchDepth > 0 {
+		var zero S
+		return zero, false
+	}
+	return s.state.Copy(), true
+}
+
+// pushHistory records prev as an undo step and drops the redo stack.
+// Callers must hold s.mu.
+func (s *Store[S, A]) pushHistory(prev S) {
+	s.appendPast(prev)
+	s.future = nil
+}
+
+// Undo restores the state before the last dispatched action. It returns
+// false when there is nothing to undo or history is disabled.
+func (s *Store[S, A]) Undo() bool {
+	s.mu.Lock()
+	if !s.historyEnabled || len(s.past) == 0 {
+		s.mu.Unlock()
+		return false
+	}
+	last := len(s.past) - 1
+	prev := s.past[last]
+	s.future = append(s.future, s.state)
+	s.truncatePast(last)
+	s.setState(restoreHistory(prev, s.state))
+	s.mu.Unlock()
+
+	s.changed()
+	s.settle()
+	return true
+}
+
+// Redo reapplies the most recently undone change. It returns false when there
+// is nothing to redo or history is disabled.
+func (s *Store[S, A]) Redo() bool {
+	s.mu.Lock()
+	if !s.historyEnabled || len(s.future) == 0 {
+		s.mu.Unlock()
+		return false
+	}
+	last := len(s.future) - 1
+	next := s.future[last]
+	s.appendPast(s.state)
+	s.future = s.future[:last]
+	s.setState(restoreHistory(next, s.state))
+	s.mu.Unlock()
+
+	s.changed()
+	s.settle()
+	return true
+}
+
+// HistoryLen returns the number of undo steps available
+func (s *Store[S, A]) HistoryLen() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.past)
+}
+
+// dropHistory removes the n most recent undo steps, leaving the state as it
+// is, and reports whether there were that many
+func (s *Store[S, A]) dropHistory(n int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if n > len(s.past) {
+		return false
+	}
+	s.truncatePast(len(s.past) - n)
+	s.dirty = true
+	return true
+}
+
+func (s *Store[S, A]) CanUndo() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.historyEnabled && len(s.past) > 0
+}
+
+func (s *Store[S, A]) CanRedo() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.historyEnabled && len(s.future) > 0
+}
+
+// JumpTo makes state the current state without running the reducer or any
+// middleware, as time travel does. Undo history is left as it was and
+// subscribers are notified.
+func (s *Store[S, A]) JumpTo(state S) {
+	s.mu.Lock()
+	s.setState(state)
+	s.mu.Unlock()
+
+	s.changed()
+	s.settle()
+}
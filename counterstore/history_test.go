@@ -0,0 +1,189 @@
+package counterstore
+
+import "testing"
+
+func TestUndoRedo(t *testing.T) {
+	store := NewStore(Reduce, State{})
+	store.Dispatch(IncrementAction{})
+	store.Dispatch(IncrementAction{})
+
+	if !store.Undo() {
+		t.Fatal("Undo returned false")
+	}
+	if got := store.GetState().Count; got != 1 {
+		t.Errorf("Count after Undo = %d, want 1", got)
+	}
+	if !store.CanRedo() {
+		t.Fatal("CanRedo false after Undo")
+	}
+	if !store.Redo() {
+		t.Fatal("Redo returned false")
+	}
+	if got := store.GetState().Count; got != 2 {
+		t.Errorf("Count after Redo = %d, want 2", got)
+	}
+	if store.Redo() {
+		t.Error("Redo with nothing undone returned true")
+	}
+}
+
+func TestUndoDisabled(t *testing.T) {
+	store := NewStoreWithOptions(Reduce, State{}, WithHistory[State, AppAction](false))
+	store.Dispatch(IncrementAction{})
+	store.Dispatch(IncrementAction{})
+
+	if store.CanUndo() {
+		t.Error("CanUndo true with history disabled")
+	}
+	if store.Undo() {
+		t.Error("Undo returned true with history disabled")
+	}
+	if store.Redo() {
+		t.Error("Redo returned true with history disabled")
+	}
+	if got := store.GetState().Count; got != 2 {
+		t.Errorf("Count = %d, want 2", got)
+	}
+	if got := store.HistoryLen(); got != 0 {
+		t.Errorf("HistoryLen = %d, want 0", got)
+	}
+}
+
+func TestHistoryLimit(t *testing.T) {
+	store := NewStoreWithOptions(Reduce, State{}, WithHistoryLimit[State, AppAction](2))
+	for i := 0; i < 5; i++ {
+		store.Dispatch(IncrementAction{})
+	}
+	if got := store.HistoryLen(); got != 2 {
+		t.Fatalf("HistoryLen = %d, want 2", got)
+	}
+	for store.Undo() {
+	}
+	if got := store.GetState().Count; got != 3 {
+		t.Errorf("Count after undoing everything kept = %d, want 3", got)
+	}
+}
+
+func BenchmarkDispatchHistory(b *testing.B) {
+	for _, bm := range []struct {
+		name    string
+		enabled bool
+	}{
+		{"on", true},
+		{"off", false},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			store := NewStoreWithOptions(Reduce, State{}, WithHistory[State, AppAction](bm.enabled))
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				store.Dispatch(IncrementAction{})
+			}
+		})
+	}
+}
+
+func TestBatchIsOneUndoStep(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 5})
+	store.Dispatch(IncrementAction{})
+
+	if err := store.DispatchBatch(IncrementAction{}, AddAmountAction{Amount: 10}, NegateAction{}); err != nil {
+		t.Fatalf("DispatchBatch: %v", err)
+	}
+	if got := store.GetState().Count; got != -17 {
+		t.Fatalf("Count after batch = %d, want -17", got)
+	}
+
+	if !store.Undo() {
+		t.Fatal("Undo returned false")
+	}
+	if got := store.GetState().Count; got != 6 {
+		t.Errorf("Count after one Undo = %d, want the pre-batch 6", got)
+	}
+	if !store.CanRedo() || !store.Redo() {
+		t.Fatal("Redo of the batch failed")
+	}
+	if got := store.GetState().Count; got != -17 {
+		t.Errorf("Count after one Redo = %d, want -17", got)
+	}
+	if got := store.HistoryLen(); got != 2 {
+		t.Errorf("HistoryLen = %d, want 2", got)
+	}
+}
+
+func TestBatchOfNonUndoableActionsRecordsNoStep(t *testing.T) {
+	store := NewStore(Reduce, State{})
+	store.DispatchBatch(ToggleMinimalAction{}, ToggleAlwaysOnTopAction{})
+	if store.CanUndo() {
+		t.Error("a batch of non-undoable actions became an undo step")
+	}
+}
+
+func TestBatchQueuedWhilePausedIsOneUndoStep(t *testing.T) {
+	store := NewStore(Reduce, State{})
+	store.Pause()
+	store.DispatchBatch(IncrementAction{}, IncrementAction{}, IncrementAction{})
+	store.Dispatch(IncrementAction{})
+	if err := store.Resume(); err != nil {
+		t.Fatalf("Resume: %v", err)
+	}
+	if got := store.GetState().Count; got != 4 {
+		t.Fatalf("Count after Resume = %d, want 4", got)
+	}
+
+	store.Undo()
+	if got := store.GetState().Count; got != 3 {
+		t.Errorf("Count after undoing the single dispatch = %d, want 3", got)
+	}
+	store.Undo()
+	if got := store.GetState().Count; got != 0 {
+		t.Errorf("Count after undoing the queued batch = %d, want 0", got)
+	}
+}
+
+func TestUndoSkipsNonUndoableActions(t *testing.T) {
+	store := NewStore(Reduce, State{})
+	store.Dispatch(IncrementAction{})
+	store.Dispatch(ToggleMinimalAction{})
+	store.Dispatch(AddAmountAction{Amount: 5})
+	store.Dispatch(ToggleAlwaysOnTopAction{})
+	store.Dispatch(Annotated{AppAction: ToggleMinimalAction{}})
+
+	if got := store.HistoryLen(); got != 2 {
+		t.Fatalf("HistoryLen = %d, want only the two counting actions", got)
+	}
+	state := store.GetState()
+	if state.Minimal || !state.AlwaysOnTop {
+		t.Fatalf("non-undoable actions were not applied: %+v", state)
+	}
+
+	// Undo steps back over the counting transitions only
+	store.Undo()
+	if got := store.GetState(); got.Count != 1 {
+		t.Errorf("Count after one Undo = %d, want 1", got.Count)
+	}
+	store.Undo()
+	if got := store.GetState(); got.Count != 0 {
+		t.Errorf("Count after two Undos = %d, want 0", got.Count)
+	}
+	if store.Undo() {
+		t.Error("a third Undo succeeded")
+	}
+}
+
+func TestIsUndoable(t *testing.T) {
+	tests := []struct {
+		action AppAction
+		want   bool
+	}{
+		{IncrementAction{}, true},
+		{ToggleMinimalAction{}, false},
+		{Annotated{AppAction: IncrementAction{}}, true},
+		{Annotated{AppAction: ToggleAlwaysOnTopAction{}}, false},
+	}
+	for _, tt := range tests {
+		if got := isUndoable(tt.action); got != tt.want {
+			t.Errorf("isUndoable(%T) = %v, want %v", tt.action, got, tt.want)
+		}
+	}
+}
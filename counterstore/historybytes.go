@@ -0,0 +1,74 @@
+package counterstore
+
+import "encoding/json"
+
+// WithHistoryByteLimit caps undo history by its estimated size instead of, or
+// as well as, its length: once the snapshots kept for undo add up to more
+// than limit bytes, the oldest are dropped until they fit again. A snapshot's
+// size is estimated as the length of its JSON encoding, which tracks states
+// that grow, such as ones holding maps or slices, better than a fixed entry
+// count does. Zero, the default, means no byte limit. It costs an encoding
+// per undo step, so it is best left off for states of fixed size.
+func WithHistoryByteLimit[S StateProvider[S], A Action[S]](limit int) StoreOption[S, A] {
+	return func(s *Store[S, A]) {
+		s.historyByteLimit = limit
+	}
+}
+
+// HistoryBytes returns the estimated size of the undo history, or zero
+// without a byte limit
+func (s *Store[S, A]) HistoryBytes() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.pastBytesTotal
+}
+
+// historyEntrySize estimates the memory state takes as an undo step. It is
+// zero without a byte limit, so the encoding is skipped.
+func (s *Store[S, A]) historyEntrySize(state S) int {
+	if s.historyByteLimit <= 0 {
+		return 0
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return 0
+	}
+	return len(data)
+}
+
+// appendPast records state as the newest undo step, without touching the
+// redo stack, and evicts the oldest steps over the length and byte limits.
+// Callers must hold s.mu.
+func (s *Store[S, A]) appendPast(state S) {
+	size := s.historyEntrySize(state)
+	s.past = append(s.past, state)
+	s.pastBytes = append(s.pastBytes, size)
+	s.pastBytesTotal += size
+
+	drop := 0
+	if s.historyLimit > 0 && len(s.past) > s.historyLimit {
+		drop = len(s.past) - s.historyLimit
+	}
+	total := s.pastBytesTotal
+	for i := 0; i < drop; i++ {
+		total -= s.pastBytes[i]
+	}
+	for s.historyByteLimit > 0 && total > s.historyByteLimit && drop < len(s.past) {
+		total -= s.pastBytes[drop]
+		drop++
+	}
+	if drop > 0 {
+		s.past = s.past[drop:]
+		s.pastBytes = s.pastBytes[drop:]
+		s.pastBytesTotal = total
+	}
+}
+
+// truncatePast drops the undo steps from index n on. Callers must hold s.mu.
+func (s *Store[S, A]) truncatePast(n int) {
+	for _, size := range s.pastBytes[n:] {
+		s.pastBytesTotal -= size
+	}
+	s.past = s.past[:n]
+	s.pastBytes = s.pastBytes[:n]
+}
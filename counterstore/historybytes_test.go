@@ -0,0 +1,86 @@
+package counterstore
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// snapshotSize is the estimated history size of state
+func snapshotSize(t *testing.T, state State) int {
+	t.Helper()
+	data, err := json.Marshal(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return len(data)
+}
+
+func TestHistoryByteLimitEvicts(t *testing.T) {
+	// Counts 10 to 19 all encode to the same size
+	size := snapshotSize(t, State{Count: 10})
+	store := NewStoreWithOptions(Reduce, State{Count: 10}, WithHistoryByteLimit[State, AppAction](3*size))
+
+	for i := 0; i < 2; i++ {
+		store.Dispatch(IncrementAction{})
+	}
+	if got := store.HistoryLen(); got != 2 {
+		t.Fatalf("HistoryLen within the budget = %d, want 2", got)
+	}
+	for i := 0; i < 3; i++ {
+		store.Dispatch(IncrementAction{})
+	}
+	if got := store.HistoryLen(); got != 3 {
+		t.Errorf("HistoryLen over the budget = %d, want the 3 that fit", got)
+	}
+	if got := store.HistoryBytes(); got != 3*size {
+		t.Errorf("HistoryBytes = %d, want %d", got, 3*size)
+	}
+
+	// Undo still reaches back as far as the budget kept
+	for store.Undo() {
+	}
+	if got := store.GetState().Count; got != 12 {
+		t.Errorf("Count after undoing everything kept = %d, want 12", got)
+	}
+	if got := store.HistoryBytes(); got != 0 {
+		t.Errorf("HistoryBytes with nothing left to undo = %d, want 0", got)
+	}
+}
+
+func TestHistoryByteLimitTracksGrowingState(t *testing.T) {
+	small := snapshotSize(t, State{})
+	store := NewStoreWithOptions(Reduce, State{}, WithHistoryByteLimit[State, AppAction](4*small))
+	for i := 0; i < 3; i++ {
+		store.Dispatch(SetTargetAction{Target: 1 + i})
+	}
+	if got := store.HistoryLen(); got != 3 {
+		t.Fatalf("HistoryLen of small states = %d, want 3", got)
+	}
+
+	// A snapshot worth more than three small ones leaves room for nothing
+	// else in a budget of four
+	store.Dispatch(IncrementAction{})
+	large := State{Count: 1, Notes: map[int]string{}}
+	for i := 0; snapshotSize(t, large) < 3*small; i++ {
+		large.Notes[i] = "note"
+	}
+	store.SetState(large)
+	store.Dispatch(IncrementAction{})
+	if got := store.HistoryLen(); got != 1 {
+		t.Errorf("HistoryLen after the large snapshot = %d, want only it", got)
+	}
+	if !store.Undo() || len(store.GetState().Notes) != len(large.Notes) {
+		t.Error("Undo did not restore the large snapshot")
+	}
+	if got := store.HistoryBytes(); got > 4*small {
+		t.Errorf("HistoryBytes = %d over the %d budget", got, 4*small)
+	}
+}
+
+func TestHistoryBytesWithoutLimit(t *testing.T) {
+	store := NewStore(Reduce, State{})
+	store.Dispatch(IncrementAction{})
+	if got := store.HistoryBytes(); got != 0 {
+		t.Errorf("HistoryBytes without a limit = %d, want 0", got)
+	}
+}
@@ -0,0 +1,122 @@
+package counterstore
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// SetIdleAction marks the UI idle or active again
+type SetIdleAction struct {
+	Idle bool
+}
+
+func (a SetIdleAction) Undoable() bool { return false }
+
+func (a SetIdleAction) Apply(s State) State {
+	if s.UI.Idle == a.Idle {
+		return s
+	}
+	state := s.Copy()
+	state.UI.Idle = a.Idle
+	return state
+}
+
+// IdleWatch sets State.UI.Idle after a period without user activity and clears
+// it on the next. Activity is any undoable action dispatched through its
+// middleware, or a call to Activity, which a UI makes for input that
+// dispatches nothing, such as moving the pointer. Background actions like
+// toast expiry or countdown ticks do not count.
+type IdleWatch struct {
+	mu      sync.Mutex
+	clock   Clock
+	timeout time.Duration
+	store   *Store[State, AppAction]
+	timer   Timer
+	closed  bool
+}
+
+// NewIdleWatch creates an idle watch going idle after timeout without
+// activity, read from clock or the wall clock when clock is nil. A timeout
+// of zero or less disables it.
+func NewIdleWatch(clock Clock, timeout time.Duration) *IdleWatch {
+	return &IdleWatch{clock: orRealClock(clock), timeout: timeout}
+}
+
+// Middleware returns the middleware that treats user actions as activity
+func (w *IdleWatch) Middleware() Middleware[State, AppAction] {
+	return func(store *Store[State, AppAction], next Dispatch[AppAction]) Dispatch[AppAction] {
+		w.mu.Lock()
+		w.store = store
+		w.restart()
+		w.mu.Unlock()
+
+		return func(action AppAction) error {
+			if _, ok := plainAction(action).(SetIdleAction); ok || !isUndoable(action) {
+				return next(action)
+			}
+			if store.GetState().UI.Idle {
+				if err := next(SetIdleAction{Idle: false}); err != nil {
+					log.Printf("idle: %v", err)
+				}
+			}
+			err := next(action)
+			w.mu.Lock()
+			w.restart()
+			w.mu.Unlock()
+			return err
+		}
+	}
+}
+
+// Activity records user input that dispatches nothing, leaving idle and
+// restarting the timeout
+func (w *IdleWatch) Activity() {
+	w.mu.Lock()
+	store := w.store
+	w.restart()
+	w.mu.Unlock()
+
+	if store != nil && store.GetStateFast().UI.Idle {
+		if err := store.Dispatch(SetIdleAction{Idle: false}); err != nil {
+			log.Printf("idle: %v", err)
+		}
+	}
+}
+
+// restart rearms the idle timer. Callers must hold w.mu.
+func (w *IdleWatch) restart() {
+	if w.timer != nil {
+		w.timer.Stop()
+		w.timer = nil
+	}
+	if w.closed || w.timeout <= 0 || w.store == nil {
+		return
+	}
+	w.timer = w.clock.AfterFunc(w.timeout, w.expire)
+}
+
+func (w *IdleWatch) expire() {
+	w.mu.Lock()
+	store := w.store
+	w.timer = nil
+	if w.closed {
+		store = nil
+	}
+	w.mu.Unlock()
+
+	if store == nil {
+		return
+	}
+	if err := store.Dispatch(SetIdleAction{Idle: true}); err != nil {
+		log.Printf("idle: %v", err)
+	}
+}
+
+// Close stops the idle timer for good
+func (w *IdleWatch) Close() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.closed = true
+	w.restart()
+}
@@ -0,0 +1,117 @@
+package counterstore
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIdleWatch(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	idle := NewIdleWatch(clock, 30*time.Second)
+	defer idle.Close()
+	store := NewStore(Reduce, State{}, idle.Middleware())
+
+	clock.Advance(29 * time.Second)
+	if store.GetState().UI.Idle {
+		t.Fatal("idle before the timeout")
+	}
+	clock.Advance(time.Second)
+	if !store.GetState().UI.Idle {
+		t.Fatal("not idle after the timeout")
+	}
+
+	// A user action clears idle and restarts the timeout
+	store.Dispatch(IncrementAction{})
+	if got := store.GetState(); got.UI.Idle || got.Count != 1 {
+		t.Fatalf("after a dispatch: %+v, want active with Count 1", got)
+	}
+	clock.Advance(20 * time.Second)
+	store.Dispatch(IncrementAction{})
+	clock.Advance(20 * time.Second)
+	if store.GetState().UI.Idle {
+		t.Error("idle 20s after the last action")
+	}
+	clock.Advance(10 * time.Second)
+	if !store.GetState().UI.Idle {
+		t.Error("not idle 30s after the last action")
+	}
+
+	// Waking is not an undo step of its own
+	store.Undo()
+	if got := store.GetState().Count; got != 1 {
+		t.Errorf("Count after one undo = %d, want 1", got)
+	}
+}
+
+func TestIdleWatchBackgroundActions(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	idle := NewIdleWatch(clock, 30*time.Second)
+	defer idle.Close()
+	store := NewStore(Reduce, State{Count: 3, Countdown: true}, idle.Middleware())
+
+	clock.Advance(20 * time.Second)
+	store.Dispatch(TickAction{})
+	clock.Advance(10 * time.Second)
+	if !store.GetState().UI.Idle {
+		t.Fatal("a background action kept the window awake")
+	}
+	store.Dispatch(TickAction{})
+	if !store.GetState().UI.Idle {
+		t.Error("a background action woke the window")
+	}
+}
+
+func TestIdleWatchAnnotated(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	idle := NewIdleWatch(clock, 30*time.Second)
+	defer idle.Close()
+	store := NewStore(Reduce, State{}, idle.Middleware())
+	meta := map[string]string{"source": "script"}
+
+	// An annotated SetIdleAction is not activity
+	store.Dispatch(WithMeta(SetIdleAction{Idle: true}, meta))
+	if !store.GetState().UI.Idle {
+		t.Fatal("an annotated SetIdleAction was taken for activity")
+	}
+	// An annotated user action is
+	store.Dispatch(WithMeta(IncrementAction{}, meta))
+	if got := store.GetState(); got.UI.Idle || got.Count != 1 {
+		t.Errorf("after an annotated increment: %+v, want active with Count 1", got)
+	}
+}
+
+func TestIdleWatchActivity(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	idle := NewIdleWatch(clock, 30*time.Second)
+	store := NewStore(Reduce, State{}, idle.Middleware())
+
+	clock.Advance(30 * time.Second)
+	version := store.Version()
+	idle.Activity()
+	if store.GetState().UI.Idle {
+		t.Fatal("Activity did not wake the window")
+	}
+	clock.Advance(29 * time.Second)
+	idle.Activity()
+	clock.Advance(29 * time.Second)
+	if store.GetState().UI.Idle || store.Version() != version+1 {
+		t.Errorf("Activity while awake: Idle %v at version %d, want awake at %d",
+			store.GetState().UI.Idle, store.Version(), version+1)
+	}
+
+	idle.Close()
+	clock.Advance(time.Hour)
+	if store.GetState().UI.Idle || clock.Pending() != 0 {
+		t.Error("a closed idle watch still went idle")
+	}
+}
+
+func TestIdleWatchDisabled(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	store := NewStore(Reduce, State{}, NewIdleWatch(clock, 0).Middleware())
+	store.Dispatch(IncrementAction{})
+	clock.Advance(time.Hour)
+	if store.GetState().UI.Idle || clock.Pending() != 0 {
+		t.Error("an idle watch with no timeout went idle")
+	}
+}
@@ -0,0 +1,30 @@
+//go:build devcheck
+
+package counterstore
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ImmutableAction Middleware checks that actions are treated as values: it
+// snapshots each action's JSON encoding, which follows pointers, slices and
+// maps, before passing it down the chain and panics if the encoding differs
+// once the chain returns, naming the action. It is compiled in only with
+// the devcheck build tag, to catch middleware or reducers writing through a
+// pointer an action carries; without the tag it passes actions straight
+// through. Actions that cannot be encoded are not checked.
+func ImmutableActionMiddleware(store *Store[State, AppAction], next Dispatch[AppAction]) Dispatch[AppAction] {
+	return func(action AppAction) error {
+		before, err := json.Marshal(action)
+		if err != nil {
+			return next(action)
+		}
+		dispatchErr := next(action)
+		after, err := json.Marshal(action)
+		if err != nil || string(after) != string(before) {
+			panic(fmt.Sprintf("action %s mutated during dispatch: was %s, now %s", ActionName(action), before, after))
+		}
+		return dispatchErr
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !devcheck
+
+package counterstore
+
+// ImmutableAction Middleware passes actions straight through; built with the
+// devcheck tag, it panics when an action is mutated during dispatch
+func ImmutableActionMiddleware(store *Store[State, AppAction], next Dispatch[AppAction]) Dispatch[AppAction] {
+	return next
+}
@@ -0,0 +1,18 @@
+//go:build !devcheck
+
+package counterstore
+
+import "testing"
+
+func TestImmutableActionMiddlewareOffPassesThrough(t *testing.T) {
+	called := false
+	next := func(action AppAction) error {
+		called = true
+		return nil
+	}
+	dispatch := ImmutableActionMiddleware(nil, next)
+	dispatch(IncrementAction{})
+	if !called {
+		t.Error("the middleware did not forward the action")
+	}
+}
@@ -0,0 +1,63 @@
+//go:build devcheck
+
+package counterstore
+
+import (
+	"strings"
+	"testing"
+)
+
+// boxedAmountAction carries its amount through a pointer
+type boxedAmountAction struct {
+	Amount *int
+}
+
+func (a boxedAmountAction) Apply(s State) State {
+	state := s.Copy()
+	state.Count += *a.Amount
+	return state
+}
+
+// doubleAfter is a misbehaving middleware writing through the action's
+// pointer once the reducer has run
+func doubleAfter(store *Store[State, AppAction], next Dispatch[AppAction]) Dispatch[AppAction] {
+	return func(action AppAction) error {
+		err := next(action)
+		if boxed, ok := action.(boxedAmountAction); ok {
+			*boxed.Amount *= 2
+		}
+		return err
+	}
+}
+
+func TestImmutableActionMiddlewareCatchesMutation(t *testing.T) {
+	store := NewStore(Reduce, State{}, ImmutableActionMiddleware, doubleAfter)
+	amount := 3
+
+	defer func() {
+		r := recover()
+		msg, _ := r.(string)
+		if !strings.Contains(msg, "mutated during dispatch") || !strings.Contains(msg, `{"Amount":3}`) {
+			t.Errorf("panic = %v, want the mutation reported with the original encoding", r)
+		}
+	}()
+	store.Dispatch(boxedAmountAction{Amount: &amount})
+	t.Error("mutating the action did not panic")
+}
+
+func TestImmutableActionMiddlewareAllowsValues(t *testing.T) {
+	store := NewStore(Reduce, State{}, ImmutableActionMiddleware, doubleAfter)
+	amount := 3
+	store.Dispatch(IncrementAction{})
+	store.Dispatch(AddAmountAction{Amount: 4})
+	if got := store.GetState().Count; got != 5 {
+		t.Errorf("Count = %d, want 5", got)
+	}
+
+	// A pointer-carrying action nobody writes through passes as well
+	store = NewStore(Reduce, State{}, ImmutableActionMiddleware)
+	store.Dispatch(boxedAmountAction{Amount: &amount})
+	if got := store.GetState().Count; got != 3 {
+		t.Errorf("Count = %d, want 3", got)
+	}
+}
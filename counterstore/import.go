@@ -0,0 +1,50 @@
+package counterstore
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ImportCountsAction replaces State.Counts, the set of values imported for
+// data-driven demos, with Values
+type ImportCountsAction struct {
+	Values []int
+}
+
+func (a ImportCountsAction) Apply(s State) State {
+	state := s.Copy()
+	state.Counts = append([]int(nil), a.Values...)
+	return state
+}
+
+// ParseCountsCSV reads counts from CSV, every field of every row in order.
+// Rows may have any number of fields; blank lines are skipped. A field that
+// is not an integer fails with its line number.
+func ParseCountsCSV(r io.Reader) ([]int, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	var counts []int
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return counts, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse counts: %w", err)
+		}
+		for i, field := range record {
+			count, err := strconv.Atoi(strings.TrimSpace(field))
+			if err != nil {
+				line, _ := cr.FieldPos(i)
+				return nil, fmt.Errorf("parse counts: line %d: %q is not a count", line, field)
+			}
+			counts = append(counts, count)
+		}
+	}
+}
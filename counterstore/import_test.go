@@ -0,0 +1,64 @@
+package counterstore
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseCountsCSV(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []int
+	}{
+		{"one per line", "1\n2\n3\n", []int{1, 2, 3}},
+		{"rows of any width", "1,2,3\n4\n-5, 6\n", []int{1, 2, 3, 4, -5, 6}},
+		{"blank lines", "7\n\n8\n\n", []int{7, 8}},
+		{"padded fields", " 9 ,\" 10 \"\n", []int{9, 10}},
+		{"empty input", "", nil},
+	}
+	for _, tt := range tests {
+		got, err := ParseCountsCSV(strings.NewReader(tt.input))
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: ParseCountsCSV = %v, %v; want %v", tt.name, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseCountsCSVMalformed(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"word", "1,2\n3,four\n", `line 2: "four"`},
+		{"empty field", "1\n2\n\n3,,4\n", "line 4:"},
+		{"fraction", "1.5\n", "line 1:"},
+		{"unclosed quote", "1\n\"2\n", "parse counts:"},
+	}
+	for _, tt := range tests {
+		got, err := ParseCountsCSV(strings.NewReader(tt.input))
+		if err == nil || got != nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: ParseCountsCSV = %v, %v; want an error mentioning %s", tt.name, got, err, tt.want)
+		}
+	}
+}
+
+func TestImportCountsAction(t *testing.T) {
+	values := []int{4, 5, 6}
+	store := NewStore(Reduce, State{Count: 9, Counts: []int{1, 2}})
+	store.Dispatch(ImportCountsAction{Values: values})
+
+	got := store.GetState()
+	if !reflect.DeepEqual(got.Counts, []int{4, 5, 6}) || got.Count != 9 {
+		t.Errorf("after import: Counts %v, Count %d; want [4 5 6] and 9 untouched", got.Counts, got.Count)
+	}
+	values[0] = 99
+	if store.GetState().Counts[0] != 4 {
+		t.Error("the imported values share the action's slice")
+	}
+	if !store.Undo() || !reflect.DeepEqual(store.GetState().Counts, []int{1, 2}) {
+		t.Errorf("Counts after undo = %v, want [1 2]", store.GetState().Counts)
+	}
+}
@@ -0,0 +1,46 @@
+package counterstore
+
+import "fmt"
+
+// InvariantViolation is the error a dispatch returns when the reducer
+// produced a state the store's invariant rejects. The store keeps its
+// previous state; State is the rejected one.
+type InvariantViolation[S any] struct {
+	State S
+	Err   error
+}
+
+func (e *InvariantViolation[S]) Error() string {
+	return fmt.Sprintf("invariant violated: %v", e.Err)
+}
+
+func (e *InvariantViolation[S]) Unwrap() error {
+	return e.Err
+}
+
+// WithInvariant has the store check every state a reducer produces with
+// invariant. A state it rejects is discarded, as if the action had failed,
+// and onViolation (which may be nil) is called with the action and the
+// rejected state, after the store's lock has been released.
+func WithInvariant[S StateProvider[S], A Action[S]](invariant func(S) error, onViolation func(action A, rejected S, err error)) StoreOption[S, A] {
+	return func(s *Store[S, A]) {
+		s.invariant = invariant
+		s.onInvariantViolation = onViolation
+	}
+}
+
+// ReplayUntilViolation folds actions over initial with reducer, checking
+// invariant after each one, and stops at the first action whose result the
+// invariant rejects. It returns that action's index and an
+// *InvariantViolation holding the rejected state, or -1 and nil when every
+// state passes. The initial state itself is not checked.
+func ReplayUntilViolation[S any, A Action[S]](reducer Reducer[S, A], initial S, actions []A, invariant func(S) error) (int, error) {
+	state := initial
+	for i, action := range actions {
+		state = reducer(state, action)
+		if err := invariant(state); err != nil {
+			return i, &InvariantViolation[S]{State: state, Err: err}
+		}
+	}
+	return -1, nil
+}
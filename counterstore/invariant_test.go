@@ -0,0 +1,107 @@
+package counterstore
+
+import (
+	"errors"
+	"testing"
+)
+
+var errBelowMin = errors.New("count below minimum")
+
+// minZero rejects states with a negative count
+func minZero(s State) error {
+	if s.Count < 0 {
+		return errBelowMin
+	}
+	return nil
+}
+
+func TestInvariantRevertsBadReducer(t *testing.T) {
+	// buggy subtracts ten on every reset instead of zeroing
+	buggy := func(state State, action AppAction) State {
+		if _, ok := action.(ResetAction); ok {
+			state.Count -= 10
+			return state
+		}
+		return Reduce(state, action)
+	}
+	type violation struct {
+		action   AppAction
+		rejected int
+		err      error
+	}
+	var violations []violation
+	store := NewStoreWithOptions(buggy, State{Count: 3},
+		WithInvariant[State, AppAction](minZero, func(action AppAction, rejected State, err error) {
+			violations = append(violations, violation{action, rejected.Count, err})
+		}),
+	)
+	notified := 0
+	store.Subscribe(func() { notified++ })
+
+	err := store.Dispatch(ResetAction{})
+	var iv *InvariantViolation[State]
+	if !errors.As(err, &iv) || !errors.Is(err, errBelowMin) || iv.State.Count != -7 {
+		t.Fatalf("Dispatch error = %v, want an InvariantViolation holding Count -7", err)
+	}
+	if got := store.GetState().Count; got != 3 {
+		t.Errorf("Count = %d, want the previous state kept", got)
+	}
+	if len(violations) != 1 || violations[0] != (violation{ResetAction{}, -7, errBelowMin}) {
+		t.Errorf("onViolation calls = %+v", violations)
+	}
+	if notified != 0 || store.CanUndo() {
+		t.Errorf("rejected state notified %d times, undo %v", notified, store.CanUndo())
+	}
+
+	if err := store.Dispatch(IncrementAction{}); err != nil || store.GetState().Count != 4 {
+		t.Errorf("valid dispatch after a violation: Count %d, err %v", store.GetState().Count, err)
+	}
+}
+
+func TestReplayUntilViolation(t *testing.T) {
+	actions := []AppAction{IncrementAction{}, DecrementAction{}, DecrementAction{}, IncrementAction{}}
+	i, err := ReplayUntilViolation(Reduce, State{}, actions, minZero)
+	var iv *InvariantViolation[State]
+	if i != 2 || !errors.As(err, &iv) || iv.State.Count != -1 {
+		t.Errorf("ReplayUntilViolation = %d, %v; want 2 with Count -1", i, err)
+	}
+
+	if i, err := ReplayUntilViolation(Reduce, State{}, actions[:2], minZero); i != -1 || err != nil {
+		t.Errorf("clean replay = %d, %v; want -1, nil", i, err)
+	}
+}
+
+func TestReplayUntilViolationLogs(t *testing.T) {
+	// atMostTen rejects any count above ten
+	atMostTen := func(s State) error {
+		if s.Count > 10 {
+			return errors.New("count above ten")
+		}
+		return nil
+	}
+	tests := []struct {
+		name    string
+		initial State
+		actions []AppAction
+		want    int
+	}{
+		{"empty log", State{}, nil, -1},
+		{"stays valid", State{Count: 5}, []AppAction{AddAmountAction{Amount: 5}, DecrementAction{}, SetCountAction{Value: 10}}, -1},
+		{"jump past the limit", State{}, []AppAction{IncrementAction{}, SetCountAction{Value: 9}, AddAmountAction{Amount: 4}, ResetAction{}}, 2},
+		{"first action", State{Count: 10}, []AppAction{IncrementAction{}, DecrementAction{}}, 0},
+		{"invalid initial state is not checked", State{Count: 20}, []AppAction{ResetAction{}}, -1},
+	}
+	for _, tt := range tests {
+		got, err := ReplayUntilViolation(Reduce, tt.initial, tt.actions, atMostTen)
+		if got != tt.want || (err == nil) != (tt.want == -1) {
+			t.Errorf("%s: ReplayUntilViolation = %d, %v; want %d", tt.name, got, err, tt.want)
+		}
+	}
+
+	// The error carries the invariant's own, and the state it rejected
+	_, err := ReplayUntilViolation(Reduce, State{}, []AppAction{DecrementAction{}}, minZero)
+	var iv *InvariantViolation[State]
+	if !errors.Is(err, errBelowMin) || !errors.As(err, &iv) || iv.State.Count != -1 {
+		t.Errorf("violation error = %v, want errBelowMin at Count -1", err)
+	}
+}
@@ -0,0 +1,308 @@
+package counterstore
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"sync"
+)
+
+// journalRecord is one line of a journal: either a state snapshot or an
+// encoded action to fold on top of it
+type journalRecord struct {
+	Snapshot *State          `json:"snapshot,omitempty"`
+	Action   json.RawMessage `json:"action,omitempty"`
+}
+
+// Journal persists a store incrementally: a snapshot followed by an
+// append-only tail of actions, one JSON record per line. Appending never
+// rewrites the file; Compact collapses the tail into a fresh snapshot.
+type Journal struct {
+	mu           sync.Mutex
+	path         string
+	compactEvery int
+	file         *os.File
+	latest       State
+	tail         int
+}
+
+// NewJournal creates a journal at path. When compactEvery is positive the
+// journal compacts itself after that many appended actions.
+func NewJournal(path string, compactEvery int) *Journal {
+	return &Journal{path: path, compactEvery: compactEvery}
+}
+
+// Load rebuilds the state by folding the journal's actions over its last
+// snapshot (or initial when there is none) and opens the journal for
+// appending. Each action is validated and its result checked with
+// invariant, which may be nil, as the store would; an action either rejects
+// means the journal no longer matches the reducer and is reported with its
+// line number. A torn final record, as left by a crash mid-write, is
+// truncated away; corruption anywhere else is reported with its line number.
+func (j *Journal) Load(reducer func(State, AppAction) State, initial State, invariant func(State) error) (State, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	data, err := os.ReadFile(j.path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return initial, err
+	}
+
+	state, tail := initial, 0
+	valid := 0
+	for line := 1; valid < len(data); line++ {
+		rest := data[valid:]
+		end := bytes.IndexByte(rest, '\n')
+		last := end < 0
+		if last {
+			end = len(rest)
+		}
+
+		if !last && len(bytes.TrimSpace(rest[:end])) == 0 {
+			valid += end + 1
+			continue
+		}
+
+		next, isAction, err := decodeJournalRecord(rest[:end], state, reducer, invariant)
+		var rejected *journalRejection
+		if errors.As(err, &rejected) {
+			return initial, fmt.Errorf("journal %s line %d: %w", j.path, line, rejected.err)
+		}
+		if err != nil {
+			if last || valid+end+1 >= len(data) {
+				log.Printf("journal %s: truncating torn record at line %d: %v", j.path, line, err)
+				if err := os.Truncate(j.path, int64(valid)); err != nil {
+					return initial, err
+				}
+				break
+			}
+			return initial, fmt.Errorf("journal %s line %d: %w", j.path, line, err)
+		}
+
+		state = next
+		if isAction {
+			tail++
+		} else {
+			tail = 0
+		}
+		valid += end + 1
+		if last {
+			// A complete final record missing its newline; terminate it so
+			// the next append starts on a line of its own.
+			valid = len(data)
+			if err := appendFile(j.path, []byte("\n")); err != nil {
+				return initial, err
+			}
+		}
+	}
+
+	file, err := os.OpenFile(j.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return initial, err
+	}
+	j.file = file
+	j.latest = state
+	j.tail = tail
+	return state, nil
+}
+
+// journalRejection is an action that decoded but that validation or the
+// invariant rejected on replay. Unlike a decode error it is never taken for
+// a torn record.
+type journalRejection struct {
+	err error
+}
+
+func (e *journalRejection) Error() string { return e.err.Error() }
+
+func decodeJournalRecord(line []byte, state State, reducer func(State, AppAction) State, invariant func(State) error) (State, bool, error) {
+	if len(bytes.TrimSpace(line)) == 0 {
+		return state, false, errors.New("empty record")
+	}
+	var rec journalRecord
+	if err := json.Unmarshal(line, &rec); err != nil {
+		return state, false, err
+	}
+	switch {
+	case rec.Snapshot != nil:
+		return rec.Snapshot.Copy(), false, nil
+	case len(rec.Action) > 0:
+		action, err := DecodeAction(rec.Action)
+		if err != nil {
+			return state, false, err
+		}
+		if v, ok := action.(Validator[State]); ok {
+			if err := v.Validate(state); err != nil {
+				return state, false, &journalRejection{err: err}
+			}
+		}
+		next := reducer(state, action)
+		if invariant != nil {
+			if err := invariant(next); err != nil {
+				return state, false, &journalRejection{err: &InvariantViolation[State]{State: next, Err: err}}
+			}
+		}
+		return next, true, nil
+	}
+	return state, false, errors.New("record has neither snapshot nor action")
+}
+
+// Append writes action to the tail; state is the store's state after it,
+// kept for the next compaction. An action the codec cannot encode, such as
+// a RepeatAction or TransactionAction holding other actions, is journaled as
+// a snapshot of state instead, so the journal never skips a commit.
+func (j *Journal) Append(action AppAction, state State) error {
+	rec := journalRecord{}
+	if encoded, err := EncodeAction(action); err == nil {
+		rec.Action = encoded
+	} else {
+		snapshot := state.Copy()
+		rec.Snapshot = &snapshot
+	}
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.file == nil {
+		return errors.New("journal: not loaded")
+	}
+	if _, err := j.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	j.latest = state
+	if rec.Snapshot != nil {
+		j.tail = 0
+	} else {
+		j.tail++
+	}
+
+	if j.compactEvery > 0 && j.tail >= j.compactEvery {
+		return j.compactLocked()
+	}
+	return nil
+}
+
+// Compact replaces the journal with a single snapshot of the latest state.
+// The new file is written aside and renamed into place, so a crash leaves
+// either the old journal or the new one.
+func (j *Journal) Compact() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.compactLocked()
+}
+
+// SaveCheckpoint makes state the journal's snapshot and truncates the tail,
+// so the next Load starts from state without replaying anything. Unlike
+// Compact it takes the state from the caller, which also covers changes
+// that never went through the journal, such as an Undo.
+func (j *Journal) SaveCheckpoint(state State) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.latest = state.Copy()
+	return j.compactLocked()
+}
+
+// TailLen returns the number of actions appended since the last snapshot,
+// which is how many a Load would replay
+func (j *Journal) TailLen() int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.tail
+}
+
+func (j *Journal) compactLocked() error {
+	snapshot := j.latest.Copy()
+	line, err := json.Marshal(journalRecord{Snapshot: &snapshot})
+	if err != nil {
+		return err
+	}
+
+	tmp := j.path + ".tmp"
+	if err := writeFileSync(tmp, append(line, '\n')); err != nil {
+		return err
+	}
+	if j.file != nil {
+		j.file.Close()
+		j.file = nil
+	}
+	if err := os.Rename(tmp, j.path); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(j.path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	j.file = file
+	j.tail = 0
+	return nil
+}
+
+// Close releases the journal file
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.file == nil {
+		return nil
+	}
+	err := j.file.Close()
+	j.file = nil
+	return err
+}
+
+func writeFileSync(path string, data []byte) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func appendFile(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Attach journals every action store commits through its reducer, as the
+// reducer saw it: after AddTransform rewrites, with any annotation or
+// redaction, actor or origin stripped, and in commit order; an action that
+// cannot be encoded is journaled as a snapshot of the state it produced.
+// Dropped and rejected actions are never journaled, so a Load replays
+// exactly what was applied. Commits that
+// bypass the reducer, such as an Undo, are not journaled; SaveCheckpoint
+// covers those. The write happens under the store's lock, which is what
+// keeps the journal in commit order across goroutines. Write errors go to
+// onError, or the standard logger when it is nil; onError must not call
+// back into the store.
+func (j *Journal) Attach(store *Store[State, AppAction], onError func(error)) {
+	if onError == nil {
+		onError = func(err error) { log.Printf("journal: %v", err) }
+	}
+	store.AddApplyHook(func(action AppAction, prev, next State) {
+		if err := j.Append(plainAction(action), next); err != nil {
+			onError(err)
+		}
+	})
+}
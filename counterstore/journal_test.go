@@ -0,0 +1,360 @@
+package counterstore
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// openJournal loads the journal at path and attaches it to a new store
+// holding the loaded state
+func openJournal(t *testing.T, path string, compactEvery int) (*Journal, *Store[State, AppAction]) {
+	t.Helper()
+	j := NewJournal(path, compactEvery)
+	state, err := j.Load(Reduce, State{}, nil)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	store := NewStore(Reduce, state)
+	j.Attach(store, func(err error) { t.Errorf("journal: %v", err) })
+	t.Cleanup(func() { j.Close() })
+	return j, store
+}
+
+func journalLines(t *testing.T, path string) []string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+}
+
+func TestJournalAppend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal")
+	j, store := openJournal(t, path, 0)
+
+	store.Dispatch(IncrementAction{})
+	store.Dispatch(AddAmountAction{Amount: 4})
+	store.Dispatch(DecrementAction{})
+
+	lines := journalLines(t, path)
+	if len(lines) != 3 {
+		t.Fatalf("journal has %d lines, want 3:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+	if want := `{"action":{"type":"AddAmount","payload":{"Amount":4}}}`; lines[1] != want {
+		t.Errorf("line 2 = %s, want %s", lines[1], want)
+	}
+	if got := j.TailLen(); got != 3 {
+		t.Errorf("TailLen = %d, want 3", got)
+	}
+}
+
+func TestJournalReplayLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal")
+	j, store := openJournal(t, path, 0)
+	store.Dispatch(SetTargetAction{Target: 20})
+	store.Dispatch(SetCountAction{Value: 7})
+	store.Dispatch(IncrementAction{})
+	j.Close()
+
+	loaded, err := NewJournal(path, 0).Load(Reduce, State{}, nil)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	want := store.GetState()
+	if loaded.Count != want.Count || loaded.Target != want.Target || loaded.Status != want.Status {
+		t.Errorf("loaded %+v, want %+v", loaded, want)
+	}
+}
+
+func TestJournalCompact(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal")
+	j, store := openJournal(t, path, 0)
+	for i := 0; i < 5; i++ {
+		store.Dispatch(IncrementAction{})
+	}
+
+	if err := j.Compact(); err != nil {
+		t.Fatalf("Compact: %v", err)
+	}
+	lines := journalLines(t, path)
+	if len(lines) != 1 || !strings.HasPrefix(lines[0], `{"snapshot":`) {
+		t.Fatalf("after Compact the journal is %q, want one snapshot", lines)
+	}
+	if got := j.TailLen(); got != 0 {
+		t.Errorf("TailLen after Compact = %d, want 0", got)
+	}
+
+	store.Dispatch(IncrementAction{})
+	j.Close()
+	if lines := journalLines(t, path); len(lines) != 2 {
+		t.Errorf("after one more action the journal has %d lines, want snapshot + 1", len(lines))
+	}
+	loaded, err := NewJournal(path, 0).Load(Reduce, State{}, nil)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if loaded.Count != 6 {
+		t.Errorf("loaded Count = %d, want 6", loaded.Count)
+	}
+}
+
+func TestJournalCompactEvery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal")
+	j, store := openJournal(t, path, 3)
+	for i := 0; i < 4; i++ {
+		store.Dispatch(IncrementAction{})
+	}
+	if got := j.TailLen(); got != 1 {
+		t.Errorf("TailLen = %d, want 1 after compacting at 3", got)
+	}
+	if lines := journalLines(t, path); len(lines) != 2 {
+		t.Errorf("journal has %d lines, want snapshot + 1", len(lines))
+	}
+}
+
+func TestJournalTornRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal")
+	j, store := openJournal(t, path, 0)
+	store.Dispatch(IncrementAction{})
+	store.Dispatch(IncrementAction{})
+	j.Close()
+
+	intact, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	torn := append(append([]byte(nil), intact...), `{"action":{"type":"Incr`...)
+	if err := os.WriteFile(path, torn, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	j, store = openJournal(t, path, 0)
+	if got := store.GetState().Count; got != 2 {
+		t.Errorf("Count after loading a torn journal = %d, want 2", got)
+	}
+	if data, _ := os.ReadFile(path); string(data) != string(intact) {
+		t.Errorf("torn record was not truncated:\n%s", data)
+	}
+
+	// Appending after the truncation continues on a line of its own
+	store.Dispatch(IncrementAction{})
+	j.Close()
+	loaded, err := NewJournal(path, 0).Load(Reduce, State{}, nil)
+	if err != nil || loaded.Count != 3 {
+		t.Errorf("reload = %d, %v; want 3, nil", loaded.Count, err)
+	}
+}
+
+func TestJournalCorruptMiddleRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal")
+	data := `{"action":{"type":"Increment"}}` + "\n" + `{garbage` + "\n" + `{"action":{"type":"Increment"}}` + "\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := NewJournal(path, 0).Load(Reduce, State{}, nil)
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Load error = %v, want one naming line 2", err)
+	}
+}
+
+func TestJournalRecordsTransformedActions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal")
+	j, store := openJournal(t, path, 0)
+	store.AddTransform(func(action AppAction, state State) AppAction {
+		switch action.(type) {
+		case IncrementAction:
+			return AddAmountAction{Amount: 10}
+		case ResetAction:
+			return nil
+		}
+		return action
+	})
+
+	store.Dispatch(IncrementAction{})
+	store.Dispatch(ResetAction{})
+	store.Dispatch(Annotated{AppAction: DecrementAction{}, Meta: map[string]string{"source": "test"}})
+	j.Close()
+
+	lines := journalLines(t, path)
+	want := []string{
+		`{"action":{"type":"AddAmount","payload":{"Amount":10}}}`,
+		`{"action":{"type":"Decrement","payload":{}}}`,
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("journal =\n%s\nwant\n%s", strings.Join(lines, "\n"), strings.Join(want, "\n"))
+	}
+
+	loaded, err := NewJournal(path, 0).Load(Reduce, State{}, nil)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if live := store.GetState().Count; loaded.Count != live {
+		t.Errorf("loaded Count = %d, live Count = %d", loaded.Count, live)
+	}
+}
+
+func TestJournalSkipsRejectedActions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal")
+	j := NewJournal(path, 0)
+	if _, err := j.Load(Reduce, State{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	store := NewStoreWithOptions(Reduce, State{Flags: map[string]bool{FlagNoNegatives: true}},
+		WithInvariant[State, AppAction](func(s State) error {
+			if s.Count > 5 {
+				return errors.New("too big")
+			}
+			return nil
+		}, nil),
+	)
+	j.Attach(store, nil)
+
+	store.Dispatch(DecrementAction{})
+	store.Dispatch(AddAmountAction{Amount: 9})
+	store.Dispatch(IncrementAction{})
+	j.Close()
+
+	if lines := journalLines(t, path); len(lines) != 1 || lines[0] != `{"action":{"type":"Increment","payload":{}}}` {
+		t.Errorf("journal = %q, want only the increment", lines)
+	}
+}
+
+func TestJournalReplayChecksInvariant(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal")
+	data := `{"action":{"type":"Increment"}}` + "\n" + `{"action":{"type":"AddAmount","payload":{"Amount":9}}}` + "\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tooBig := errors.New("too big")
+	_, err := NewJournal(path, 0).Load(Reduce, State{}, func(s State) error {
+		if s.Count > 5 {
+			return tooBig
+		}
+		return nil
+	})
+	if !errors.Is(err, tooBig) || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Load error = %v, want the invariant failure on line 2", err)
+	}
+	if data2, _ := os.ReadFile(path); string(data2) != data {
+		t.Error("a rejected record was truncated like a torn one")
+	}
+}
+
+func TestJournalReplayValidates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal")
+	data := `{"snapshot":{"Count":0,"Flags":{"no-negatives":true}}}` + "\n" + `{"action":{"type":"Decrement"}}` + "\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewJournal(path, 0).Load(Reduce, State{}, nil); !errors.Is(err, ErrNegativeCount) {
+		t.Errorf("Load error = %v, want %v", err, ErrNegativeCount)
+	}
+}
+
+func TestJournalCheckpointMatchesFullReplay(t *testing.T) {
+	dir := t.TempDir()
+	full := filepath.Join(dir, "full")
+	checkpointed := filepath.Join(dir, "checkpointed")
+	fullJ, fullStore := openJournal(t, full, 0)
+	cpJ, cpStore := openJournal(t, checkpointed, 0)
+
+	actions := []AppAction{SetTargetAction{Target: 30}, AddAmountAction{Amount: 7}, NegateAction{}, IncrementAction{}}
+	for i := 0; i < 25; i++ {
+		fullStore.Dispatch(actions[i%len(actions)])
+		cpStore.Dispatch(actions[i%len(actions)])
+		if i == 19 {
+			if err := cpJ.SaveCheckpoint(cpStore.GetState()); err != nil {
+				t.Fatalf("SaveCheckpoint: %v", err)
+			}
+		}
+	}
+	if got := fullJ.TailLen(); got != 25 {
+		t.Errorf("full journal tail = %d, want 25", got)
+	}
+	if got := cpJ.TailLen(); got != 5 {
+		t.Errorf("checkpointed tail = %d, want 5", got)
+	}
+	fullJ.Close()
+	cpJ.Close()
+
+	fromFull, err := NewJournal(full, 0).Load(Reduce, State{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromCheckpoint, err := NewJournal(checkpointed, 0).Load(Reduce, State{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fromFull.Hash() != fromCheckpoint.Hash() {
+		t.Errorf("checkpointed load %+v, full replay %+v", fromCheckpoint, fromFull)
+	}
+	if lines := journalLines(t, checkpointed); len(lines) != 6 {
+		t.Errorf("checkpointed journal has %d lines, want snapshot + 5", len(lines))
+	}
+}
+
+func TestJournalSaveCheckpointCoversUndo(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal")
+	j, store := openJournal(t, path, 0)
+	store.Dispatch(AddAmountAction{Amount: 5})
+	store.Dispatch(IncrementAction{})
+	store.Undo()
+	if err := j.SaveCheckpoint(store.GetState()); err != nil {
+		t.Fatal(err)
+	}
+	j.Close()
+
+	loaded, err := NewJournal(path, 0).Load(Reduce, State{}, nil)
+	if err != nil || loaded.Count != 5 {
+		t.Errorf("Load = %d, %v; want the undone state 5", loaded.Count, err)
+	}
+}
+
+func TestJournalReplaysEveryCommit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal")
+	history := NewScopedHistory()
+	j := NewJournal(path, 0)
+	state, err := j.Load(Reduce, State{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := NewStore(Reduce, state, history.Middleware(), TraceMiddleware(sequentialIDs()))
+	j.Attach(store, func(err error) { t.Errorf("journal: %v", err) })
+
+	store.Dispatch(RepeatAction{Inner: AddAmountAction{Amount: 2}, Times: 3})
+	store.Dispatch(TransactionAction{Steps: []AppAction{IncrementAction{}, SetTargetAction{Target: 40}}})
+	store.Dispatch(SetNoteAction{Count: 7, Text: "seven"})
+	DispatchAs(store, "alice", IncrementAction{})
+	store.Dispatch(SyncedAction{Origin: "peer", Action: SetFlagAction{Name: "beta", Enabled: true}})
+	if !history.UndoScope(NotesScope) {
+		t.Fatal("nothing to undo in the notes scope")
+	}
+	store.Dispatch(IncrementAction{})
+	j.Close()
+
+	loaded, err := NewJournal(path, 0).Load(Reduce, State{}, nil)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	want := store.GetState()
+	want.UI = UIState{}
+	if !reflect.DeepEqual(loaded, want) {
+		t.Errorf("loaded\n%+v\nwant\n%+v", loaded, want)
+	}
+
+	// Plain actions stay actions; only what cannot be encoded is a snapshot
+	lines := journalLines(t, path)
+	if len(lines) != 7 {
+		t.Fatalf("journal has %d lines, want one per commit:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+	for i, kind := range []string{"snapshot", "snapshot", "action", "action", "action", "action", "action"} {
+		if !strings.HasPrefix(lines[i], `{"`+kind+`"`) {
+			t.Errorf("line %d = %s, want a %s", i+1, lines[i], kind)
+		}
+	}
+}
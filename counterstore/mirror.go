@@ -0,0 +1,73 @@
+package counterstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// StateMirror Middleware keeps the file at path holding the current state
+// as JSON, for other processes to watch. Each write goes to a temporary file
+// that is then renamed over path, so a reader only ever sees a complete
+// state. Writes are coalesced: the first change schedules one for d later on
+// clock, and it writes whatever the state is by then, so a burst of
+// dispatches costs one write per d and the last state is always written.
+// The initial state is written the same way once the middleware is
+// installed. Write errors go to onError, or the standard logger when it is
+// nil. A nil clock means the wall clock.
+func StateMirrorMiddleware(clock Clock, path string, d time.Duration, onError func(error)) Middleware[State, AppAction] {
+	clock = orRealClock(clock)
+	if onError == nil {
+		onError = func(err error) { log.Printf("state mirror: %v", err) }
+	}
+	return func(store *Store[State, AppAction], next Dispatch[AppAction]) Dispatch[AppAction] {
+		var mu sync.Mutex
+		pending := false
+		var writeMu sync.Mutex
+
+		write := func() {
+			mu.Lock()
+			pending = false
+			mu.Unlock()
+
+			writeMu.Lock()
+			defer writeMu.Unlock()
+			if err := mirrorState(path, store.GetState()); err != nil {
+				onError(err)
+			}
+		}
+		schedule := func() {
+			mu.Lock()
+			defer mu.Unlock()
+			if !pending {
+				pending = true
+				clock.AfterFunc(d, write)
+			}
+		}
+
+		schedule()
+		return func(action AppAction) error {
+			err := next(action)
+			if err == nil {
+				schedule()
+			}
+			return err
+		}
+	}
+}
+
+// mirrorState replaces the file at path with state's JSON encoding
+func mirrorState(path string, state State) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("encode state: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := writeFileSync(tmp, append(data, '\n')); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
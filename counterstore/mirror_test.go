@@ -0,0 +1,77 @@
+package counterstore
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// readMirror decodes the state mirrored at path
+func readMirror(t *testing.T, path string) State {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read mirror: %v", err)
+	}
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		t.Fatalf("mirror holds invalid JSON %q: %v", data, err)
+	}
+	return state
+}
+
+func TestStateMirrorMiddleware(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	path := filepath.Join(t.TempDir(), "state.json")
+	onError := func(err error) { t.Errorf("mirror: %v", err) }
+	store := NewStore(Reduce, State{Count: 3},
+		StateMirrorMiddleware(clock, path, 50*time.Millisecond, onError))
+
+	// The initial state is written once the first period is over
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("mirror written before its period: %v", err)
+	}
+	clock.Advance(50 * time.Millisecond)
+	if got := readMirror(t, path).Count; got != 3 {
+		t.Fatalf("mirrored Count = %d, want the initial 3", got)
+	}
+
+	// A burst costs one write, holding the state the burst ended on
+	for i := 0; i < 20; i++ {
+		store.Dispatch(IncrementAction{})
+	}
+	store.Dispatch(SetTargetAction{Target: 40})
+	if got := clock.Pending(); got != 1 {
+		t.Errorf("%d writes scheduled for the burst, want 1", got)
+	}
+	if got := readMirror(t, path).Count; got != 3 {
+		t.Errorf("mirrored Count = %d mid-burst, want the last written 3", got)
+	}
+	clock.Advance(50 * time.Millisecond)
+	if got := readMirror(t, path); got.Count != 23 || got.Target != 40 {
+		t.Errorf("mirrored state = %+v, want Count 23 and Target 40", got)
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("temporary file left behind: %v", err)
+	}
+
+	// A rejected action schedules nothing
+	store.Dispatch(RepeatAction{Inner: IncrementAction{}, Times: -1})
+	if got := clock.Pending(); got != 0 {
+		t.Errorf("%d writes scheduled for a rejected action, want 0", got)
+	}
+}
+
+func TestStateMirrorMiddlewareReportsErrors(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	path := filepath.Join(t.TempDir(), "missing", "state.json")
+	var errs []error
+	NewStore(Reduce, State{}, StateMirrorMiddleware(clock, path, time.Millisecond, func(err error) { errs = append(errs, err) }))
+
+	clock.Advance(time.Millisecond)
+	if len(errs) != 1 {
+		t.Errorf("%d errors reported writing into a missing directory, want 1", len(errs))
+	}
+}
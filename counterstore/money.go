@@ -0,0 +1,29 @@
+package counterstore
+
+import "fmt"
+
+// AddCentsAction adds Cents to the count read as a fixed-point amount of
+// money in cents, so sums never suffer float rounding. Overflow and the
+// no-negatives flag apply as for AddAmountAction.
+type AddCentsAction struct {
+	Cents int
+}
+
+func (a AddCentsAction) Apply(s State) State {
+	return AddAmountAction{Amount: a.Cents}.Apply(s)
+}
+
+func (a AddCentsAction) Validate(s State) error {
+	return AddAmountAction{Amount: a.Cents}.Validate(s)
+}
+
+// FormatCents formats an amount in cents as dollars, such as $12.34, -$0.05
+// or $0.00
+func FormatCents(cents int) string {
+	sign := ""
+	n := uint64(cents)
+	if cents < 0 {
+		sign, n = "-", -n
+	}
+	return fmt.Sprintf("%s$%d.%02d", sign, n/100, n%100)
+}
@@ -0,0 +1,75 @@
+package counterstore
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestAddCentsAccumulates(t *testing.T) {
+	store := NewStore(Reduce, State{})
+	for _, cents := range []int{1, 99, 250, -5} {
+		store.Dispatch(AddCentsAction{Cents: cents})
+	}
+	if got := store.GetState().Count; got != 345 {
+		t.Errorf("Count = %d cents, want 345", got)
+	}
+	if got := FormatCents(store.GetState().Count); got != "$3.45" {
+		t.Errorf("formatted = %q, want $3.45", got)
+	}
+	// Each addition is its own undo step
+	store.Undo()
+	if got := store.GetState().Count; got != 350 {
+		t.Errorf("Count after undo = %d, want 350", got)
+	}
+}
+
+func TestAddCentsLimits(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 50, Flags: map[string]bool{FlagNoNegatives: true}})
+	if err := store.Dispatch(AddCentsAction{Cents: -100}); !errors.Is(err, ErrNegativeCount) {
+		t.Errorf("taking a dollar off 50¢: %v, want ErrNegativeCount", err)
+	}
+
+	tests := []struct {
+		mode OverflowMode
+		want int
+		err  error
+	}{
+		{OverflowSaturate, math.MaxInt, nil},
+		{OverflowError, math.MaxInt - 1, ErrCountOverflow},
+		{OverflowWrap, math.MinInt + 1, nil},
+	}
+	for _, tt := range tests {
+		action := AddCentsAction{Cents: 3}
+		state := State{Count: math.MaxInt - 1, Overflow: tt.mode}
+		if err := action.Validate(state); !errors.Is(err, tt.err) {
+			t.Errorf("mode %d: Validate = %v, want %v", tt.mode, err, tt.err)
+		}
+		if tt.err == nil {
+			if got := action.Apply(state).Count; got != tt.want {
+				t.Errorf("mode %d: Count = %d, want %d", tt.mode, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestFormatCents(t *testing.T) {
+	tests := []struct {
+		cents int
+		want  string
+	}{
+		{0, "$0.00"},
+		{5, "$0.05"},
+		{99, "$0.99"},
+		{100, "$1.00"},
+		{1234, "$12.34"},
+		{-5, "-$0.05"},
+		{-1234, "-$12.34"},
+		{math.MinInt, "-$92233720368547758.08"},
+	}
+	for _, tt := range tests {
+		if got := FormatCents(tt.cents); got != tt.want {
+			t.Errorf("FormatCents(%d) = %q, want %q", tt.cents, got, tt.want)
+		}
+	}
+}
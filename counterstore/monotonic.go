@@ -0,0 +1,24 @@
+package counterstore
+
+// Monotonic Middleware enforces a "score only goes up" mode: when
+// nonDecreasing is set, any action whose dry-run result would lower Count is
+// dropped and reported to onBlocked, which may be nil. Actions that leave
+// Count alone or raise it always pass.
+func MonotonicMiddleware(nonDecreasing bool, onBlocked func(action AppAction, from, to int)) Middleware[State, AppAction] {
+	return func(store *Store[State, AppAction], next Dispatch[AppAction]) Dispatch[AppAction] {
+		if !nonDecreasing {
+			return next
+		}
+		return func(action AppAction) error {
+			from := store.GetState().Count
+			if to := store.DryRun(action).Count; to < from {
+				if onBlocked != nil {
+					onBlocked(action, from, to)
+				}
+				store.ReportBlocked("count may not go down")
+				return nil
+			}
+			return next(action)
+		}
+	}
+}
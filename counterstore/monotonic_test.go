@@ -0,0 +1,44 @@
+package counterstore
+
+import "testing"
+
+func TestMonotonicMiddleware(t *testing.T) {
+	type blocked struct {
+		action   AppAction
+		from, to int
+	}
+	var got []blocked
+	store := NewStore(Reduce, State{Count: 5}, MonotonicMiddleware(true, func(action AppAction, from, to int) {
+		got = append(got, blocked{action, from, to})
+	}))
+
+	for _, action := range []AppAction{DecrementAction{}, AddAmountAction{Amount: -2}, ResetAction{}, NegateAction{}} {
+		result := store.DispatchWithResult(action)
+		if result.Applied || result.Reason != "count may not go down" {
+			t.Errorf("%v: result %+v, want blocked", action, result)
+		}
+	}
+	if count := store.GetState().Count; count != 5 {
+		t.Fatalf("Count = %d after blocked decreases, want 5", count)
+	}
+	if len(got) != 4 || got[0] != (blocked{DecrementAction{}, 5, 4}) || got[2] != (blocked{ResetAction{}, 5, 0}) {
+		t.Errorf("onBlocked calls = %v", got)
+	}
+
+	store.Dispatch(IncrementAction{})
+	store.Dispatch(SetTargetAction{Target: 3})
+	if count := store.GetState().Count; count != 6 {
+		t.Errorf("Count = %d, want increases and neutral actions to pass", count)
+	}
+}
+
+func TestMonotonicMiddlewareDisabled(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 5}, MonotonicMiddleware(false, func(AppAction, int, int) {
+		t.Error("onBlocked called with the mode disabled")
+	}))
+	store.Dispatch(DecrementAction{})
+	store.Dispatch(AddAmountAction{Amount: -10})
+	if count := store.GetState().Count; count != -6 {
+		t.Errorf("Count = %d, want -6", count)
+	}
+}
@@ -0,0 +1,36 @@
+package counterstore
+
+// SetNoteAction attaches Text as the note for Count, replacing any note it
+// had; an empty Text removes the note
+type SetNoteAction struct {
+	Count int
+	Text  string
+}
+
+func (a SetNoteAction) Apply(s State) State {
+	if _, ok := s.Notes[a.Count]; !ok && a.Text == "" {
+		return s
+	}
+	state := s.Copy()
+	if a.Text == "" {
+		delete(state.Notes, a.Count)
+		return state
+	}
+	if state.Notes == nil {
+		state.Notes = map[int]string{}
+	}
+	state.Notes[a.Count] = a.Text
+	return state
+}
+
+// copyNotes returns an independent copy of notes, keeping nil as nil
+func copyNotes(notes map[int]string) map[int]string {
+	if notes == nil {
+		return nil
+	}
+	out := make(map[int]string, len(notes))
+	for count, text := range notes {
+		out[count] = text
+	}
+	return out
+}
@@ -0,0 +1,42 @@
+package counterstore
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSetNoteAction(t *testing.T) {
+	tests := []struct {
+		name   string
+		notes  map[int]string
+		action SetNoteAction
+		want   map[int]string
+	}{
+		{"first note", nil, SetNoteAction{Count: 3, Text: "three"}, map[int]string{3: "three"}},
+		{"another count", map[int]string{3: "three"}, SetNoteAction{Count: -1, Text: "minus"}, map[int]string{3: "three", -1: "minus"}},
+		{"overwrite", map[int]string{3: "three"}, SetNoteAction{Count: 3, Text: "again"}, map[int]string{3: "again"}},
+		{"clear", map[int]string{3: "three", 4: "four"}, SetNoteAction{Count: 3}, map[int]string{4: "four"}},
+		{"clear the last", map[int]string{3: "three"}, SetNoteAction{Count: 3}, map[int]string{}},
+		{"clear a missing note", nil, SetNoteAction{Count: 3}, nil},
+	}
+	for _, tt := range tests {
+		prev := State{Notes: copyNotes(tt.notes)}
+		got := tt.action.Apply(prev).Notes
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Notes = %v, want %v", tt.name, got, tt.want)
+		}
+		if !reflect.DeepEqual(prev.Notes, tt.notes) {
+			t.Errorf("%s: the previous state's notes changed to %v", tt.name, prev.Notes)
+		}
+	}
+}
+
+func TestSetNoteUndo(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 2})
+	store.Dispatch(SetNoteAction{Count: 2, Text: "two"})
+	store.Dispatch(SetNoteAction{Count: 2, Text: "deux"})
+	store.Undo()
+	if got := store.GetState().Notes[2]; got != "two" {
+		t.Errorf("note after undo = %q, want two", got)
+	}
+}
@@ -0,0 +1,50 @@
+package counterstore
+
+import "sync"
+
+// Value is one selected piece of a store's state that widgets can bind to
+// on its own, created by Observe
+type Value[T comparable] struct {
+	mu        sync.Mutex
+	value     T
+	listeners []func(T)
+}
+
+// Observe selects a value from store's state with sel and keeps it current
+// through a single subscription. Listeners added with OnChange fire only
+// when the selected value actually changes, not on every notification. The
+// returned function unsubscribes.
+func Observe[S StateProvider[S], A Action[S], T comparable](store *Store[S, A], sel func(S) T) (*Value[T], func()) {
+	v := &Value[T]{value: sel(store.GetState())}
+	unsubscribe := store.Subscribe(func() {
+		next := sel(store.GetState())
+
+		v.mu.Lock()
+		if next == v.value {
+			v.mu.Unlock()
+			return
+		}
+		v.value = next
+		listeners := v.listeners
+		v.mu.Unlock()
+
+		for _, fn := range listeners {
+			fn(next)
+		}
+	})
+	return v, unsubscribe
+}
+
+// Get returns the selected value as of the last notification
+func (v *Value[T]) Get() T {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.value
+}
+
+// OnChange calls fn with the new value each time the selected value changes
+func (v *Value[T]) OnChange(fn func(T)) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.listeners = append(v.listeners[:len(v.listeners):len(v.listeners)], fn)
+}
@@ -0,0 +1,40 @@
+package counterstore
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestObserveFiresOnlyOnChange(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 2})
+	count, unsubscribe := Observe(store, func(s State) int { return s.Count })
+	if got := count.Get(); got != 2 {
+		t.Fatalf("initial Get = %d, want 2", got)
+	}
+
+	var seen []int
+	count.OnChange(func(n int) { seen = append(seen, n) })
+	var second int
+	count.OnChange(func(int) { second++ })
+
+	store.Dispatch(IncrementAction{})
+	store.Dispatch(SetTargetAction{Target: 9}) // count unchanged
+	store.Dispatch(SetCountAction{Value: 3})   // count unchanged
+	store.Dispatch(AddAmountAction{Amount: -5})
+
+	if want := []int{3, -2}; !reflect.DeepEqual(seen, want) {
+		t.Errorf("OnChange saw %v, want %v", seen, want)
+	}
+	if second != 2 {
+		t.Errorf("second listener fired %d times, want 2", second)
+	}
+	if got := count.Get(); got != -2 {
+		t.Errorf("Get = %d, want -2", got)
+	}
+
+	unsubscribe()
+	store.Dispatch(IncrementAction{})
+	if len(seen) != 2 || count.Get() != -2 {
+		t.Errorf("the value still followed the store after unsubscribing: %v, %d", seen, count.Get())
+	}
+}
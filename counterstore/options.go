@@ -0,0 +1,91 @@
+package counterstore
+
+// NotifyMode controls when subscribers hear about state changes
+type NotifyMode int
+
+const (
+	// NotifyImmediate notifies subscribers synchronously after every action
+	NotifyImmediate NotifyMode = iota
+	// NotifyCoalesced notifies once at the end of a batch, or once per burst
+	// of dispatches outside a batch
+	NotifyCoalesced
+	// FrameCommitMode commits once per frame: reducers still apply every
+	// action in turn, and GetState has the result, but the commit itself
+	// waits for CommitFrame. Only then do Version and GetStateFast move, the
+	// commit hooks run and subscribers hear of it, once, with the latest
+	// state. Persistence saves once per frame.
+	FrameCommitMode
+)
+
+// StoreOption configures a store created with NewStoreWithOptions
+type StoreOption[S StateProvider[S], A Action[S]] func(*Store[S, A])
+
+// WithMiddleware appends middleware to the store's dispatch chain; the first
+// middleware given is the outermost
+func WithMiddleware[S StateProvider[S], A Action[S]](middleware ...Middleware[S, A]) StoreOption[S, A] {
+	return func(s *Store[S, A]) {
+		s.middleware = append(s.middleware, middleware...)
+	}
+}
+
+// WithNotifyMode selects immediate, coalesced or per-frame subscriber
+// notification
+func WithNotifyMode[S StateProvider[S], A Action[S]](mode NotifyMode) StoreOption[S, A] {
+	return func(s *Store[S, A]) {
+		s.notifyMode = mode
+	}
+}
+
+// WithHistory turns undo/redo history on or off. With history off, dispatch
+// skips the per-action snapshot copy and Undo/Redo always return false.
+func WithHistory[S StateProvider[S], A Action[S]](enabled bool) StoreOption[S, A] {
+	return func(s *Store[S, A]) {
+		s.historyEnabled = enabled
+	}
+}
+
+// WithHistoryLimit caps the number of undo steps kept; zero means unlimited
+func WithHistoryLimit[S StateProvider[S], A Action[S]](limit int) StoreOption[S, A] {
+	return func(s *Store[S, A]) {
+		s.historyLimit = limit
+	}
+}
+
+// WithReplicaID labels the store so synced actions can be traced to it
+func WithReplicaID[S StateProvider[S], A Action[S]](id string) StoreOption[S, A] {
+	return func(s *Store[S, A]) {
+		s.replicaID = id
+	}
+}
+
+// WithPanicLogger replaces the standard-logger output of RecoverMiddleware
+func WithPanicLogger[S StateProvider[S], A Action[S]](logger PanicLogger[A]) StoreOption[S, A] {
+	return func(s *Store[S, A]) {
+		s.panicLogger = logger
+	}
+}
+
+// WithSubscriberPanicHook routes a panic in a subscriber to hook, with the
+// subscriber's index in notification order, instead of the standard logger.
+// Either way the remaining subscribers are still notified.
+func WithSubscriberPanicHook[S StateProvider[S], A Action[S]](hook func(listenerIndex int, r any)) StoreOption[S, A] {
+	return func(s *Store[S, A]) {
+		s.onSubscriberPanic = hook
+	}
+}
+
+// WithRandSeed seeds the random source thunks and RollMiddleware draw from;
+// by default it is seeded from the wall clock
+func WithRandSeed[S StateProvider[S], A Action[S]](seed int64) StoreOption[S, A] {
+	return func(s *Store[S, A]) {
+		s.seed = seed
+	}
+}
+
+// WithClock sets the clock behind the store's own timers, such as periodic
+// checkpoints; the wall clock is used by default
+func WithClock[S StateProvider[S], A Action[S]](clock Clock) StoreOption[S, A] {
+	return func(s *Store[S, A]) {
+		s.clock = clock
+	}
+}
@@ -0,0 +1,74 @@
+package counterstore
+
+import (
+	"errors"
+	"math"
+)
+
+// Validator is implemented by actions that can refuse to apply to a state.
+// The store checks it before running the reducer; an error is returned from
+// Dispatch and leaves the state unchanged.
+type Validator[S any] interface {
+	Validate(state S) error
+}
+
+// OverflowMode selects what increments and decrements do at the ends of the
+// int range
+type OverflowMode int
+
+const (
+	// OverflowWrap wraps around, as Go integer arithmetic does
+	OverflowWrap OverflowMode = iota
+	// OverflowSaturate stays at math.MaxInt or math.MinInt
+	OverflowSaturate
+	// OverflowError rejects the action with ErrCountOverflow
+	OverflowError
+)
+
+var ErrCountOverflow = errors.New("count overflow")
+
+// addCount adds delta to count under mode. In error mode an overflowing
+// result is reported as ErrCountOverflow with count returned unchanged.
+func addCount(count, delta int, mode OverflowMode) (int, error) {
+	overflows := (delta > 0 && count > math.MaxInt-delta) || (delta < 0 && count < math.MinInt-delta)
+	if !overflows {
+		return count + delta, nil
+	}
+	switch mode {
+	case OverflowSaturate:
+		if delta > 0 {
+			return math.MaxInt, nil
+		}
+		return math.MinInt, nil
+	case OverflowError:
+		return count, ErrCountOverflow
+	}
+	return count + delta, nil
+}
+
+// negateCount negates count under mode. Only math.MinInt has no positive
+// counterpart: it wraps to itself, saturates to math.MaxInt, or is reported
+// as ErrCountOverflow.
+func negateCount(count int, mode OverflowMode) (int, error) {
+	if count != math.MinInt {
+		return -count, nil
+	}
+	switch mode {
+	case OverflowSaturate:
+		return math.MaxInt, nil
+	case OverflowError:
+		return count, ErrCountOverflow
+	}
+	return count, nil
+}
+
+// SetOverflowModeAction
+type SetOverflowModeAction struct {
+	Mode OverflowMode
+}
+
+func (a SetOverflowModeAction) Apply(s State) State {
+	state := s.Copy()
+	state.Overflow = a.Mode
+	return state
+}
@@ -0,0 +1,53 @@
+package counterstore
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestOverflowModes(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    OverflowMode
+		start   int
+		action  AppAction
+		want    int
+		wantErr error
+	}{
+		{"saturate increment", OverflowSaturate, math.MaxInt - 1, AddAmountAction{Amount: 5}, math.MaxInt, nil},
+		{"saturate decrement", OverflowSaturate, math.MinInt, DecrementAction{}, math.MinInt, nil},
+		{"saturate negate", OverflowSaturate, math.MinInt, NegateAction{}, math.MaxInt, nil},
+		{"wrap increment", OverflowWrap, math.MaxInt, IncrementAction{}, math.MinInt, nil},
+		{"wrap decrement", OverflowWrap, math.MinInt, DecrementAction{}, math.MaxInt, nil},
+		{"wrap negate", OverflowWrap, math.MinInt, NegateAction{}, math.MinInt, nil},
+		{"error increment", OverflowError, math.MaxInt, IncrementAction{}, math.MaxInt, ErrCountOverflow},
+		{"error add", OverflowError, math.MinInt + 2, AddAmountAction{Amount: -3}, math.MinInt + 2, ErrCountOverflow},
+		{"error negate", OverflowError, math.MinInt, NegateAction{}, math.MinInt, ErrCountOverflow},
+		{"error in range", OverflowError, math.MaxInt - 1, IncrementAction{}, math.MaxInt, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewStore(Reduce, State{Count: tt.start, Overflow: tt.mode})
+			err := store.Dispatch(tt.action)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Dispatch error = %v, want %v", err, tt.wantErr)
+			}
+			if got := store.GetState().Count; got != tt.want {
+				t.Errorf("Count = %d, want %d", got, tt.want)
+			}
+			if tt.wantErr != nil && store.CanUndo() {
+				t.Error("a rejected overflow became an undo step")
+			}
+		})
+	}
+}
+
+func TestSetOverflowModeAction(t *testing.T) {
+	store := NewStore(Reduce, State{Count: math.MaxInt})
+	store.Dispatch(SetOverflowModeAction{Mode: OverflowSaturate})
+	store.Dispatch(IncrementAction{})
+	if got := store.GetState().Count; got != math.MaxInt {
+		t.Errorf("Count = %d, want saturated at MaxInt", got)
+	}
+}
@@ -0,0 +1,34 @@
+package counterstore
+
+// Palette selects the colours the UI uses where colour carries meaning
+type Palette int
+
+const (
+	// PaletteDefault is the blue to red palette
+	PaletteDefault Palette = iota
+	// PaletteColorBlind avoids red/green pairs, using blue and orange
+	PaletteColorBlind
+)
+
+func (p Palette) String() string {
+	switch p {
+	case PaletteDefault:
+		return "default"
+	case PaletteColorBlind:
+		return "color-blind"
+	}
+	return "unknown"
+}
+
+// SetPaletteAction switches the palette every colour-deriving selector uses
+type SetPaletteAction struct {
+	Palette Palette
+}
+
+func (a SetPaletteAction) Undoable() bool { return false }
+
+func (a SetPaletteAction) Apply(s State) State {
+	state := s.Copy()
+	state.Palette = a.Palette
+	return state
+}
@@ -0,0 +1,279 @@
+package counterstore
+
+import (
+	"reflect"
+	"sync"
+)
+
+// StatePatch holds only the fields that changed between two states; a nil
+// field was left alone. Flags are patched key by key: Flags holds the keys
+// set or changed and RemovedFlags those deleted. Toasts, bookmarks, notes,
+// imported counts, categories, dwell and rolls are replaced whole.
+type StatePatch struct {
+	Count            *int            `json:"count,omitempty"`
+	Target           *int            `json:"target,omitempty"`
+	PresentationMode *bool           `json:"presentationMode,omitempty"`
+	AlwaysOnTop      *bool           `json:"alwaysOnTop,omitempty"`
+	ShowHelp         *bool           `json:"showHelp,omitempty"`
+	Flags            map[string]bool `json:"flags,omitempty"`
+	RemovedFlags     []string        `json:"removedFlags,omitempty"`
+	Overflow         *OverflowMode   `json:"overflow,omitempty"`
+	Toasts           *[]Toast        `json:"toasts,omitempty"`
+	Status           *string         `json:"status,omitempty"`
+	ConfirmPending   *bool           `json:"confirmPending,omitempty"`
+	Countdown        *bool           `json:"countdown,omitempty"`
+	Palette          *Palette        `json:"palette,omitempty"`
+	ScoreA           *int            `json:"scoreA,omitempty"`
+	ScoreB           *int            `json:"scoreB,omitempty"`
+	Turn             *int            `json:"turn,omitempty"`
+	Bookmarks        *map[string]int `json:"bookmarks,omitempty"`
+	Idle             *bool           `json:"idle,omitempty"`
+	Notes            *map[int]string `json:"notes,omitempty"`
+	Stopwatch        *bool           `json:"stopwatch,omitempty"`
+	Counts           *[]int          `json:"counts,omitempty"`
+	Minimal          *bool           `json:"minimal,omitempty"`
+	Categories       *map[string]int `json:"categories,omitempty"`
+	PresetIndex      *int            `json:"presetIndex,omitempty"`
+	StepSize         *int            `json:"stepSize,omitempty"`
+	Dwell            *map[int]int    `json:"dwell,omitempty"`
+	Rolls            *[]int          `json:"rolls,omitempty"`
+}
+
+// Empty reports whether the patch changes nothing
+func (p StatePatch) Empty() bool {
+	return reflect.DeepEqual(p, StatePatch{})
+}
+
+// ComputePatch returns the patch that turns prev into next
+func ComputePatch(prev, next State) StatePatch {
+	var p StatePatch
+	if prev.Count != next.Count {
+		p.Count = &next.Count
+	}
+	if prev.Target != next.Target {
+		p.Target = &next.Target
+	}
+	if prev.PresentationMode != next.PresentationMode {
+		p.PresentationMode = &next.PresentationMode
+	}
+	if prev.AlwaysOnTop != next.AlwaysOnTop {
+		p.AlwaysOnTop = &next.AlwaysOnTop
+	}
+	if prev.UI.ShowHelp != next.UI.ShowHelp {
+		p.ShowHelp = &next.UI.ShowHelp
+	}
+	for name, enabled := range next.Flags {
+		if was, ok := prev.Flags[name]; !ok || was != enabled {
+			if p.Flags == nil {
+				p.Flags = map[string]bool{}
+			}
+			p.Flags[name] = enabled
+		}
+	}
+	for name := range prev.Flags {
+		if _, ok := next.Flags[name]; !ok {
+			p.RemovedFlags = append(p.RemovedFlags, name)
+		}
+	}
+	if prev.Overflow != next.Overflow {
+		p.Overflow = &next.Overflow
+	}
+	if !reflect.DeepEqual(prev.Toasts, next.Toasts) {
+		toasts := append([]Toast{}, next.Toasts...)
+		p.Toasts = &toasts
+	}
+	if prev.Status != next.Status {
+		p.Status = &next.Status
+	}
+	if prev.UI.ConfirmPending != next.UI.ConfirmPending {
+		p.ConfirmPending = &next.UI.ConfirmPending
+	}
+	if prev.Countdown != next.Countdown {
+		p.Countdown = &next.Countdown
+	}
+	if prev.Palette != next.Palette {
+		p.Palette = &next.Palette
+	}
+	if prev.ScoreA != next.ScoreA {
+		p.ScoreA = &next.ScoreA
+	}
+	if prev.ScoreB != next.ScoreB {
+		p.ScoreB = &next.ScoreB
+	}
+	if prev.Turn != next.Turn {
+		p.Turn = &next.Turn
+	}
+	if !reflect.DeepEqual(prev.Bookmarks, next.Bookmarks) {
+		bookmarks := orEmpty(copyBookmarks(next.Bookmarks))
+		p.Bookmarks = &bookmarks
+	}
+	if prev.UI.Idle != next.UI.Idle {
+		p.Idle = &next.UI.Idle
+	}
+	if !reflect.DeepEqual(prev.Notes, next.Notes) {
+		notes := orEmpty(copyNotes(next.Notes))
+		p.Notes = &notes
+	}
+	if prev.UI.Stopwatch != next.UI.Stopwatch {
+		p.Stopwatch = &next.UI.Stopwatch
+	}
+	if !reflect.DeepEqual(prev.Counts, next.Counts) {
+		counts := append([]int{}, next.Counts...)
+		p.Counts = &counts
+	}
+	if prev.Minimal != next.Minimal {
+		p.Minimal = &next.Minimal
+	}
+	if !reflect.DeepEqual(prev.Categories, next.Categories) {
+		categories := orEmpty(copyCategories(next.Categories))
+		p.Categories = &categories
+	}
+	if prev.PresetIndex != next.PresetIndex {
+		p.PresetIndex = &next.PresetIndex
+	}
+	if prev.StepSize != next.StepSize {
+		p.StepSize = &next.StepSize
+	}
+	if !reflect.DeepEqual(prev.UI.Dwell, next.UI.Dwell) {
+		dwell := orEmpty(copyDwell(next.UI.Dwell))
+		p.Dwell = &dwell
+	}
+	if !reflect.DeepEqual(prev.Rolls, next.Rolls) {
+		rolls := append([]int{}, next.Rolls...)
+		p.Rolls = &rolls
+	}
+	return p
+}
+
+// orEmpty returns m, or an empty map when it is nil. A field a patch
+// replaces with nothing carries an empty value rather than nil, which JSON
+// would encode as null and decode as the field being left alone.
+func orEmpty[K comparable, V any](m map[K]V) map[K]V {
+	if m == nil {
+		return map[K]V{}
+	}
+	return m
+}
+
+// ApplyTo returns s with the patch applied
+func (p StatePatch) ApplyTo(s State) State {
+	state := s.Copy()
+	if p.Count != nil {
+		state.Count = *p.Count
+	}
+	if p.Target != nil {
+		state.Target = *p.Target
+	}
+	if p.PresentationMode != nil {
+		state.PresentationMode = *p.PresentationMode
+	}
+	if p.AlwaysOnTop != nil {
+		state.AlwaysOnTop = *p.AlwaysOnTop
+	}
+	if p.ShowHelp != nil {
+		state.UI.ShowHelp = *p.ShowHelp
+	}
+	if len(p.Flags) > 0 && state.Flags == nil {
+		state.Flags = map[string]bool{}
+	}
+	for name, enabled := range p.Flags {
+		state.Flags[name] = enabled
+	}
+	for _, name := range p.RemovedFlags {
+		delete(state.Flags, name)
+	}
+	if p.Overflow != nil {
+		state.Overflow = *p.Overflow
+	}
+	if p.Toasts != nil {
+		state.Toasts = append([]Toast(nil), (*p.Toasts)...)
+	}
+	if p.Status != nil {
+		state.Status = *p.Status
+	}
+	if p.ConfirmPending != nil {
+		state.UI.ConfirmPending = *p.ConfirmPending
+	}
+	if p.Countdown != nil {
+		state.Countdown = *p.Countdown
+	}
+	if p.Palette != nil {
+		state.Palette = *p.Palette
+	}
+	if p.ScoreA != nil {
+		state.ScoreA = *p.ScoreA
+	}
+	if p.ScoreB != nil {
+		state.ScoreB = *p.ScoreB
+	}
+	if p.Turn != nil {
+		state.Turn = *p.Turn
+	}
+	if p.Bookmarks != nil {
+		state.Bookmarks = copyBookmarks(*p.Bookmarks)
+	}
+	if p.Idle != nil {
+		state.UI.Idle = *p.Idle
+	}
+	if p.Notes != nil {
+		state.Notes = copyNotes(*p.Notes)
+	}
+	if p.Stopwatch != nil {
+		state.UI.Stopwatch = *p.Stopwatch
+	}
+	if p.Counts != nil {
+		state.Counts = append([]int(nil), (*p.Counts)...)
+	}
+	if p.Minimal != nil {
+		state.Minimal = *p.Minimal
+	}
+	if p.Categories != nil {
+		state.Categories = copyCategories(*p.Categories)
+	}
+	if p.PresetIndex != nil {
+		state.PresetIndex = *p.PresetIndex
+	}
+	if p.StepSize != nil {
+		state.StepSize = *p.StepSize
+	}
+	if p.Dwell != nil {
+		state.UI.Dwell = copyDwell(*p.Dwell)
+	}
+	if p.Rolls != nil {
+		state.Rolls = append([]int(nil), (*p.Rolls)...)
+	}
+	return state
+}
+
+// SubscribePatch calls fn with the patch from the previous to the new state
+// after each notification, for syncing a replica with only what changed
+// through ApplyPatchAction. A notification that changed nothing delivers
+// nothing; in coalesced mode one patch spans all the dispatches folded into
+// that notification. It returns the unsubscribe function.
+func SubscribePatch(store *Store[State, AppAction], fn func(StatePatch)) func() {
+	var mu sync.Mutex
+	prev := store.GetState()
+
+	return store.Subscribe(func() {
+		next := store.GetState()
+
+		mu.Lock()
+		patch := ComputePatch(prev, next)
+		prev = next
+		mu.Unlock()
+
+		if !patch.Empty() {
+			fn(patch)
+		}
+	})
+}
+
+// ApplyPatchAction applies a StatePatch to the current state, for syncing
+// replicas by sending what changed instead of whole states
+type ApplyPatchAction struct {
+	Patch StatePatch
+}
+
+func (a ApplyPatchAction) Apply(s State) State {
+	return a.Patch.ApplyTo(s)
+}
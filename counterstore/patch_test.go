@@ -0,0 +1,151 @@
+package counterstore
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestComputePatchRoundTrip(t *testing.T) {
+	prev := State{
+		Count:     3,
+		Target:    10,
+		Flags:     map[string]bool{"a": true, "b": true},
+		Bookmarks: map[string]int{"start": 0},
+		Status:    "below",
+		Turn:      1,
+	}
+	next := prev.Copy()
+	next.Count = -4
+	next.Flags = map[string]bool{"a": false, "c": true}
+	next.Toasts = []Toast{{Text: "hi"}}
+	next.Bookmarks = map[string]int{"start": 0, "peak": 9}
+	next.Palette = PaletteColorBlind
+	next.Counts = []int{1, 2}
+
+	patch := ComputePatch(prev, next)
+	if patch.Target != nil || patch.Status != nil || patch.Turn != nil {
+		t.Errorf("patch carries unchanged fields: %+v", patch)
+	}
+	if patch.Count == nil || *patch.Count != -4 {
+		t.Errorf("patch Count = %v, want -4", patch.Count)
+	}
+	if want := map[string]bool{"a": false, "c": true}; !reflect.DeepEqual(patch.Flags, want) {
+		t.Errorf("patch Flags = %v, want %v", patch.Flags, want)
+	}
+	if !reflect.DeepEqual(patch.RemovedFlags, []string{"b"}) {
+		t.Errorf("patch RemovedFlags = %v, want [b]", patch.RemovedFlags)
+	}
+
+	if got := patch.ApplyTo(prev); !reflect.DeepEqual(got, next) {
+		t.Errorf("patch applied to prev =\n%+v\nwant\n%+v", got, next)
+	}
+
+	// A patch survives the wire
+	data, err := json.Marshal(patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded StatePatch
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if got := decoded.ApplyTo(prev); !reflect.DeepEqual(got, next) {
+		t.Errorf("decoded patch applied to prev = %+v, want %+v", got, next)
+	}
+}
+
+func TestApplyPatchActionLeavesAbsentFields(t *testing.T) {
+	count := 42
+	store := NewStore(Reduce, State{Count: 1, Target: 7, Status: "below", Flags: map[string]bool{"x": true}})
+	store.Dispatch(ApplyPatchAction{Patch: StatePatch{Count: &count}})
+
+	got := store.GetState()
+	if got.Count != 42 {
+		t.Errorf("Count = %d, want 42", got.Count)
+	}
+	if got.Target != 7 || !got.Flags["x"] {
+		t.Errorf("fields absent from the patch changed: %+v", got)
+	}
+	if !ComputePatch(got, got).Empty() {
+		t.Error("patch between equal states is not empty")
+	}
+}
+
+func TestSubscribePatchSyncsReplica(t *testing.T) {
+	source := NewStore(Reduce, State{})
+	replica := NewStore(Reduce, State{})
+	var patches int
+	SubscribePatch(source, func(p StatePatch) {
+		patches++
+		replica.Dispatch(ApplyPatchAction{Patch: p})
+	})
+
+	source.Dispatch(AddAmountAction{Amount: 5})
+	source.Dispatch(SetTargetAction{Target: 20})
+	source.Dispatch(SetFlagAction{Name: "beta", Enabled: true})
+	source.Dispatch(SetCountAction{Value: 5}) // changes nothing
+
+	if patches != 3 {
+		t.Errorf("%d patches sent, want 3", patches)
+	}
+	if got, want := replica.GetState(), source.GetState(); got.Hash() != want.Hash() {
+		t.Errorf("replica %+v, source %+v", got, want)
+	}
+}
+
+func TestSubscribePatchMinimal(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 1, Target: 50, Flags: map[string]bool{"beta": true}})
+	var patches []string
+	unsubscribe := SubscribePatch(store, func(p StatePatch) {
+		data, err := json.Marshal(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		patches = append(patches, string(data))
+	})
+
+	store.Dispatch(IncrementAction{})
+	store.Dispatch(SetTargetAction{Target: 20})
+	store.Dispatch(SetCountAction{Value: 2}) // changes nothing
+	store.Dispatch(SetFlagAction{Name: "beta", Enabled: false})
+	store.Dispatch(SetHelpAction{Visible: true})
+	// A batch notifies after each of its actions, and its undo once
+	store.DispatchBatch(AddAmountAction{Amount: 3}, SetTargetAction{Target: 9})
+	store.Undo()
+
+	want := []string{
+		`{"count":2}`,
+		`{"target":20}`,
+		`{"flags":{"beta":false}}`,
+		`{"showHelp":true}`,
+		`{"count":5}`,
+		`{"target":9}`,
+		`{"count":2,"target":20}`,
+	}
+	if !reflect.DeepEqual(patches, want) {
+		t.Errorf("patches =\n%v\nwant\n%v", patches, want)
+	}
+
+	unsubscribe()
+	store.Dispatch(IncrementAction{})
+	if len(patches) != len(want) {
+		t.Errorf("a patch was delivered after unsubscribing: %v", patches[len(want):])
+	}
+}
+
+func TestPatchClearingSurvivesJSON(t *testing.T) {
+	prev := State{Notes: map[int]string{1: "one"}, Rolls: []int{4}, Bookmarks: map[string]int{"a": 1}}
+	patch := ComputePatch(prev, State{})
+	data, err := json.Marshal(patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded StatePatch
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if got := decoded.ApplyTo(prev); len(got.Notes) != 0 || len(got.Rolls) != 0 || len(got.Bookmarks) != 0 {
+		t.Errorf("decoded patch %s left %+v, want notes, rolls and bookmarks cleared", data, got)
+	}
+}
@@ -0,0 +1,128 @@
+package counterstore
+
+import "errors"
+
+// Pause stops dispatches from reaching the middleware chain; they queue up
+// until Resume. Subscribers are notified so a view can show the queue.
+func (s *Store[S, A]) Pause() {
+	s.mu.Lock()
+	s.paused = true
+	s.dirty = true
+	s.mu.Unlock()
+	s.settle()
+}
+
+// Resume dispatches the queued actions in the order they arrived, then
+// unpauses the store, returning their errors joined. The store stays paused
+// until the queue is empty, so actions dispatched meanwhile, such as those
+// of a queued thunk or a subscriber, queue behind it rather than jumping
+// ahead. A batch queued by DispatchBatch is replayed as one batch, so it is
+// still a single undo step.
+func (s *Store[S, A]) Resume() error {
+	var errs []error
+	for {
+		s.mu.Lock()
+		if len(s.queued) == 0 {
+			s.paused = false
+			s.dirty = true
+			s.mu.Unlock()
+			break
+		}
+		group := s.queued[0]
+		s.queued = s.queued[1:]
+		s.mu.Unlock()
+
+		var err error
+		if len(group) == 1 {
+			err = s.chain()(group[0])
+		} else {
+			err = s.dispatchBatch(group)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	s.settle()
+	return errors.Join(errs...)
+}
+
+func (s *Store[S, A]) Paused() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.paused
+}
+
+// PendingActions returns a copy of the actions queued while paused, oldest
+// first
+func (s *Store[S, A]) PendingActions() []A {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.pendingLocked()
+}
+
+// pendingLocked flattens the queue into its actions, oldest first, in a new
+// slice. Callers must hold s.mu.
+func (s *Store[S, A]) pendingLocked() []A {
+	var pending []A
+	for _, group := range s.queued {
+		pending = append(pending, group...)
+	}
+	return pending
+}
+
+// enqueue queues actions if the store is paused, reporting whether it did.
+// The actions of one call are queued together and replayed together.
+func (s *Store[S, A]) enqueue(actions ...A) bool {
+	s.mu.Lock()
+	if !s.paused {
+		s.mu.Unlock()
+		return false
+	}
+	s.queued = append(s.queued, append([]A(nil), actions...))
+	s.reportBlockedLocked("queued while paused")
+	s.dirty = true
+	s.mu.Unlock()
+	s.settle()
+	return true
+}
+
+// StatePreview is what one queued action would do: the state after it, or
+// the error that would reject it, in which case State is the unchanged state
+type StatePreview[S any, A any] struct {
+	Action A
+	State  S
+	Err    error
+}
+
+// PreviewNext dry-runs the next n queued actions in order, each against the
+// result of the one before, without applying any of them. It returns fewer
+// than n previews when fewer are queued. Each action goes through the
+// store's transforms, validation, the reducer and the invariant as it would
+// when committed; an action a transform drops leaves the state as it is.
+// Middleware does not run, so a preview can differ from what Resume does
+// when middleware rewrites or drops actions.
+func (s *Store[S, A]) PreviewNext(n int) []StatePreview[S, A] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	state := s.state.Copy()
+	queued := s.pendingLocked()
+	if n < len(queued) {
+		queued = queued[:max(n, 0)]
+	}
+
+	previews := make([]StatePreview[S, A], 0, len(queued))
+	for _, action := range queued {
+		preview := StatePreview[S, A]{Action: action}
+		_, next, err := s.reduceLocked(state.Copy(), action)
+		switch {
+		case err == errTransformDropped:
+		case err != nil:
+			preview.Err = err
+		default:
+			state = next
+		}
+		preview.State = state.Copy()
+		previews = append(previews, preview)
+	}
+	return previews
+}
@@ -0,0 +1,172 @@
+package counterstore
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPauseQueuesUntilResume(t *testing.T) {
+	store := NewStore(Reduce, State{})
+	store.Pause()
+	if !store.Paused() {
+		t.Fatal("Paused false after Pause")
+	}
+
+	store.Dispatch(IncrementAction{})
+	store.DispatchBatch(AddAmountAction{Amount: 2}, DecrementAction{})
+	if got := store.GetState().Count; got != 0 {
+		t.Errorf("Count while paused = %d, want 0", got)
+	}
+	want := []AppAction{IncrementAction{}, AddAmountAction{Amount: 2}, DecrementAction{}}
+	if got := store.PendingActions(); !reflect.DeepEqual(got, want) {
+		t.Errorf("PendingActions = %v, want %v", got, want)
+	}
+
+	if err := store.Resume(); err != nil {
+		t.Fatalf("Resume: %v", err)
+	}
+	if got := store.GetState().Count; got != 2 {
+		t.Errorf("Count after Resume = %d, want 2", got)
+	}
+	if store.Paused() || len(store.PendingActions()) != 0 {
+		t.Error("store still paused or holding actions after Resume")
+	}
+}
+
+func TestResumeJoinsErrors(t *testing.T) {
+	store := NewStore(Reduce, State{Flags: map[string]bool{FlagNoNegatives: true}})
+	store.Pause()
+	store.Dispatch(DecrementAction{})
+	store.Dispatch(IncrementAction{})
+
+	if err := store.Resume(); err == nil {
+		t.Error("Resume hid the rejected decrement")
+	}
+	if got := store.GetState().Count; got != 1 {
+		t.Errorf("Count = %d, want 1", got)
+	}
+}
+
+func TestPendingActionsIsACopy(t *testing.T) {
+	store := NewStore(Reduce, State{})
+	store.Pause()
+	store.Dispatch(IncrementAction{})
+	store.Dispatch(DecrementAction{})
+
+	snapshot := store.PendingActions()
+	snapshot[0] = ResetAction{}
+	snapshot = append(snapshot[:1], NegateAction{})
+	store.Dispatch(AddAmountAction{Amount: 4})
+
+	want := []AppAction{IncrementAction{}, DecrementAction{}, AddAmountAction{Amount: 4}}
+	if got := store.PendingActions(); !reflect.DeepEqual(got, want) {
+		t.Errorf("PendingActions = %v after editing an earlier snapshot, want %v", got, want)
+	}
+	if len(snapshot) != 2 || snapshot[1] != (NegateAction{}) {
+		t.Errorf("a later enqueue changed the caller's snapshot: %v", snapshot)
+	}
+}
+
+func TestPreviewNext(t *testing.T) {
+	flags := map[string]bool{FlagNoNegatives: true}
+	store := NewStore(Reduce, State{Count: 1, Flags: flags})
+	store.Pause()
+	queued := []AppAction{AddAmountAction{Amount: 4}, NegateAction{}, DecrementAction{}, SetCountAction{Value: 2}, IncrementAction{}}
+	for _, action := range queued {
+		store.Dispatch(action)
+	}
+
+	previews := store.PreviewNext(3)
+	if len(previews) != 3 {
+		t.Fatalf("PreviewNext(3) returned %d previews", len(previews))
+	}
+	if got := store.GetState().Count; got != 1 {
+		t.Errorf("preview applied actions: Count = %d", got)
+	}
+	if got := len(store.PendingActions()); got != len(queued) {
+		t.Errorf("preview consumed the queue: %d pending", got)
+	}
+
+	// Fewer pending than asked for returns what there is
+	previews = store.PreviewNext(10)
+	if len(previews) != len(queued) {
+		t.Fatalf("PreviewNext(10) returned %d previews, want %d", len(previews), len(queued))
+	}
+	if got := store.PreviewNext(-1); len(got) != 0 {
+		t.Errorf("PreviewNext(-1) = %v", got)
+	}
+
+	// Each preview matches dispatching the same actions one by one
+	actual := NewStore(Reduce, State{Count: 1, Flags: flags})
+	for i, action := range queued {
+		err := actual.Dispatch(action)
+		preview := previews[i]
+		if preview.Action != action {
+			t.Errorf("preview %d is of %v, want %v", i, preview.Action, action)
+		}
+		if (err == nil) != (preview.Err == nil) {
+			t.Errorf("preview %d error = %v, dispatch error = %v", i, preview.Err, err)
+		}
+		if got, want := preview.State.Count, actual.GetState().Count; got != want {
+			t.Errorf("preview %d Count = %d, dispatched %d", i, got, want)
+		}
+	}
+	if previews[2].Err == nil {
+		t.Error("the decrement below zero was not previewed as rejected")
+	}
+}
+
+func TestResumeQueuesDispatchesBehind(t *testing.T) {
+	store := NewStore(Reduce, State{}, ThunkMiddleware[State, AppAction])
+	store.Pause()
+	store.Dispatch(Thunk[State, AppAction]{Run: func(dispatch Dispatch[AppAction], _ func() State) {
+		dispatch(SetCountAction{Value: 10})
+	}})
+	store.Dispatch(IncrementAction{})
+
+	// The thunk's dispatch runs after the increment queued before it
+	if err := store.Resume(); err != nil {
+		t.Fatalf("Resume: %v", err)
+	}
+	if got := store.GetState().Count; got != 10 {
+		t.Errorf("Count = %d, want 10", got)
+	}
+	if store.Paused() || len(store.PendingActions()) != 0 {
+		t.Error("store still paused or holding actions after Resume")
+	}
+}
+
+func TestPreviewNextTransforms(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 1})
+	store.AddTransform(func(action AppAction, state State) AppAction {
+		switch a := action.(type) {
+		case AddAmountAction:
+			return AddAmountAction{Amount: a.Amount * 2}
+		case ResetAction:
+			return nil
+		}
+		return action
+	})
+	store.Pause()
+	queued := []AppAction{AddAmountAction{Amount: 3}, ResetAction{}, IncrementAction{}}
+	for _, action := range queued {
+		store.Dispatch(action)
+	}
+
+	previews := store.PreviewNext(len(queued))
+	var counts []int
+	for i, preview := range previews {
+		if preview.Err != nil {
+			t.Errorf("preview %d: %v", i, preview.Err)
+		}
+		counts = append(counts, preview.State.Count)
+	}
+	// The add is doubled and the reset dropped, as when committed
+	if want := []int{7, 7, 8}; !reflect.DeepEqual(counts, want) {
+		t.Errorf("previewed counts %v, want %v", counts, want)
+	}
+	store.Resume()
+	if got := store.GetState().Count; got != counts[len(counts)-1] {
+		t.Errorf("Count after Resume = %d, last preview %d", got, counts[len(counts)-1])
+	}
+}
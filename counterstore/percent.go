@@ -0,0 +1,40 @@
+package counterstore
+
+import "math"
+
+// SetPercentAction sets Count to Percent percent of Target, rounded to the
+// nearest integer. Percent is clamped to [0, 100] unless AllowOver100 is set,
+// in which case only negative values are clamped. A zero Target yields 0.
+type SetPercentAction struct {
+	Percent      float64
+	AllowOver100 bool
+}
+
+func (a SetPercentAction) Apply(s State) State {
+	state := s.Copy()
+	state.Count = percentOf(s.Target, a.clampedPercent())
+	return state
+}
+
+func (a SetPercentAction) clampedPercent() float64 {
+	p := a.Percent
+	if math.IsNaN(p) || p < 0 {
+		return 0
+	}
+	if p > 100 && !a.AllowOver100 {
+		return 100
+	}
+	return p
+}
+
+// percentOf returns round(target * percent / 100), saturating at the int range
+func percentOf(target int, percent float64) int {
+	v := math.Round(float64(target) * percent / 100)
+	switch {
+	case v >= math.MaxInt:
+		return math.MaxInt
+	case v <= math.MinInt:
+		return math.MinInt
+	}
+	return int(v)
+}
@@ -0,0 +1,48 @@
+package counterstore
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSetPercentAction(t *testing.T) {
+	tests := []struct {
+		name   string
+		target int
+		action SetPercentAction
+		want   int
+	}{
+		{"0%", 80, SetPercentAction{Percent: 0}, 0},
+		{"50%", 80, SetPercentAction{Percent: 50}, 40},
+		{"100%", 80, SetPercentAction{Percent: 100}, 80},
+		{"150% clamped", 80, SetPercentAction{Percent: 150}, 80},
+		{"150% allowed", 80, SetPercentAction{Percent: 150, AllowOver100: true}, 120},
+		{"negative", 80, SetPercentAction{Percent: -20}, 0},
+		{"NaN", 80, SetPercentAction{Percent: math.NaN()}, 0},
+		{"rounded", 3, SetPercentAction{Percent: 50}, 2},
+		{"zero target", 0, SetPercentAction{Percent: 50}, 0},
+		{"saturated", math.MaxInt, SetPercentAction{Percent: 200, AllowOver100: true}, math.MaxInt},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := tt.action.Apply(State{Count: 7, Target: tt.target})
+			if state.Count != tt.want {
+				t.Errorf("Count = %d, want %d", state.Count, tt.want)
+			}
+			if state.Target != tt.target {
+				t.Errorf("Target changed to %d", state.Target)
+			}
+		})
+	}
+}
+
+func TestSetPercentActionGated(t *testing.T) {
+	state := State{Count: 7, Target: 80}
+	if got := Reduce(state, SetPercentAction{Percent: 50}).Count; got != 7 {
+		t.Errorf("with the flag off Count = %d, want 7", got)
+	}
+	state.Flags = map[string]bool{FlagPercent: true}
+	if got := Reduce(state, SetPercentAction{Percent: 50}).Count; got != 40 {
+		t.Errorf("with the flag on Count = %d, want 40", got)
+	}
+}
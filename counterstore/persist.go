@@ -0,0 +1,194 @@
+package counterstore
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"sync"
+)
+
+// ErrNoSavedState is returned by a Storage's Load before anything was saved
+var ErrNoSavedState = errors.New("no saved state")
+
+// Serializer turns states into bytes and back, so where a state is stored
+// and in which format are separate choices. Like JSON, every serializer
+// leaves the transient UI out.
+type Serializer interface {
+	Marshal(state State) ([]byte, error)
+	Unmarshal(data []byte) (State, error)
+}
+
+// JSONSerializer encodes states as JSON
+type JSONSerializer struct{}
+
+func (JSONSerializer) Marshal(state State) ([]byte, error) {
+	return json.Marshal(state)
+}
+
+func (JSONSerializer) Unmarshal(data []byte) (State, error) {
+	var state State
+	err := json.Unmarshal(data, &state)
+	return state, err
+}
+
+// GobSerializer encodes states with encoding/gob, which is more compact than
+// JSON but only readable from Go
+type GobSerializer struct{}
+
+func (GobSerializer) Marshal(state State) ([]byte, error) {
+	// gob does not honour json tags, so drop the UI as JSON would
+	state.UI = UIState{}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(state); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (GobSerializer) Unmarshal(data []byte) (State, error) {
+	var state State
+	err := gob.NewDecoder(bytes.NewReader(data)).Decode(&state)
+	return state, err
+}
+
+// Storage saves a state and loads it back
+type Storage interface {
+	Save(state State) error
+	Load() (State, error)
+}
+
+// FileStorage keeps the state in the file at Path in Serializer's format,
+// replacing it whole on each save so a crash never leaves it half written
+type FileStorage struct {
+	Path       string
+	Serializer Serializer
+}
+
+func (f FileStorage) Save(state State) error {
+	data, err := f.Serializer.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("save state: %w", err)
+	}
+	tmp := f.Path + ".tmp"
+	if err := writeFileSync(tmp, data); err != nil {
+		return fmt.Errorf("save state: %w", err)
+	}
+	if err := os.Rename(tmp, f.Path); err != nil {
+		return fmt.Errorf("save state: %w", err)
+	}
+	return nil
+}
+
+// Load reads the state back, returning ErrNoSavedState if the file does not
+// exist
+func (f FileStorage) Load() (State, error) {
+	data, err := os.ReadFile(f.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return State{}, ErrNoSavedState
+	}
+	if err != nil {
+		return State{}, fmt.Errorf("load state: %w", err)
+	}
+	state, err := f.Serializer.Unmarshal(data)
+	if err != nil {
+		return State{}, fmt.Errorf("load state %s: %w", f.Path, err)
+	}
+	return state, nil
+}
+
+// MemoryStorage keeps the last saved state in memory in its serializer's
+// format, for tests and for trying out a format
+type MemoryStorage struct {
+	serializer Serializer
+
+	mu   sync.Mutex
+	data []byte
+}
+
+// NewMemoryStorage creates an empty in-memory storage using serializer
+func NewMemoryStorage(serializer Serializer) *MemoryStorage {
+	return &MemoryStorage{serializer: serializer}
+}
+
+func (m *MemoryStorage) Save(state State) error {
+	data, err := m.serializer.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("save state: %w", err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.data = data
+	return nil
+}
+
+func (m *MemoryStorage) Load() (State, error) {
+	m.mu.Lock()
+	data := m.data
+	m.mu.Unlock()
+	if data == nil {
+		return State{}, ErrNoSavedState
+	}
+	state, err := m.serializer.Unmarshal(data)
+	if err != nil {
+		return State{}, fmt.Errorf("load state: %w", err)
+	}
+	return state, nil
+}
+
+// LoadOrDefault loads the state from storage, falling back to fallback when
+// nothing was saved yet
+func LoadOrDefault(storage Storage, fallback State) (State, error) {
+	state, err := storage.Load()
+	if errors.Is(err, ErrNoSavedState) {
+		return fallback, nil
+	}
+	return state, err
+}
+
+// Persist Middleware saves the state to storage after every action that
+// changed it, or in FrameCommitMode once per frame that committed a change.
+// Save errors go to onError, or the standard logger when it is nil; they
+// never fail the dispatch.
+func PersistMiddleware(storage Storage, onError func(error)) Middleware[State, AppAction] {
+	if onError == nil {
+		onError = func(err error) { log.Printf("persist: %v", err) }
+	}
+	var mu sync.Mutex
+	subscribed := map[*Store[State, AppAction]]bool{}
+	return func(store *Store[State, AppAction], next Dispatch[AppAction]) Dispatch[AppAction] {
+		if store.notifyMode == FrameCommitMode {
+			// Subscribers hear of each frame's commit once, so saving from
+			// one saves once per frame. Rebuilding the chain keeps the one
+			// subscription.
+			mu.Lock()
+			if !subscribed[store] {
+				subscribed[store] = true
+				store.Subscribe(func() {
+					if err := storage.Save(store.GetState()); err != nil {
+						onError(err)
+					}
+				})
+			}
+			mu.Unlock()
+			return next
+		}
+		return func(action AppAction) error {
+			before := store.Version()
+			if err := next(action); err != nil {
+				return err
+			}
+			if store.Version() == before {
+				return nil
+			}
+			if err := storage.Save(store.GetState()); err != nil {
+				onError(err)
+			}
+			return nil
+		}
+	}
+}
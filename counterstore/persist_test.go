@@ -0,0 +1,118 @@
+package counterstore
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// serializers are every serializer the package ships
+var serializers = map[string]Serializer{
+	"json": JSONSerializer{},
+	"gob":  GobSerializer{},
+}
+
+// fullState sets every persisted field of the state
+func fullState() State {
+	return State{
+		Count:            42,
+		Target:           50,
+		PresentationMode: true,
+		AlwaysOnTop:      true,
+		Flags:            map[string]bool{FlagNoNegatives: true},
+		Overflow:         OverflowSaturate,
+		Toasts:           []Toast{{Text: "saved", ExpiresAt: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)}},
+		Status:           "near target",
+		Countdown:        true,
+		Palette:          PaletteColorBlind,
+		ScoreA:           3,
+		ScoreB:           4,
+		Turn:             1,
+		Bookmarks:        map[string]int{"start": 0},
+		Notes:            map[int]string{42: "answer"},
+		Counts:           []int{1, 2},
+		Minimal:          true,
+		Categories:       map[string]int{"coffee": 2},
+		PresetIndex:      1,
+		StepSize:         5,
+		Rolls:            []int{6, 2},
+	}
+}
+
+func TestSerializerRoundTrip(t *testing.T) {
+	for name, serializer := range serializers {
+		t.Run(name, func(t *testing.T) {
+			state := fullState()
+			state.UI = UIState{ShowHelp: true, Stopwatch: true, Dwell: map[int]int{1: 2}}
+			data, err := serializer.Marshal(state)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := serializer.Unmarshal(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := fullState(); !reflect.DeepEqual(got, want) {
+				t.Errorf("round trip gave\n%+v\nwant\n%+v", got, want)
+			}
+			if !state.UI.ShowHelp {
+				t.Error("Marshal changed the UI of the state it was given")
+			}
+		})
+	}
+}
+
+func TestSerializerRejectsGarbage(t *testing.T) {
+	for name, serializer := range serializers {
+		if _, err := serializer.Unmarshal([]byte("not a state")); err == nil {
+			t.Errorf("%s decoded garbage without an error", name)
+		}
+	}
+}
+
+func TestPersistWithEitherSerializer(t *testing.T) {
+	for name, serializer := range serializers {
+		t.Run(name, func(t *testing.T) {
+			storages := map[string]Storage{
+				"memory": NewMemoryStorage(serializer),
+				"file":   FileStorage{Path: filepath.Join(t.TempDir(), "state"), Serializer: serializer},
+			}
+			for kind, storage := range storages {
+				if _, err := storage.Load(); !errors.Is(err, ErrNoSavedState) {
+					t.Errorf("%s: Load before a save = %v, want ErrNoSavedState", kind, err)
+				}
+				initial, err := LoadOrDefault(storage, State{Count: 10})
+				if err != nil || initial.Count != 10 {
+					t.Fatalf("%s: LoadOrDefault = %+v, %v; want the fallback", kind, initial, err)
+				}
+
+				store := NewStore(Reduce, initial, PersistMiddleware(storage, func(err error) { t.Error(err) }))
+				store.Dispatch(IncrementAction{})
+				store.Dispatch(SetTargetAction{Target: 20})
+				store.Dispatch(SetNoteAction{Count: 11, Text: "eleven"})
+
+				reopened, err := LoadOrDefault(storage, State{})
+				if err != nil {
+					t.Fatalf("%s: %v", kind, err)
+				}
+				if reopened.Count != 11 || reopened.Target != 20 || reopened.Notes[11] != "eleven" {
+					t.Errorf("%s: reloaded %+v, want Count 11, Target 20 and the note", kind, reopened)
+				}
+			}
+		})
+	}
+}
+
+func TestFileStorageCorrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state")
+	if err := os.WriteFile(path, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadOrDefault(FileStorage{Path: path, Serializer: JSONSerializer{}}, State{}); err == nil ||
+		errors.Is(err, ErrNoSavedState) {
+		t.Errorf("loading a corrupt file: %v, want a decode error", err)
+	}
+}
@@ -0,0 +1,132 @@
+package counterstore
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// PipeErrorPolicy is what PipeActionsWithPolicy does with a bad line
+type PipeErrorPolicy int
+
+const (
+	// PipeStopOnError stops at the first line that fails to parse or dispatch
+	PipeStopOnError PipeErrorPolicy = iota
+	// PipeSkipErrors reports the line and carries on with the next
+	PipeSkipErrors
+)
+
+// ParseCommand parses one line of the action command grammar:
+//
+//	inc        increment
+//	inc N      add N
+//	dec        decrement
+//	dec N      subtract N
+//	set N      set the count to N
+//	cents N    add N cents to the count read as money
+//	reset      reset the count
+//	seq OPS    run a ParseSequence op list, such as seq *2,+1,neg
+//
+// Commands are case-insensitive and N is a decimal integer.
+func ParseCommand(line string) (AppAction, error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("empty command")
+	}
+	name, args := strings.ToLower(fields[0]), fields[1:]
+
+	arg := func() (int, error) {
+		if len(args) != 1 {
+			return 0, fmt.Errorf("%s takes one number", name)
+		}
+		n, err := strconv.Atoi(args[0])
+		if err != nil {
+			return 0, fmt.Errorf("%s: %q is not a number", name, args[0])
+		}
+		return n, nil
+	}
+
+	switch name {
+	case "inc", "dec":
+		if len(args) == 0 {
+			if name == "inc" {
+				return IncrementAction{}, nil
+			}
+			return DecrementAction{}, nil
+		}
+		n, err := arg()
+		if err != nil {
+			return nil, err
+		}
+		if name == "dec" {
+			n = -n
+		}
+		return AddAmountAction{Amount: n}, nil
+	case "set":
+		n, err := arg()
+		if err != nil {
+			return nil, err
+		}
+		return SetCountAction{Value: n}, nil
+	case "cents":
+		n, err := arg()
+		if err != nil {
+			return nil, err
+		}
+		return AddCentsAction{Cents: n}, nil
+	case "reset":
+		if len(args) != 0 {
+			return nil, fmt.Errorf("reset takes no arguments")
+		}
+		return ResetAction{}, nil
+	case "seq":
+		if len(args) == 0 {
+			return nil, fmt.Errorf("seq takes a list of ops")
+		}
+		ops, err := ParseSequence(strings.Join(args, " "))
+		if err != nil {
+			return nil, err
+		}
+		return SequenceAction{Ops: ops}, nil
+	}
+	return nil, fmt.Errorf("unknown command %q", fields[0])
+}
+
+// PipeActions dispatches the commands read from r, one per line, until EOF,
+// stopping at the first bad line. Blank lines and lines starting with # are
+// skipped.
+func PipeActions(store *Store[State, AppAction], r io.Reader) error {
+	return PipeActionsWithPolicy(store, r, PipeStopOnError, nil)
+}
+
+// PipeActionsWithPolicy is PipeActions with a choice of what to do with a
+// line that fails to parse or dispatch. Under PipeSkipErrors each such error
+// goes to onSkip, or the standard logger when it is nil, and only a read
+// error stops the pipe.
+func PipeActionsWithPolicy(store *Store[State, AppAction], r io.Reader, policy PipeErrorPolicy, onSkip func(error)) error {
+	if onSkip == nil {
+		onSkip = func(err error) { log.Printf("pipe: %v", err) }
+	}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		action, err := ParseCommand(text)
+		if err == nil {
+			err = store.Dispatch(action)
+		}
+		if err != nil {
+			err = fmt.Errorf("line %d: %w", line, err)
+			if policy == PipeStopOnError {
+				return err
+			}
+			onSkip(err)
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,74 @@
+package counterstore
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		line string
+		want AppAction
+	}{
+		{"inc", IncrementAction{}},
+		{"INC 4", AddAmountAction{Amount: 4}},
+		{"dec", DecrementAction{}},
+		{"dec 3", AddAmountAction{Amount: -3}},
+		{"  set   -12 ", SetCountAction{Value: -12}},
+		{"cents 250", AddCentsAction{Cents: 250}},
+		{"reset", ResetAction{}},
+	}
+	for _, tt := range tests {
+		got, err := ParseCommand(tt.line)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseCommand(%q) = %v, %v; want %v", tt.line, got, err, tt.want)
+		}
+	}
+
+	for _, line := range []string{"", "jump", "set", "set x", "inc 1 2", "reset now", "seq"} {
+		if action, err := ParseCommand(line); err == nil {
+			t.Errorf("ParseCommand(%q) = %v, want an error", line, action)
+		}
+	}
+}
+
+func TestPipeActions(t *testing.T) {
+	store := NewStore(Reduce, State{})
+	input := "inc\ninc 9\n\n# a comment\ndec 2\nset 20\ndec\n"
+	if err := PipeActions(store, strings.NewReader(input)); err != nil {
+		t.Fatalf("PipeActions: %v", err)
+	}
+	if got := store.GetState().Count; got != 19 {
+		t.Errorf("Count = %d, want 19", got)
+	}
+}
+
+func TestPipeActionsStopsOnError(t *testing.T) {
+	store := NewStore(Reduce, State{})
+	err := PipeActions(store, strings.NewReader("inc\nbogus\ninc\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("error = %v, want one naming line 2", err)
+	}
+	if got := store.GetState().Count; got != 1 {
+		t.Errorf("Count = %d, want only the line before the error applied", got)
+	}
+}
+
+func TestPipeActionsSkipErrors(t *testing.T) {
+	store := NewStore(Reduce, State{Flags: map[string]bool{FlagNoNegatives: true}})
+	var skipped []error
+	input := "inc\nbogus\ndec 5\ninc\n"
+	if err := PipeActionsWithPolicy(store, strings.NewReader(input), PipeSkipErrors, func(err error) { skipped = append(skipped, err) }); err != nil {
+		t.Fatalf("PipeActionsWithPolicy: %v", err)
+	}
+	if got := store.GetState().Count; got != 2 {
+		t.Errorf("Count = %d, want 2", got)
+	}
+	if len(skipped) != 2 {
+		t.Fatalf("skipped %d lines, want the parse error and the rejected dispatch: %v", len(skipped), skipped)
+	}
+	if !strings.Contains(skipped[0].Error(), "line 2") || !errors.Is(skipped[1], ErrNegativeCount) {
+		t.Errorf("skipped = %v", skipped)
+	}
+}
@@ -0,0 +1,163 @@
+package counterstore
+
+import "time"
+
+// PlaybackMode is where a Playback is in its stopped/playing/paused/scrubbing
+// cycle
+type PlaybackMode int
+
+const (
+	PlaybackStopped PlaybackMode = iota
+	PlaybackPlaying
+	PlaybackPaused
+	PlaybackScrubbing
+)
+
+func (m PlaybackMode) String() string {
+	switch m {
+	case PlaybackPlaying:
+		return "playing"
+	case PlaybackPaused:
+		return "paused"
+	case PlaybackScrubbing:
+		return "scrubbing"
+	}
+	return "stopped"
+}
+
+// Playback steps through a recorded session: scrubbing to any point, or
+// playing it back at the pace it was recorded. It only tracks a position;
+// the caller shows StateAt(Position()), typically with Store.JumpTo, and
+// calls Advance as time passes, so it needs no goroutine or timer of its own.
+//
+// Position p means the first p steps have happened: 0 is the state before
+// the first step and Len() the state after the last.
+type Playback struct {
+	steps []RecordedAction
+	mode  PlaybackMode
+	pos   int
+	// due is when the step at pos happens while playing
+	due time.Time
+	// resume is the mode scrubbing returns to
+	resume PlaybackMode
+}
+
+// NewPlayback creates a stopped playback of steps at position 0
+func NewPlayback(steps []RecordedAction) *Playback {
+	return &Playback{steps: steps}
+}
+
+func (p *Playback) Mode() PlaybackMode { return p.mode }
+func (p *Playback) Position() int      { return p.pos }
+func (p *Playback) Len() int           { return len(p.steps) }
+
+// StateAt returns the state at position pos, clamped to the session
+func (p *Playback) StateAt(pos int) State {
+	if len(p.steps) == 0 {
+		return State{}
+	}
+	pos = p.clamp(pos)
+	if pos == 0 {
+		return p.steps[0].Prev.Copy()
+	}
+	return p.steps[pos-1].Next.Copy()
+}
+
+// Play starts or resumes playback at now. Playing from the end, or from a
+// stopped playback, starts over from the beginning. It does nothing while
+// scrubbing.
+func (p *Playback) Play(now time.Time) {
+	switch p.mode {
+	case PlaybackPlaying, PlaybackScrubbing:
+		return
+	case PlaybackStopped:
+		p.pos = 0
+	}
+	if p.pos >= len(p.steps) {
+		p.pos = 0
+	}
+	p.mode = PlaybackPlaying
+	p.due = now.Add(p.gap(p.pos))
+}
+
+// Pause holds playback at the current position
+func (p *Playback) Pause() {
+	if p.mode == PlaybackPlaying {
+		p.mode = PlaybackPaused
+	}
+}
+
+// Stop ends playback and rewinds to the beginning
+func (p *Playback) Stop() {
+	p.mode = PlaybackStopped
+	p.pos = 0
+}
+
+// Scrub moves to pos, entering scrubbing mode until EndScrub. Playback
+// does not advance while scrubbing.
+func (p *Playback) Scrub(pos int) {
+	if p.mode != PlaybackScrubbing {
+		p.resume = p.mode
+		p.mode = PlaybackScrubbing
+	}
+	p.pos = p.clamp(pos)
+}
+
+// EndScrub leaves scrubbing at now. A playback that was playing carries on
+// from the scrubbed position; a stopped one is paused there, so the position
+// is kept.
+func (p *Playback) EndScrub(now time.Time) {
+	if p.mode != PlaybackScrubbing {
+		return
+	}
+	if p.resume == PlaybackPlaying && p.pos < len(p.steps) {
+		p.mode = PlaybackPlaying
+		p.due = now.Add(p.gap(p.pos))
+		return
+	}
+	p.mode = PlaybackPaused
+}
+
+// Advance moves playback past every step due by now and reports whether the
+// position changed. Reaching the end pauses there.
+func (p *Playback) Advance(now time.Time) bool {
+	if p.mode != PlaybackPlaying {
+		return false
+	}
+	moved := false
+	for p.pos < len(p.steps) && !now.Before(p.due) {
+		p.pos++
+		moved = true
+		if p.pos < len(p.steps) {
+			p.due = p.due.Add(p.gap(p.pos))
+		}
+	}
+	if p.pos >= len(p.steps) {
+		p.mode = PlaybackPaused
+	}
+	return moved
+}
+
+// NextDue returns when the next step happens; ok is false unless playing
+func (p *Playback) NextDue() (due time.Time, ok bool) {
+	if p.mode != PlaybackPlaying {
+		return time.Time{}, false
+	}
+	return p.due, true
+}
+
+// gap is the recorded time between step i and the one before it; the first
+// step happens straight away
+func (p *Playback) gap(i int) time.Duration {
+	if i <= 0 || i >= len(p.steps) {
+		return 0
+	}
+	if d := p.steps[i].At.Sub(p.steps[i-1].At); d > 0 {
+		return d
+	}
+	return 0
+}
+
+func (p *Playback) clamp(pos int) int {
+	return max(0, min(pos, len(p.steps)))
+}
@@ -0,0 +1,145 @@
+package counterstore
+
+import (
+	"testing"
+	"time"
+)
+
+// playbackSteps records three increments at 0s, 1s and 3s after epoch
+func playbackSteps() []RecordedAction {
+	offsets := []time.Duration{0, time.Second, 3 * time.Second}
+	steps := make([]RecordedAction, len(offsets))
+	for i, d := range offsets {
+		steps[i] = RecordedAction{
+			Seq:    i + 1,
+			At:     epoch.Add(d),
+			Action: IncrementAction{},
+			Prev:   State{Count: i},
+			Next:   State{Count: i + 1},
+		}
+	}
+	return steps
+}
+
+func TestPlaybackPlaysAtRecordedPace(t *testing.T) {
+	p := NewPlayback(playbackSteps())
+	p.Play(epoch)
+	if p.Mode() != PlaybackPlaying || p.Position() != 0 {
+		t.Fatalf("after Play: %v at %d", p.Mode(), p.Position())
+	}
+
+	// The first step is due straight away, the second a second later
+	if !p.Advance(epoch) || p.Position() != 1 {
+		t.Fatalf("Advance at start: position %d, want 1", p.Position())
+	}
+	if p.Advance(epoch.Add(999 * time.Millisecond)) {
+		t.Errorf("advanced before the recorded gap")
+	}
+	if due, ok := p.NextDue(); !ok || !due.Equal(epoch.Add(time.Second)) {
+		t.Errorf("NextDue = %v, %v; want epoch+1s", due, ok)
+	}
+	if !p.Advance(epoch.Add(time.Second)) || p.Position() != 2 {
+		t.Fatalf("Advance at 1s: position %d, want 2", p.Position())
+	}
+
+	// Reaching the end pauses there
+	if !p.Advance(epoch.Add(time.Hour)) || p.Position() != 3 {
+		t.Fatalf("Advance at the end: position %d, want 3", p.Position())
+	}
+	if p.Mode() != PlaybackPaused {
+		t.Errorf("mode at the end = %v, want paused", p.Mode())
+	}
+	if _, ok := p.NextDue(); ok {
+		t.Error("NextDue reported a step while paused")
+	}
+	if got := p.StateAt(p.Position()).Count; got != 3 {
+		t.Errorf("StateAt the end = %d, want 3", got)
+	}
+}
+
+func TestPlaybackTransitions(t *testing.T) {
+	p := NewPlayback(playbackSteps())
+
+	p.Pause()
+	if p.Mode() != PlaybackStopped {
+		t.Errorf("Pause while stopped moved to %v", p.Mode())
+	}
+
+	p.Play(epoch)
+	p.Advance(epoch.Add(time.Second))
+	p.Pause()
+	if p.Mode() != PlaybackPaused || p.Position() != 2 {
+		t.Fatalf("after Pause: %v at %d, want paused at 2", p.Mode(), p.Position())
+	}
+	if p.Advance(epoch.Add(time.Hour)) {
+		t.Error("advanced while paused")
+	}
+
+	// Resuming from pause keeps the position
+	p.Play(epoch.Add(time.Minute))
+	if p.Mode() != PlaybackPlaying || p.Position() != 2 {
+		t.Fatalf("after resuming: %v at %d", p.Mode(), p.Position())
+	}
+
+	p.Stop()
+	if p.Mode() != PlaybackStopped || p.Position() != 0 {
+		t.Errorf("after Stop: %v at %d, want stopped at 0", p.Mode(), p.Position())
+	}
+
+	// Playing from the end starts over
+	p.Play(epoch)
+	p.Advance(epoch.Add(time.Hour))
+	p.Play(epoch.Add(time.Hour))
+	if p.Position() != 0 || p.Mode() != PlaybackPlaying {
+		t.Errorf("Play from the end: %v at %d, want playing at 0", p.Mode(), p.Position())
+	}
+}
+
+func TestPlaybackScrub(t *testing.T) {
+	p := NewPlayback(playbackSteps())
+
+	// Scrubbing a stopped playback leaves it paused at the scrubbed position
+	p.Scrub(2)
+	if p.Mode() != PlaybackScrubbing || p.Position() != 2 {
+		t.Fatalf("after Scrub: %v at %d", p.Mode(), p.Position())
+	}
+	p.Play(epoch)
+	if p.Mode() != PlaybackScrubbing {
+		t.Errorf("Play while scrubbing moved to %v", p.Mode())
+	}
+	p.Scrub(99)
+	if p.Position() != 3 {
+		t.Errorf("Scrub past the end = %d, want clamped to 3", p.Position())
+	}
+	p.Scrub(1)
+	p.EndScrub(epoch)
+	if p.Mode() != PlaybackPaused || p.Position() != 1 {
+		t.Errorf("after EndScrub from stopped: %v at %d, want paused at 1", p.Mode(), p.Position())
+	}
+
+	// Scrubbing while playing does not advance and carries on afterwards
+	p.Play(epoch)
+	p.Scrub(2)
+	if p.Advance(epoch.Add(time.Hour)) {
+		t.Error("advanced while scrubbing")
+	}
+	p.EndScrub(epoch.Add(time.Minute))
+	if p.Mode() != PlaybackPlaying || p.Position() != 2 {
+		t.Fatalf("after EndScrub from playing: %v at %d, want playing at 2", p.Mode(), p.Position())
+	}
+	if due, _ := p.NextDue(); !due.Equal(epoch.Add(time.Minute + 2*time.Second)) {
+		t.Errorf("NextDue after EndScrub = %v, want minute+2s", due)
+	}
+}
+
+func TestPlaybackStateAt(t *testing.T) {
+	p := NewPlayback(playbackSteps())
+	for _, tt := range []struct{ pos, want int }{{-5, 0}, {0, 0}, {2, 2}, {3, 3}, {9, 3}} {
+		if got := p.StateAt(tt.pos).Count; got != tt.want {
+			t.Errorf("StateAt(%d) = %d, want %d", tt.pos, got, tt.want)
+		}
+	}
+	if got := NewPlayback(nil).StateAt(1); got.Count != 0 {
+		t.Errorf("StateAt on an empty session = %+v", got)
+	}
+}
@@ -0,0 +1,79 @@
+package counterstore
+
+// Preset is a named bundle of settings applied together
+type Preset struct {
+	Name     string
+	Palette  Palette
+	StepSize int
+}
+
+// Presets are the presets CyclePresetAction steps through, in order
+var Presets = []Preset{
+	{Name: "Standard", Palette: PaletteDefault, StepSize: 1},
+	{Name: "Accessible", Palette: PaletteColorBlind, StepSize: 1},
+	{Name: "Coarse", Palette: PaletteDefault, StepSize: 10},
+}
+
+// CurrentPreset returns the preset State.PresetIndex selects
+func (s State) CurrentPreset() Preset {
+	return Presets[presetIndex(s.PresetIndex)]
+}
+
+// presetIndex wraps i into the range of Presets
+func presetIndex(i int) int {
+	i %= len(Presets)
+	if i < 0 {
+		i += len(Presets)
+	}
+	return i
+}
+
+// CyclePresetAction advances PresetIndex to the next preset, wrapping after
+// the last. It only moves the index; CyclePreset also applies the settings.
+type CyclePresetAction struct{}
+
+func (a CyclePresetAction) Undoable() bool { return false }
+
+func (a CyclePresetAction) Apply(s State) State {
+	state := s.Copy()
+	state.PresetIndex = presetIndex(s.PresetIndex + 1)
+	return state
+}
+
+// SetStepSizeAction sets how far a single increment or decrement moves the
+// count; sizes below 1 mean 1
+type SetStepSizeAction struct {
+	Size int
+}
+
+func (a SetStepSizeAction) Undoable() bool { return false }
+
+func (a SetStepSizeAction) Apply(s State) State {
+	state := s.Copy()
+	state.StepSize = a.Size
+	return state
+}
+
+// Step returns the step size, 1 when none is set
+func (s State) Step() int {
+	if s.StepSize < 1 {
+		return 1
+	}
+	return s.StepSize
+}
+
+// Actions returns the actions applying p's settings
+func (p Preset) Actions() []AppAction {
+	return []AppAction{
+		SetPaletteAction{Palette: p.Palette},
+		SetStepSizeAction{Size: p.StepSize},
+	}
+}
+
+// CyclePreset moves store to the next preset and applies its settings as one
+// DispatchBatch, so in coalesced mode subscribers only see the preset once
+// all of it is applied
+func CyclePreset(store *Store[State, AppAction]) error {
+	next := Presets[presetIndex(store.GetState().PresetIndex+1)]
+	return store.DispatchBatch(append([]AppAction{CyclePresetAction{}}, next.Actions()...)...)
+}
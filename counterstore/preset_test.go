@@ -0,0 +1,55 @@
+package counterstore
+
+import "testing"
+
+func TestCyclePresetActionWraps(t *testing.T) {
+	state := State{}
+	var names []string
+	for i := 0; i < len(Presets)+1; i++ {
+		state = CyclePresetAction{}.Apply(state)
+		names = append(names, state.CurrentPreset().Name)
+	}
+	want := []string{"Accessible", "Coarse", "Standard", "Accessible"}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("presets cycled through %v, want %v", names, want)
+		}
+	}
+	if state.PresetIndex != 1 {
+		t.Errorf("PresetIndex = %d, want 1 after wrapping", state.PresetIndex)
+	}
+
+	// An index out of range still selects a preset
+	if got := (State{PresetIndex: -1}).CurrentPreset().Name; got != "Coarse" {
+		t.Errorf("preset at index -1 = %q, want Coarse", got)
+	}
+	if got := (CyclePresetAction{}).Apply(State{PresetIndex: 7}).PresetIndex; got != 2 {
+		t.Errorf("cycling from index 7 gave %d, want 2", got)
+	}
+}
+
+func TestCyclePresetAppliesSettings(t *testing.T) {
+	store := NewStoreWithOptions(Reduce, State{Count: 4},
+		WithNotifyMode[State, AppAction](NotifyCoalesced))
+	var seen []State
+	store.Subscribe(func() { seen = append(seen, store.GetState()) })
+
+	for _, want := range []Preset{Presets[1], Presets[2], Presets[0]} {
+		seen = nil
+		if err := CyclePreset(store); err != nil {
+			t.Fatal(err)
+		}
+		got := store.GetState()
+		if got.CurrentPreset() != want || got.Palette != want.Palette || got.Step() != want.StepSize {
+			t.Errorf("after cycling: preset %q, palette %d, step %d; want %+v",
+				got.CurrentPreset().Name, got.Palette, got.Step(), want)
+		}
+		// Subscribers only see the preset once all of it is applied
+		if len(seen) != 1 || seen[0].PresetIndex != got.PresetIndex || seen[0].StepSize != want.StepSize {
+			t.Errorf("subscribers saw %+v, want one state with the whole preset", seen)
+		}
+	}
+	if store.CanUndo() {
+		t.Error("switching presets left an undo step")
+	}
+}
@@ -0,0 +1,120 @@
+package counterstore
+
+import (
+	"sync"
+	"time"
+)
+
+// ActionStats aggregates the dispatch timings of one action type. Sampled is
+// the number of dispatches actually timed; under sampling, Count and Total
+// are extrapolated from those, so they are estimates.
+type ActionStats struct {
+	Count   int
+	Total   time.Duration
+	Sampled int
+}
+
+// Average returns the mean dispatch time, or zero before any dispatch
+func (s ActionStats) Average() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Count)
+}
+
+// Profiler collects how long dispatches spend in middleware and the reducer
+type Profiler struct {
+	mu    sync.Mutex
+	clock Clock
+	stats map[string]ActionStats
+	last  time.Duration
+}
+
+// NewProfiler creates a profiler timing with clock, or the wall clock when
+// clock is nil
+func NewProfiler(clock Clock) *Profiler {
+	return &Profiler{clock: orRealClock(clock), stats: map[string]ActionStats{}}
+}
+
+// Last returns the duration of the most recent dispatch
+func (p *Profiler) Last() time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.last
+}
+
+// Stats returns a copy of the per-action statistics keyed by ActionName
+func (p *Profiler) Stats() map[string]ActionStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	stats := make(map[string]ActionStats, len(p.stats))
+	for k, v := range p.stats {
+		stats[k] = v
+	}
+	return stats
+}
+
+func (p *Profiler) observe(key string, d time.Duration) {
+	p.observeSample(key, d, 1)
+}
+
+// observeSample records d as one timed dispatch standing for weight dispatches
+func (p *Profiler) observeSample(key string, d time.Duration, weight int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	st := p.stats[key]
+	st.Count += weight
+	st.Total += d * time.Duration(weight)
+	st.Sampled++
+	p.stats[key] = st
+	p.last = d
+}
+
+// Profiler Middleware times the rest of the chain, i.e. the inner middleware
+// and the reducer. Subscribers run after the chain and are not included.
+func ProfilerMiddleware[S StateProvider[S], A Action[S]](p *Profiler) Middleware[S, A] {
+	return func(store *Store[S, A], next Dispatch[A]) Dispatch[A] {
+		return func(action A) error {
+			start := p.clock.Now()
+			err := next(action)
+			p.observe(actionKey(action), p.clock.Now().Sub(start))
+			return err
+		}
+	}
+}
+
+// SampleEvery returns a sampler reporting true for the first call and every
+// rate-th one after it. It is safe for concurrent use.
+func SampleEvery(rate int) func() bool {
+	var mu sync.Mutex
+	n := 0
+	return func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		sampled := n%rate == 0
+		n++
+		return sampled
+	}
+}
+
+// SampledProfiler Middleware is ProfilerMiddleware timing only the
+// dispatches sample picks, which should be about one in rate; each timed
+// dispatch is recorded as standing for rate dispatches. A nil sample uses
+// SampleEvery(rate). Rates below 1 are treated as 1.
+func SampledProfilerMiddleware[S StateProvider[S], A Action[S]](p *Profiler, rate int, sample func() bool) Middleware[S, A] {
+	rate = max(rate, 1)
+	if sample == nil {
+		sample = SampleEvery(rate)
+	}
+	return func(store *Store[S, A], next Dispatch[A]) Dispatch[A] {
+		return func(action A) error {
+			if !sample() {
+				return next(action)
+			}
+			start := p.clock.Now()
+			err := next(action)
+			p.observeSample(actionKey(action), p.clock.Now().Sub(start), rate)
+			return err
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package counterstore
+
+import (
+	"testing"
+	"time"
+)
+
+// advancing is middleware making every dispatch take d on clock
+func advancing(clock *FakeClock, d time.Duration) Middleware[State, AppAction] {
+	return func(store *Store[State, AppAction], next Dispatch[AppAction]) Dispatch[AppAction] {
+		return func(action AppAction) error {
+			clock.Advance(d)
+			return next(action)
+		}
+	}
+}
+
+func TestProfilerMiddleware(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	profiler := NewProfiler(clock)
+	store := NewStore(Reduce, State{}, ProfilerMiddleware[State, AppAction](profiler), advancing(clock, 3*time.Millisecond))
+
+	store.Dispatch(IncrementAction{})
+	store.Dispatch(IncrementAction{})
+	store.Dispatch(ResetAction{})
+
+	if got := profiler.Last(); got != 3*time.Millisecond {
+		t.Errorf("Last = %v, want 3ms", got)
+	}
+	stats := profiler.Stats()
+	inc := stats["Increment"]
+	if inc.Count != 2 || inc.Total != 6*time.Millisecond || inc.Average() != 3*time.Millisecond {
+		t.Errorf("increment stats = %+v", inc)
+	}
+	if reset := stats["Reset"]; reset.Count != 1 {
+		t.Errorf("reset stats = %+v", reset)
+	}
+	if (ActionStats{}).Average() != 0 {
+		t.Error("Average of no dispatches is not zero")
+	}
+}
+
+func TestSampleEvery(t *testing.T) {
+	sample := SampleEvery(3)
+	var got []bool
+	for i := 0; i < 7; i++ {
+		got = append(got, sample())
+	}
+	want := []bool{true, false, false, true, false, false, true}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("SampleEvery(3) = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestSampledProfilerMiddleware(t *testing.T) {
+	tests := []struct {
+		rate    int
+		sampled int
+		count   int
+	}{
+		{1, 9, 9},
+		{3, 3, 9},
+		{0, 9, 9}, // treated as 1
+	}
+	for _, tt := range tests {
+		clock := NewFakeClock(time.Unix(0, 0))
+		profiler := NewProfiler(clock)
+		store := NewStore(Reduce, State{},
+			SampledProfilerMiddleware[State, AppAction](profiler, tt.rate, nil), advancing(clock, 2*time.Millisecond))
+		for i := 0; i < 9; i++ {
+			store.Dispatch(IncrementAction{})
+		}
+
+		inc := profiler.Stats()["Increment"]
+		if inc.Sampled != tt.sampled {
+			t.Errorf("rate %d: %d dispatches timed, want %d", tt.rate, inc.Sampled, tt.sampled)
+		}
+		// Each sample stands for rate dispatches, so the totals extrapolate
+		if inc.Count != tt.count || inc.Total != 18*time.Millisecond || inc.Average() != 2*time.Millisecond {
+			t.Errorf("rate %d: stats %+v, want %d dispatches over 18ms", tt.rate, inc, tt.count)
+		}
+	}
+}
+
+func TestSampledProfilerMiddlewareInjectedSampler(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	profiler := NewProfiler(clock)
+	pattern := []bool{false, true, false, false}
+	n := 0
+	sample := func() bool {
+		s := pattern[n%len(pattern)]
+		n++
+		return s
+	}
+	store := NewStore(Reduce, State{},
+		SampledProfilerMiddleware[State, AppAction](profiler, 4, sample), advancing(clock, time.Millisecond))
+	for i := 0; i < 8; i++ {
+		store.Dispatch(DecrementAction{})
+	}
+	dec := profiler.Stats()["Decrement"]
+	if dec.Sampled != 2 || dec.Count != 8 || dec.Total != 8*time.Millisecond {
+		t.Errorf("stats = %+v, want 2 samples standing for 8 dispatches", dec)
+	}
+	if got := store.GetState().Count; got != -8 {
+		t.Errorf("Count = %d, want every dispatch applied", got)
+	}
+}
@@ -0,0 +1,108 @@
+package counterstore
+
+import (
+	"sync"
+	"time"
+)
+
+// SystemActor is the actor recorded for actions dispatched without one
+const SystemActor = "system"
+
+// AttributedAction is an action dispatched on behalf of Actor
+type AttributedAction struct {
+	Actor  string
+	Action AppAction
+}
+
+func (a AttributedAction) Apply(s State) State {
+	return a.Action.Apply(s)
+}
+
+func (a AttributedAction) Validate(s State) error {
+	if v, ok := a.Action.(Validator[State]); ok {
+		return v.Validate(s)
+	}
+	return nil
+}
+
+func (a AttributedAction) Undoable() bool {
+	return isUndoable(a.Action)
+}
+
+// DispatchAs dispatches action on behalf of actor, for ProvenanceMiddleware
+// to record
+func DispatchAs(store *Store[State, AppAction], actor string, action AppAction) error {
+	return store.Dispatch(AttributedAction{Actor: actor, Action: action})
+}
+
+// AuditEntry records who caused a state transition
+type AuditEntry struct {
+	Actor     string
+	Action    AppAction
+	TraceID   string
+	At        time.Time
+	PrevCount int
+	NewCount  int
+}
+
+// AuditSink receives audit entries; implementations must only ever append
+type AuditSink interface {
+	Append(entry AuditEntry)
+}
+
+// MemoryAuditSink is an in-memory AuditSink
+type MemoryAuditSink struct {
+	mu      sync.Mutex
+	entries []AuditEntry
+}
+
+func (m *MemoryAuditSink) Append(entry AuditEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries = append(m.entries, entry)
+}
+
+// Entries returns a copy of the recorded entries in order
+func (m *MemoryAuditSink) Entries() []AuditEntry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]AuditEntry(nil), m.entries...)
+}
+
+// Provenance Middleware records an audit entry in sink for every applied
+// action, timestamped by clock (the wall clock when nil), with the trace ID
+// TraceMiddleware gave it, if any. It unwraps AttributedActions, also inside
+// an Annotated, so middleware after it sees the plain action; others are
+// attributed to SystemActor. Put it outermost, but for TraceMiddleware, so
+// every entry point is covered.
+func ProvenanceMiddleware(sink AuditSink, clock Clock) Middleware[State, AppAction] {
+	clock = orRealClock(clock)
+	return func(store *Store[State, AppAction], next Dispatch[AppAction]) Dispatch[AppAction] {
+		return func(action AppAction) error {
+			actor := SystemActor
+			if attributed, ok := action.(AttributedAction); ok {
+				actor, action = attributed.Actor, attributed.Action
+			} else if annotated, ok := action.(Annotated); ok {
+				if attributed, ok := annotated.AppAction.(AttributedAction); ok {
+					actor = attributed.Actor
+					action = Annotated{AppAction: attributed.Action, Meta: annotated.Meta}
+				}
+			}
+
+			at := clock.Now()
+			prevCount := store.GetState().Count
+			if err := next(action); err != nil {
+				return err
+			}
+			sink.Append(AuditEntry{
+				Actor:     actor,
+				Action:    Shown(action),
+				TraceID:   ActionTraceID(action),
+				At:        at,
+				PrevCount: prevCount,
+				NewCount:  store.GetState().Count,
+			})
+			return nil
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package counterstore
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestProvenanceMiddleware(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	var sink MemoryAuditSink
+	var forwarded []AppAction
+	spy := func(store *Store[State, AppAction], next Dispatch[AppAction]) Dispatch[AppAction] {
+		return func(action AppAction) error {
+			forwarded = append(forwarded, action)
+			return next(action)
+		}
+	}
+	store := NewStore(Reduce, State{Flags: map[string]bool{FlagNoNegatives: true}}, ProvenanceMiddleware(&sink, clock), spy)
+
+	DispatchAs(store, "alice", AddAmountAction{Amount: 5})
+	clock.Advance(time.Second)
+	store.Dispatch(IncrementAction{})
+	clock.Advance(time.Second)
+	store.Dispatch(Annotated{AppAction: AttributedAction{Actor: "bob", Action: DecrementAction{}}, Meta: map[string]string{"source": "kbd"}})
+	if err := DispatchAs(store, "mallory", AddAmountAction{Amount: -50}); err == nil {
+		t.Fatal("rejected action succeeded")
+	}
+
+	want := []AuditEntry{
+		{Actor: "alice", Action: AddAmountAction{Amount: 5}, At: epoch, PrevCount: 0, NewCount: 5},
+		{Actor: SystemActor, Action: IncrementAction{}, At: epoch.Add(time.Second), PrevCount: 5, NewCount: 6},
+		{Actor: "bob", Action: Annotated{AppAction: DecrementAction{}, Meta: map[string]string{"source": "kbd"}}, At: epoch.Add(2 * time.Second), PrevCount: 6, NewCount: 5},
+	}
+	if got := sink.Entries(); !reflect.DeepEqual(got, want) {
+		t.Errorf("audit entries:\n%+v\nwant\n%+v", got, want)
+	}
+
+	// Later middleware sees the action without its attribution
+	for _, action := range forwarded {
+		if _, ok := plainAction(action).(AttributedAction); ok {
+			t.Errorf("middleware after provenance saw %v", action)
+		}
+	}
+}
+
+func TestAttributedActionWithoutMiddleware(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 1, Flags: map[string]bool{FlagNoNegatives: true}})
+	DispatchAs(store, "alice", IncrementAction{})
+	if got := store.GetState().Count; got != 2 {
+		t.Errorf("Count = %d, want the inner action applied", got)
+	}
+	if err := DispatchAs(store, "alice", AddAmountAction{Amount: -5}); err == nil {
+		t.Error("the inner action's validation was skipped")
+	}
+	if (AttributedAction{Action: ToggleMinimalAction{}}).Undoable() {
+		t.Error("attribution made a non-undoable action undoable")
+	}
+}
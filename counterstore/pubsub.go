@@ -0,0 +1,82 @@
+package counterstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+)
+
+// Publisher sends a message on a broker topic, as a NATS or Redis client
+// would
+type Publisher interface {
+	Publish(topic string, data []byte) error
+}
+
+// Subscriber delivers the messages published on a broker topic until the
+// returned channel is closed
+type Subscriber interface {
+	Subscribe(topic string) (<-chan []byte, error)
+}
+
+// pubSubMessage is the wire form of a SyncedAction
+type pubSubMessage struct {
+	Origin string          `json:"origin"`
+	Action json.RawMessage `json:"action"`
+}
+
+// PubSub Middleware fans locally dispatched actions out over pub on topic,
+// tagged with the store's replica ID. It is SyncMiddleware with a broker as
+// the transport, so stores sharing a topic need distinct replica IDs.
+func PubSubMiddleware(pub Publisher, topic string) Middleware[State, AppAction] {
+	return SyncMiddleware(func(synced SyncedAction) {
+		data, err := encodePubSub(synced)
+		if err == nil {
+			err = pub.Publish(topic, data)
+		}
+		if err != nil {
+			log.Printf("pubsub %s: %v", topic, err)
+		}
+	})
+}
+
+// SubscribeDispatch dispatches every action arriving on topic into store
+// until the subscription ends. Messages the store published itself are
+// skipped, and undecodable ones are logged and dropped.
+func SubscribeDispatch(store *Store[State, AppAction], sub Subscriber, topic string) error {
+	messages, err := sub.Subscribe(topic)
+	if err != nil {
+		return fmt.Errorf("pubsub %s: %w", topic, err)
+	}
+	for data := range messages {
+		synced, err := decodePubSub(data)
+		if err != nil {
+			log.Printf("pubsub %s: %v", topic, err)
+			continue
+		}
+		if synced.Origin == store.ReplicaID() {
+			continue
+		}
+		store.Dispatch(synced)
+	}
+	return nil
+}
+
+func encodePubSub(synced SyncedAction) ([]byte, error) {
+	action, err := EncodeAction(synced.Action)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(pubSubMessage{Origin: synced.Origin, Action: action})
+}
+
+func decodePubSub(data []byte) (SyncedAction, error) {
+	var msg pubSubMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return SyncedAction{}, fmt.Errorf("decode message: %w", err)
+	}
+	action, err := DecodeAction(msg.Action)
+	if err != nil {
+		return SyncedAction{}, err
+	}
+	return SyncedAction{Origin: msg.Origin, Action: action}, nil
+}
@@ -0,0 +1,124 @@
+package counterstore
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+// memBroker is an in-memory Publisher and Subscriber. Publish hands each
+// message to every subscriber of the topic, including the publisher's own
+// subscription, as a real broker would.
+type memBroker struct {
+	mu     sync.Mutex
+	subs   map[string][]chan []byte
+	closed bool
+}
+
+func (b *memBroker) Publish(topic string, data []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return errors.New("broker closed")
+	}
+	for _, ch := range b.subs[topic] {
+		ch <- data
+	}
+	return nil
+}
+
+func (b *memBroker) Subscribe(topic string) (<-chan []byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.subs == nil {
+		b.subs = map[string][]chan []byte{}
+	}
+	ch := make(chan []byte, 64)
+	b.subs[topic] = append(b.subs[topic], ch)
+	return ch, nil
+}
+
+// Close ends every subscription once the messages already published have
+// been read
+func (b *memBroker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for _, chans := range b.subs {
+		for _, ch := range chans {
+			close(ch)
+		}
+	}
+}
+
+// failingSubscriber refuses every subscription
+type failingSubscriber struct{}
+
+func (failingSubscriber) Subscribe(string) (<-chan []byte, error) {
+	return nil, errors.New("no broker")
+}
+
+func TestPubSubFanOut(t *testing.T) {
+	broker := &memBroker{}
+	alice := NewStoreWithID("alice", Reduce, State{}, PubSubMiddleware(broker, "counter"))
+	bob := NewStoreWithID("bob", Reduce, State{}, PubSubMiddleware(broker, "counter"))
+	carol := NewStoreWithID("carol", Reduce, State{}, PubSubMiddleware(broker, "other"))
+
+	var wg sync.WaitGroup
+	for _, store := range []*Store[State, AppAction]{alice, bob, carol} {
+		topic := "counter"
+		if store == carol {
+			topic = "other"
+		}
+		messages, _ := broker.Subscribe(topic)
+		wg.Add(1)
+		go func(store *Store[State, AppAction]) {
+			defer wg.Done()
+			SubscribeDispatch(store, channelSubscriber(messages), topic)
+		}(store)
+	}
+
+	alice.Dispatch(IncrementAction{})
+	alice.Dispatch(AddAmountAction{Amount: 4})
+	broker.Close()
+	wg.Wait()
+
+	// alice skips her own echoes, bob applies both, carol is on another topic
+	if got := alice.GetState().Count; got != 5 {
+		t.Errorf("alice Count = %d, want 5", got)
+	}
+	if got := bob.GetState().Count; got != 5 {
+		t.Errorf("bob Count = %d, want 5", got)
+	}
+	if got := carol.GetState().Count; got != 0 {
+		t.Errorf("carol Count = %d, want 0", got)
+	}
+}
+
+func TestSubscribeDispatchDropsUndecodable(t *testing.T) {
+	store := NewStoreWithID("bob", Reduce, State{})
+	messages := make(chan []byte, 3)
+	messages <- []byte(`{not json`)
+	messages <- []byte(`{"origin":"alice","action":{"type":"Nope"}}`)
+	messages <- []byte(`{"origin":"alice","action":{"type":"Increment"}}`)
+	close(messages)
+
+	if err := SubscribeDispatch(store, channelSubscriber(messages), "counter"); err != nil {
+		t.Fatalf("SubscribeDispatch: %v", err)
+	}
+	if got := store.GetState().Count; got != 1 {
+		t.Errorf("Count = %d, want only the valid message applied", got)
+	}
+}
+
+func TestSubscribeDispatchSubscribeError(t *testing.T) {
+	store := NewStore(Reduce, State{})
+	if err := SubscribeDispatch(store, failingSubscriber{}, "counter"); err == nil {
+		t.Error("SubscribeDispatch with a failing subscriber returned nil")
+	}
+}
+
+// channelSubscriber serves an already open subscription
+type channelSubscriber <-chan []byte
+
+func (c channelSubscriber) Subscribe(string) (<-chan []byte, error) { return c, nil }
@@ -0,0 +1,26 @@
+package counterstore
+
+import "time"
+
+// NetRate returns the net count change per second over the window ending at
+// now. Time in the window before the first record counts as idle, so early
+// on and for windows longer than the recorded data the rate is averaged over
+// the whole window rather than inflated.
+func NetRate(records []RecordedAction, now time.Time, window time.Duration) float64 {
+	if window <= 0 {
+		return 0
+	}
+	since := now.Add(-window)
+	delta := 0
+	for i := len(records) - 1; i >= 0; i-- {
+		rec := records[i]
+		if rec.At.Before(since) {
+			break
+		}
+		if rec.At.After(now) {
+			continue
+		}
+		delta += rec.Next.Count - rec.Prev.Count
+	}
+	return float64(delta) / window.Seconds()
+}
@@ -0,0 +1,57 @@
+package counterstore
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNetRate(t *testing.T) {
+	start := time.Unix(1000, 0)
+	at := func(seconds float64, prev, next int) RecordedAction {
+		return RecordedAction{
+			At:   start.Add(time.Duration(seconds * float64(time.Second))),
+			Prev: State{Count: prev},
+			Next: State{Count: next},
+		}
+	}
+	records := []RecordedAction{
+		at(0, 0, 1),
+		at(1, 1, 2),
+		at(2, 2, 3),
+		at(3, 3, 2),
+		at(4, 2, 12),
+	}
+	now := start.Add(5 * time.Second)
+
+	tests := []struct {
+		name    string
+		records []RecordedAction
+		window  time.Duration
+		want    float64
+	}{
+		{"empty history", nil, 5 * time.Second, 0},
+		{"whole history", records, 5 * time.Second, 12.0 / 5},
+		{"last two seconds", records, 2 * time.Second, 9.0 / 2},
+		{"window longer than the data", records, 10 * time.Second, 12.0 / 10},
+		{"quiet window", records, 500 * time.Millisecond, 0},
+		{"no window", records, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NetRate(tt.records, now, tt.window); got != tt.want {
+				t.Errorf("NetRate = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNetRateIgnoresFutureRecords(t *testing.T) {
+	now := time.Unix(1000, 0)
+	records := []RecordedAction{
+		{At: now.Add(-time.Second), Prev: State{Count: 0}, Next: State{Count: 4}},
+		{At: now.Add(time.Second), Prev: State{Count: 4}, Next: State{Count: 100}},
+	}
+	if got := NetRate(records, now, 2*time.Second); got != 2 {
+		t.Errorf("NetRate = %v, want 2", got)
+	}
+}
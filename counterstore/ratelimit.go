@@ -0,0 +1,74 @@
+package counterstore
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// tokenBucket admits work while it has tokens, refilling at rate per second
+// up to burst
+type tokenBucket struct {
+	mu     sync.Mutex
+	clock  Clock
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(clock Clock, rate float64, burst int) *tokenBucket {
+	clock = orRealClock(clock)
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{clock: clock, rate: rate, burst: float64(burst), tokens: float64(burst), last: clock.Now()}
+}
+
+// take spends cost tokens if available and reports whether it did
+func (b *tokenBucket) take(cost float64) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.clock.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+
+	if b.tokens < cost {
+		return false
+	}
+	b.tokens -= cost
+	return true
+}
+
+// RateLimit Middleware drops actions beyond perSecond, allowing bursts of up
+// to burst actions. A nil clock means the wall clock.
+func RateLimitMiddleware[S StateProvider[S], A Action[S]](clock Clock, perSecond float64, burst int) Middleware[S, A] {
+	return CostRateLimitMiddleware[S, A](clock, perSecond, burst, nil)
+}
+
+// CostRateLimit Middleware is RateLimitMiddleware with actions weighed by
+// cost: each spends cost(action) units from a budget of burst units that
+// refills at perSecond units a second, so heavy actions use it up sooner
+// than cheap ones. A nil cost charges every action 1, and costs below 0
+// count as 0. An action costing more than burst is always dropped.
+func CostRateLimitMiddleware[S StateProvider[S], A Action[S]](clock Clock, perSecond float64, burst int, cost func(A) int) Middleware[S, A] {
+	bucket := newTokenBucket(clock, perSecond, burst)
+	return func(store *Store[S, A], next Dispatch[A]) Dispatch[A] {
+		return func(action A) error {
+			units := 1
+			if cost != nil {
+				units = max(cost(action), 0)
+			}
+			if !bucket.take(float64(units)) {
+				log.Printf("Action dropped by rate limit: %T", action)
+				store.ReportBlocked("rate limited")
+				return nil
+			}
+			return next(action)
+		}
+	}
+}
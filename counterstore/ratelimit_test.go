@@ -0,0 +1,91 @@
+package counterstore
+
+import (
+	"testing"
+	"time"
+)
+
+// repeatCost charges a repeat five budget units and any other action one
+func repeatCost(action AppAction) int {
+	if _, ok := action.(RepeatAction); ok {
+		return 5
+	}
+	return 1
+}
+
+// admitted dispatches action n times and counts the dispatches that
+// committed
+func admitted(store *Store[State, AppAction], action AppAction, n int) int {
+	count := 0
+	for i := 0; i < n; i++ {
+		if store.DispatchWithResult(action).Applied {
+			count++
+		}
+	}
+	return count
+}
+
+func TestCostRateLimitMiddleware(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	cheap := NewStore(Reduce, State{},
+		CostRateLimitMiddleware[State, AppAction](clock, 10, 10, repeatCost))
+	heavy := NewStore(Reduce, State{Count: 1},
+		CostRateLimitMiddleware[State, AppAction](clock, 10, 10, repeatCost))
+
+	// A budget of ten units admits ten increments but only two repeats
+	if got := admitted(cheap, IncrementAction{}, 12); got != 10 {
+		t.Errorf("%d of 12 increments admitted, want 10", got)
+	}
+	if got := admitted(heavy, RepeatAction{Inner: IncrementAction{}, Times: 5}, 4); got != 2 {
+		t.Errorf("%d of 4 repeats admitted, want 2", got)
+	}
+	result := heavy.DispatchWithResult(RepeatAction{Inner: IncrementAction{}, Times: 5})
+	if result.Applied || result.Reason != "rate limited" {
+		t.Errorf("result = %+v, want blocked as rate limited", result)
+	}
+
+	// Half a second refills five units: one repeat, or five increments
+	clock.Advance(500 * time.Millisecond)
+	if got := admitted(heavy, RepeatAction{Inner: IncrementAction{}, Times: 5}, 2); got != 1 {
+		t.Errorf("%d repeats admitted after the refill, want 1", got)
+	}
+	if got := admitted(cheap, IncrementAction{}, 6); got != 5 {
+		t.Errorf("%d increments admitted after the refill, want 5", got)
+	}
+}
+
+func TestCostRateLimitMiddlewareLimits(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	cost := func(action AppAction) int {
+		switch action.(type) {
+		case RepeatAction:
+			return 20
+		case SetTargetAction:
+			return -3
+		}
+		return 1
+	}
+	store := NewStore(Reduce, State{Count: 1},
+		CostRateLimitMiddleware[State, AppAction](clock, 10, 10, cost))
+
+	// An action costing more than the whole budget never passes
+	clock.Advance(time.Hour)
+	if got := admitted(store, RepeatAction{Inner: IncrementAction{}, Times: 5}, 1); got != 0 {
+		t.Error("an action costing more than burst was admitted")
+	}
+	// A negative cost is free, and refills nothing
+	if got := admitted(store, SetTargetAction{Target: 4}, 3); got != 3 {
+		t.Errorf("%d of 3 free actions admitted, want 3", got)
+	}
+	if got := admitted(store, IncrementAction{}, 11); got != 10 {
+		t.Errorf("%d of 11 increments admitted, want 10", got)
+	}
+}
+
+func TestRateLimitMiddlewareChargesOne(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	store := NewStore(Reduce, State{Count: 1}, RateLimitMiddleware[State, AppAction](clock, 1, 3))
+	if got := admitted(store, RepeatAction{Inner: IncrementAction{}, Times: 5}, 5); got != 3 {
+		t.Errorf("%d of 5 repeats admitted without costs, want 3", got)
+	}
+}
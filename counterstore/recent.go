@@ -0,0 +1,55 @@
+package counterstore
+
+import "sync"
+
+// appendRecent appends value to recent, evicting the oldest entries so at
+// most limit remain. It never modifies the slice it is given.
+func appendRecent(recent []int, value, limit int) []int {
+	if limit <= 0 {
+		return nil
+	}
+	next := make([]int, 0, min(len(recent)+1, limit))
+	if drop := len(recent) + 1 - limit; drop > 0 {
+		recent = recent[drop:]
+	}
+	next = append(next, recent...)
+	return append(next, value)
+}
+
+// RecentCounts is auxiliary state holding the last few distinct counts
+type RecentCounts struct {
+	mu     sync.Mutex
+	limit  int
+	values []int
+}
+
+func NewRecentCounts(limit int) *RecentCounts {
+	return &RecentCounts{limit: limit}
+}
+
+// Values returns the recorded counts, oldest first
+func (r *RecentCounts) Values() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]int(nil), r.values...)
+}
+
+func (r *RecentCounts) add(value int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.values = appendRecent(r.values, value, r.limit)
+}
+
+// Recent Middleware records the count into recent whenever an action changes it
+func RecentMiddleware(recent *RecentCounts) Middleware[State, AppAction] {
+	return func(store *Store[State, AppAction], next Dispatch[AppAction]) Dispatch[AppAction] {
+		return func(action AppAction) error {
+			prevCount := store.GetState().Count
+			err := next(action)
+			if count := store.GetState().Count; count != prevCount {
+				recent.add(count)
+			}
+			return err
+		}
+	}
+}
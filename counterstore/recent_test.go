@@ -0,0 +1,62 @@
+package counterstore
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAppendRecent(t *testing.T) {
+	tests := []struct {
+		name   string
+		recent []int
+		value  int
+		limit  int
+		want   []int
+	}{
+		{"empty", nil, 1, 3, []int{1}},
+		{"below the cap", []int{1, 2}, 3, 3, []int{1, 2, 3}},
+		{"at the cap evicts the oldest", []int{1, 2, 3}, 4, 3, []int{2, 3, 4}},
+		{"over the cap trims to it", []int{1, 2, 3, 4, 5}, 6, 3, []int{4, 5, 6}},
+		{"cap of one", []int{7}, 8, 1, []int{8}},
+		{"no cap keeps nothing", []int{1}, 2, 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := appendRecent(tt.recent, tt.value, tt.limit); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("appendRecent = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppendRecentLeavesInputAlone(t *testing.T) {
+	recent := make([]int, 3, 10)
+	copy(recent, []int{1, 2, 3})
+	next := appendRecent(recent, 4, 5)
+	next[0] = 99
+	if !reflect.DeepEqual(recent, []int{1, 2, 3}) || recent[:4][3] != 0 {
+		t.Errorf("appendRecent modified its input: %v", recent[:4])
+	}
+}
+
+func TestAppendRecentOverflow(t *testing.T) {
+	var recent []int
+	for i := 1; i <= 25; i++ {
+		recent = appendRecent(recent, i, 20)
+	}
+	if len(recent) != 20 || recent[0] != 6 || recent[19] != 25 {
+		t.Errorf("after 25 values capped at 20: %v", recent)
+	}
+}
+
+func TestRecentMiddleware(t *testing.T) {
+	recent := NewRecentCounts(3)
+	store := NewStore(Reduce, State{}, RecentMiddleware(recent))
+
+	for _, action := range []AppAction{IncrementAction{}, SetCountAction{Value: 1}, IncrementAction{}, IncrementAction{}, ResetAction{}} {
+		store.Dispatch(action)
+	}
+	if got := recent.Values(); !reflect.DeepEqual(got, []int{2, 3, 0}) {
+		t.Errorf("Values = %v, want [2 3 0]", got)
+	}
+}
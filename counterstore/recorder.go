@@ -0,0 +1,161 @@
+package counterstore
+
+import (
+	"encoding/csv"
+	"io"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RecordedAction is one dispatch captured by a Recorder. Depth is 0 for a
+// top-level dispatch and counts up for actions dispatched while another was
+// in flight, such as those a thunk dispatches.
+type RecordedAction struct {
+	Seq    int
+	At     time.Time
+	Action AppAction
+	Prev   State
+	Next   State
+	Depth  int
+}
+
+// Recorder keeps an audit trail of every dispatch that passed through its
+// middleware, with the state on either side of it
+type Recorder struct {
+	mu      sync.Mutex
+	clock   Clock
+	seed    int64
+	depth   int
+	actions []RecordedAction
+}
+
+// NewRecorder creates an empty recorder timestamping with clock, or the
+// wall clock when clock is nil
+func NewRecorder(clock Clock) *Recorder {
+	return &Recorder{clock: orRealClock(clock)}
+}
+
+// Actions returns a copy of the recorded dispatches in order
+func (r *Recorder) Actions() []RecordedAction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]RecordedAction(nil), r.actions...)
+}
+
+// Filter returns the recorded dispatches pred accepts, in order
+func (r *Recorder) Filter(pred func(RecordedAction) bool) []RecordedAction {
+	var matched []RecordedAction
+	for _, rec := range r.Actions() {
+		if pred(rec) {
+			matched = append(matched, rec)
+		}
+	}
+	return matched
+}
+
+// FilterByType returns the recorded dispatches of the same concrete type as
+// sample, e.g. FilterByType(SetCountAction{})
+func (r *Recorder) FilterByType(sample any) []RecordedAction {
+	t := reflect.TypeOf(sample)
+	return r.Filter(func(rec RecordedAction) bool {
+		return reflect.TypeOf(rec.Action) == t
+	})
+}
+
+// Seed returns the random seed of the recorded store
+func (r *Recorder) Seed() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.seed
+}
+
+func (r *Recorder) enter() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.depth++
+	return r.depth - 1
+}
+
+func (r *Recorder) leave() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.depth--
+}
+
+func (r *Recorder) record(action AppAction, prev, next State, depth int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.actions = append(r.actions, RecordedAction{
+		Seq:    len(r.actions) + 1,
+		At:     r.clock.Now(),
+		Action: action,
+		Prev:   prev,
+		Next:   next,
+		Depth:  depth,
+	})
+}
+
+// ExportCSV writes the trail as CSV with the header
+// seq,timestamp,action_type,prev_count,new_count. An empty recorder produces
+// just the header.
+func (r *Recorder) ExportCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"seq", "timestamp", "action_type", "prev_count", "new_count"}); err != nil {
+		return err
+	}
+	for _, rec := range r.Actions() {
+		err := cw.Write([]string{
+			strconv.Itoa(rec.Seq),
+			rec.At.Format(time.RFC3339Nano),
+			ActionName(rec.Action),
+			strconv.Itoa(rec.Prev.Count),
+			strconv.Itoa(rec.Next.Count),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// Recorder Middleware appends every dispatch to rec and captures the store's
+// random seed. Depth is tracked per recorder, not per goroutine, so
+// dispatches from several goroutines at once may be marked nested.
+func RecorderMiddleware(rec *Recorder) Middleware[State, AppAction] {
+	return func(store *Store[State, AppAction], next Dispatch[AppAction]) Dispatch[AppAction] {
+		rec.mu.Lock()
+		rec.seed = store.Seed()
+		rec.mu.Unlock()
+
+		return func(action AppAction) error {
+			prevState := store.GetState()
+			depth := rec.enter()
+			defer rec.leave()
+			if err := next(action); err != nil {
+				return err
+			}
+			rec.record(action, prevState, store.GetState(), depth)
+			return nil
+		}
+	}
+}
+
+// Replay rebuilds a recorded session: a new store seeded like the recorded
+// one redispatches the top-level actions, so thunks recompute what they
+// dispatched, random choices included. middleware should match the recorded
+// store's, ThunkMiddleware in particular.
+func Replay(rec *Recorder, reducer Reducer[State, AppAction], initial State, middleware ...Middleware[State, AppAction]) *Store[State, AppAction] {
+	store := NewStoreWithOptions(reducer, initial,
+		WithRandSeed[State, AppAction](rec.Seed()),
+		WithMiddleware(middleware...),
+	)
+	for _, recorded := range rec.Actions() {
+		if recorded.Depth == 0 {
+			store.Dispatch(recorded.Action)
+		}
+	}
+	return store
+}
@@ -0,0 +1,129 @@
+package counterstore
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecorderExportCSV(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC))
+	rec := NewRecorder(clock)
+	store := NewStore(Reduce, State{}, RecorderMiddleware(rec))
+
+	store.Dispatch(IncrementAction{})
+	clock.Advance(1500 * time.Millisecond)
+	store.Dispatch(SetCountAction{Value: 10})
+	store.Dispatch(DecrementAction{})
+
+	var b strings.Builder
+	if err := rec.ExportCSV(&b); err != nil {
+		t.Fatalf("ExportCSV: %v", err)
+	}
+	want := "seq,timestamp,action_type,prev_count,new_count\n" +
+		"1,2024-05-06T07:08:09Z,Increment,0,1\n" +
+		"2,2024-05-06T07:08:10.5Z,SetCount,1,10\n" +
+		"3,2024-05-06T07:08:10.5Z,Decrement,10,9\n"
+	if b.String() != want {
+		t.Errorf("CSV =\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestRecorderExportCSVEmpty(t *testing.T) {
+	var b strings.Builder
+	if err := NewRecorder(nil).ExportCSV(&b); err != nil {
+		t.Fatalf("ExportCSV: %v", err)
+	}
+	if want := "seq,timestamp,action_type,prev_count,new_count\n"; b.String() != want {
+		t.Errorf("CSV = %q, want just the header", b.String())
+	}
+}
+
+func TestRecorderSkipsRejected(t *testing.T) {
+	rec := NewRecorder(nil)
+	store := NewStore(Reduce, State{Flags: map[string]bool{FlagNoNegatives: true}}, RecorderMiddleware(rec))
+
+	store.Dispatch(DecrementAction{})
+	store.Dispatch(IncrementAction{})
+	if got := rec.Actions(); len(got) != 1 || got[0].Action != (IncrementAction{}) {
+		t.Errorf("recorded %+v, want only the increment", got)
+	}
+}
+
+// randomSteps is a thunk adding three random amounts drawn from the store's
+// random source
+var randomSteps = Thunk[State, AppAction]{RunContext: func(ctx ThunkContext[State, AppAction]) {
+	for i := 0; i < 3; i++ {
+		ctx.Dispatch(AddAmountAction{Amount: ctx.Rand.Intn(100) - 50})
+	}
+}}
+
+func TestReplayReproducesRandomThunks(t *testing.T) {
+	rec := NewRecorder(nil)
+	live := NewStoreWithOptions(Reduce, State{},
+		WithRandSeed[State, AppAction](42),
+		WithMiddleware(RecorderMiddleware(rec), ThunkMiddleware[State, AppAction]),
+	)
+	for i := 0; i < 4; i++ {
+		live.Dispatch(randomSteps)
+		live.Dispatch(IncrementAction{})
+	}
+	if rec.Seed() != 42 {
+		t.Fatalf("recorded seed %d, want 42", rec.Seed())
+	}
+
+	replayed := Replay(rec, Reduce, State{}, ThunkMiddleware[State, AppAction])
+	if got, want := replayed.GetState().Count, live.GetState().Count; got != want {
+		t.Errorf("replayed Count = %d, live Count = %d", got, want)
+	}
+	if got, want := replayed.HistoryLen(), live.HistoryLen(); got != want {
+		t.Errorf("replayed %d undo steps, live %d", got, want)
+	}
+
+	// The amounts themselves came from the seed, not from replaying the
+	// recorded children
+	other := NewStoreWithOptions(Reduce, State{},
+		WithRandSeed[State, AppAction](43),
+		WithMiddleware(ThunkMiddleware[State, AppAction]),
+	)
+	for i := 0; i < 4; i++ {
+		other.Dispatch(randomSteps)
+		other.Dispatch(IncrementAction{})
+	}
+	if other.GetState().Count == live.GetState().Count {
+		t.Errorf("a different seed gave the same Count %d", other.GetState().Count)
+	}
+}
+
+func TestRecorderFilter(t *testing.T) {
+	rec := NewRecorder(nil)
+	store := NewStore(Reduce, State{}, RecorderMiddleware(rec))
+	for _, action := range []AppAction{
+		SetCountAction{Value: 50}, IncrementAction{}, SetCountAction{Value: 150},
+		AddAmountAction{Amount: 200}, SetCountAction{Value: 101}, IncrementAction{},
+	} {
+		store.Dispatch(action)
+	}
+
+	sets := rec.FilterByType(SetCountAction{})
+	if len(sets) != 3 || sets[0].Seq != 1 || sets[1].Seq != 3 || sets[2].Seq != 5 {
+		t.Errorf("FilterByType(SetCountAction) = %+v, want seqs 1, 3, 5", sets)
+	}
+	if got := rec.FilterByType(NegateAction{}); len(got) != 0 {
+		t.Errorf("FilterByType of an unrecorded type = %+v", got)
+	}
+
+	big := rec.Filter(func(r RecordedAction) bool {
+		set, ok := r.Action.(SetCountAction)
+		return ok && set.Value > 100
+	})
+	if len(big) != 2 || big[0].Action != (SetCountAction{Value: 150}) || big[1].Action != (SetCountAction{Value: 101}) {
+		t.Errorf("SetCount > 100 = %+v", big)
+	}
+
+	// Filtering by a transition rather than the payload
+	rose := rec.Filter(func(r RecordedAction) bool { return r.Next.Count > r.Prev.Count })
+	if len(rose) != 5 {
+		t.Errorf("%d dispatches raised the count, want 5", len(rose))
+	}
+}
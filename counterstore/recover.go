@@ -0,0 +1,35 @@
+package counterstore
+
+import (
+	"fmt"
+	"log"
+	"runtime/debug"
+)
+
+// PanicLogger receives a panic recovered while dispatching action, with the
+// stack trace captured at the point of recovery
+type PanicLogger[A any] func(action A, r any, stack []byte)
+
+func defaultPanicLogger[A any](action A, r any, stack []byte) {
+	log.Printf("Action panicked: %T: %v\n%s", action, r, stack)
+}
+
+// Recover Middleware stops a panic in the rest of the chain from escaping
+// Dispatch. The state is left as it was before the action and the panic is
+// passed to the store's panic logger (see WithPanicLogger) and returned to
+// the dispatcher as an error.
+func RecoverMiddleware[S StateProvider[S], A Action[S]](store *Store[S, A], next Dispatch[A]) Dispatch[A] {
+	return func(action A) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger := store.panicLogger
+				if logger == nil {
+					logger = defaultPanicLogger[A]
+				}
+				logger(action, r, debug.Stack())
+				err = fmt.Errorf("action %T panicked: %v", action, r)
+			}
+		}()
+		return next(action)
+	}
+}
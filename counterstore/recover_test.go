@@ -0,0 +1,104 @@
+package counterstore
+
+import (
+	"log"
+	"strings"
+	"testing"
+)
+
+// panicAction panics in its reducer
+type panicAction struct{ Why string }
+
+func (a panicAction) Apply(s State) State { panic(a.Why) }
+
+func TestRecoverMiddlewarePanicLogger(t *testing.T) {
+	var (
+		loggedAction AppAction
+		loggedValue  any
+		loggedStack  []byte
+	)
+	store := NewStoreWithOptions(Reduce, State{Count: 2},
+		WithPanicLogger[State, AppAction](func(action AppAction, r any, stack []byte) {
+			loggedAction, loggedValue, loggedStack = action, r, stack
+		}),
+		WithMiddleware(RecoverMiddleware[State, AppAction]),
+	)
+
+	err := store.Dispatch(panicAction{Why: "boom"})
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("Dispatch error = %v, want the panic", err)
+	}
+	if loggedAction != (panicAction{Why: "boom"}) || loggedValue != "boom" {
+		t.Errorf("logger got %v, %v", loggedAction, loggedValue)
+	}
+	if len(loggedStack) == 0 || !strings.Contains(string(loggedStack), "panicAction") {
+		t.Errorf("logger got no useful stack:\n%s", loggedStack)
+	}
+
+	// The state is untouched and the store's lock was released
+	if got := store.GetState().Count; got != 2 {
+		t.Errorf("Count = %d, want 2", got)
+	}
+	if err := store.Dispatch(IncrementAction{}); err != nil || store.GetState().Count != 3 {
+		t.Errorf("dispatch after the panic: %v, Count = %d", err, store.GetState().Count)
+	}
+}
+
+func TestSubscriberPanicHook(t *testing.T) {
+	var (
+		panicked []int
+		values   []any
+	)
+	store := NewStoreWithOptions(Reduce, State{},
+		WithSubscriberPanicHook[State, AppAction](func(listenerIndex int, r any) {
+			panicked = append(panicked, listenerIndex)
+			values = append(values, r)
+		}),
+	)
+	before, after := 0, 0
+	store.Subscribe(func() { before++ })
+	store.Subscribe(func() { panic("faulty subscriber") })
+	store.Subscribe(func() { after++ })
+
+	if err := store.Dispatch(IncrementAction{}); err != nil {
+		t.Fatalf("Dispatch: %v", err)
+	}
+	if before != 1 || after != 1 {
+		t.Errorf("healthy subscribers ran %d and %d times, want once each", before, after)
+	}
+	if len(panicked) != 1 || panicked[0] != 1 || values[0] != "faulty subscriber" {
+		t.Errorf("hook got %v, %v; want listener 1 and its panic", panicked, values)
+	}
+
+	// The store carries on as normal
+	if err := store.Dispatch(IncrementAction{}); err != nil || store.GetState().Count != 2 {
+		t.Errorf("dispatch after the panic: %v, Count = %d", err, store.GetState().Count)
+	}
+	if after != 2 || len(panicked) != 2 {
+		t.Errorf("second dispatch: after %d, %d panics", after, len(panicked))
+	}
+}
+
+func TestSubscriberPanicLoggedWithoutHook(t *testing.T) {
+	store := NewStore(Reduce, State{})
+	var out strings.Builder
+	logOutput, logFlags := log.Writer(), log.Flags()
+	log.SetOutput(&out)
+	log.SetFlags(0)
+	defer func() {
+		log.SetOutput(logOutput)
+		log.SetFlags(logFlags)
+	}()
+
+	ran := false
+	store.Subscribe(func() { panic("oops") })
+	store.Subscribe(func() { ran = true })
+	store.Dispatch(IncrementAction{})
+
+	if !ran {
+		t.Error("the subscriber after the panicking one did not run")
+	}
+	if got := out.String(); !strings.Contains(got, "subscriber 0 panicked: oops") {
+		t.Errorf("log = %q", got)
+	}
+}
@@ -0,0 +1,139 @@
+package counterstore
+
+import (
+	"reflect"
+	"sync"
+)
+
+// RedactedPlaceholder replaces string fields RedactTagged redacts
+const RedactedPlaceholder = "[redacted]"
+
+// Redacted carries an action together with a copy of it with its sensitive
+// fields replaced by placeholders. The store validates and applies the real
+// action; middleware that logs or reports actions shows the copy, through
+// Shown.
+type Redacted struct {
+	AppAction
+	real AppAction
+}
+
+func (r Redacted) Apply(s State) State {
+	return r.real.Apply(s)
+}
+
+func (r Redacted) Validate(s State) error {
+	if v, ok := r.real.(Validator[State]); ok {
+		return v.Validate(s)
+	}
+	return nil
+}
+
+func (r Redacted) Undoable() bool {
+	return isUndoable(r.real)
+}
+
+// Unredacted returns the real action
+func (r Redacted) Unredacted() AppAction {
+	return r.real
+}
+
+// Shown returns action as logs and reports may show it: the redacted copy
+// of a Redacted, also inside an Annotated, and any other action as it is
+func Shown(action AppAction) AppAction {
+	switch a := action.(type) {
+	case Redacted:
+		return a.AppAction
+	case Annotated:
+		if r, ok := a.AppAction.(Redacted); ok {
+			return Annotated{AppAction: r.AppAction, Meta: a.Meta}
+		}
+	}
+	return action
+}
+
+// plainAction strips every wrapper that only carries an action on, such as
+// an annotation, a redaction, an actor or a replica origin, leaving the
+// action the reducer applies
+func plainAction(action AppAction) AppAction {
+	for {
+		switch a := action.(type) {
+		case Annotated:
+			action = a.AppAction
+		case Redacted:
+			action = a.real
+		case AttributedAction:
+			action = a.Action
+		case SyncedAction:
+			action = a.Action
+		default:
+			return action
+		}
+	}
+}
+
+// Redact Middleware passes every action on as a Redacted holding the copy
+// redactor returns, so the middleware after it log and report the copy
+// while the reducer still applies the real action. An annotated action is
+// redacted inside its annotation; thunks, and actions redactor returns
+// unchanged, pass as they are. Install it outside LoggingMiddleware and
+// ProvenanceMiddleware.
+func RedactMiddleware(redactor func(AppAction) AppAction) Middleware[State, AppAction] {
+	return func(store *Store[State, AppAction], next Dispatch[AppAction]) Dispatch[AppAction] {
+		return func(action AppAction) error {
+			return next(redact(action, redactor))
+		}
+	}
+}
+
+func redact(action AppAction, redactor func(AppAction) AppAction) AppAction {
+	switch a := action.(type) {
+	case Thunk[State, AppAction], Redacted:
+		return action
+	case Annotated:
+		return Annotated{AppAction: redact(a.AppAction, redactor), Meta: a.Meta}
+	}
+	shown := redactor(action)
+	if shown == nil || reflect.DeepEqual(shown, action) {
+		return action
+	}
+	return Redacted{AppAction: shown, real: action}
+}
+
+// redactFields caches, per action type, the indexes of its fields tagged
+// redact:"true"
+var redactFields sync.Map
+
+// RedactTagged is a redactor for RedactMiddleware blanking the fields of an
+// action struct tagged redact:"true": strings become RedactedPlaceholder and
+// others their zero value
+func RedactTagged(action AppAction) AppAction {
+	t := reflect.TypeOf(action)
+	if t == nil || t.Kind() != reflect.Struct {
+		return action
+	}
+	fields, ok := redactFields.Load(t)
+	if !ok {
+		var tagged []int
+		for i := 0; i < t.NumField(); i++ {
+			if f := t.Field(i); f.IsExported() && f.Tag.Get("redact") == "true" {
+				tagged = append(tagged, i)
+			}
+		}
+		fields, _ = redactFields.LoadOrStore(t, tagged)
+	}
+	if len(fields.([]int)) == 0 {
+		return action
+	}
+
+	copied := reflect.New(t).Elem()
+	copied.Set(reflect.ValueOf(action))
+	for _, i := range fields.([]int) {
+		field := copied.Field(i)
+		if field.Kind() == reflect.String {
+			field.SetString(RedactedPlaceholder)
+		} else {
+			field.SetZero()
+		}
+	}
+	return copied.Interface().(AppAction)
+}
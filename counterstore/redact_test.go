@@ -0,0 +1,92 @@
+package counterstore
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+// loginAttemptAction counts a sign-in attempt, carrying the password tried
+type loginAttemptAction struct {
+	Password string `redact:"true"`
+	PIN      int    `redact:"true"`
+	Attempt  int
+}
+
+func (a loginAttemptAction) Apply(s State) State {
+	state := s.Copy()
+	state.Count = a.Attempt + a.PIN
+	state.Notes = map[int]string{state.Count: a.Password}
+	return state
+}
+
+func TestRedactMiddlewareLogsCopy(t *testing.T) {
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+
+	var sink MemoryAuditSink
+	store := NewStore(Reduce, State{},
+		RedactMiddleware(RedactTagged),
+		LoggingMiddleware[State, AppAction],
+		ProvenanceMiddleware(&sink, nil),
+	)
+	store.Dispatch(loginAttemptAction{Password: "hunter2", PIN: 40, Attempt: 2})
+
+	// The reducer applied the real action
+	if got := store.GetState(); got.Count != 42 || got.Notes[42] != "hunter2" {
+		t.Errorf("state = Count %d, notes %v; want the real password and PIN applied", got.Count, got.Notes)
+	}
+
+	// The log shows only the redacted copy of the action
+	var actionLine string
+	for _, line := range strings.Split(logged.String(), "\n") {
+		if strings.Contains(line, "loginAttemptAction {") {
+			actionLine = line
+		}
+	}
+	if want := "loginAttemptAction {Password:[redacted] PIN:0 Attempt:2}"; !strings.Contains(actionLine, want) {
+		t.Errorf("logged action %q, want it to show %s", actionLine, want)
+	}
+	if entries := sink.Entries(); len(entries) != 1 ||
+		entries[0].Action != (loginAttemptAction{Password: RedactedPlaceholder, Attempt: 2}) {
+		t.Errorf("audit entries = %+v, want the redacted copy", entries)
+	}
+
+	// A redacted action is undoable like the real one
+	if !store.Undo() || store.GetState().Count != 0 {
+		t.Error("the redacted action was not an undo step")
+	}
+}
+
+func TestRedactMiddlewarePassesUntagged(t *testing.T) {
+	var seen []AppAction
+	record := func(store *Store[State, AppAction], next Dispatch[AppAction]) Dispatch[AppAction] {
+		return func(action AppAction) error {
+			seen = append(seen, action)
+			return next(action)
+		}
+	}
+	store := NewStore(Reduce, State{Count: 1, Flags: map[string]bool{FlagNoNegatives: true}},
+		RedactMiddleware(RedactTagged), record)
+
+	store.Dispatch(IncrementAction{})
+	store.Dispatch(WithMeta(loginAttemptAction{Password: "x", Attempt: 5}, map[string]string{"source": "form"}))
+	if _, ok := seen[0].(IncrementAction); !ok {
+		t.Errorf("an action without tagged fields arrived as %T", seen[0])
+	}
+	annotated, ok := seen[1].(Annotated)
+	if !ok {
+		t.Fatalf("an annotated action arrived as %T", seen[1])
+	}
+	if r, ok := annotated.AppAction.(Redacted); !ok || r.Unredacted() != (loginAttemptAction{Password: "x", Attempt: 5}) {
+		t.Errorf("annotated action holds %+v, want it redacted inside the annotation", annotated.AppAction)
+	}
+
+	// Validation sees the real action
+	if err := store.Dispatch(Redacted{AppAction: DecrementAction{}, real: AddAmountAction{Amount: -50}}); err == nil {
+		t.Error("a redacted action skipped the real action's validation")
+	}
+}
@@ -0,0 +1,26 @@
+package counterstore
+
+import "reflect"
+
+// ReducerMap routes each action to a reducer registered for its concrete
+// type, so behaviour can be added or overridden per action without a
+// central switch. Register everything before the map's Reduce is handed to
+// a store; the map is not safe for concurrent modification.
+type ReducerMap map[reflect.Type]func(State, AppAction) State
+
+// RegisterReducer makes fn the reducer for actions of sample's concrete type,
+// replacing any registered before
+func (m ReducerMap) RegisterReducer(sample AppAction, fn func(State, AppAction) State) {
+	m[reflect.TypeOf(sample)] = fn
+}
+
+// Reduce runs the reducer registered for action's type, falling back to the
+// action's own Apply. Annotated and redacted actions are looked up by the
+// action they wrap.
+func (m ReducerMap) Reduce(state State, action AppAction) State {
+	action = plainAction(action)
+	if fn, ok := m[reflect.TypeOf(action)]; ok {
+		return fn(state, action)
+	}
+	return action.Apply(state)
+}
@@ -0,0 +1,42 @@
+package counterstore
+
+import "testing"
+
+func TestReducerMapOverridesApply(t *testing.T) {
+	reducers := ReducerMap{}
+	reducers.RegisterReducer(IncrementAction{}, func(state State, action AppAction) State {
+		state.Count += 2
+		return state
+	})
+	store := NewStore(reducers.Reduce, State{})
+
+	store.Dispatch(IncrementAction{})
+	if got := store.GetState().Count; got != 2 {
+		t.Fatalf("Count after the overridden increment = %d, want 2", got)
+	}
+	// Unregistered types fall back to their own Apply
+	store.Dispatch(DecrementAction{})
+	if got := store.GetState().Count; got != 1 {
+		t.Errorf("Count after the built-in decrement = %d, want 1", got)
+	}
+	// Wrapped actions are routed by the action they wrap
+	store.Dispatch(Annotated{AppAction: IncrementAction{}, Meta: map[string]string{"source": "test"}})
+	if got := store.GetState().Count; got != 3 {
+		t.Errorf("Count after an annotated increment = %d, want 3", got)
+	}
+}
+
+func TestReducerMapRegisterReplaces(t *testing.T) {
+	reducers := ReducerMap{}
+	reducers.RegisterReducer(IncrementAction{}, func(state State, action AppAction) State {
+		state.Count += 2
+		return state
+	})
+	reducers.RegisterReducer(IncrementAction{}, func(state State, action AppAction) State {
+		state.Count += 10
+		return state
+	})
+	if got := reducers.Reduce(State{}, IncrementAction{}); got.Count != 10 {
+		t.Errorf("Count = %d, want the later registration's 10", got.Count)
+	}
+}
@@ -0,0 +1,60 @@
+package counterstore
+
+import (
+	"errors"
+	"fmt"
+)
+
+// maxRepeat is the largest Times a RepeatAction accepts
+const maxRepeat = 1000
+
+// ErrRepeatTimes rejects a RepeatAction whose Times is negative or above
+// maxRepeat
+var ErrRepeatTimes = fmt.Errorf("repeat times must be between 0 and %d", maxRepeat)
+
+// RepeatAction applies Inner Times times in a row, as one step: it is a
+// single undo step, undoable when Inner is, and subscribers hear of it once.
+// Times outside 0 to maxRepeat is rejected with ErrRepeatTimes; reaching
+// the reducer anyway, it is clamped into that range. Validation checks every
+// repetition against the state it would see, so a repeat that would fail
+// part way is rejected as a whole.
+type RepeatAction struct {
+	Inner AppAction
+	Times int
+}
+
+func (a RepeatAction) Apply(s State) State {
+	if a.Inner == nil {
+		return s
+	}
+	state := s
+	for range min(max(a.Times, 0), maxRepeat) {
+		state = reduceAction(state, a.Inner)
+	}
+	return state
+}
+
+func (a RepeatAction) Validate(s State) error {
+	if a.Times < 0 || a.Times > maxRepeat {
+		return ErrRepeatTimes
+	}
+	if a.Inner == nil {
+		return errors.New("repeat: no action to repeat")
+	}
+	v, ok := a.Inner.(Validator[State])
+	if !ok {
+		return nil
+	}
+	state := s
+	for i := range a.Times {
+		if err := v.Validate(state); err != nil {
+			return fmt.Errorf("repeat %d of %d: %w", i+1, a.Times, err)
+		}
+		state = reduceAction(state, a.Inner)
+	}
+	return nil
+}
+
+func (a RepeatAction) Undoable() bool {
+	return isUndoable(a.Inner)
+}
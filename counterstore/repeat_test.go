@@ -0,0 +1,76 @@
+package counterstore
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRepeatAction(t *testing.T) {
+	tests := []struct {
+		name   string
+		action RepeatAction
+		start  int
+		want   int
+	}{
+		{"increment five times", RepeatAction{Inner: IncrementAction{}, Times: 5}, 0, 5},
+		{"decrement three times", RepeatAction{Inner: DecrementAction{}, Times: 3}, 10, 7},
+		{"amount twice", RepeatAction{Inner: AddAmountAction{Amount: 25}, Times: 2}, 0, 50},
+		{"zero times", RepeatAction{Inner: IncrementAction{}, Times: 0}, 4, 4},
+	}
+	for _, tt := range tests {
+		store := NewStore(Reduce, State{Count: tt.start})
+		notified := 0
+		store.Subscribe(func() { notified++ })
+		if err := store.Dispatch(tt.action); err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got := store.GetState().Count; got != tt.want {
+			t.Errorf("%s: Count = %d, want %d", tt.name, got, tt.want)
+		}
+		if notified != 1 {
+			t.Errorf("%s: %d notifications, want 1", tt.name, notified)
+		}
+	}
+}
+
+func TestRepeatActionIsOneUndoStep(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 1})
+	store.Dispatch(RepeatAction{Inner: IncrementAction{}, Times: 5})
+	if !store.Undo() || store.GetState().Count != 1 {
+		t.Errorf("Count after one undo = %d, want 1", store.GetState().Count)
+	}
+	if store.CanUndo() {
+		t.Error("the repeat left more than one undo step")
+	}
+}
+
+func TestRepeatActionGuards(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 2, Flags: map[string]bool{FlagNoNegatives: true}})
+	for _, times := range []int{-1, maxRepeat + 1} {
+		err := store.Dispatch(RepeatAction{Inner: IncrementAction{}, Times: times})
+		if !errors.Is(err, ErrRepeatTimes) {
+			t.Errorf("Times %d: error = %v, want ErrRepeatTimes", times, err)
+		}
+	}
+	if err := store.Dispatch(RepeatAction{Times: 1}); err == nil {
+		t.Error("a repeat without an action was accepted")
+	}
+
+	// A repeat that would fail part way is rejected as a whole
+	err := store.Dispatch(RepeatAction{Inner: DecrementAction{}, Times: 3})
+	if !errors.Is(err, ErrNegativeCount) {
+		t.Errorf("error = %v, want ErrNegativeCount", err)
+	}
+	if got := store.GetState().Count; got != 2 {
+		t.Errorf("Count = %d after rejected repeats, want 2", got)
+	}
+
+	// Reaching the reducer anyway, Times is clamped
+	if got := (RepeatAction{Inner: IncrementAction{}, Times: -4}).Apply(State{Count: 2}); got.Count != 2 {
+		t.Errorf("negative Times applied = Count %d, want 2", got.Count)
+	}
+	if got := (RepeatAction{Inner: IncrementAction{}, Times: maxRepeat * 2}).Apply(State{}); got.Count != maxRepeat {
+		t.Errorf("huge Times applied = Count %d, want %d", got.Count, maxRepeat)
+	}
+}
@@ -0,0 +1,113 @@
+package counterstore
+
+// transitionCapture receives the last commit made while the middleware chain
+// of a DispatchReturning or DispatchWithResult runs, or the reason the
+// action was blocked
+type transitionCapture[S any] struct {
+	prev, next S
+	done       bool
+	reason     string
+}
+
+// captureTransition hands the commit from prev to next to a waiting
+// DispatchReturning. A later commit replaces an earlier one, so an action
+// that middleware forwards after dispatching one of its own, the way
+// IdleWatch clears the idle flag, is reported rather than the action before
+// it. Callers must hold s.mu.
+func (s *Store[S, A]) captureTransition(prev, next S) {
+	if s.capture != nil {
+		s.capture.prev, s.capture.next, s.capture.done = prev.Copy(), next.Copy(), true
+	}
+}
+
+// ReportBlocked lets middleware that drops an action say why, for
+// DispatchWithResult to pass on; outside such a dispatch it does nothing.
+// The first reason reported for a dispatch is kept.
+func (s *Store[S, A]) ReportBlocked(reason string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reportBlockedLocked(reason)
+}
+
+func (s *Store[S, A]) reportBlockedLocked(reason string) {
+	if s.capture != nil && s.capture.reason == "" {
+		s.capture.reason = reason
+	}
+}
+
+// dispatchCaptured dispatches action with a capture installed and returns
+// what it caught. The capture is removed once the middleware chain returns,
+// before subscribers are notified, so a subscriber dispatching in turn is
+// not mistaken for the action's own commit. When nothing was committed, prev
+// and next are both the current state.
+func (s *Store[S, A]) dispatchCaptured(action A) (*transitionCapture[S], error) {
+	s.returningMu.Lock()
+	defer s.returningMu.Unlock()
+
+	capture := &transitionCapture[S]{}
+	s.mu.Lock()
+	s.capture = capture
+	s.mu.Unlock()
+
+	var err error
+	queued := s.enqueue(action)
+	if !queued {
+		err = s.chain()(action)
+	}
+
+	s.mu.Lock()
+	s.capture = nil
+	if !capture.done {
+		capture.prev, capture.next = s.state.Copy(), s.state.Copy()
+	}
+	s.mu.Unlock()
+
+	if !queued {
+		s.settle()
+	}
+	return capture, err
+}
+
+// DispatchReturning dispatches action through the full middleware chain and
+// returns the states either side of the commit it made, both taken under
+// the lock at the moment of the commit, so they are always consecutive. If
+// middleware commits more than once on the action's way through, the last
+// commit is the one returned. When nothing is committed, because middleware
+// dropped the action, it failed or the store is paused, prev and next are
+// both the current state.
+//
+// Calls are serialized with each other, but not with plain dispatches: if
+// another goroutine commits while the call is in flight, its commit may be
+// the one returned. Tooling that needs exact attribution should dispatch
+// from one goroutine.
+func (s *Store[S, A]) DispatchReturning(action A) (prev, next S, err error) {
+	capture, err := s.dispatchCaptured(action)
+	return capture.prev, capture.next, err
+}
+
+// DispatchResult describes what one dispatch did, for UI feedback. Applied
+// reports whether it committed a new state; if not, Reason says why, from
+// the error rejecting it or the reason middleware gave with ReportBlocked.
+type DispatchResult[S any] struct {
+	Applied    bool
+	Prev, Next S
+	Reason     string
+	Err        error
+}
+
+// DispatchWithResult is DispatchReturning reporting its outcome as a
+// DispatchResult, and shares its caveat about concurrent dispatches
+func (s *Store[S, A]) DispatchWithResult(action A) DispatchResult[S] {
+	capture, err := s.dispatchCaptured(action)
+	result := DispatchResult[S]{Applied: capture.done, Prev: capture.prev, Next: capture.next, Err: err}
+	switch {
+	case err != nil:
+		result.Reason = err.Error()
+	case !capture.done:
+		result.Reason = capture.reason
+		if result.Reason == "" {
+			result.Reason = "no change committed"
+		}
+	}
+	return result
+}
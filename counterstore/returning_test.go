@@ -0,0 +1,103 @@
+package counterstore
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDispatchReturning(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 4})
+	for _, want := range []struct{ prev, next int }{{4, 5}, {5, 6}, {6, 7}} {
+		prev, next, err := store.DispatchReturning(IncrementAction{})
+		if err != nil || prev.Count != want.prev || next.Count != want.next {
+			t.Errorf("DispatchReturning = %d, %d, %v; want %d, %d", prev.Count, next.Count, err, want.prev, want.next)
+		}
+	}
+	if got := store.GetState().Count; got != 7 {
+		t.Errorf("Count = %d, want 7", got)
+	}
+}
+
+func TestDispatchReturningRunsMiddleware(t *testing.T) {
+	// double turns every increment into +2 on its way to the reducer
+	double := func(store *Store[State, AppAction], next Dispatch[AppAction]) Dispatch[AppAction] {
+		return func(action AppAction) error {
+			if _, ok := action.(IncrementAction); ok {
+				return next(AddAmountAction{Amount: 2})
+			}
+			return next(action)
+		}
+	}
+	store := NewStore(Reduce, State{}, double)
+	prev, next, err := store.DispatchReturning(IncrementAction{})
+	if err != nil || prev.Count != 0 || next.Count != 2 {
+		t.Errorf("DispatchReturning through middleware = %d, %d, %v; want 0, 2", prev.Count, next.Count, err)
+	}
+}
+
+func TestDispatchReturningWithoutCommit(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 3, Flags: map[string]bool{FlagNoNegatives: true}})
+
+	store.Pause()
+	prev, next, err := store.DispatchReturning(IncrementAction{})
+	if err != nil || prev.Count != 3 || next.Count != 3 {
+		t.Errorf("paused: %d, %d, %v; want the current state twice", prev.Count, next.Count, err)
+	}
+	store.Resume()
+
+	store.Dispatch(SetCountAction{Value: 0})
+	prev, next, err = store.DispatchReturning(DecrementAction{})
+	if err == nil || prev.Count != 0 || next.Count != 0 {
+		t.Errorf("rejected: %d, %d, %v; want the current state twice and an error", prev.Count, next.Count, err)
+	}
+}
+
+func TestDispatchWithResultApplied(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	idle := NewIdleWatch(clock, time.Minute)
+	defer idle.Close()
+	store := NewStore(Reduce, State{Count: 2}, idle.Middleware())
+	clock.Advance(time.Minute)
+	if !store.GetState().UI.Idle {
+		t.Fatal("the store did not go idle")
+	}
+
+	// Waking from idle commits SetIdleAction first; the result is still the
+	// increment's own commit
+	result := store.DispatchWithResult(IncrementAction{})
+	if !result.Applied || result.Reason != "" || result.Err != nil {
+		t.Errorf("result = %+v, want applied without a reason", result)
+	}
+	if result.Prev.Count != 2 || result.Next.Count != 3 {
+		t.Errorf("result Prev %d Next %d, want 2 and 3", result.Prev.Count, result.Next.Count)
+	}
+	if result.Prev.UI.Idle || result.Next.UI.Idle {
+		t.Errorf("result spans the idle commit: Prev %+v Next %+v", result.Prev.UI, result.Next.UI)
+	}
+}
+
+func TestDispatchWithResultBlocked(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 5, Flags: map[string]bool{FlagNoNegatives: true}},
+		MonotonicMiddleware(true, nil))
+
+	result := store.DispatchWithResult(DecrementAction{})
+	if result.Applied || result.Reason != "count may not go down" || result.Err != nil {
+		t.Errorf("blocked result = %+v, want the middleware's reason", result)
+	}
+	if result.Prev.Count != 5 || result.Next.Count != 5 {
+		t.Errorf("blocked result Prev %d Next %d, want the current 5 twice", result.Prev.Count, result.Next.Count)
+	}
+
+	store = NewStore(Reduce, State{Flags: map[string]bool{FlagNoNegatives: true}})
+	result = store.DispatchWithResult(AddAmountAction{Amount: -1})
+	if result.Applied || !errors.Is(result.Err, ErrNegativeCount) || result.Reason != ErrNegativeCount.Error() {
+		t.Errorf("rejected result = %+v, want ErrNegativeCount as the reason", result)
+	}
+
+	store.Pause()
+	result = store.DispatchWithResult(IncrementAction{})
+	if result.Applied || result.Reason != "queued while paused" {
+		t.Errorf("paused result = %+v, want queued while paused", result)
+	}
+}
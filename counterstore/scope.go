@@ -0,0 +1,151 @@
+package counterstore
+
+import "sync"
+
+// DefaultScope is the undo scope of actions without one of their own
+const DefaultScope = "default"
+
+// NotesScope is the undo scope of note edits
+const NotesScope = "notes"
+
+// Scoped is implemented by actions belonging to a named undo scope
+type Scoped interface {
+	Scope() string
+}
+
+func (a SetNoteAction) Scope() string { return NotesScope }
+
+// ActionScope returns the undo scope of action, looking through an
+// annotation or redaction, or DefaultScope when it has none
+func ActionScope(action AppAction) string {
+	if scoped, ok := plainAction(action).(Scoped); ok && scoped.Scope() != "" {
+		return scoped.Scope()
+	}
+	return DefaultScope
+}
+
+// scopedStep is one recorded transition: undo reverts the fields the action
+// changed and redo sets them again
+type scopedStep struct {
+	undo, redo StatePatch
+}
+
+// ScopedHistory keeps a separate undo history per scope, so a screen can
+// undo its own feature's edits without reverting anything else. A step is
+// recorded as a patch of only the fields its action changed, and UndoScope
+// sets just those back; later changes to other fields, from any scope, are
+// kept. Transient fields are never recorded. The store's own history is
+// unaffected, and scoped undos and redos do not become steps of it.
+type ScopedHistory struct {
+	mu     sync.Mutex
+	store  *Store[State, AppAction]
+	past   map[string][]scopedStep
+	future map[string][]scopedStep
+}
+
+// NewScopedHistory creates an empty scoped history
+func NewScopedHistory() *ScopedHistory {
+	return &ScopedHistory{
+		past:   make(map[string][]scopedStep),
+		future: make(map[string][]scopedStep),
+	}
+}
+
+// Middleware returns the middleware recording each undoable action that
+// changed the state as a step of its scope
+func (h *ScopedHistory) Middleware() Middleware[State, AppAction] {
+	return func(store *Store[State, AppAction], next Dispatch[AppAction]) Dispatch[AppAction] {
+		h.mu.Lock()
+		h.store = store
+		h.mu.Unlock()
+
+		return func(action AppAction) error {
+			if !isUndoable(action) {
+				return next(action)
+			}
+			before := store.GetState()
+			if err := next(action); err != nil {
+				return err
+			}
+			after := store.GetState()
+			step := scopedStep{
+				undo: ComputePatch(after, before.KeepTransient(after)),
+				redo: ComputePatch(before, after.KeepTransient(before)),
+			}
+			if step.undo.Empty() {
+				return nil
+			}
+
+			scope := ActionScope(action)
+			h.mu.Lock()
+			h.past[scope] = append(h.past[scope], step)
+			delete(h.future, scope)
+			h.mu.Unlock()
+			return nil
+		}
+	}
+}
+
+// UndoScope reverts the last recorded step of scope. It returns false when
+// scope has nothing to undo.
+func (h *ScopedHistory) UndoScope(scope string) bool {
+	h.mu.Lock()
+	steps := h.past[scope]
+	if len(steps) == 0 || h.store == nil {
+		h.mu.Unlock()
+		return false
+	}
+	step := steps[len(steps)-1]
+	h.past[scope] = steps[:len(steps)-1]
+	h.future[scope] = append(h.future[scope], step)
+	store := h.store
+	h.mu.Unlock()
+
+	store.Dispatch(scopeRestoreAction{Patch: step.undo})
+	return true
+}
+
+// RedoScope reapplies the step of scope undone last. It returns false when
+// scope has nothing to redo.
+func (h *ScopedHistory) RedoScope(scope string) bool {
+	h.mu.Lock()
+	steps := h.future[scope]
+	if len(steps) == 0 || h.store == nil {
+		h.mu.Unlock()
+		return false
+	}
+	step := steps[len(steps)-1]
+	h.future[scope] = steps[:len(steps)-1]
+	h.past[scope] = append(h.past[scope], step)
+	store := h.store
+	h.mu.Unlock()
+
+	store.Dispatch(scopeRestoreAction{Patch: step.redo})
+	return true
+}
+
+// CanUndoScope reports whether scope has a step to undo
+func (h *ScopedHistory) CanUndoScope(scope string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.past[scope]) > 0
+}
+
+// CanRedoScope reports whether scope has a step to redo
+func (h *ScopedHistory) CanRedoScope(scope string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.future[scope]) > 0
+}
+
+// scopeRestoreAction applies a recorded scoped step. It is not undoable, so
+// neither history records it.
+type scopeRestoreAction struct {
+	Patch StatePatch
+}
+
+func (a scopeRestoreAction) Undoable() bool { return false }
+
+func (a scopeRestoreAction) Apply(s State) State {
+	return a.Patch.ApplyTo(s)
+}
@@ -0,0 +1,86 @@
+package counterstore
+
+import "testing"
+
+func TestUndoScopeInterleaved(t *testing.T) {
+	history := NewScopedHistory()
+	store := NewStore(Reduce, State{Target: 100}, history.Middleware())
+
+	store.Dispatch(IncrementAction{})
+	store.Dispatch(SetNoteAction{Count: 1, Text: "first"})
+	store.Dispatch(IncrementAction{})
+	store.Dispatch(SetNoteAction{Count: 2, Text: "second"})
+	store.Dispatch(SetHelpAction{Visible: true}) // transient, never recorded
+
+	// Undoing notes leaves the count alone
+	if !history.UndoScope(NotesScope) {
+		t.Fatal("nothing to undo in the notes scope")
+	}
+	if got := store.GetState(); got.Count != 2 || got.Notes[2] != "" || got.Notes[1] != "first" {
+		t.Errorf("after undoing a note: Count %d, notes %v; want Count 2 and only the first note", got.Count, got.Notes)
+	}
+	// Undoing the default scope leaves the notes alone
+	if !history.UndoScope(DefaultScope) {
+		t.Fatal("nothing to undo in the default scope")
+	}
+	if got := store.GetState(); got.Count != 1 || got.Notes[1] != "first" || !got.UI.ShowHelp {
+		t.Errorf("after undoing an increment: %+v, want Count 1 with the first note", got)
+	}
+	history.UndoScope(NotesScope)
+	if history.UndoScope(NotesScope) {
+		t.Error("undid more notes than were set")
+	}
+	if got := store.GetState(); got.Count != 1 || len(got.Notes) != 0 {
+		t.Errorf("after undoing every note: Count %d, notes %v", got.Count, got.Notes)
+	}
+
+	// Redo is per scope too
+	if !history.RedoScope(NotesScope) || !history.RedoScope(NotesScope) {
+		t.Fatal("could not redo both notes")
+	}
+	if got := store.GetState(); got.Count != 1 || got.Notes[2] != "second" {
+		t.Errorf("after redoing the notes: Count %d, notes %v", got.Count, got.Notes)
+	}
+	if history.RedoScope(NotesScope) || !history.CanRedoScope(DefaultScope) {
+		t.Error("redo stacks crossed scopes")
+	}
+
+	// Scoped undos are not steps of the store's history
+	if got := store.HistoryLen(); got != 4 {
+		t.Errorf("store history holds %d steps, want the 4 dispatched", got)
+	}
+}
+
+func TestUndoScopeNewStepClearsRedo(t *testing.T) {
+	history := NewScopedHistory()
+	store := NewStore(Reduce, State{}, history.Middleware())
+	store.Dispatch(SetNoteAction{Count: 0, Text: "a"})
+	store.Dispatch(IncrementAction{})
+	history.UndoScope(NotesScope)
+
+	// A new step in another scope keeps this scope's redo
+	store.Dispatch(IncrementAction{})
+	if !history.CanRedoScope(NotesScope) {
+		t.Fatal("a default-scope step cleared the notes redo")
+	}
+	store.Dispatch(SetNoteAction{Count: 2, Text: "b"})
+	if history.CanRedoScope(NotesScope) {
+		t.Error("a new note kept the notes redo")
+	}
+	// A dispatch that changes nothing records no step
+	store.Dispatch(SetNoteAction{Count: 2, Text: "b"})
+	history.UndoScope(NotesScope)
+	if history.CanUndoScope(NotesScope) {
+		t.Error("a no-op note was recorded as a step")
+	}
+	if got := store.GetState(); got.Count != 2 || len(got.Notes) != 0 {
+		t.Errorf("state = Count %d, notes %v; want Count 2 and no notes", got.Count, got.Notes)
+	}
+
+	if ActionScope(WithMeta(SetNoteAction{}, nil)) != NotesScope || ActionScope(IncrementAction{}) != DefaultScope {
+		t.Error("ActionScope did not look through the annotation")
+	}
+	if NewScopedHistory().UndoScope(NotesScope) {
+		t.Error("an unattached history undid something")
+	}
+}
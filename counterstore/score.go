@@ -0,0 +1,50 @@
+package counterstore
+
+import "errors"
+
+const (
+	// PlayerA takes the first turn
+	PlayerA = iota
+	// PlayerB takes the second turn
+	PlayerB
+)
+
+// ErrNotYourTurn rejects a ScoreAction for the player who is not on turn
+var ErrNotYourTurn = errors.New("not this player's turn")
+
+// ScoreAction adds Delta to Player's score. Only the player on turn may
+// score; for the other player the action is rejected with ErrNotYourTurn.
+type ScoreAction struct {
+	Player int
+	Delta  int
+}
+
+func (a ScoreAction) Apply(s State) State {
+	if a.Player != s.Turn {
+		return s
+	}
+	state := s.Copy()
+	switch a.Player {
+	case PlayerA:
+		state.ScoreA += a.Delta
+	case PlayerB:
+		state.ScoreB += a.Delta
+	}
+	return state
+}
+
+func (a ScoreAction) Validate(s State) error {
+	if a.Player != s.Turn {
+		return ErrNotYourTurn
+	}
+	return nil
+}
+
+// PassTurnAction hands the turn to the other player
+type PassTurnAction struct{}
+
+func (a PassTurnAction) Apply(s State) State {
+	state := s.Copy()
+	state.Turn = 1 - state.Turn
+	return state
+}
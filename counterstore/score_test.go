@@ -0,0 +1,47 @@
+package counterstore
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestScoreOnEachTurn(t *testing.T) {
+	store := NewStore(Reduce, State{})
+
+	if err := store.Dispatch(ScoreAction{Player: PlayerA, Delta: 3}); err != nil {
+		t.Fatalf("A scoring on turn: %v", err)
+	}
+	store.Dispatch(PassTurnAction{})
+	if got := store.GetState().Turn; got != PlayerB {
+		t.Fatalf("Turn after passing = %d, want B", got)
+	}
+	if err := store.Dispatch(ScoreAction{Player: PlayerB, Delta: 5}); err != nil {
+		t.Fatalf("B scoring on turn: %v", err)
+	}
+	store.Dispatch(PassTurnAction{})
+	store.Dispatch(ScoreAction{Player: PlayerA, Delta: -1})
+
+	got := store.GetState()
+	if got.ScoreA != 2 || got.ScoreB != 5 || got.Turn != PlayerA {
+		t.Errorf("state = A %d, B %d, turn %d; want 2, 5, A", got.ScoreA, got.ScoreB, got.Turn)
+	}
+}
+
+func TestScoreOffTurnIsRejected(t *testing.T) {
+	store := NewStore(Reduce, State{ScoreA: 1, ScoreB: 1})
+	version := store.Version()
+
+	if err := store.Dispatch(ScoreAction{Player: PlayerB, Delta: 10}); !errors.Is(err, ErrNotYourTurn) {
+		t.Errorf("B scoring on A's turn: %v, want %v", err, ErrNotYourTurn)
+	}
+	if got := store.GetState(); got.ScoreA != 1 || got.ScoreB != 1 || store.Version() != version {
+		t.Errorf("an off-turn score changed the state: %+v", got)
+	}
+	// Apply on its own ignores it too
+	if got := (ScoreAction{Player: PlayerB, Delta: 10}).Apply(State{}); got.ScoreB != 0 {
+		t.Errorf("Apply off turn set ScoreB to %d", got.ScoreB)
+	}
+	if got := (ScoreAction{Player: 7, Delta: 10}).Validate(State{}); got == nil {
+		t.Error("an unknown player may score")
+	}
+}
@@ -0,0 +1,81 @@
+package counterstore
+
+import "sync"
+
+// SelectorCache memoizes named selectors over a store's state. Cached values
+// are keyed on the store's version, as MemoizeArg's are: the first Get after
+// any commit drops them all at once, so a value is only ever reused within
+// the state version it was computed from, whatever the notify mode.
+type SelectorCache[S StateProvider[S], A Action[S]] struct {
+	store *Store[S, A]
+
+	mu      sync.Mutex
+	version uint64
+	values  map[string]any
+}
+
+// NewSelectorCache creates a cache over store
+func NewSelectorCache[S StateProvider[S], A Action[S]](store *Store[S, A]) *SelectorCache[S, A] {
+	return &SelectorCache[S, A]{store: store, values: map[string]any{}}
+}
+
+// Get returns the cached value of the selector registered under name,
+// computing it from the current state on a miss. Selectors sharing a name
+// must compute the same thing.
+func Get[S StateProvider[S], A Action[S], T any](c *SelectorCache[S, A], name string, compute func(S) T) T {
+	state, v := c.store.versionedState()
+
+	c.mu.Lock()
+	if v > c.version {
+		c.version = v
+		c.values = map[string]any{}
+	}
+	if value, ok := c.values[name]; ok && v == c.version {
+		c.mu.Unlock()
+		return value.(T)
+	}
+	c.mu.Unlock()
+
+	value := compute(state)
+
+	c.mu.Lock()
+	if c.version == v {
+		c.values[name] = value
+	}
+	c.mu.Unlock()
+	return value
+}
+
+// MemoizeArg caches a selector taking an argument, such as isMultipleOf(n),
+// per argument value. Cached results are kept for one state version of
+// store: the first call after any commit recomputes and starts a fresh
+// cache.
+func MemoizeArg[K comparable, T any](store *Store[State, AppAction], compute func(State, K) T) func(K) T {
+	var mu sync.Mutex
+	var version uint64
+	cache := map[K]T{}
+
+	return func(arg K) T {
+		state, v := store.versionedState()
+
+		mu.Lock()
+		if v != version {
+			version = v
+			cache = map[K]T{}
+		}
+		if value, ok := cache[arg]; ok {
+			mu.Unlock()
+			return value
+		}
+		mu.Unlock()
+
+		value := compute(state, arg)
+
+		mu.Lock()
+		if version == v {
+			cache[arg] = value
+		}
+		mu.Unlock()
+		return value
+	}
+}
@@ -0,0 +1,127 @@
+package counterstore
+
+import "testing"
+
+func TestSelectorCacheHitsWithinVersion(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 3})
+	cache := NewSelectorCache(store)
+	computed := 0
+	double := func(s State) int {
+		computed++
+		return s.Count * 2
+	}
+
+	for i := 0; i < 3; i++ {
+		if got := Get(cache, "double", double); got != 6 {
+			t.Fatalf("Get = %d, want 6", got)
+		}
+	}
+	if computed != 1 {
+		t.Errorf("selector computed %d times within one version, want 1", computed)
+	}
+}
+
+func TestSelectorCacheInvalidatesAfterDispatch(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 3})
+	cache := NewSelectorCache(store)
+	computed := 0
+	double := func(s State) int {
+		computed++
+		return s.Count * 2
+	}
+	isEven := func(s State) bool { return s.Count%2 == 0 }
+
+	Get(cache, "double", double)
+	Get(cache, "even", isEven)
+	store.Dispatch(IncrementAction{})
+
+	if got := Get(cache, "double", double); got != 8 {
+		t.Errorf("Get after dispatch = %d, want 8", got)
+	}
+	if got := Get(cache, "even", isEven); !got {
+		t.Error("even selector kept its stale value")
+	}
+	if computed != 2 {
+		t.Errorf("selector computed %d times over two versions, want 2", computed)
+	}
+}
+
+func TestSelectorCacheFreshInEveryNotifyMode(t *testing.T) {
+	modes := []struct {
+		name string
+		opts []StoreOption[State, AppAction]
+	}{
+		{"coalesced", []StoreOption[State, AppAction]{WithNotifyMode[State, AppAction](NotifyCoalesced)}},
+		{"async subscribers", []StoreOption[State, AppAction]{WithSubscriberMode[State, AppAction](SubscriberAsync, 1)}},
+	}
+	for _, mode := range modes {
+		t.Run(mode.name, func(t *testing.T) {
+			store := NewStoreWithOptions(Reduce, State{Count: 3}, mode.opts...)
+			cache := NewSelectorCache(store)
+			count := func(s State) int { return s.Count }
+
+			Get(cache, "count", count)
+			store.Dispatch(IncrementAction{})
+			// No notification has necessarily run yet, but the version moved
+			if got := Get(cache, "count", count); got != 4 {
+				t.Errorf("Get straight after Dispatch = %d, want 4", got)
+			}
+		})
+	}
+}
+
+func TestSelectorCacheFrameCommit(t *testing.T) {
+	store := NewStoreWithOptions(Reduce, State{Count: 3}, WithNotifyMode[State, AppAction](FrameCommitMode))
+	cache := NewSelectorCache(store)
+	computed := 0
+	count := func(s State) int {
+		computed++
+		return s.Count
+	}
+
+	Get(cache, "count", count)
+	store.Dispatch(IncrementAction{})
+	store.Dispatch(IncrementAction{})
+	// Until the frame commits, selectors see the last committed state
+	if got := Get(cache, "count", count); got != 3 || computed != 1 {
+		t.Errorf("Get before the frame = %d after %d computations, want the cached 3", got, computed)
+	}
+	store.CommitFrame()
+	if got := Get(cache, "count", count); got != 5 || computed != 2 {
+		t.Errorf("Get after the frame = %d after %d computations, want 5 computed once more", got, computed)
+	}
+}
+
+func TestMemoizeArg(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 12})
+	computed := map[int]int{}
+	isMultipleOf := MemoizeArg(store, func(s State, n int) bool {
+		computed[n]++
+		return s.Count%n == 0
+	})
+
+	for i := 0; i < 3; i++ {
+		if !isMultipleOf(3) || !isMultipleOf(4) || isMultipleOf(5) {
+			t.Fatal("wrong results for 12")
+		}
+	}
+	if computed[3] != 1 || computed[4] != 1 || computed[5] != 1 {
+		t.Errorf("computed %v within one version, want once per argument", computed)
+	}
+
+	// A dispatch recomputes every argument against the new state
+	store.Dispatch(AddAmountAction{Amount: 3})
+	if isMultipleOf(3) != true || isMultipleOf(4) != false || isMultipleOf(5) != true {
+		t.Error("stale results after the dispatch")
+	}
+	if computed[3] != 2 || computed[4] != 2 || computed[5] != 2 {
+		t.Errorf("computed %v over two versions, want twice per argument", computed)
+	}
+
+	// A rejected dispatch commits nothing, so the cache survives it
+	store.Dispatch(ApplyExprAction{Expr: "x/0"})
+	isMultipleOf(3)
+	if computed[3] != 2 {
+		t.Errorf("a rejected dispatch invalidated the cache: %v", computed)
+	}
+}
@@ -0,0 +1,166 @@
+package counterstore
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidOp is wrapped by ParseSequence for an op it cannot read
+var ErrInvalidOp = errors.New("invalid op")
+
+// OpKind is the operation an Op performs on the count
+type OpKind int
+
+const (
+	// OpAdd adds N
+	OpAdd OpKind = iota
+	// OpMul multiplies by N
+	OpMul
+	// OpNeg negates; N is unused
+	OpNeg
+)
+
+// Op is one step of a SequenceAction
+type Op struct {
+	Kind OpKind
+	N    int
+}
+
+// String formats op as ParseSequence reads it
+func (op Op) String() string {
+	switch op.Kind {
+	case OpAdd:
+		if op.N < 0 {
+			return strconv.Itoa(op.N)
+		}
+		return "+" + strconv.Itoa(op.N)
+	case OpMul:
+		return "*" + strconv.Itoa(op.N)
+	case OpNeg:
+		return "neg"
+	}
+	return fmt.Sprintf("op(%d)", int(op.Kind))
+}
+
+// apply performs op on count under mode
+func (op Op) apply(count int, mode OverflowMode) (int, error) {
+	switch op.Kind {
+	case OpAdd:
+		return addCount(count, op.N, mode)
+	case OpMul:
+		return mulCount(count, op.N, mode)
+	case OpNeg:
+		return negateCount(count, mode)
+	}
+	return count, fmt.Errorf("%w: %s", ErrInvalidOp, op)
+}
+
+// SequenceAction runs Ops on the count left to right, so "double then add
+// one" needs no action type of its own. Each step follows the overflow mode;
+// in error mode a step that overflows rejects the whole sequence.
+type SequenceAction struct {
+	Ops []Op
+}
+
+func (a SequenceAction) Apply(s State) State {
+	count, err := runSequence(a.Ops, s.Count, s.Overflow)
+	if err != nil {
+		return s
+	}
+	state := s.Copy()
+	state.Count = count
+	return state
+}
+
+func (a SequenceAction) Validate(s State) error {
+	_, err := runSequence(a.Ops, s.Count, s.Overflow)
+	return err
+}
+
+func runSequence(ops []Op, count int, mode OverflowMode) (int, error) {
+	for _, op := range ops {
+		var err error
+		if count, err = op.apply(count, mode); err != nil {
+			return 0, err
+		}
+	}
+	return count, nil
+}
+
+// ParseSequence reads a comma-separated list of ops, such as "*2,+1,neg":
+// +n and -n add, *n multiplies, with n negative too, and neg negates.
+// Spaces around ops are ignored; an empty list or an op that is none of
+// these fails with ErrInvalidOp.
+func ParseSequence(text string) ([]Op, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("sequence %q: %w: no ops", text, ErrInvalidOp)
+	}
+	fields := strings.Split(text, ",")
+	ops := make([]Op, 0, len(fields))
+	for _, field := range fields {
+		op, err := parseOp(strings.TrimSpace(field))
+		if err != nil {
+			return nil, fmt.Errorf("sequence %q: %w", text, err)
+		}
+		ops = append(ops, op)
+	}
+	return ops, nil
+}
+
+func parseOp(field string) (Op, error) {
+	if field == "neg" {
+		return Op{Kind: OpNeg}, nil
+	}
+	if field == "" {
+		return Op{}, fmt.Errorf("%w: empty op", ErrInvalidOp)
+	}
+	var kind OpKind
+	switch field[0] {
+	case '+', '-':
+		kind = OpAdd
+	case '*':
+		kind = OpMul
+	default:
+		return Op{}, fmt.Errorf("%w %q", ErrInvalidOp, field)
+	}
+	number := field[1:]
+	if field[0] == '-' {
+		number = field
+	}
+	// Atoi would also take a sign of its own, as in "+-1"; only *n may be
+	// negative
+	digits := field[1:]
+	if kind == OpMul {
+		digits = strings.TrimPrefix(digits, "-")
+	}
+	if digits == "" || digits[0] < '0' || digits[0] > '9' {
+		return Op{}, fmt.Errorf("%w %q", ErrInvalidOp, field)
+	}
+	n, err := strconv.Atoi(number)
+	if err != nil {
+		return Op{}, fmt.Errorf("%w %q", ErrInvalidOp, field)
+	}
+	return Op{Kind: kind, N: n}, nil
+}
+
+// mulCount multiplies count by factor under mode, as addCount adds
+func mulCount(count, factor int, mode OverflowMode) (int, error) {
+	product := count * factor
+	overflows := count != 0 && (product/count != factor || (count == -1 && factor == math.MinInt))
+	if !overflows {
+		return product, nil
+	}
+	switch mode {
+	case OverflowSaturate:
+		if (count < 0) == (factor < 0) {
+			return math.MaxInt, nil
+		}
+		return math.MinInt, nil
+	case OverflowError:
+		return count, ErrCountOverflow
+	}
+	return product, nil
+}
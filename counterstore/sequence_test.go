@@ -0,0 +1,91 @@
+package counterstore
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestParseSequence(t *testing.T) {
+	tests := []struct {
+		text string
+		want []Op
+	}{
+		{"*2,+1,neg", []Op{{Kind: OpMul, N: 2}, {Kind: OpAdd, N: 1}, {Kind: OpNeg}}},
+		{"-3", []Op{{Kind: OpAdd, N: -3}}},
+		{" *-1 , +10 ", []Op{{Kind: OpMul, N: -1}, {Kind: OpAdd, N: 10}}},
+		{"neg,neg", []Op{{Kind: OpNeg}, {Kind: OpNeg}}},
+	}
+	for _, tt := range tests {
+		got, err := ParseSequence(tt.text)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseSequence(%q) = %v, %v; want %v", tt.text, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseSequenceRejects(t *testing.T) {
+	for _, text := range []string{"", "  ", "*2,,+1", "/2", "+", "*", "+-1", "--1", "+x", "*2.5", "Neg", "+1 0", "+99999999999999999999"} {
+		if ops, err := ParseSequence(text); !errors.Is(err, ErrInvalidOp) || ops != nil {
+			t.Errorf("ParseSequence(%q) = %v, %v; want ErrInvalidOp", text, ops, err)
+		}
+	}
+}
+
+func TestOpStringRoundTrip(t *testing.T) {
+	ops := []Op{{Kind: OpMul, N: -2}, {Kind: OpAdd, N: 4}, {Kind: OpAdd, N: -4}, {Kind: OpNeg}}
+	for _, op := range ops {
+		got, err := ParseSequence(op.String())
+		if err != nil || len(got) != 1 || got[0] != op {
+			t.Errorf("ParseSequence(%q) = %v, %v; want %v", op.String(), got, err, op)
+		}
+	}
+}
+
+func TestSequenceAction(t *testing.T) {
+	tests := []struct {
+		text  string
+		start int
+		want  int
+	}{
+		{"*2,+1", 5, 11},
+		{"+1,*2", 5, 12},
+		{"*2,+1,neg", 3, -7},
+		{"neg,-1,*3", -2, 3},
+		{"*0,+7", 1000, 7},
+	}
+	for _, tt := range tests {
+		ops, err := ParseSequence(tt.text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		store := NewStore(Reduce, State{Count: tt.start})
+		if err := store.Dispatch(SequenceAction{Ops: ops}); err != nil {
+			t.Errorf("%q from %d: %v", tt.text, tt.start, err)
+			continue
+		}
+		if got := store.GetState().Count; got != tt.want {
+			t.Errorf("%q from %d = %d, want %d", tt.text, tt.start, got, tt.want)
+		}
+	}
+}
+
+func TestSequenceActionOverflow(t *testing.T) {
+	ops := []Op{{Kind: OpAdd, N: -1}, {Kind: OpMul, N: 2}}
+	store := NewStore(Reduce, State{Count: math.MaxInt, Overflow: OverflowError})
+	if err := store.Dispatch(SequenceAction{Ops: ops}); !errors.Is(err, ErrCountOverflow) {
+		t.Errorf("overflowing sequence = %v, want ErrCountOverflow", err)
+	}
+	if got := store.GetState().Count; got != math.MaxInt {
+		t.Errorf("Count = %d, want the sequence rejected as a whole", got)
+	}
+
+	saturated := SequenceAction{Ops: ops}.Apply(State{Count: math.MaxInt, Overflow: OverflowSaturate})
+	if saturated.Count != math.MaxInt {
+		t.Errorf("saturating sequence = %d, want math.MaxInt", saturated.Count)
+	}
+	if err := (SequenceAction{Ops: []Op{{Kind: OpKind(9)}}}).Validate(State{}); !errors.Is(err, ErrInvalidOp) {
+		t.Errorf("unknown op kind = %v, want ErrInvalidOp", err)
+	}
+}
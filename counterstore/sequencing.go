@@ -0,0 +1,113 @@
+package counterstore
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+// SequencedAction is an action with the sequence number SequencingMiddleware
+// gave it on arrival
+type SequencedAction[A any] struct {
+	Seq    uint64
+	Action A
+}
+
+// Sequencer numbers the actions reaching its middleware and logs them in
+// the order it passes them on. Between Hold and Release it is a barrier:
+// actions are parked rather than passed on, and Release applies them in
+// sequence order, so a test dispatching from several goroutines gets one
+// ordering that the log, and anything recording after it, agree on.
+type Sequencer[A any] struct {
+	mu     sync.Mutex
+	seq    uint64
+	held   bool
+	parked []SequencedAction[A]
+	log    []SequencedAction[A]
+	next   Dispatch[A]
+	settle func()
+}
+
+// NewSequencer creates a sequencer passing actions straight on
+func NewSequencer[A any]() *Sequencer[A] {
+	return &Sequencer[A]{}
+}
+
+// Hold raises the barrier: from now until Release, actions are parked
+func (q *Sequencer[A]) Hold() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.held = true
+}
+
+// Release lowers the barrier and passes the parked actions on in sequence
+// order, returning their errors joined. Actions they dispatch in turn pass
+// straight through.
+func (q *Sequencer[A]) Release() error {
+	q.mu.Lock()
+	parked, next, settle := q.parked, q.next, q.settle
+	q.held = false
+	q.parked = nil
+	q.mu.Unlock()
+
+	sort.Slice(parked, func(i, j int) bool { return parked[i].Seq < parked[j].Seq })
+	var errs []error
+	for _, sequenced := range parked {
+		if err := q.forward(next, sequenced); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if settle != nil {
+		settle()
+	}
+	return errors.Join(errs...)
+}
+
+// Pending returns the number of actions parked at the barrier
+func (q *Sequencer[A]) Pending() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.parked)
+}
+
+// Log returns a copy of the actions passed on so far, in the order they were
+func (q *Sequencer[A]) Log() []SequencedAction[A] {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return append([]SequencedAction[A](nil), q.log...)
+}
+
+func (q *Sequencer[A]) forward(next Dispatch[A], sequenced SequencedAction[A]) error {
+	q.mu.Lock()
+	q.log = append(q.log, sequenced)
+	q.mu.Unlock()
+	return next(sequenced.Action)
+}
+
+// Sequencing Middleware gives each action the next number of q's sequence
+// and passes it on, or parks it while q holds its barrier. Outside a
+// barrier, goroutines dispatching at once may still reach the reducer in a
+// different order than their numbers; behind the barrier they reach it in
+// exactly that order. Install one sequencer per store.
+func SequencingMiddleware[S StateProvider[S], A Action[S]](q *Sequencer[A]) Middleware[S, A] {
+	return func(store *Store[S, A], next Dispatch[A]) Dispatch[A] {
+		q.mu.Lock()
+		q.next = next
+		q.settle = store.settle
+		q.mu.Unlock()
+
+		return func(action A) error {
+			q.mu.Lock()
+			q.seq++
+			sequenced := SequencedAction[A]{Seq: q.seq, Action: action}
+			if q.held {
+				q.parked = append(q.parked, sequenced)
+				q.mu.Unlock()
+				store.ReportBlocked("held at sequencer barrier")
+				return nil
+			}
+			q.mu.Unlock()
+			return q.forward(next, sequenced)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package counterstore
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// waitPending waits until n actions are parked at q's barrier
+func waitPending(t *testing.T, q *Sequencer[AppAction], n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for q.Pending() < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d actions parked, want %d", q.Pending(), n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestSequencingMiddlewareBarrier(t *testing.T) {
+	q := NewSequencer[AppAction]()
+	rec := NewRecorder(NewFakeClock(epoch))
+	store := NewStore(Reduce, State{}, SequencingMiddleware[State, AppAction](q), RecorderMiddleware(rec))
+	notified := 0
+	store.Subscribe(func() { notified++ })
+
+	q.Hold()
+	var wg sync.WaitGroup
+	const goroutines = 8
+	for i := 1; i <= goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			store.Dispatch(SetCountAction{Value: i})
+		}()
+	}
+	wg.Wait()
+	waitPending(t, q, goroutines)
+	if got := store.GetState().Count; got != 0 || notified != 0 {
+		t.Fatalf("Count %d with %d notifications behind the barrier, want nothing applied", got, notified)
+	}
+
+	if err := q.Release(); err != nil {
+		t.Fatal(err)
+	}
+	log := q.Log()
+	recorded := rec.Actions()
+	if len(log) != goroutines || len(recorded) != goroutines {
+		t.Fatalf("logged %d and recorded %d actions, want %d", len(log), len(recorded), goroutines)
+	}
+	// The recorder agrees with the sequence, whatever order the goroutines
+	// happened to arrive in
+	for i, sequenced := range log {
+		if sequenced.Seq != uint64(i+1) {
+			t.Errorf("log %d has seq %d, want %d", i, sequenced.Seq, i+1)
+		}
+		if recorded[i].Action != sequenced.Action {
+			t.Errorf("recorded %d = %v, want %v", i, recorded[i].Action, sequenced.Action)
+		}
+	}
+	last := log[len(log)-1].Action.(SetCountAction)
+	if got := store.GetState().Count; got != last.Value {
+		t.Errorf("final Count = %d, want the highest sequence's %d", got, last.Value)
+	}
+	if notified != 1 {
+		t.Errorf("%d notifications after the release, want 1", notified)
+	}
+}
+
+func TestSequencingMiddlewareDeterministicOrder(t *testing.T) {
+	// Arrivals paced one at a time get the same numbers in every run
+	run := func() []AppAction {
+		q := NewSequencer[AppAction]()
+		rec := NewRecorder(NewFakeClock(epoch))
+		store := NewStore(Reduce, State{}, SequencingMiddleware[State, AppAction](q), RecorderMiddleware(rec))
+		q.Hold()
+		actions := []AppAction{AddAmountAction{Amount: 3}, SequenceAction{Ops: []Op{{Kind: OpMul, N: 2}}}, NegateAction{}, IncrementAction{}}
+		for i, action := range actions {
+			go store.Dispatch(action)
+			waitPending(t, q, i+1)
+		}
+		if err := q.Release(); err != nil {
+			t.Fatal(err)
+		}
+		if got := store.GetState().Count; got != -5 {
+			t.Errorf("final Count = %d, want ((0+3)*2) negated plus one = -5", got)
+		}
+		var order []AppAction
+		for _, r := range rec.Actions() {
+			order = append(order, r.Action)
+		}
+		return order
+	}
+	if first, second := run(), run(); !reflect.DeepEqual(first, second) {
+		t.Errorf("runs ordered differently:\n%v\n%v", first, second)
+	}
+}
+
+func TestSequencingMiddlewarePassesThrough(t *testing.T) {
+	q := NewSequencer[AppAction]()
+	store := NewStore(Reduce, State{}, SequencingMiddleware[State, AppAction](q))
+	store.Dispatch(IncrementAction{})
+	store.Dispatch(IncrementAction{})
+	if got := store.GetState().Count; got != 2 || q.Pending() != 0 || len(q.Log()) != 2 {
+		t.Errorf("Count %d, %d pending, %d logged; want 2, 0, 2", got, q.Pending(), len(q.Log()))
+	}
+
+	q.Hold()
+	result := store.DispatchWithResult(IncrementAction{})
+	if result.Applied || result.Reason != "held at sequencer barrier" {
+		t.Errorf("result behind the barrier = %+v", result)
+	}
+	q.Release()
+	if got := store.GetState().Count; got != 3 {
+		t.Errorf("Count after release = %d, want 3", got)
+	}
+}
@@ -0,0 +1,145 @@
+package counterstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// sessionVersion is the version of the session file format SaveSession writes
+const sessionVersion = 1
+
+// Session is a captured debugging session: the state it started from, every
+// recorded dispatch and the dispatch timeline. It stands alone, so a saved
+// session can be reopened for analysis with no live app.
+type Session struct {
+	Initial  State
+	Seed     int64
+	Actions  []RecordedAction
+	Timeline []TimelineEntry
+}
+
+// CaptureSession snapshots rec and timeline, either of which may be nil, as a
+// session that started from initial
+func CaptureSession(initial State, rec *Recorder, timeline *Timeline) *Session {
+	session := &Session{Initial: initial.Copy()}
+	if rec != nil {
+		session.Seed = rec.Seed()
+		session.Actions = rec.Actions()
+	}
+	if timeline != nil {
+		session.Timeline = timeline.Entries()
+	}
+	return session
+}
+
+// sessionEnvelope is the versioned file form of a session
+type sessionEnvelope struct {
+	Version  int             `json:"version"`
+	Initial  State           `json:"initial"`
+	Seed     int64           `json:"seed"`
+	Actions  []sessionAction `json:"actions"`
+	Timeline []TimelineEntry `json:"timeline"`
+}
+
+// sessionAction is a RecordedAction on file. Action is omitted for actions
+// the codec cannot encode, such as thunks; Type still names them.
+type sessionAction struct {
+	Seq    int             `json:"seq"`
+	At     time.Time       `json:"at"`
+	Type   string          `json:"type"`
+	Action json.RawMessage `json:"action,omitempty"`
+	Prev   State           `json:"prev"`
+	Next   State           `json:"next"`
+	Depth  int             `json:"depth"`
+}
+
+// SaveSession writes the session to w as a single versioned JSON document
+func (s *Session) SaveSession(w io.Writer) error {
+	env := sessionEnvelope{
+		Version:  sessionVersion,
+		Initial:  s.Initial,
+		Seed:     s.Seed,
+		Actions:  make([]sessionAction, 0, len(s.Actions)),
+		Timeline: s.Timeline,
+	}
+	for _, rec := range s.Actions {
+		encoded, err := EncodeAction(rec.Action)
+		if err != nil {
+			encoded = nil
+		}
+		env.Actions = append(env.Actions, sessionAction{
+			Seq:    rec.Seq,
+			At:     rec.At,
+			Type:   ActionName(rec.Action),
+			Action: encoded,
+			Prev:   rec.Prev,
+			Next:   rec.Next,
+			Depth:  rec.Depth,
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(env)
+}
+
+// LoadSession reads a session written by SaveSession. Actions that were
+// saved without their encoding come back with a nil Action.
+func LoadSession(r io.Reader) (*Session, error) {
+	var env sessionEnvelope
+	if err := json.NewDecoder(r).Decode(&env); err != nil {
+		return nil, fmt.Errorf("load session: %w", err)
+	}
+	if env.Version != sessionVersion {
+		return nil, fmt.Errorf("load session: unsupported version %d", env.Version)
+	}
+
+	session := &Session{
+		Initial:  env.Initial,
+		Seed:     env.Seed,
+		Actions:  make([]RecordedAction, 0, len(env.Actions)),
+		Timeline: env.Timeline,
+	}
+	for _, sa := range env.Actions {
+		var action AppAction
+		if len(sa.Action) > 0 {
+			decoded, err := DecodeAction(sa.Action)
+			if err != nil {
+				return nil, fmt.Errorf("load session: action %d: %w", sa.Seq, err)
+			}
+			action = decoded
+		}
+		session.Actions = append(session.Actions, RecordedAction{
+			Seq:    sa.Seq,
+			At:     sa.At,
+			Action: action,
+			Prev:   sa.Prev,
+			Next:   sa.Next,
+			Depth:  sa.Depth,
+		})
+	}
+	return session, nil
+}
+
+// Store rebuilds the session's store by dispatching its recorded actions
+// in order, from the initial state and with the recorded seed. Actions
+// without an encoding are skipped: these are thunks, whose own effect is the
+// nested actions recorded alongside them.
+func (s *Session) Store(reducer Reducer[State, AppAction]) (*Store[State, AppAction], error) {
+	store := NewStoreWithOptions(reducer, s.Initial.Copy(), WithRandSeed[State, AppAction](s.Seed))
+	for _, rec := range s.Actions {
+		if rec.Action == nil {
+			continue
+		}
+		if err := store.Dispatch(rec.Action); err != nil {
+			return nil, fmt.Errorf("rebuild session: action %d: %w", rec.Seq, err)
+		}
+	}
+	return store, nil
+}
+
+// Playback returns a playback of the session for time travel
+func (s *Session) Playback() *Playback {
+	return NewPlayback(s.Actions)
+}
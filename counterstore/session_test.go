@@ -0,0 +1,92 @@
+package counterstore
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSessionRoundTrip(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	rec := NewRecorder(clock)
+	timeline := NewTimeline(clock)
+	initial := State{Count: 3, Target: 10}
+	live := NewStoreWithOptions(Reduce, initial,
+		WithRandSeed[State, AppAction](7),
+		WithMiddleware(TimelineMiddleware[State, AppAction](timeline), RecorderMiddleware(rec), ThunkMiddleware[State, AppAction]),
+	)
+	live.Dispatch(IncrementAction{})
+	clock.Advance(time.Second)
+	live.Dispatch(Thunk[State, AppAction]{Run: func(dispatch Dispatch[AppAction], getState func() State) {
+		dispatch(AddAmountAction{Amount: 4})
+		dispatch(NegateAction{})
+	}})
+	clock.Advance(250 * time.Millisecond)
+	live.Dispatch(SetTargetAction{Target: 2})
+
+	var buf bytes.Buffer
+	if err := CaptureSession(initial, rec, timeline).SaveSession(&buf); err != nil {
+		t.Fatalf("SaveSession: %v", err)
+	}
+	loaded, err := LoadSession(&buf)
+	if err != nil {
+		t.Fatalf("LoadSession: %v", err)
+	}
+
+	if loaded.Initial.Count != 3 || loaded.Seed != 7 {
+		t.Errorf("loaded Initial %+v, Seed %d", loaded.Initial, loaded.Seed)
+	}
+	want := rec.Actions()
+	if len(loaded.Actions) != len(want) {
+		t.Fatalf("loaded %d actions, recorded %d", len(loaded.Actions), len(want))
+	}
+	for i, got := range loaded.Actions {
+		w := want[i]
+		if _, thunk := w.Action.(Thunk[State, AppAction]); thunk {
+			if got.Action != nil {
+				t.Errorf("action %d: thunk came back as %v", i, got.Action)
+			}
+		} else if got.Action != w.Action {
+			t.Errorf("action %d = %v, want %v", i, got.Action, w.Action)
+		}
+		if got.Seq != w.Seq || !got.At.Equal(w.At) || got.Depth != w.Depth || got.Next.Count != w.Next.Count {
+			t.Errorf("action %d = %+v, want %+v", i, got, w)
+		}
+	}
+	if got := len(loaded.Timeline); got != len(timeline.Entries()) {
+		t.Errorf("loaded %d timeline entries, want %d", got, len(timeline.Entries()))
+	}
+
+	// The session rebuilds the live store with no app attached
+	rebuilt, err := loaded.Store(Reduce)
+	if err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+	if got, want := rebuilt.GetState(), live.GetState(); got.Hash() != want.Hash() {
+		t.Errorf("rebuilt %+v, live %+v", got, want)
+	}
+
+	// Time travel over the loaded session reaches every recorded state
+	p := loaded.Playback()
+	if got := p.StateAt(0).Count; got != 3 {
+		t.Errorf("playback start Count = %d, want 3", got)
+	}
+	if got := p.StateAt(p.Len()).Count; got != live.GetState().Count {
+		t.Errorf("playback end Count = %d, want %d", got, live.GetState().Count)
+	}
+	rebuilt.JumpTo(p.StateAt(1))
+	if got := rebuilt.GetState().Count; got != 4 {
+		t.Errorf("JumpTo step 1 Count = %d, want 4", got)
+	}
+}
+
+func TestLoadSessionRejectsUnknownVersion(t *testing.T) {
+	_, err := LoadSession(strings.NewReader(`{"version":99}`))
+	if err == nil || !strings.Contains(err.Error(), "unsupported version 99") {
+		t.Errorf("LoadSession error = %v, want an unsupported version", err)
+	}
+	if _, err := LoadSession(strings.NewReader(`{"version":`)); err == nil {
+		t.Error("LoadSession accepted truncated JSON")
+	}
+}
@@ -0,0 +1,63 @@
+package counterstore
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ShortCircuitMiddleware is middleware that may answer an action itself.
+// For each action it must use exactly one of next (continue down the chain
+// to the reducer) or setState (commit a result and stop the chain); calling
+// both would apply the action twice, calling neither drops it.
+type ShortCircuitMiddleware[S StateProvider[S], A Action[S]] func(store *Store[S, A], next Dispatch[A], setState func(S)) Dispatch[A]
+
+// ShortCircuit adapts mw to a regular Middleware, handing it a setState that
+// commits like the reducer would: recorded in history, then subscribers notified
+func ShortCircuit[S StateProvider[S], A Action[S]](mw ShortCircuitMiddleware[S, A]) Middleware[S, A] {
+	return func(store *Store[S, A], next Dispatch[A]) Dispatch[A] {
+		return mw(store, next, store.commit)
+	}
+}
+
+// commit replaces the state as if a reducer had produced it
+func (s *Store[S, A]) commit(state S) {
+	s.mu.Lock()
+	if prev, ok := s.historySnapshot(); ok {
+		s.pushHistory(prev)
+	}
+	s.setState(state)
+	s.mu.Unlock()
+
+	s.changed()
+}
+
+// Cache Middleware remembers the state each (state, action) pair produced and
+// on a repeat commits it via setState without running the reducer. Entries
+// are never evicted, so it is meant for demos with a small state space.
+func CacheMiddleware[S StateProvider[S], A Action[S]]() Middleware[S, A] {
+	return ShortCircuit(func(store *Store[S, A], next Dispatch[A], setState func(S)) Dispatch[A] {
+		var mu sync.Mutex
+		cache := map[string]S{}
+
+		return func(action A) error {
+			key := fmt.Sprintf("%#v|%#v", store.GetState(), action)
+
+			mu.Lock()
+			cached, ok := cache[key]
+			mu.Unlock()
+			if ok {
+				setState(cached.Copy())
+				return nil
+			}
+
+			if err := next(action); err != nil {
+				return err
+			}
+
+			mu.Lock()
+			cache[key] = store.GetState()
+			mu.Unlock()
+			return nil
+		}
+	})
+}
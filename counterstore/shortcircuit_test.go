@@ -0,0 +1,66 @@
+package counterstore
+
+import "testing"
+
+func TestCacheMiddlewareShortCircuits(t *testing.T) {
+	reduced := 0
+	reducer := func(state State, action AppAction) State {
+		reduced++
+		return Reduce(state, action)
+	}
+	store := NewStore(reducer, State{}, CacheMiddleware[State, AppAction]())
+	notifications := 0
+	store.Subscribe(func() { notifications++ })
+	reduced = 0
+
+	store.Dispatch(SetCountAction{Value: 5})
+	store.Dispatch(SetCountAction{Value: 0})
+	if reduced != 2 {
+		t.Fatalf("reducer ran %d times for two new actions, want 2", reduced)
+	}
+
+	// The same action on the same state again is answered from the cache
+	store.Dispatch(SetCountAction{Value: 5})
+	if reduced != 2 {
+		t.Errorf("reducer ran on a cache hit (%d runs)", reduced)
+	}
+	if got := store.GetState().Count; got != 5 {
+		t.Errorf("Count after cache hit = %d, want 5", got)
+	}
+	if notifications != 3 {
+		t.Errorf("%d notifications, want one per dispatch (3)", notifications)
+	}
+
+	// A short-circuited result is an undo step like any other
+	if !store.Undo() || store.GetState().Count != 0 {
+		t.Errorf("Undo of the cached result left Count = %d, want 0", store.GetState().Count)
+	}
+}
+
+func TestShortCircuitSetState(t *testing.T) {
+	// answer commits a fixed state for SetTargetAction and passes on the rest
+	answer := ShortCircuit(func(store *Store[State, AppAction], next Dispatch[AppAction], setState func(State)) Dispatch[AppAction] {
+		return func(action AppAction) error {
+			if _, ok := action.(SetTargetAction); ok {
+				setState(State{Count: 99})
+				return nil
+			}
+			return next(action)
+		}
+	})
+	reduced := 0
+	store := NewStore(func(state State, action AppAction) State {
+		reduced++
+		return Reduce(state, action)
+	}, State{}, answer)
+	reduced = 0
+
+	store.Dispatch(SetTargetAction{Target: 3})
+	if reduced != 0 || store.GetState().Count != 99 {
+		t.Errorf("short-circuit: reducer ran %d times, Count = %d", reduced, store.GetState().Count)
+	}
+	store.Dispatch(IncrementAction{})
+	if reduced != 1 || store.GetState().Count != 100 {
+		t.Errorf("pass-through: reducer ran %d times, Count = %d", reduced, store.GetState().Count)
+	}
+}
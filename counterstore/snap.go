@@ -0,0 +1,46 @@
+package counterstore
+
+import (
+	"slices"
+	"sort"
+)
+
+// SnapReducer runs inner and then snaps the count to the nearest of allowed,
+// so after any action the count is one of them. A count halfway between two
+// allowed values goes to the lower one, and counts beyond either end go to
+// that end. With no allowed values it is inner unchanged.
+func SnapReducer(allowed []int, inner Reducer[State, AppAction]) Reducer[State, AppAction] {
+	if len(allowed) == 0 {
+		return inner
+	}
+	allowed = slices.Clone(allowed)
+	slices.Sort(allowed)
+	return func(state State, action AppAction) State {
+		next := inner(state, action)
+		snapped := snapCount(next.Count, allowed)
+		if snapped == next.Count {
+			return next
+		}
+		next = next.Copy()
+		next.Count = snapped
+		return next
+	}
+}
+
+// snapCount returns the value of sorted, which is not empty, nearest to
+// count, preferring the lower of two equally near
+func snapCount(count int, sorted []int) int {
+	i := sort.SearchInts(sorted, count)
+	switch {
+	case i == len(sorted):
+		return sorted[i-1]
+	case sorted[i] == count || i == 0:
+		return sorted[i]
+	}
+	lo, hi := sorted[i-1], sorted[i]
+	// Unsigned differences cannot overflow: both are non-negative
+	if uint64(count)-uint64(lo) <= uint64(hi)-uint64(count) {
+		return lo
+	}
+	return hi
+}
@@ -0,0 +1,62 @@
+package counterstore
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSnapReducer(t *testing.T) {
+	// Given out of order, to check SnapReducer sorts its own copy
+	allowed := []int{10, 0, 25, 5}
+	reducer := SnapReducer(allowed, Reduce)
+	tests := []struct {
+		name  string
+		count int
+		want  int
+	}{
+		{"exact", 5, 5},
+		{"exact end", 25, 25},
+		{"nearer the lower", 6, 5},
+		{"nearer the upper", 9, 10},
+		{"tie goes lower", 15, 10},
+		{"tie at the bottom", 2, 0},
+		{"wide gap", 18, 25},
+		{"below range", -40, 0},
+		{"above range", 1000, 25},
+		{"extreme low", math.MinInt, 0},
+		{"extreme high", math.MaxInt, 25},
+	}
+	for _, tt := range tests {
+		got := reducer(State{}, SetCountAction{Value: tt.count})
+		if got.Count != tt.want {
+			t.Errorf("%s: count %d snapped to %d, want %d", tt.name, tt.count, got.Count, tt.want)
+		}
+	}
+	if allowed[0] != 10 {
+		t.Errorf("SnapReducer reordered the caller's slice: %v", allowed)
+	}
+}
+
+func TestSnapReducerInStore(t *testing.T) {
+	store := NewStore(SnapReducer([]int{0, 5, 10, 25}, Reduce), State{Count: 5})
+	store.Dispatch(IncrementAction{})
+	if got := store.GetState().Count; got != 5 {
+		t.Errorf("increment from 5 = %d, want it snapped back to 5", got)
+	}
+	store.Dispatch(AddAmountAction{Amount: 4})
+	if got := store.GetState().Count; got != 10 {
+		t.Errorf("adding 4 to 5 = %d, want 10", got)
+	}
+	// Fields other than the count are left to inner
+	store.Dispatch(SetTargetAction{Target: 7})
+	if got := store.GetState(); got.Target != 7 || got.Count != 10 {
+		t.Errorf("state = %+v, want Target 7 and Count 10", got)
+	}
+}
+
+func TestSnapReducerWithoutValues(t *testing.T) {
+	reducer := SnapReducer(nil, Reduce)
+	if got := reducer(State{}, SetCountAction{Value: 13}).Count; got != 13 {
+		t.Errorf("Count = %d with nothing allowed, want inner's 13", got)
+	}
+}
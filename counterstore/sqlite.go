@@ -0,0 +1,116 @@
+package counterstore
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// sqliteQueueSize is the number of rows SQLiteMiddleware buffers for its
+// writer before it starts dropping them
+const sqliteQueueSize = 256
+
+var errSQLiteBehind = errors.New("writer is behind")
+
+var sqliteTableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// sqliteRow is one applied action waiting to be written
+type sqliteRow struct {
+	seq     int
+	at      time.Time
+	action  string
+	payload []byte
+	count   int
+}
+
+// SQLite Middleware inserts a row per applied action into table, creating it
+// if absent, with columns seq, ts (RFC 3339), action_type, payload (the
+// codec's JSON, NULL for unencodable actions such as thunks) and count (the
+// resulting count). db may be any database/sql handle with SQLite's dialect;
+// the driver is the caller's choice.
+//
+// Rows are written in order by a goroutine of its own through a prepared
+// statement, so dispatch never waits on the database. Should the writer
+// fall sqliteQueueSize rows behind, further rows are dropped. Setup, write
+// and dropped-row errors go to onError, or the standard logger when it is
+// nil.
+func SQLiteMiddleware(db *sql.DB, table string, onError func(error)) Middleware[State, AppAction] {
+	if onError == nil {
+		onError = func(err error) { log.Printf("sqlite: %v", err) }
+	}
+	return func(store *Store[State, AppAction], next Dispatch[AppAction]) Dispatch[AppAction] {
+		stmt, seq, err := prepareSQLiteTable(db, table)
+		if err != nil {
+			onError(err)
+			return next
+		}
+
+		rows := make(chan sqliteRow, sqliteQueueSize)
+		go func() {
+			for row := range rows {
+				var payload any
+				if row.payload != nil {
+					payload = string(row.payload)
+				}
+				if _, err := stmt.Exec(row.seq, row.at.Format(time.RFC3339Nano), row.action, payload, row.count); err != nil {
+					onError(fmt.Errorf("insert row %d: %w", row.seq, err))
+				}
+			}
+		}()
+
+		var mu sync.Mutex
+		return func(action AppAction) error {
+			if err := next(action); err != nil {
+				return err
+			}
+			payload, err := EncodeAction(action)
+			if err != nil {
+				payload = nil
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			seq++
+			row := sqliteRow{seq: seq, at: time.Now(), action: ActionName(action), payload: payload, count: store.GetState().Count}
+			select {
+			case rows <- row:
+			default:
+				onError(fmt.Errorf("row %d dropped: %w", seq, errSQLiteBehind))
+			}
+			return nil
+		}
+	}
+}
+
+// prepareSQLiteTable creates table if needed and prepares its insert. It
+// returns the highest seq already in the table, so a restarted app carries
+// on numbering after it.
+func prepareSQLiteTable(db *sql.DB, table string) (*sql.Stmt, int, error) {
+	if !sqliteTableName.MatchString(table) {
+		return nil, 0, fmt.Errorf("invalid table name %q", table)
+	}
+	create := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	seq INTEGER PRIMARY KEY,
+	ts TEXT NOT NULL,
+	action_type TEXT NOT NULL,
+	payload TEXT,
+	count INTEGER NOT NULL
+)`, table)
+	if _, err := db.Exec(create); err != nil {
+		return nil, 0, fmt.Errorf("create table %s: %w", table, err)
+	}
+
+	var last sql.NullInt64
+	if err := db.QueryRow(fmt.Sprintf(`SELECT MAX(seq) FROM %s`, table)).Scan(&last); err != nil {
+		return nil, 0, fmt.Errorf("read last seq of %s: %w", table, err)
+	}
+	stmt, err := db.Prepare(fmt.Sprintf(`INSERT INTO %s (seq, ts, action_type, payload, count) VALUES (?, ?, ?, ?, ?)`, table))
+	if err != nil {
+		return nil, 0, fmt.Errorf("prepare insert into %s: %w", table, err)
+	}
+	return stmt, int(last.Int64), nil
+}
@@ -0,0 +1,126 @@
+package counterstore
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+type sqliteTestRow struct {
+	seq     int
+	ts      string
+	action  string
+	payload sql.NullString
+	count   int
+}
+
+// waitForRows polls table until it holds n rows, as the middleware writes
+// them from a goroutine of its own
+func waitForRows(t *testing.T, db *sql.DB, table string, n int) []sqliteTestRow {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		rows, err := db.Query(`SELECT seq, ts, action_type, payload, count FROM ` + table + ` ORDER BY seq`)
+		if err != nil {
+			t.Fatalf("query: %v", err)
+		}
+		var got []sqliteTestRow
+		for rows.Next() {
+			var r sqliteTestRow
+			if err := rows.Scan(&r.seq, &r.ts, &r.action, &r.payload, &r.count); err != nil {
+				t.Fatalf("scan: %v", err)
+			}
+			got = append(got, r)
+		}
+		rows.Close()
+		if len(got) >= n || time.Now().After(deadline) {
+			return got
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Every connection to :memory: is a database of its own
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestSQLiteMiddleware(t *testing.T) {
+	db := openTestDB(t)
+	onError := func(err error) { t.Errorf("sqlite: %v", err) }
+	store := NewStore(Reduce, State{Flags: map[string]bool{FlagNoNegatives: true}},
+		SQLiteMiddleware(db, "actions", onError), ThunkMiddleware[State, AppAction])
+
+	store.Dispatch(IncrementAction{})
+	store.Dispatch(DecrementAction{})
+	store.Dispatch(DecrementAction{}) // rejected, so not recorded
+	store.Dispatch(AddAmountAction{Amount: 5})
+	store.Dispatch(Thunk[State, AppAction]{Run: func(dispatch Dispatch[AppAction], getState func() State) {}})
+
+	rows := waitForRows(t, db, "actions", 4)
+	want := []struct {
+		action  string
+		payload string
+		count   int
+	}{
+		{"Increment", `{"type":"Increment","payload":{}}`, 1},
+		{"Decrement", `{"type":"Decrement","payload":{}}`, 0},
+		{"AddAmount", `{"type":"AddAmount","payload":{"Amount":5}}`, 5},
+	}
+	if len(rows) != len(want)+1 {
+		t.Fatalf("table has %d rows, want %d: %+v", len(rows), len(want)+1, rows)
+	}
+	for i, w := range want {
+		r := rows[i]
+		if r.seq != i+1 || r.action != w.action || r.payload.String != w.payload || r.count != w.count {
+			t.Errorf("row %d = %+v, want seq %d %s %s count %d", i, r, i+1, w.action, w.payload, w.count)
+		}
+		if _, err := time.Parse(time.RFC3339Nano, r.ts); err != nil {
+			t.Errorf("row %d timestamp %q: %v", i, r.ts, err)
+		}
+	}
+	if thunk := rows[3]; thunk.payload.Valid || thunk.count != 5 {
+		t.Errorf("thunk row = %+v, want a NULL payload", thunk)
+	}
+}
+
+func TestSQLiteMiddlewareContinuesNumbering(t *testing.T) {
+	db := openTestDB(t)
+	first := NewStore(Reduce, State{}, SQLiteMiddleware(db, "actions", nil))
+	first.Dispatch(IncrementAction{})
+	first.Dispatch(IncrementAction{})
+	waitForRows(t, db, "actions", 2)
+
+	second := NewStore(Reduce, State{}, SQLiteMiddleware(db, "actions", nil))
+	second.Dispatch(DecrementAction{})
+	rows := waitForRows(t, db, "actions", 3)
+	if len(rows) != 3 || rows[2].seq != 3 || rows[2].action != "Decrement" {
+		t.Errorf("rows after reopening = %+v, want the decrement as seq 3", rows)
+	}
+}
+
+func TestSQLiteMiddlewareInvalidTable(t *testing.T) {
+	db := openTestDB(t)
+	var got error
+	store := NewStore(Reduce, State{}, SQLiteMiddleware(db, "actions; DROP TABLE x", func(err error) { got = err }))
+	if got == nil {
+		t.Fatal("an invalid table name was not reported")
+	}
+	// The store still dispatches without the middleware
+	if err := store.Dispatch(IncrementAction{}); err != nil || store.GetState().Count != 1 {
+		t.Errorf("Dispatch = %v, Count %d", err, store.GetState().Count)
+	}
+	if errors.Is(got, errSQLiteBehind) {
+		t.Errorf("error = %v", got)
+	}
+}
@@ -0,0 +1,55 @@
+package counterstore
+
+import "time"
+
+// StackConfig selects the middleware BuildStack assembles. Zero values turn
+// the corresponding middleware off.
+type StackConfig struct {
+	// Logging logs every action with the state before and after it
+	Logging bool
+	// Profiler, when set, receives dispatch timings
+	Profiler *Profiler
+	// RateLimit is the number of actions admitted per second, with bursts
+	// of up to RateBurst
+	RateLimit float64
+	RateBurst int
+	// RateCost weighs actions against the rate limit; nil charges each one 1
+	RateCost func(action any) int
+	// Debounce holds back actions until no action of the same type has
+	// arrived for this long
+	Debounce time.Duration
+	// Throttle forwards at most one action of each type per interval
+	Throttle time.Duration
+	// Clock drives the timer-based middleware; nil means the wall clock
+	Clock Clock
+}
+
+// BuildStack assembles the configured middleware, outermost first:
+//
+//  1. logging, so every dispatch attempt is logged, even dropped ones
+//  2. rate limit, so excess actions are dropped before any further work
+//  3. throttle, then debounce, so only the surviving action of a burst goes on
+//  4. profiler, innermost, so it times just the reducer
+func BuildStack[S StateProvider[S], A Action[S]](cfg StackConfig) []Middleware[S, A] {
+	var stack []Middleware[S, A]
+	if cfg.Logging {
+		stack = append(stack, LoggingMiddleware[S, A])
+	}
+	if cfg.RateLimit > 0 {
+		var cost func(A) int
+		if cfg.RateCost != nil {
+			cost = func(action A) int { return cfg.RateCost(action) }
+		}
+		stack = append(stack, CostRateLimitMiddleware[S, A](cfg.Clock, cfg.RateLimit, cfg.RateBurst, cost))
+	}
+	if cfg.Throttle > 0 {
+		stack = append(stack, ThrottleMiddleware[S, A](cfg.Clock, cfg.Throttle))
+	}
+	if cfg.Debounce > 0 {
+		stack = append(stack, DebounceMiddleware[S, A](cfg.Clock, cfg.Debounce))
+	}
+	if cfg.Profiler != nil {
+		stack = append(stack, ProfilerMiddleware[S, A](cfg.Profiler))
+	}
+	return stack
+}
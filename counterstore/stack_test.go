@@ -0,0 +1,79 @@
+package counterstore
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// middlewareKinds names each middleware in stack by comparing its code
+// pointer with a reference instance of every middleware BuildStack uses.
+// LoggingMiddleware is a plain generic function, whose value depends on where
+// it is instantiated, so its reference comes from BuildStack itself.
+func middlewareKinds(stack []Middleware[State, AppAction]) []string {
+	logging := BuildStack[State, AppAction](StackConfig{Logging: true})[0]
+	references := map[uintptr]string{
+		reflect.ValueOf(logging).Pointer():                                                   "logging",
+		reflect.ValueOf(CostRateLimitMiddleware[State, AppAction](nil, 1, 1, nil)).Pointer(): "ratelimit",
+		reflect.ValueOf(ThrottleMiddleware[State, AppAction](nil, time.Second)).Pointer():    "throttle",
+		reflect.ValueOf(DebounceMiddleware[State, AppAction](nil, time.Second)).Pointer():    "debounce",
+		reflect.ValueOf(ProfilerMiddleware[State, AppAction](NewProfiler(nil))).Pointer():    "profiler",
+	}
+	kinds := make([]string, 0, len(stack))
+	for _, mw := range stack {
+		kind, ok := references[reflect.ValueOf(mw).Pointer()]
+		if !ok {
+			kind = "unknown"
+		}
+		kinds = append(kinds, kind)
+	}
+	return kinds
+}
+
+func TestBuildStack(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  StackConfig
+		want []string
+	}{
+		{"empty", StackConfig{}, []string{}},
+		{"logging only", StackConfig{Logging: true}, []string{"logging"}},
+		{"profiler only", StackConfig{Profiler: NewProfiler(nil)}, []string{"profiler"}},
+		{
+			"everything",
+			StackConfig{
+				Logging:   true,
+				Profiler:  NewProfiler(nil),
+				RateLimit: 10,
+				RateBurst: 2,
+				Debounce:  time.Millisecond,
+				Throttle:  time.Millisecond,
+			},
+			[]string{"logging", "ratelimit", "throttle", "debounce", "profiler"},
+		},
+		{"rate limit and debounce", StackConfig{RateLimit: 5, Debounce: time.Second}, []string{"ratelimit", "debounce"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := middlewareKinds(BuildStack[State, AppAction](tt.cfg)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("stack = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildStackRateLimitDrops(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	store := NewStore(Reduce, State{}, BuildStack[State, AppAction](StackConfig{RateLimit: 1, RateBurst: 2, Clock: clock})...)
+	for i := 0; i < 5; i++ {
+		store.Dispatch(IncrementAction{})
+	}
+	if got := store.GetState().Count; got != 2 {
+		t.Errorf("Count = %d, want the burst of 2", got)
+	}
+	clock.Advance(time.Second)
+	store.Dispatch(IncrementAction{})
+	if got := store.GetState().Count; got != 3 {
+		t.Errorf("Count after a refill = %d, want 3", got)
+	}
+}
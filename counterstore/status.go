@@ -0,0 +1,46 @@
+package counterstore
+
+const (
+	StatusReset         = "Reset performed"
+	StatusTargetReached = "Reached target"
+)
+
+// SetStatusAction puts Text on the status line, until the next action that
+// changes the counter clears it
+type SetStatusAction struct {
+	Text string
+}
+
+func (a SetStatusAction) Undoable() bool { return false }
+
+func (a SetStatusAction) Apply(s State) State {
+	state := s.Copy()
+	state.Status = a.Text
+	return state
+}
+
+// nextStatus decides State.Status after action took prev to next. Resets
+// and reaching the target set a message; any other action that changes the
+// counter clears it. Actions opting out of undo, which only touch the UI,
+// leave it as it was, so a background toast expiry does not wipe it, and a
+// patch or SetStatusAction keeps the status it carries.
+func nextStatus(action AppAction, prev, next State) string {
+	switch action.(type) {
+	case ResetAction:
+		return StatusReset
+	case ConfirmResetAction:
+		if prev.UI.ConfirmPending {
+			return StatusReset
+		}
+		return prev.Status
+	case ApplyPatchAction, SetStatusAction:
+		return next.Status
+	}
+	if next.Target != 0 && next.Count == next.Target && prev.Count != prev.Target {
+		return StatusTargetReached
+	}
+	if !isUndoable(action) {
+		return prev.Status
+	}
+	return ""
+}
@@ -0,0 +1,45 @@
+package counterstore
+
+import "testing"
+
+func TestReduceStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		state  State
+		action AppAction
+		want   string
+	}{
+		{"reset sets it", State{Count: 4}, ResetAction{}, StatusReset},
+		{"confirmed reset sets it", State{Count: 4, UI: UIState{ConfirmPending: true}}, ConfirmResetAction{}, StatusReset},
+		{"confirm with nothing pending keeps it", State{Status: "x"}, ConfirmResetAction{}, "x"},
+		{"reaching the target sets it", State{Count: 9, Target: 10}, IncrementAction{}, StatusTargetReached},
+		{"staying on the target clears it", State{Count: 10, Target: 10, Status: StatusTargetReached}, SetCountAction{Value: 10}, ""},
+		{"no target never reaches it", State{Count: -1}, IncrementAction{}, ""},
+		{"a counting action clears it", State{Count: 3, Status: StatusReset}, IncrementAction{}, ""},
+		{"a UI-only action keeps it", State{Status: StatusReset}, ToggleMinimalAction{}, StatusReset},
+		{"SetStatus sets its text", State{Status: StatusReset}, SetStatusAction{Text: "hello"}, "hello"},
+		{"wrapped actions count as themselves", State{Count: 4}, Annotated{AppAction: ResetAction{}}, StatusReset},
+	}
+	for _, tt := range tests {
+		if got := Reduce(tt.state, tt.action).Status; got != tt.want {
+			t.Errorf("%s: Status = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestStatusThroughDispatch(t *testing.T) {
+	store := NewStore(Reduce, State{Target: 2})
+	store.Dispatch(IncrementAction{})
+	store.Dispatch(IncrementAction{})
+	if got := store.GetState().Status; got != StatusTargetReached {
+		t.Fatalf("Status = %q, want %q", got, StatusTargetReached)
+	}
+	store.Dispatch(ToggleAlwaysOnTopAction{})
+	if got := store.GetState().Status; got != StatusTargetReached {
+		t.Errorf("a UI toggle cleared the status to %q", got)
+	}
+	store.Dispatch(IncrementAction{})
+	if got := store.GetState().Status; got != "" {
+		t.Errorf("Status after moving past the target = %q, want cleared", got)
+	}
+}
@@ -0,0 +1,188 @@
+package counterstore
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// stopwatchInterval is how often a running stopwatch ticks
+const stopwatchInterval = 50 * time.Millisecond
+
+// StartStopwatchAction sets the stopwatch running, so ticks add to the count
+type StartStopwatchAction struct{}
+
+func (a StartStopwatchAction) Undoable() bool { return false }
+
+func (a StartStopwatchAction) Apply(s State) State {
+	state := s.Copy()
+	state.UI.Stopwatch = true
+	return state
+}
+
+// StopStopwatchAction stops the stopwatch, keeping the count it reached
+type StopStopwatchAction struct{}
+
+func (a StopStopwatchAction) Undoable() bool { return false }
+
+func (a StopStopwatchAction) Apply(s State) State {
+	state := s.Copy()
+	state.UI.Stopwatch = false
+	return state
+}
+
+// StopwatchTickAction adds DeltaMs elapsed milliseconds to the count while
+// the stopwatch is running, and does nothing while it is stopped. It is not
+// TickAction, which is a second of a countdown.
+type StopwatchTickAction struct {
+	DeltaMs int
+}
+
+func (a StopwatchTickAction) Undoable() bool { return false }
+
+func (a StopwatchTickAction) Apply(s State) State {
+	if !s.UI.Stopwatch || a.DeltaMs == 0 {
+		return s
+	}
+	state := s.Copy()
+	state.Count, _ = addCount(state.Count, a.DeltaMs, state.Overflow)
+	return state
+}
+
+// FormatStopwatch formats a count of milliseconds as mm:ss.mmm. Minutes are
+// not wrapped into hours, and a negative count gets a leading minus.
+func FormatStopwatch(ms int) string {
+	sign := ""
+	n := uint64(ms)
+	if ms < 0 {
+		sign, n = "-", -n
+	}
+	return fmt.Sprintf("%s%02d:%02d.%03d", sign, n/60000, n/1000%60, n%1000)
+}
+
+// Stopwatch drives the stopwatch: while State.UI.Stopwatch is set it
+// dispatches a StopwatchTickAction every interval on its clock with the
+// milliseconds elapsed since the last one, and a final one as the
+// stopwatch is stopped, so no time between ticks is lost.
+type Stopwatch struct {
+	mu     sync.Mutex
+	clock  Clock
+	store  *Store[State, AppAction]
+	timer  Timer
+	run    int
+	last   time.Time
+	done   func()
+	closed bool
+}
+
+// NewStopwatch creates a stopwatch driver ticking on clock, or the wall
+// clock when it is nil
+func NewStopwatch(clock Clock) *Stopwatch {
+	return &Stopwatch{clock: orRealClock(clock)}
+}
+
+// Middleware returns the middleware that starts and stops the ticker as the
+// stopwatch is started and stopped
+func (w *Stopwatch) Middleware() Middleware[State, AppAction] {
+	return func(store *Store[State, AppAction], next Dispatch[AppAction]) Dispatch[AppAction] {
+		w.mu.Lock()
+		w.store = store
+		w.mu.Unlock()
+		w.sync()
+
+		return func(action AppAction) error {
+			if _, ok := action.(StopStopwatchAction); ok {
+				w.flush()
+			}
+			err := next(action)
+			w.sync()
+			return err
+		}
+	}
+}
+
+// sync arms the ticker while the stopwatch is running and disarms it
+// otherwise, listing the running stopwatch as one of the store's tasks;
+// cancelling it stops the stopwatch
+func (w *Stopwatch) sync() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.store == nil {
+		return
+	}
+	running := w.store.GetState().UI.Stopwatch && !w.closed
+	switch {
+	case running && w.timer == nil:
+		w.run++
+		run := w.run
+		w.last = w.clock.Now()
+		w.timer = w.clock.AfterFunc(stopwatchInterval, func() { w.tick(run) })
+		store := w.store
+		_, w.done = store.RegisterTask("stopwatch", func() {
+			store.Dispatch(StopStopwatchAction{})
+		})
+	case !running && w.timer != nil:
+		w.timer.Stop()
+		w.timer = nil
+		w.run++
+		w.done()
+		w.done = nil
+	}
+}
+
+// elapsedLocked returns the whole milliseconds since the last tick and
+// moves the mark on by that much, carrying the remainder over. Callers must
+// hold w.mu.
+func (w *Stopwatch) elapsedLocked() int {
+	ms := w.clock.Now().Sub(w.last) / time.Millisecond
+	w.last = w.last.Add(ms * time.Millisecond)
+	return int(ms)
+}
+
+// tick dispatches the time elapsed for the run armed as run, and
+// reschedules itself unless that run has been disarmed since
+func (w *Stopwatch) tick(run int) {
+	w.mu.Lock()
+	if w.timer == nil || w.run != run || w.closed {
+		w.mu.Unlock()
+		return
+	}
+	ms := w.elapsedLocked()
+	w.timer = w.clock.AfterFunc(stopwatchInterval, func() { w.tick(run) })
+	store := w.store
+	w.mu.Unlock()
+
+	w.dispatch(store, ms)
+}
+
+// flush dispatches the time elapsed since the last tick, if running
+func (w *Stopwatch) flush() {
+	w.mu.Lock()
+	if w.timer == nil {
+		w.mu.Unlock()
+		return
+	}
+	ms := w.elapsedLocked()
+	store := w.store
+	w.mu.Unlock()
+
+	w.dispatch(store, ms)
+}
+
+func (w *Stopwatch) dispatch(store *Store[State, AppAction], ms int) {
+	if ms == 0 {
+		return
+	}
+	if err := store.Dispatch(StopwatchTickAction{DeltaMs: ms}); err != nil {
+		log.Printf("stopwatch: %v", err)
+	}
+}
+
+// Close stops the ticker for good
+func (w *Stopwatch) Close() {
+	w.mu.Lock()
+	w.closed = true
+	w.mu.Unlock()
+	w.sync()
+}
@@ -0,0 +1,105 @@
+package counterstore
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestStopwatchTickAction(t *testing.T) {
+	running := State{Count: 100, UI: UIState{Stopwatch: true}}
+	if got := (StopwatchTickAction{DeltaMs: 50}).Apply(running).Count; got != 150 {
+		t.Errorf("tick while running = %d, want 150", got)
+	}
+	if got := (StopwatchTickAction{DeltaMs: 50}).Apply(State{Count: 100}).Count; got != 100 {
+		t.Errorf("tick while stopped = %d, want 100", got)
+	}
+	started := StartStopwatchAction{}.Apply(State{Count: 7})
+	if !started.UI.Stopwatch || started.Count != 7 {
+		t.Errorf("started = %+v, want running at 7", started)
+	}
+	if stopped := (StopStopwatchAction{}).Apply(started); stopped.UI.Stopwatch || stopped.Count != 7 {
+		t.Errorf("stopped = %+v, want stopped at 7", stopped)
+	}
+}
+
+func TestFormatStopwatch(t *testing.T) {
+	tests := []struct {
+		ms   int
+		want string
+	}{
+		{0, "00:00.000"},
+		{7, "00:00.007"},
+		{1030, "00:01.030"},
+		{61_250, "01:01.250"},
+		{3_600_000, "60:00.000"},
+		{-1500, "-00:01.500"},
+		{math.MinInt, "-153722867280912:55.808"},
+	}
+	for _, tt := range tests {
+		if got := FormatStopwatch(tt.ms); got != tt.want {
+			t.Errorf("FormatStopwatch(%d) = %q, want %q", tt.ms, got, tt.want)
+		}
+	}
+}
+
+func TestStopwatchCycles(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	watch := NewStopwatch(clock)
+	defer watch.Close()
+	store := NewStore(Reduce, State{}, watch.Middleware())
+
+	clock.Advance(time.Second)
+	if got := store.GetState().Count; got != 0 {
+		t.Fatalf("Count = %d before starting, want 0", got)
+	}
+
+	store.Dispatch(StartStopwatchAction{})
+	if got := len(store.Tasks()); got != 1 {
+		t.Errorf("%d tasks while running, want 1", got)
+	}
+	clock.Advance(time.Second)
+	if got := store.GetState().Count; got != 1000 {
+		t.Errorf("Count after running 1s = %d, want 1000", got)
+	}
+	// Stopping between ticks keeps the time since the last one
+	clock.Advance(30 * time.Millisecond)
+	store.Dispatch(StopStopwatchAction{})
+	clock.Advance(time.Second)
+	if got := store.GetState().Count; got != 1030 {
+		t.Errorf("Count after stopping = %d, want 1030", got)
+	}
+	if got := len(store.Tasks()); got != 0 || clock.Pending() != 0 {
+		t.Errorf("%d tasks and %d timers once stopped, want none", got, clock.Pending())
+	}
+
+	store.Dispatch(StartStopwatchAction{})
+	clock.Advance(120 * time.Millisecond)
+	store.Dispatch(StopStopwatchAction{})
+	if got := store.GetState().Count; got != 1150 {
+		t.Errorf("Count after a second cycle = %d, want 1150", got)
+	}
+
+	store.Dispatch(ResetAction{})
+	if got := store.GetState().Count; got != 0 {
+		t.Errorf("Count after reset = %d, want 0", got)
+	}
+}
+
+func TestStopwatchCancelTask(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	watch := NewStopwatch(clock)
+	defer watch.Close()
+	store := NewStore(Reduce, State{}, watch.Middleware())
+
+	store.Dispatch(StartStopwatchAction{})
+	clock.Advance(200 * time.Millisecond)
+	tasks := store.Tasks()
+	if len(tasks) != 1 || !store.CancelTask(tasks[0].ID) {
+		t.Fatalf("could not cancel the stopwatch task: %v", tasks)
+	}
+	clock.Advance(time.Second)
+	if got := store.GetState(); got.UI.Stopwatch || got.Count != 200 {
+		t.Errorf("after cancelling: %+v, want stopped at 200", got)
+	}
+}
@@ -0,0 +1,848 @@
+// Package counterstore is the counter's state, actions and Redux-style
+// store, free of any UI so it can back a server or CLI as well as the Gio app.
+package counterstore
+
+import (
+	"errors"
+	"log"
+	"math/rand"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// StateProvider interface for state types
+type StateProvider[T any] interface {
+	Copy() T
+}
+
+// State
+type State struct {
+	Count            int
+	Target           int
+	PresentationMode bool
+	AlwaysOnTop      bool
+	Flags            map[string]bool
+	Overflow         OverflowMode
+	Toasts           []Toast
+	Status           string
+	Countdown        bool
+	Palette          Palette
+	ScoreA           int
+	ScoreB           int
+	Turn             int
+	Bookmarks        map[string]int
+	Notes            map[int]string
+	Counts           []int
+	Minimal          bool
+	Categories       map[string]int
+	PresetIndex      int
+	StepSize         int
+	Rolls            []int
+	UI               UIState `json:"-"`
+}
+
+func (s State) Copy() State {
+	return State{
+		Count:            s.Count,
+		Target:           s.Target,
+		PresentationMode: s.PresentationMode,
+		AlwaysOnTop:      s.AlwaysOnTop,
+		Flags:            copyFlags(s.Flags),
+		Overflow:         s.Overflow,
+		Toasts:           append([]Toast(nil), s.Toasts...),
+		Status:           s.Status,
+		Countdown:        s.Countdown,
+		Palette:          s.Palette,
+		ScoreA:           s.ScoreA,
+		ScoreB:           s.ScoreB,
+		Turn:             s.Turn,
+		Bookmarks:        copyBookmarks(s.Bookmarks),
+		Notes:            copyNotes(s.Notes),
+		Counts:           append([]int(nil), s.Counts...),
+		Minimal:          s.Minimal,
+		Categories:       copyCategories(s.Categories),
+		PresetIndex:      s.PresetIndex,
+		StepSize:         s.StepSize,
+		Rolls:            append([]int(nil), s.Rolls...),
+		UI:               s.UI.Copy(),
+	}
+}
+
+// Action
+type Action[S any] interface {
+	Apply(s S) S
+}
+
+// IncrementAction
+type IncrementAction struct{}
+
+func (a IncrementAction) Apply(s State) State {
+	state := s.Copy()
+	state.Count, _ = addCount(state.Count, 1, state.Overflow)
+	return state
+}
+
+func (a IncrementAction) Validate(s State) error {
+	_, err := addCount(s.Count, 1, s.Overflow)
+	return err
+}
+
+// DecrementAction
+type DecrementAction struct{}
+
+func (a DecrementAction) Apply(s State) State {
+	state := s.Copy()
+	state.Count, _ = addCount(state.Count, -1, state.Overflow)
+	return state
+}
+
+func (a DecrementAction) Validate(s State) error {
+	next, err := addCount(s.Count, -1, s.Overflow)
+	if err != nil {
+		return err
+	}
+	return checkNoNegatives(s, next)
+}
+
+// AddAmountAction
+type AddAmountAction struct {
+	Amount int
+}
+
+func (a AddAmountAction) Apply(s State) State {
+	state := s.Copy()
+	state.Count, _ = addCount(state.Count, a.Amount, state.Overflow)
+	return state
+}
+
+func (a AddAmountAction) Validate(s State) error {
+	next, err := addCount(s.Count, a.Amount, s.Overflow)
+	if err != nil {
+		return err
+	}
+	if a.Amount < 0 {
+		return checkNoNegatives(s, next)
+	}
+	return nil
+}
+
+// ResetAction
+type ResetAction struct{}
+
+func (a ResetAction) Apply(s State) State {
+	state := s.Copy()
+	state.Count = 0
+	return state
+}
+
+// NegateAction
+type NegateAction struct{}
+
+func (a NegateAction) Apply(s State) State {
+	state := s.Copy()
+	state.Count, _ = negateCount(state.Count, state.Overflow)
+	return state
+}
+
+func (a NegateAction) Validate(s State) error {
+	_, err := negateCount(s.Count, s.Overflow)
+	return err
+}
+
+// SetCountAction
+type SetCountAction struct {
+	Value int
+}
+
+func (a SetCountAction) Apply(s State) State {
+	state := s.Copy()
+	state.Count = a.Value
+	return state
+}
+
+// SetTargetAction
+type SetTargetAction struct {
+	Target int
+}
+
+func (a SetTargetAction) Apply(s State) State {
+	state := s.Copy()
+	state.Target = a.Target
+	return state
+}
+
+// SetPresentationModeAction
+type SetPresentationModeAction struct {
+	Enabled bool
+}
+
+func (a SetPresentationModeAction) Undoable() bool { return false }
+
+func (a SetPresentationModeAction) Apply(s State) State {
+	state := s.Copy()
+	state.PresentationMode = a.Enabled
+	return state
+}
+
+// ToggleAlwaysOnTopAction
+type ToggleAlwaysOnTopAction struct{}
+
+func (a ToggleAlwaysOnTopAction) Undoable() bool { return false }
+
+func (a ToggleAlwaysOnTopAction) Apply(s State) State {
+	state := s.Copy()
+	state.AlwaysOnTop = !state.AlwaysOnTop
+	return state
+}
+
+// ToggleMinimalAction switches between the full layout and the minimal one
+// showing only the count
+type ToggleMinimalAction struct{}
+
+func (a ToggleMinimalAction) Undoable() bool { return false }
+
+func (a ToggleMinimalAction) Apply(s State) State {
+	state := s.Copy()
+	state.Minimal = !state.Minimal
+	return state
+}
+
+// SetHelpAction
+type SetHelpAction struct {
+	Visible bool
+}
+
+func (a SetHelpAction) Undoable() bool { return false }
+
+func (a SetHelpAction) Apply(s State) State {
+	state := s.Copy()
+	state.UI.ShowHelp = a.Visible
+	return state
+}
+
+// Reducer type
+type Reducer[S any, A Action[S]] func(state S, action A) S
+
+// Middleware type
+type Middleware[S StateProvider[S], A Action[S]] func(store *Store[S, A], next Dispatch[A]) Dispatch[A]
+type Dispatch[A any] func(action A) error
+
+// Logging Middleware
+func LoggingMiddleware[S StateProvider[S], A Action[S]](store *Store[S, A], next Dispatch[A]) Dispatch[A] {
+	return func(action A) error {
+		prevState := store.GetState()
+		shown := any(action)
+		if app, ok := shown.(AppAction); ok {
+			shown = Shown(app)
+		}
+		if m, ok := shown.(Metadata); ok {
+			log.Printf("Action dispatched: %T %+v, Meta: %v, Previous State: %+v", shown, shown, m.Metadata(), prevState)
+		} else {
+			log.Printf("Action dispatched: %T %+v, Previous State: %+v", shown, shown, prevState)
+		}
+		if err := next(action); err != nil {
+			log.Printf("Action failed: %T: %v", shown, err)
+			return err
+		}
+		newState := store.GetState()
+		log.Printf("Action dispatched: %T, New State: %+v", shown, newState)
+		return nil
+	}
+}
+
+// Store
+type Store[S StateProvider[S], A Action[S]] struct {
+	mu          sync.RWMutex
+	state       S
+	reducer     Reducer[S, A]
+	middleware  []Middleware[S, A]
+	dispatch    Dispatch[A]
+	subscribers []subscriber
+	replicaID   string
+	panicLogger PanicLogger[A]
+
+	nextSubscriberID int
+
+	notifyMode   NotifyMode
+	batchDepth   int
+	dirty        bool
+	flushPending bool
+
+	framePending   bool
+	onFrameRequest func()
+	frameBase      S
+	frameBuffered  bool
+
+	historyEnabled bool
+	historyLimit   int
+	past           []S
+	future         []S
+
+	historyByteLimit int
+	pastBytes        []int
+	pastBytesTotal   int
+
+	paused bool
+	queued [][]A
+
+	seed   int64
+	randMu sync.Mutex
+	rand   *rand.Rand
+
+	asyncMu     sync.Mutex
+	asyncQueue  chan asyncJob[S, A]
+	asyncDone   chan struct{}
+	asyncClosed bool
+
+	fast atomic.Pointer[Frozen[S]]
+
+	invariant            func(S) error
+	onInvariantViolation func(action A, rejected S, err error)
+
+	clock       Clock
+	checkpoints []S
+
+	version  uint64
+	versions []versionedState[S]
+	polled   uint64
+
+	onSubscriberPanic func(listenerIndex int, r any)
+
+	returningMu sync.Mutex
+	capture     *transitionCapture[S]
+
+	transforms []func(A, S) A
+
+	subscriberMode SubscriberMode
+	subscriberPool *subscriberPool
+
+	commitHooks []func(prev, next S)
+	applyHooks  []func(action A, prev, next S)
+
+	tasks taskRegistry
+}
+
+func NewStore[S StateProvider[S], A Action[S]](
+	reducer Reducer[S, A],
+	initialState S,
+	middleware ...Middleware[S, A],
+) *Store[S, A] {
+	return NewStoreWithOptions(reducer, initialState, WithMiddleware(middleware...))
+}
+
+// NewStoreWithID is NewStore for a store labeled with a replica ID
+func NewStoreWithID[S StateProvider[S], A Action[S]](
+	id string,
+	reducer Reducer[S, A],
+	initialState S,
+	middleware ...Middleware[S, A],
+) *Store[S, A] {
+	return NewStoreWithOptions(reducer, initialState, WithReplicaID[S, A](id), WithMiddleware(middleware...))
+}
+
+// NewStoreWithOptions is NewStore with access to the full set of store options
+func NewStoreWithOptions[S StateProvider[S], A Action[S]](
+	reducer Reducer[S, A],
+	initialState S,
+	opts ...StoreOption[S, A],
+) *Store[S, A] {
+	store := &Store[S, A]{
+		state:          initialState,
+		reducer:        reducer,
+		subscribers:    []subscriber{},
+		historyEnabled: true,
+		historyLimit:   defaultHistoryLimit,
+		seed:           time.Now().UnixNano(),
+	}
+	for _, opt := range opts {
+		opt(store)
+	}
+	store.rand = rand.New(&lockedSource{mu: &store.randMu, src: rand.NewSource(store.seed).(rand.Source64)})
+	store.clock = orRealClock(store.clock)
+	store.commitLocked(store.state, store.state)
+
+	store.dispatch = store.applyMiddleware(store.middleware)
+	return store
+}
+
+func (s *Store[S, A]) dispatchInternal() Dispatch[A] {
+	return func(action A) error {
+		if err := s.apply(action); err != nil {
+			if err == errTransformDropped {
+				return nil
+			}
+			var violation *InvariantViolation[S]
+			if errors.As(err, &violation) && s.onInvariantViolation != nil {
+				s.onInvariantViolation(action, violation.State, violation.Err)
+			}
+			return err
+		}
+		s.changed()
+		return nil
+	}
+}
+
+// apply runs the reducer and commits its result. An action failing its own
+// validation, a result failing the store's invariant, or a panicking reducer
+// leaves state and history untouched and the lock released.
+func (s *Store[S, A]) apply(action A) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	action, next, err := s.reduceLocked(s.state, action)
+	if err == errTransformDropped {
+		s.reportBlockedLocked("dropped by transform")
+	}
+	if err != nil {
+		return err
+	}
+
+	prev, track := s.historySnapshot()
+	if track && isUndoable(action) {
+		s.pushHistory(prev)
+	}
+	current := s.state
+	s.setState(next)
+	s.runApplyHooks(action, current, next)
+	return nil
+}
+
+// reduceLocked runs action against state the way apply commits it: through
+// the transforms, validation, the reducer and the invariant. It returns the
+// action as the reducer saw it and the resulting state, and commits nothing.
+// Callers must hold s.mu, for reading at least.
+func (s *Store[S, A]) reduceLocked(state S, action A) (A, S, error) {
+	action, ok := s.transform(action, state)
+	if !ok {
+		return action, state, errTransformDropped
+	}
+	if v, ok := any(action).(Validator[S]); ok {
+		if err := v.Validate(state); err != nil {
+			return action, state, err
+		}
+	}
+	next := s.reducer(state, action)
+	if s.invariant != nil {
+		if err := s.invariant(next); err != nil {
+			return action, state, &InvariantViolation[S]{State: next, Err: err}
+		}
+	}
+	return action, next, nil
+}
+
+// changed marks the state as changed; the notification itself is left to
+// settle, so subscribers run only once the middleware chain has finished
+func (s *Store[S, A]) changed() {
+	s.mu.Lock()
+	s.dirty = true
+	s.mu.Unlock()
+}
+
+// settle delivers a pending notification after a dispatch: right away in
+// immediate mode, in coalesced mode through a background flush that picks up
+// every change made until it runs, and in frame commit mode at the next
+// CommitFrame. Inside a batch it does nothing; the batch settles itself.
+func (s *Store[S, A]) settle() {
+	s.mu.Lock()
+	if !s.dirty || s.batchDepth > 0 {
+		s.mu.Unlock()
+		return
+	}
+	if s.notifyMode == FrameCommitMode {
+		s.requestFrameLocked()
+		return
+	}
+	if s.notifyMode == NotifyCoalesced {
+		if !s.flushPending {
+			s.flushPending = true
+			go s.flushLater()
+		}
+		s.mu.Unlock()
+		return
+	}
+	s.mu.Unlock()
+
+	s.flush()
+}
+
+// flushLater is the background flush of coalesced mode. A batch that
+// started in the meantime keeps the change pending for its own flush.
+func (s *Store[S, A]) flushLater() {
+	s.mu.Lock()
+	s.flushPending = false
+	inBatch := s.batchDepth > 0
+	s.mu.Unlock()
+
+	if !inBatch {
+		s.flush()
+	}
+}
+
+// flush delivers a pending notification, if any. In frame commit mode only
+// CommitFrame delivers, so it asks for a frame instead.
+func (s *Store[S, A]) flush() {
+	if s.notifyMode == FrameCommitMode {
+		s.settle()
+		return
+	}
+	s.mu.Lock()
+	dirty := s.dirty
+	s.dirty = false
+	s.mu.Unlock()
+
+	if dirty {
+		s.notify()
+	}
+}
+
+func (s *Store[S, A]) notify() {
+	s.mu.RLock()
+	subscribers := s.subscribers
+	s.mu.RUnlock()
+
+	for i, sub := range subscribers {
+		if s.subscriberMode == SubscriberAsync {
+			s.post(i, sub)
+			continue
+		}
+		s.callSubscriber(i, sub.fn)
+	}
+}
+
+// callSubscriber runs fn, the i-th subscriber in notification order, turning
+// a panic into a call to the subscriber panic hook so the rest are still
+// notified
+func (s *Store[S, A]) callSubscriber(i int, fn func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			if s.onSubscriberPanic != nil {
+				s.onSubscriberPanic(i, r)
+				return
+			}
+			log.Printf("subscriber %d panicked: %v", i, r)
+		}
+	}()
+	fn()
+}
+
+func (s *Store[S, A]) applyMiddleware(middleware []Middleware[S, A]) Dispatch[A] {
+	dispatch := s.dispatchInternal()
+	// Apply in reverse order so first middleware is outermost
+	for i := len(middleware) - 1; i >= 0; i-- {
+		dispatch = middleware[i](s, dispatch)
+	}
+	return dispatch
+}
+
+// SetMiddleware replaces the store's middleware, first given outermost. The
+// new chain is composed before it is swapped in, so a dispatch never sees a
+// half-built chain; dispatches already in flight finish on the old one, and
+// the state is left as it is.
+func (s *Store[S, A]) SetMiddleware(middleware []Middleware[S, A]) {
+	middleware = append([]Middleware[S, A](nil), middleware...)
+	dispatch := s.applyMiddleware(middleware)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.middleware = middleware
+	s.dispatch = dispatch
+}
+
+// chain returns the current middleware chain
+func (s *Store[S, A]) chain() Dispatch[A] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.dispatch
+}
+
+// Dispatch sends action through the middleware chain, returning the first
+// error raised along it; an action rejected with an error leaves the state
+// unchanged. Actions queued while the store is paused return nil.
+func (s *Store[S, A]) Dispatch(action A) error {
+	if s.enqueue(action) {
+		return nil
+	}
+	err := s.chain()(action)
+	s.settle()
+	return err
+}
+
+// SetState replaces the whole state at once, bypassing middleware and the
+// reducer, and records the replacement as an undo step. It is meant for
+// syncing with an external source of truth, such as a server push, not for
+// normal flow, which should go through Dispatch. A state failing the
+// invariant is rejected with an *InvariantViolation and leaves the store
+// unchanged; otherwise subscribers are notified as after a dispatch.
+func (s *Store[S, A]) SetState(state S) error {
+	s.mu.Lock()
+	err := s.replaceState(state)
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	s.changed()
+	s.settle()
+	return nil
+}
+
+// replaceState is SetState without the notification. Callers must hold s.mu.
+func (s *Store[S, A]) replaceState(state S) error {
+	if s.invariant != nil {
+		if err := s.invariant(state); err != nil {
+			return &InvariantViolation[S]{State: state, Err: err}
+		}
+	}
+	if prev, ok := s.historySnapshot(); ok {
+		s.pushHistory(prev)
+	}
+	s.setState(state.Copy())
+	return nil
+}
+
+// DispatchBatch dispatches each action in order through the full middleware
+// chain. In coalesced mode subscribers are notified exactly once, after the
+// last action; in immediate mode they are notified per action as usual.
+//
+// The whole batch is a single history step: one Undo reverts all of it and
+// one Redo reapplies all of it. A batch of only non-undoable actions records
+// no step. An action failing does not stop the batch; the errors are joined.
+func (s *Store[S, A]) DispatchBatch(actions ...A) error {
+	if len(actions) == 0 || s.enqueue(actions...) {
+		return nil
+	}
+	return s.dispatchBatch(actions)
+}
+
+// dispatchBatch is DispatchBatch past the pause check, which Resume uses to
+// replay a queued batch while the store is still paused
+func (s *Store[S, A]) dispatchBatch(actions []A) error {
+	s.mu.Lock()
+	s.batchDepth++
+	outermost := s.batchDepth == 1
+	var before S
+	if outermost && s.historyEnabled {
+		before = s.state.Copy()
+	}
+	s.mu.Unlock()
+
+	var errs []error
+	for _, action := range actions {
+		if err := s.chain()(action); err != nil {
+			errs = append(errs, err)
+		}
+		if s.notifyMode == NotifyImmediate {
+			s.flush()
+		}
+	}
+
+	s.mu.Lock()
+	s.batchDepth--
+	done := s.batchDepth == 0
+	if outermost && s.historyEnabled && anyUndoable(actions) {
+		s.pushHistory(before)
+	}
+	s.mu.Unlock()
+
+	if done {
+		s.flush()
+	}
+	return errors.Join(errs...)
+}
+
+// DispatchBatchGuarded applies actions in order to a scratch copy of the
+// state and commits the result only if pred accepts it. Each action is
+// transformed, validated and reduced as Dispatch would, but the middleware
+// chain is skipped: nothing outside the store sees the batch before pred
+// has judged it. If every action succeeds and pred accepts the result, it
+// is committed as one change and one history step, subscribers are
+// notified once, and it returns true. If an action fails or pred rejects
+// the result, nothing is committed: the state, version and dirty flag are
+// as they were, no one is notified, and it returns false. While the store
+// is paused it returns false without applying anything. pred runs with the
+// store locked and must not call it.
+func (s *Store[S, A]) DispatchBatchGuarded(pred func(S) bool, actions ...A) bool {
+	type step struct {
+		action     A
+		prev, next S
+	}
+
+	s.mu.Lock()
+	if s.paused {
+		s.mu.Unlock()
+		return false
+	}
+	scratch := s.state.Copy()
+	steps := make([]step, 0, len(actions))
+	for _, action := range actions {
+		applied, next, err := s.reduceLocked(scratch, action)
+		if err != nil {
+			s.mu.Unlock()
+			return false
+		}
+		steps = append(steps, step{action: applied, prev: scratch, next: next})
+		scratch = next
+	}
+	if !pred(scratch.Copy()) {
+		s.mu.Unlock()
+		return false
+	}
+
+	if s.historyEnabled && s.batchDepth == 0 && anyUndoable(actions) {
+		s.pushHistory(s.state.Copy())
+	}
+	s.setState(scratch)
+	for _, st := range steps {
+		s.runApplyHooks(st.action, st.prev, st.next)
+	}
+	s.dirty = true
+	done := s.batchDepth == 0
+	s.mu.Unlock()
+
+	if done {
+		s.flush()
+	}
+	return true
+}
+
+// DryRun returns the state action would produce without applying it. The
+// reducer runs on a copy of the current state; middleware is skipped and
+// subscribers are not notified.
+func (s *Store[S, A]) DryRun(action A) S {
+	return s.reducer(s.GetState(), action)
+}
+
+// Seed returns the seed of the store's random source, which a recording
+// keeps so a replay draws the same numbers
+func (s *Store[S, A]) Seed() int64 {
+	return s.seed
+}
+
+// randIntn returns a number in [0, n) from the store's random source. Calls
+// are serialized with everything else drawing from it, thunks included.
+func (s *Store[S, A]) randIntn(n int) int {
+	return s.rand.Intn(n)
+}
+
+// lockedSource guards a random source with the store's mutex, so the
+// *rand.Rand thunks get may be shared by concurrent dispatches
+type lockedSource struct {
+	mu  *sync.Mutex
+	src rand.Source64
+}
+
+func (l *lockedSource) Int63() int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.src.Int63()
+}
+
+func (l *lockedSource) Uint64() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.src.Uint64()
+}
+
+func (l *lockedSource) Seed(seed int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.src.Seed(seed)
+}
+
+// ReplicaID returns the label identifying this store among synced replicas
+func (s *Store[S, A]) ReplicaID() string {
+	return s.replicaID
+}
+
+func (s *Store[S, A]) GetState() S {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.state.Copy()
+}
+
+// subscriber is a registered listener with its priority
+type subscriber struct {
+	id       int
+	priority int
+	fn       func()
+	box      *mailbox
+}
+
+func (s *Store[S, A]) Subscribe(fn func()) func() {
+	return s.SubscribeWithPriority(fn, 0)
+}
+
+// SubscribeWithPriority registers fn to run on state changes. Listeners run
+// in ascending priority order, and in subscription order among equal
+// priorities, so a persistence listener can follow UI invalidation.
+func (s *Store[S, A]) SubscribeWithPriority(fn func(), priority int) func() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextSubscriberID++
+	id := s.nextSubscriberID
+
+	// The list is replaced rather than modified in place, since notify
+	// iterates over it without holding the lock
+	at := sort.Search(len(s.subscribers), func(i int) bool {
+		return s.subscribers[i].priority > priority
+	})
+	subscribers := make([]subscriber, 0, len(s.subscribers)+1)
+	subscribers = append(subscribers, s.subscribers[:at]...)
+	subscribers = append(subscribers, subscriber{id: id, priority: priority, fn: fn, box: &mailbox{}})
+	s.subscribers = append(subscribers, s.subscribers[at:]...)
+
+	// Return unsubscribe function
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		subscribers := make([]subscriber, 0, len(s.subscribers))
+		for _, sub := range s.subscribers {
+			if sub.id != id {
+				subscribers = append(subscribers, sub)
+			}
+		}
+		s.subscribers = subscribers
+	}
+}
+
+// AppAction is the union type for all actions in this app
+type AppAction interface {
+	Action[State]
+}
+
+// Reducer function. It applies the action and then sets the status line
+// for it; see nextStatus.
+func Reduce(state State, action AppAction) State {
+	next := reduceAction(state, action)
+	next.Status = nextStatus(plainAction(action), state, next)
+	return next
+}
+
+// reduceAction applies action. The common built-in actions take a
+// type-switch fast path calling their Apply directly instead of through the
+// interface; every other action falls back to its Apply method, with the
+// same result.
+func reduceAction(state State, action AppAction) State {
+	action = plainAction(action)
+	switch a := action.(type) {
+	case IncrementAction:
+		return a.Apply(state)
+	case DecrementAction:
+		return a.Apply(state)
+	case ResetAction:
+		return a.Apply(state)
+	case NegateAction:
+		return a.Apply(state)
+	case SetCountAction:
+		return a.Apply(state)
+	case SetPercentAction:
+		return percentReducer(state, action)
+	}
+	return action.Apply(state)
+}
+
+var percentReducer = GateReducer(FlagPercent, func(state State, action AppAction) State {
+	return action.Apply(state)
+})
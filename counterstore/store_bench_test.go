@@ -0,0 +1,99 @@
+package counterstore
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// Measured with go test -bench 'Dispatch$|Reduce' -count 3 on linux/amd64
+// (AMD EPYC), history off, before and after the type-switch fast path in
+// reduceAction:
+//
+//	                        before        after
+//	Dispatch/bare         ~340 ns/op   ~335 ns/op   677 B/op  1 allocs/op
+//	Dispatch/middleware   ~347 ns/op   ~327 ns/op   677 B/op  1 allocs/op
+//	Reduce                36.5 ns/op   31.1 ns/op     0 B/op  0 allocs/op
+//
+// The fast path takes about 15% off the reducer itself, but a dispatch is
+// dominated by locking, the state copy for GetStateFast and notification,
+// so end to end the difference is within run-to-run noise.
+
+// noopMiddleware forwards every action unchanged, so the benchmarks below
+// measure the cost of the chain itself
+func noopMiddleware(store *Store[State, AppAction], next Dispatch[AppAction]) Dispatch[AppAction] {
+	return func(action AppAction) error {
+		return next(action)
+	}
+}
+
+func BenchmarkDispatch(b *testing.B) {
+	for _, bm := range []struct {
+		name       string
+		middleware []Middleware[State, AppAction]
+	}{
+		{"bare", nil},
+		{"middleware", []Middleware[State, AppAction]{noopMiddleware, noopMiddleware, noopMiddleware}},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			store := NewStoreWithOptions(Reduce, State{},
+				WithHistory[State, AppAction](false),
+				WithMiddleware(bm.middleware...),
+			)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				store.Dispatch(IncrementAction{})
+			}
+		})
+	}
+}
+
+func BenchmarkReduce(b *testing.B) {
+	actions := []AppAction{IncrementAction{}, DecrementAction{}, SetCountAction{Value: 3}, NegateAction{}, ResetAction{}}
+	state := State{}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		state = reduceAction(state, actions[i%len(actions)])
+	}
+}
+
+// TestFastPathMatchesApply checks that every action reduceAction special-cases
+// gives the result its Apply method gives, including at the overflow limits
+func TestFastPathMatchesApply(t *testing.T) {
+	states := []State{
+		{},
+		{Count: 41, Target: 50, Flags: map[string]bool{FlagPercent: true}},
+		{Count: math.MaxInt, Overflow: OverflowSaturate},
+		{Count: math.MinInt, Overflow: OverflowWrap},
+		{Count: math.MinInt, Overflow: OverflowError},
+	}
+	actions := []AppAction{
+		IncrementAction{}, DecrementAction{}, ResetAction{}, NegateAction{},
+		SetCountAction{Value: 7}, SetCountAction{Value: -3},
+	}
+	for _, state := range states {
+		for _, action := range actions {
+			got, want := reduceAction(state, action), action.Apply(state)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("%T on %+v: fast path %+v, Apply %+v", action, state, got, want)
+			}
+			annotated := reduceAction(state, WithMeta(action, map[string]string{"k": "v"}))
+			if !reflect.DeepEqual(annotated, want) {
+				t.Errorf("annotated %T on %+v: %+v, Apply %+v", action, state, annotated, want)
+			}
+		}
+	}
+}
+
+func TestFastPathPercentIsGated(t *testing.T) {
+	action := SetPercentAction{Percent: 50}
+	off := State{Count: 1, Target: 10}
+	if got := reduceAction(off, action); !reflect.DeepEqual(got, off) {
+		t.Errorf("SetPercent with the flag off changed the state to %+v", got)
+	}
+	on := State{Count: 1, Target: 10, Flags: map[string]bool{FlagPercent: true}}
+	if got, want := reduceAction(on, action), action.Apply(on); !reflect.DeepEqual(got, want) {
+		t.Errorf("SetPercent with the flag on: %+v, Apply %+v", got, want)
+	}
+}
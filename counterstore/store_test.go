@@ -0,0 +1,239 @@
+package counterstore
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"math"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestDispatchBatchNotifications(t *testing.T) {
+	tests := []struct {
+		mode NotifyMode
+		want int
+	}{
+		{NotifyImmediate, 3},
+		{NotifyCoalesced, 1},
+	}
+	for _, tt := range tests {
+		store := NewStoreWithOptions(Reduce, State{}, WithNotifyMode[State, AppAction](tt.mode))
+		notifications := 0
+		store.Subscribe(func() { notifications++ })
+
+		if err := store.DispatchBatch(IncrementAction{}, IncrementAction{}, IncrementAction{}); err != nil {
+			t.Fatalf("mode %d: DispatchBatch: %v", tt.mode, err)
+		}
+		if notifications != tt.want {
+			t.Errorf("mode %d: %d notifications, want %d", tt.mode, notifications, tt.want)
+		}
+		if got := store.GetState().Count; got != 3 {
+			t.Errorf("mode %d: Count = %d, want 3", tt.mode, got)
+		}
+	}
+}
+
+func TestSetPresentationModeAction(t *testing.T) {
+	state := Reduce(State{Count: 4}, SetPresentationModeAction{Enabled: true})
+	if !state.PresentationMode || state.Count != 4 {
+		t.Fatalf("enabling: %+v", state)
+	}
+	state = Reduce(state, SetPresentationModeAction{Enabled: true})
+	if !state.PresentationMode {
+		t.Error("enabling twice turned presentation mode off")
+	}
+	if state = Reduce(state, SetPresentationModeAction{}); state.PresentationMode {
+		t.Error("disabling left presentation mode on")
+	}
+	if isUndoable(SetPresentationModeAction{}) {
+		t.Error("SetPresentationModeAction is undoable")
+	}
+}
+
+func TestToggleAlwaysOnTopAction(t *testing.T) {
+	state := Reduce(State{Count: 2}, ToggleAlwaysOnTopAction{})
+	if !state.AlwaysOnTop || state.Count != 2 {
+		t.Fatalf("first toggle: %+v", state)
+	}
+	if state = Reduce(state, ToggleAlwaysOnTopAction{}); state.AlwaysOnTop {
+		t.Error("second toggle left AlwaysOnTop on")
+	}
+
+	store := NewStore(Reduce, State{})
+	store.Dispatch(ToggleAlwaysOnTopAction{})
+	if store.CanUndo() {
+		t.Error("pinning became an undo step")
+	}
+}
+
+func TestSetHelpAction(t *testing.T) {
+	state := Reduce(State{Count: 1}, SetHelpAction{Visible: true})
+	if !state.UI.ShowHelp || state.Count != 1 {
+		t.Fatalf("showing help: %+v", state)
+	}
+	if state = Reduce(state, SetHelpAction{}); state.UI.ShowHelp {
+		t.Error("hiding help left it shown")
+	}
+
+	store := NewStore(Reduce, State{})
+	store.Dispatch(SetHelpAction{Visible: true})
+	if store.CanUndo() {
+		t.Error("showing help became an undo step")
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	dispatched := 0
+	counting := func(store *Store[State, AppAction], next Dispatch[AppAction]) Dispatch[AppAction] {
+		return func(action AppAction) error {
+			dispatched++
+			return next(action)
+		}
+	}
+	store := NewStore(Reduce, State{Count: 4, Flags: map[string]bool{}}, counting)
+	notified := 0
+	store.Subscribe(func() { notified++ })
+
+	preview := store.DryRun(AddAmountAction{Amount: 6})
+	if preview.Count != 10 {
+		t.Errorf("DryRun Count = %d, want 10", preview.Count)
+	}
+	store.DryRun(SetFlagAction{Name: FlagNoNegatives, Enabled: true})
+
+	state := store.GetState()
+	if state.Count != 4 || len(state.Flags) != 0 {
+		t.Errorf("DryRun changed the store: %+v", state)
+	}
+	if dispatched != 0 || notified != 0 || store.CanUndo() {
+		t.Errorf("DryRun ran middleware %d times, notified %d times, undoable %v", dispatched, notified, store.CanUndo())
+	}
+}
+
+func TestSetMiddleware(t *testing.T) {
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+
+	var seen []AppAction
+	spy := func(store *Store[State, AppAction], next Dispatch[AppAction]) Dispatch[AppAction] {
+		return func(action AppAction) error {
+			seen = append(seen, action)
+			return next(action)
+		}
+	}
+	store := NewStore(Reduce, State{}, spy)
+	store.Dispatch(IncrementAction{})
+	store.Dispatch(IncrementAction{})
+	if logged.Len() != 0 {
+		t.Fatalf("logged before logging was installed: %s", logged.String())
+	}
+
+	store.SetMiddleware([]Middleware[State, AppAction]{LoggingMiddleware[State, AppAction], spy})
+	if got := store.GetState().Count; got != 2 {
+		t.Fatalf("Count after SetMiddleware = %d, want the state kept (2)", got)
+	}
+	store.Dispatch(AddAmountAction{Amount: 5})
+	if !strings.Contains(logged.String(), "Action dispatched: counterstore.AddAmountAction") {
+		t.Errorf("new logging middleware did not log the next action:\n%s", logged.String())
+	}
+	if len(seen) != 3 {
+		t.Errorf("spy saw %d actions, want 3", len(seen))
+	}
+
+	store.SetMiddleware(nil)
+	logged.Reset()
+	store.Dispatch(DecrementAction{})
+	if logged.Len() != 0 || len(seen) != 3 {
+		t.Errorf("removed middleware still ran: logged %q, spy saw %d", logged.String(), len(seen))
+	}
+	if got := store.GetState().Count; got != 6 {
+		t.Errorf("Count = %d, want 6", got)
+	}
+}
+
+func TestNegateAction(t *testing.T) {
+	tests := []struct {
+		name  string
+		state State
+		want  int
+	}{
+		{"positive", State{Count: 5}, -5},
+		{"negative", State{Count: -12}, 12},
+		{"zero", State{}, 0},
+		{"MaxInt", State{Count: math.MaxInt}, -math.MaxInt},
+		{"MinInt clamped", State{Count: math.MinInt, Overflow: OverflowSaturate}, math.MaxInt},
+	}
+	for _, tt := range tests {
+		if got := Reduce(tt.state, NegateAction{}).Count; got != tt.want {
+			t.Errorf("%s: Count = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSetState(t *testing.T) {
+	reduced := 0
+	store := NewStore(func(state State, action AppAction) State {
+		reduced++
+		return Reduce(state, action)
+	}, State{Count: 1})
+	store.Dispatch(IncrementAction{})
+	reduced = 0
+
+	var seen []int
+	store.Subscribe(func() { seen = append(seen, store.GetState().Count) })
+	pushed := State{Count: 40, Target: 50, Flags: map[string]bool{"beta": true}}
+	if err := store.SetState(pushed); err != nil {
+		t.Fatalf("SetState: %v", err)
+	}
+	pushed.Flags["beta"] = false
+
+	if reduced != 0 {
+		t.Errorf("SetState ran the reducer %d times", reduced)
+	}
+	if len(seen) != 1 || seen[0] != 40 {
+		t.Errorf("subscribers saw %v, want [40]", seen)
+	}
+	if got := store.GetState(); got.Count != 40 || got.Target != 50 || !got.Flags["beta"] {
+		t.Errorf("state = %+v, want the pushed state, unaffected by later changes to it", got)
+	}
+
+	// The replacement is an undo step back to the state before it
+	if !store.Undo() || store.GetState().Count != 2 {
+		t.Errorf("after Undo Count = %d, want 2", store.GetState().Count)
+	}
+	if !store.Redo() || store.GetState().Count != 40 {
+		t.Errorf("after Redo Count = %d, want 40", store.GetState().Count)
+	}
+}
+
+func TestSetStateChecksInvariant(t *testing.T) {
+	store := NewStoreWithOptions(Reduce, State{Count: 1},
+		WithInvariant[State, AppAction](minZero, nil))
+	notified := 0
+	store.Subscribe(func() { notified++ })
+
+	err := store.SetState(State{Count: -5})
+	var violation *InvariantViolation[State]
+	if !errors.As(err, &violation) || !errors.Is(err, errBelowMin) {
+		t.Fatalf("SetState error = %v, want an invariant violation", err)
+	}
+	if got := store.GetState().Count; got != 1 || notified != 0 || store.CanUndo() {
+		t.Errorf("rejected SetState: Count %d, %d notifications, CanUndo %v", got, notified, store.CanUndo())
+	}
+}
+
+func TestToggleMinimalAction(t *testing.T) {
+	state := State{Count: 5, PresentationMode: true}
+	on := ToggleMinimalAction{}.Apply(state)
+	if !on.Minimal || on.Count != 5 || !on.PresentationMode {
+		t.Errorf("toggled on = %+v, want Minimal with the rest kept", on)
+	}
+	if state.Minimal {
+		t.Error("toggling changed the previous state")
+	}
+	if off := (ToggleMinimalAction{}).Apply(on); off.Minimal {
+		t.Error("toggling twice left Minimal set")
+	}
+}
@@ -0,0 +1,107 @@
+package counterstore
+
+import (
+	"reflect"
+	"sync"
+	"time"
+)
+
+// thresholdCrossing reports the direction in which count moved across threshold:
+// +1 when it went from below to at-or-above, -1 for the reverse, 0 otherwise
+func thresholdCrossing(prev, next, threshold int) int {
+	switch {
+	case prev < threshold && next >= threshold:
+		return 1
+	case prev >= threshold && next < threshold:
+		return -1
+	}
+	return 0
+}
+
+// SubscribeThreshold calls onCross whenever Count crosses threshold, with +1
+// for an upward crossing and -1 for a downward one. Changes that stay on one
+// side of the threshold are ignored. It returns the unsubscribe function.
+func SubscribeThreshold(store *Store[State, AppAction], threshold int, onCross func(dir int)) func() {
+	var mu sync.Mutex
+	prev := store.GetState().Count
+
+	return store.Subscribe(func() {
+		next := store.GetState().Count
+
+		mu.Lock()
+		dir := thresholdCrossing(prev, next, threshold)
+		prev = next
+		mu.Unlock()
+
+		if dir != 0 {
+			onCross(dir)
+		}
+	})
+}
+
+// SubscribeTransition calls fn with the previous and the new state after
+// each notification that actually changed the state. In coalesced mode one
+// transition spans all the dispatches folded into that notification. It
+// returns the unsubscribe function.
+func (s *Store[S, A]) SubscribeTransition(fn func(prev, next S)) func() {
+	var mu sync.Mutex
+	prev := Freeze(s.GetState())
+
+	return s.Subscribe(func() {
+		next := s.GetState()
+
+		mu.Lock()
+		last := prev.Get()
+		changed := !reflect.DeepEqual(last, next)
+		if changed {
+			prev = Freeze(next)
+		}
+		mu.Unlock()
+
+		if changed {
+			fn(last, next)
+		}
+	})
+}
+
+// SubscribeDebounced calls listener with the state once notifications have
+// stopped for d on the store's clock, collapsing a burst of changes into one
+// call with the latest state. Every burst ends in a call, so the final state
+// is always delivered, but a burst that never pauses for d delivers nothing
+// until it does. Calls run on the clock's timer goroutine. It returns the
+// unsubscribe function, which also cancels a pending call.
+func (s *Store[S, A]) SubscribeDebounced(listener func(S), d time.Duration) func() {
+	var mu sync.Mutex
+	var timer Timer
+	stopped := false
+
+	deliver := func() {
+		mu.Lock()
+		cancelled := stopped
+		mu.Unlock()
+		if !cancelled {
+			listener(s.GetState())
+		}
+	}
+	unsubscribe := s.Subscribe(func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if stopped {
+			return
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		timer = s.clock.AfterFunc(d, deliver)
+	})
+
+	return func() {
+		unsubscribe()
+		mu.Lock()
+		defer mu.Unlock()
+		stopped = true
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package counterstore
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSubscribeThreshold(t *testing.T) {
+	tests := []struct {
+		name    string
+		start   int
+		actions []AppAction
+		want    []int
+	}{
+		{"crossing up", 8, []AppAction{IncrementAction{}, IncrementAction{}, IncrementAction{}}, []int{1}},
+		{"crossing down", 11, []AppAction{DecrementAction{}, DecrementAction{}, DecrementAction{}}, []int{-1}},
+		{"staying below", 3, []AppAction{IncrementAction{}, DecrementAction{}, SetCountAction{Value: 9}}, nil},
+		{"staying above", 20, []AppAction{DecrementAction{}, SetCountAction{Value: 10}}, nil},
+		{"up and back", 9, []AppAction{IncrementAction{}, DecrementAction{}, AddAmountAction{Amount: 5}}, []int{1, -1, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewStore(Reduce, State{Count: tt.start})
+			var got []int
+			SubscribeThreshold(store, 10, func(dir int) {
+				got = append(got, dir)
+			})
+			for _, action := range tt.actions {
+				if err := store.Dispatch(action); err != nil {
+					t.Fatalf("Dispatch(%T): %v", action, err)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("crossings = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscribeThresholdUnsubscribe(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 9})
+	calls := 0
+	unsubscribe := SubscribeThreshold(store, 10, func(int) { calls++ })
+	unsubscribe()
+
+	store.Dispatch(IncrementAction{})
+	if calls != 0 {
+		t.Errorf("onCross called %d times after unsubscribing", calls)
+	}
+}
+
+func TestSubscribeTransition(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 1})
+	type pair struct{ prev, next int }
+	var got []pair
+	unsubscribe := store.SubscribeTransition(func(prev, next State) {
+		got = append(got, pair{prev.Count, next.Count})
+	})
+
+	store.Dispatch(IncrementAction{})
+	store.Dispatch(AddAmountAction{Amount: 3})
+	store.Dispatch(SetCountAction{Value: 5}) // no change
+	store.Dispatch(NegateAction{})
+
+	want := []pair{{1, 2}, {2, 5}, {5, -5}}
+	if len(got) != len(want) {
+		t.Fatalf("transitions %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("transition %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	unsubscribe()
+	store.Dispatch(IncrementAction{})
+	if len(got) != len(want) {
+		t.Errorf("called after unsubscribe: %v", got)
+	}
+}
+
+func TestSubscribeTransitionCoalesced(t *testing.T) {
+	store := NewStoreWithOptions(Reduce, State{}, WithNotifyMode[State, AppAction](NotifyCoalesced))
+	done := make(chan [2]int, 1)
+	store.SubscribeTransition(func(prev, next State) {
+		done <- [2]int{prev.Count, next.Count}
+	})
+
+	store.DispatchBatch(IncrementAction{}, IncrementAction{}, IncrementAction{})
+	if got := <-done; got != [2]int{0, 3} {
+		t.Errorf("coalesced transition = %v, want one spanning 0 → 3", got)
+	}
+}
+
+func TestSubscribeWithPriority(t *testing.T) {
+	store := NewStore(Reduce, State{})
+	var order []string
+	listener := func(name string) func() {
+		return func() { order = append(order, name) }
+	}
+	store.SubscribeWithPriority(listener("persist"), 10)
+	store.Subscribe(listener("ui-a"))
+	store.SubscribeWithPriority(listener("first"), -5)
+	unsubscribe := store.SubscribeWithPriority(listener("gone"), 10)
+	store.Subscribe(listener("ui-b"))
+	store.SubscribeWithPriority(listener("audit"), 10)
+	unsubscribe()
+
+	store.Dispatch(IncrementAction{})
+	want := []string{"first", "ui-a", "ui-b", "persist", "audit"}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("listeners ran in order %v, want %v", order, want)
+	}
+}
+
+func TestSubscribeDebounced(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	store := NewStoreWithOptions(Reduce, State{}, WithClock[State, AppAction](clock))
+	var got []int
+	store.SubscribeDebounced(func(s State) { got = append(got, s.Count) }, 16*time.Millisecond)
+
+	// A burst that keeps pausing for less than d collapses into one call
+	for i := 0; i < 5; i++ {
+		store.Dispatch(IncrementAction{})
+		clock.Advance(10 * time.Millisecond)
+	}
+	if len(got) != 0 {
+		t.Fatalf("delivered %v mid-burst, want nothing", got)
+	}
+	clock.Advance(6 * time.Millisecond)
+	if !reflect.DeepEqual(got, []int{5}) {
+		t.Fatalf("delivered %v after the burst, want [5]", got)
+	}
+
+	// Dispatches that stop abruptly still deliver the final state
+	store.Dispatch(AddAmountAction{Amount: 10})
+	store.Dispatch(DecrementAction{})
+	clock.Advance(time.Second)
+	if !reflect.DeepEqual(got, []int{5, 14}) {
+		t.Errorf("delivered %v, want [5 14]", got)
+	}
+	if got := clock.Pending(); got != 0 {
+		t.Errorf("%d timers pending once delivered, want 0", got)
+	}
+}
+
+func TestSubscribeDebouncedUnsubscribe(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	store := NewStoreWithOptions(Reduce, State{}, WithClock[State, AppAction](clock))
+	calls := 0
+	unsubscribe := store.SubscribeDebounced(func(State) { calls++ }, 16*time.Millisecond)
+
+	store.Dispatch(IncrementAction{})
+	unsubscribe()
+	clock.Advance(time.Second)
+	store.Dispatch(IncrementAction{})
+	clock.Advance(time.Second)
+	if calls != 0 {
+		t.Errorf("listener called %d times after unsubscribing, want 0", calls)
+	}
+}
@@ -0,0 +1,113 @@
+package counterstore
+
+import "sync"
+
+// SubscriberMode controls which goroutine runs subscribers
+type SubscriberMode int
+
+const (
+	// SubscriberSync runs subscribers on the dispatching goroutine before
+	// Dispatch returns, in priority order. A slow subscriber stalls the
+	// dispatcher, but every subscriber has finished with a change by the time
+	// the next dispatch starts.
+	SubscriberSync SubscriberMode = iota
+	// SubscriberAsync hands notifications to a pool of worker goroutines, so
+	// Dispatch returns without waiting for subscribers. Each subscriber still
+	// runs one notification at a time, in order, but subscribers run
+	// concurrently with each other and with later dispatches, and priorities
+	// no longer order them. A subscriber that falls behind has the changes it
+	// missed folded into one call; since it reads the state when it runs, it
+	// sees the states in order, possibly skipping some, and always the last.
+	SubscriberAsync
+)
+
+// defaultSubscriberWorkers is the pool size of async subscriber mode when
+// none is given
+const defaultSubscriberWorkers = 4
+
+// WithSubscriberMode selects sync or async subscriber dispatch. In async
+// mode at most workers subscribers run at once; zero or less picks a small
+// default.
+func WithSubscriberMode[S StateProvider[S], A Action[S]](mode SubscriberMode, workers int) StoreOption[S, A] {
+	return func(s *Store[S, A]) {
+		s.subscriberMode = mode
+		if workers <= 0 {
+			workers = defaultSubscriberWorkers
+		}
+		s.subscriberPool = &subscriberPool{workers: workers}
+	}
+}
+
+// mailbox tracks one subscriber's async notifications: whether it is queued
+// or running, and whether another change arrived meanwhile
+type mailbox struct {
+	mu      sync.Mutex
+	running bool
+	pending bool
+}
+
+// subscriberPool runs queued subscriber calls on a fixed set of workers
+// started on first use. Its queue holds at most one entry per subscriber,
+// so it needs no bound of its own and posting never blocks.
+type subscriberPool struct {
+	workers int
+	once    sync.Once
+	mu      sync.Mutex
+	cond    *sync.Cond
+	queue   []func()
+}
+
+func (p *subscriberPool) submit(job func()) {
+	p.once.Do(func() {
+		p.cond = sync.NewCond(&p.mu)
+		for range p.workers {
+			go p.work()
+		}
+	})
+	p.mu.Lock()
+	p.queue = append(p.queue, job)
+	p.mu.Unlock()
+	p.cond.Signal()
+}
+
+func (p *subscriberPool) work() {
+	for {
+		p.mu.Lock()
+		for len(p.queue) == 0 {
+			p.cond.Wait()
+		}
+		job := p.queue[0]
+		p.queue = p.queue[1:]
+		p.mu.Unlock()
+		job()
+	}
+}
+
+// post notifies sub through the pool. A subscriber already queued or
+// running is only marked pending, and runs again once it is done.
+func (s *Store[S, A]) post(i int, sub subscriber) {
+	box := sub.box
+	box.mu.Lock()
+	if box.running {
+		box.pending = true
+		box.mu.Unlock()
+		return
+	}
+	box.running = true
+	box.mu.Unlock()
+
+	s.subscriberPool.submit(func() {
+		for {
+			s.callSubscriber(i, sub.fn)
+
+			box.mu.Lock()
+			if !box.pending {
+				box.running = false
+				box.mu.Unlock()
+				return
+			}
+			box.pending = false
+			box.mu.Unlock()
+		}
+	})
+}
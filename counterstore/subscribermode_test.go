@@ -0,0 +1,87 @@
+package counterstore
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAsyncSubscribersDoNotBlockDispatch(t *testing.T) {
+	store := NewStoreWithOptions(Reduce, State{}, WithSubscriberMode[State, AppAction](SubscriberAsync, 2))
+	release := make(chan struct{})
+	defer close(release)
+	store.Subscribe(func() { <-release })
+
+	dispatched := make(chan struct{})
+	go func() {
+		for i := 0; i < 10; i++ {
+			store.Dispatch(IncrementAction{})
+		}
+		close(dispatched)
+	}()
+	select {
+	case <-dispatched:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Dispatch waited on a blocked async subscriber")
+	}
+	if got := store.GetState().Count; got != 10 {
+		t.Errorf("Count = %d, want 10", got)
+	}
+}
+
+func TestAsyncSubscriberSeesStatesInOrder(t *testing.T) {
+	store := NewStoreWithOptions(Reduce, State{}, WithSubscriberMode[State, AppAction](SubscriberAsync, 0))
+	const n = 200
+
+	var mu sync.Mutex
+	var seen []int
+	var running, overlapped atomic.Int32
+	last := make(chan struct{})
+	var closeLast sync.Once
+	store.Subscribe(func() {
+		if running.Add(1) > 1 {
+			overlapped.Store(1)
+		}
+		defer running.Add(-1)
+		count := store.GetState().Count
+		mu.Lock()
+		seen = append(seen, count)
+		mu.Unlock()
+		if count == n {
+			// A pending run may read the final state a second time
+			closeLast.Do(func() { close(last) })
+		}
+	})
+	for i := 0; i < n; i++ {
+		store.Dispatch(IncrementAction{})
+	}
+
+	select {
+	case <-last:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the subscriber never saw the final state")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	for i := 1; i < len(seen); i++ {
+		if seen[i] < seen[i-1] {
+			t.Fatalf("subscriber saw %d after %d", seen[i], seen[i-1])
+		}
+	}
+	if overlapped.Load() != 0 {
+		t.Error("one subscriber ran concurrently with itself")
+	}
+}
+
+func TestSyncSubscribersRunBeforeDispatchReturns(t *testing.T) {
+	store := NewStore(Reduce, State{})
+	seen := 0
+	store.Subscribe(func() { seen = store.GetState().Count })
+	for i := 1; i <= 3; i++ {
+		store.Dispatch(IncrementAction{})
+		if seen != i {
+			t.Fatalf("after dispatch %d the subscriber saw %d", i, seen)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package counterstore
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Lens focuses on a slice T of a state S
+type Lens[S, T any] struct {
+	Get func(S) T
+	Set func(S, T) S
+}
+
+// CountLens focuses on State.Count
+var CountLens = Lens[State, int]{
+	Get: func(s State) int { return s.Count },
+	Set: func(s State, count int) State {
+		state := s.Copy()
+		state.Count = count
+		return state
+	},
+}
+
+// SliceAction is an action on a slice of state, dispatched to a SubStore
+type SliceAction[T any] interface {
+	Apply(t T) T
+}
+
+// ScopedAction is how a SubStore's action travels through its parent: the
+// slice action plus the sub-store's namespace, applied through the lens.
+// Parent middleware can tell sub-store actions apart by Namespace, so two
+// sub-stores using the same slice action types never collide.
+type ScopedAction[S, T any] struct {
+	Namespace string
+	Action    SliceAction[T]
+	lens      Lens[S, T]
+}
+
+func (a ScopedAction[S, T]) Apply(s S) S {
+	return a.lens.Set(s, a.Action.Apply(a.lens.Get(s)))
+}
+
+func (a ScopedAction[S, T]) String() string {
+	return fmt.Sprintf("%s/%T", a.Namespace, a.Action)
+}
+
+// SubStore exposes one slice of a parent store as a store of its own. The
+// parent stays the single source of truth: dispatches go up to it as
+// ScopedActions, and the sub-store's subscribers hear about every parent
+// change that alters the slice.
+type SubStore[S StateProvider[S], A Action[S], T any] struct {
+	parent      *Store[S, A]
+	namespace   string
+	lens        Lens[S, T]
+	unsubscribe func()
+
+	mu          sync.Mutex
+	last        T
+	subscribers []func()
+}
+
+// NewSubStore binds a sub-store to parent. The parent's action type must be
+// able to hold a ScopedAction[S, T], as an interface like AppAction can.
+func NewSubStore[S StateProvider[S], A Action[S], T any](parent *Store[S, A], namespace string, lens Lens[S, T]) (*SubStore[S, A, T], error) {
+	var probe any = ScopedAction[S, T]{}
+	if _, ok := probe.(A); !ok {
+		var zero A
+		return nil, fmt.Errorf("substore %s: %T cannot carry %T", namespace, zero, probe)
+	}
+
+	sub := &SubStore[S, A, T]{
+		parent:    parent,
+		namespace: namespace,
+		lens:      lens,
+		last:      lens.Get(parent.GetState()),
+	}
+	sub.unsubscribe = parent.Subscribe(sub.parentChanged)
+	return sub, nil
+}
+
+func (s *SubStore[S, A, T]) parentChanged() {
+	current := s.lens.Get(s.parent.GetState())
+
+	s.mu.Lock()
+	if reflect.DeepEqual(current, s.last) {
+		s.mu.Unlock()
+		return
+	}
+	s.last = current
+	subscribers := s.subscribers
+	s.mu.Unlock()
+
+	for _, sub := range subscribers {
+		sub()
+	}
+}
+
+func (s *SubStore[S, A, T]) Namespace() string {
+	return s.namespace
+}
+
+// GetState returns the slice as it currently is in the parent
+func (s *SubStore[S, A, T]) GetState() T {
+	return s.lens.Get(s.parent.GetState())
+}
+
+// Dispatch sends action up to the parent, through the parent's middleware
+func (s *SubStore[S, A, T]) Dispatch(action SliceAction[T]) error {
+	var scoped any = ScopedAction[S, T]{Namespace: s.namespace, Action: action, lens: s.lens}
+	return s.parent.Dispatch(scoped.(A))
+}
+
+// Subscribe registers fn to run whenever the slice changes
+func (s *SubStore[S, A, T]) Subscribe(fn func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subscribers = append(s.subscribers, fn)
+}
+
+// Close detaches the sub-store from its parent
+func (s *SubStore[S, A, T]) Close() {
+	s.unsubscribe()
+}
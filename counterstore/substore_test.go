@@ -0,0 +1,84 @@
+package counterstore
+
+import "testing"
+
+// addSlice adds N to an int slice
+type addSlice struct{ N int }
+
+func (a addSlice) Apply(n int) int { return n + a.N }
+
+func TestSubStoreBidirectional(t *testing.T) {
+	parent := NewStore(Reduce, State{Count: 1})
+	sub, err := NewSubStore(parent, "counter", CountLens)
+	if err != nil {
+		t.Fatalf("NewSubStore: %v", err)
+	}
+	defer sub.Close()
+	subNotified := 0
+	sub.Subscribe(func() { subNotified++ })
+
+	// Sub-store dispatches land in the parent
+	if err := sub.Dispatch(addSlice{N: 4}); err != nil {
+		t.Fatalf("Dispatch: %v", err)
+	}
+	if got := parent.GetState().Count; got != 5 {
+		t.Errorf("parent Count = %d, want 5", got)
+	}
+	if got := sub.GetState(); got != 5 {
+		t.Errorf("sub-store state = %d, want 5", got)
+	}
+
+	// Parent changes to the slice reach the sub-store
+	parent.Dispatch(IncrementAction{})
+	if got := sub.GetState(); got != 6 {
+		t.Errorf("sub-store state after a parent increment = %d, want 6", got)
+	}
+	if subNotified != 2 {
+		t.Errorf("sub-store notified %d times, want 2", subNotified)
+	}
+
+	// Changes elsewhere in the parent do not
+	parent.Dispatch(SetTargetAction{Target: 9})
+	if subNotified != 2 {
+		t.Errorf("sub-store notified for a change outside its slice")
+	}
+}
+
+func TestSubStoreActionsAreNamespaced(t *testing.T) {
+	parent := NewStore(Reduce, State{})
+	var seen []string
+	parent.SetMiddleware([]Middleware[State, AppAction]{
+		func(store *Store[State, AppAction], next Dispatch[AppAction]) Dispatch[AppAction] {
+			return func(action AppAction) error {
+				if scoped, ok := action.(ScopedAction[State, int]); ok {
+					seen = append(seen, scoped.Namespace)
+				}
+				return next(action)
+			}
+		},
+	})
+	left, _ := NewSubStore(parent, "left", CountLens)
+	right, _ := NewSubStore(parent, "right", CountLens)
+
+	left.Dispatch(addSlice{N: 1})
+	right.Dispatch(addSlice{N: 2})
+	if len(seen) != 2 || seen[0] != "left" || seen[1] != "right" {
+		t.Errorf("namespaces seen = %v, want [left right]", seen)
+	}
+	if got := (ScopedAction[State, int]{Namespace: "left", Action: addSlice{}}).String(); got != "left/counterstore.addSlice" {
+		t.Errorf("String = %q", got)
+	}
+}
+
+func TestSubStoreCloseDetaches(t *testing.T) {
+	parent := NewStore(Reduce, State{})
+	sub, _ := NewSubStore(parent, "counter", CountLens)
+	notified := 0
+	sub.Subscribe(func() { notified++ })
+	sub.Close()
+
+	parent.Dispatch(IncrementAction{})
+	if notified != 0 {
+		t.Errorf("closed sub-store was notified %d times", notified)
+	}
+}
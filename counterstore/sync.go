@@ -0,0 +1,76 @@
+package counterstore
+
+import "sync"
+
+// SyncedAction is an action broadcast by the replica named in Origin
+type SyncedAction struct {
+	Origin string
+	Action AppAction
+}
+
+func (a SyncedAction) Apply(s State) State {
+	return a.Action.Apply(s)
+}
+
+func (a SyncedAction) Validate(s State) error {
+	if v, ok := a.Action.(Validator[State]); ok {
+		return v.Validate(s)
+	}
+	return nil
+}
+
+func (a SyncedAction) Undoable() bool {
+	return isUndoable(a.Action)
+}
+
+// Sync Middleware broadcasts locally dispatched actions through send, tagged
+// with the store's replica ID. Synced actions from other replicas are applied
+// without being re-broadcast; those carrying this store's own ID are echoes
+// and are dropped.
+func SyncMiddleware(send func(SyncedAction)) Middleware[State, AppAction] {
+	return func(store *Store[State, AppAction], next Dispatch[AppAction]) Dispatch[AppAction] {
+		return func(action AppAction) error {
+			if synced, ok := action.(SyncedAction); ok {
+				if synced.Origin != store.ReplicaID() {
+					return next(action)
+				}
+				return nil
+			}
+
+			if err := next(action); err != nil {
+				return err
+			}
+			send(SyncedAction{Origin: store.ReplicaID(), Action: action})
+			return nil
+		}
+	}
+}
+
+// SyncHub connects labeled stores in-process, delivering each broadcast to
+// every joined replica (including the sender, which drops it as an echo)
+type SyncHub struct {
+	mu     sync.RWMutex
+	stores []*Store[State, AppAction]
+}
+
+// Middleware returns the sync middleware for a store that will join the hub
+func (h *SyncHub) Middleware() Middleware[State, AppAction] {
+	return SyncMiddleware(h.broadcast)
+}
+
+// Join adds a store to the hub; it must have been built with h.Middleware()
+func (h *SyncHub) Join(store *Store[State, AppAction]) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.stores = append(h.stores, store)
+}
+
+func (h *SyncHub) broadcast(action SyncedAction) {
+	h.mu.RLock()
+	stores := append([]*Store[State, AppAction](nil), h.stores...)
+	h.mu.RUnlock()
+
+	for _, store := range stores {
+		store.Dispatch(action)
+	}
+}
@@ -0,0 +1,69 @@
+package counterstore
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSyncHubConverges(t *testing.T) {
+	var hub SyncHub
+	alice := NewStoreWithID("alice", Reduce, State{}, hub.Middleware())
+	bob := NewStoreWithID("bob", Reduce, State{}, hub.Middleware())
+	hub.Join(alice)
+	hub.Join(bob)
+
+	aliceNotified, bobNotified := 0, 0
+	alice.Subscribe(func() { aliceNotified++ })
+	bob.Subscribe(func() { bobNotified++ })
+
+	alice.Dispatch(IncrementAction{})
+	bob.Dispatch(IncrementAction{})
+	bob.Dispatch(AddAmountAction{Amount: 5})
+	alice.Dispatch(DecrementAction{})
+
+	if a, b := alice.GetState().Count, bob.GetState().Count; a != 6 || b != 6 {
+		t.Errorf("counts alice %d, bob %d; want both 6", a, b)
+	}
+	// Each store hears each of the four actions once: no echoes come back
+	if aliceNotified != 4 || bobNotified != 4 {
+		t.Errorf("notifications alice %d, bob %d; want 4 each", aliceNotified, bobNotified)
+	}
+}
+
+func TestSyncMiddlewareDropsOwnEcho(t *testing.T) {
+	var sent []SyncedAction
+	store := NewStoreWithID("alice", Reduce, State{}, SyncMiddleware(func(a SyncedAction) {
+		sent = append(sent, a)
+	}))
+
+	store.Dispatch(IncrementAction{})
+	if len(sent) != 1 || sent[0].Origin != "alice" {
+		t.Fatalf("sent %+v, want one action from alice", sent)
+	}
+
+	store.Dispatch(sent[0])
+	if got := store.GetState().Count; got != 1 {
+		t.Errorf("Count = %d after own echo, want 1", got)
+	}
+	store.Dispatch(SyncedAction{Origin: "bob", Action: IncrementAction{}})
+	if got := store.GetState().Count; got != 2 {
+		t.Errorf("Count = %d after a remote action, want 2", got)
+	}
+	if len(sent) != 1 {
+		t.Errorf("remote and echoed actions were re-broadcast: %+v", sent)
+	}
+}
+
+func TestSyncMiddlewareSkipsRejected(t *testing.T) {
+	var sent []SyncedAction
+	store := NewStoreWithID("alice", Reduce, State{Count: math.MaxInt, Overflow: OverflowError}, SyncMiddleware(func(a SyncedAction) {
+		sent = append(sent, a)
+	}))
+
+	if err := store.Dispatch(IncrementAction{}); err == nil {
+		t.Fatal("overflowing increment succeeded")
+	}
+	if len(sent) != 0 {
+		t.Errorf("a rejected action was broadcast: %+v", sent)
+	}
+}
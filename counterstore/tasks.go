@@ -0,0 +1,98 @@
+package counterstore
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// TaskInfo describes a piece of scheduled or running work registered with
+// a store, such as a countdown ticker or a debounced action
+type TaskInfo struct {
+	ID      uint64
+	Name    string
+	Started time.Time
+}
+
+type task struct {
+	info   TaskInfo
+	cancel func()
+}
+
+// taskRegistry is a store's list of registered work
+type taskRegistry struct {
+	mu     sync.Mutex
+	nextID uint64
+	tasks  map[uint64]task
+}
+
+// RegisterTask records work named name until the returned done is called,
+// so it shows up in Tasks and CancelTask can stop it by calling cancel.
+// Calling done more than once, or after the task was cancelled, is harmless.
+func (s *Store[S, A]) RegisterTask(name string, cancel func()) (id uint64, done func()) {
+	r := &s.tasks
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.tasks == nil {
+		r.tasks = make(map[uint64]task)
+	}
+	r.nextID++
+	id = r.nextID
+	r.tasks[id] = task{info: TaskInfo{ID: id, Name: name, Started: s.clock.Now()}, cancel: cancel}
+	return id, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.tasks, id)
+	}
+}
+
+// Tasks returns the registered work in the order it was registered
+func (s *Store[S, A]) Tasks() []TaskInfo {
+	r := &s.tasks
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	infos := make([]TaskInfo, 0, len(r.tasks))
+	for _, t := range r.tasks {
+		infos = append(infos, t.info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].ID < infos[j].ID })
+	return infos
+}
+
+// CancelTask stops the work registered as id and removes it from Tasks. It
+// returns false when there is no such task, as after it finished.
+func (s *Store[S, A]) CancelTask(id uint64) bool {
+	r := &s.tasks
+	r.mu.Lock()
+	t, ok := r.tasks[id]
+	delete(r.tasks, id)
+	r.mu.Unlock()
+
+	if ok && t.cancel != nil {
+		t.cancel()
+	}
+	return ok
+}
+
+// CancelAllTasks stops all registered work, for a clean shutdown, and
+// returns how many tasks it cancelled. Work registered by the cancellations
+// themselves is left running.
+func (s *Store[S, A]) CancelAllTasks() int {
+	r := &s.tasks
+	r.mu.Lock()
+	tasks := r.tasks
+	r.tasks = nil
+	r.mu.Unlock()
+
+	ids := make([]uint64, 0, len(tasks))
+	for id := range tasks {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for _, id := range ids {
+		if cancel := tasks[id].cancel; cancel != nil {
+			cancel()
+		}
+	}
+	return len(tasks)
+}
@@ -0,0 +1,81 @@
+package counterstore
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestTaskRegistry(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	store := NewStoreWithOptions(Reduce, State{}, WithClock[State, AppAction](clock))
+
+	var cancelled []string
+	fake := func(name string) (uint64, func()) {
+		return store.RegisterTask(name, func() { cancelled = append(cancelled, name) })
+	}
+	a, _ := fake("a")
+	clock.Advance(time.Second)
+	b, doneB := fake("b")
+	c, _ := fake("c")
+
+	tasks := store.Tasks()
+	if len(tasks) != 3 || tasks[0].ID != a || tasks[1].Name != "b" || tasks[2].ID != c {
+		t.Fatalf("Tasks = %+v, want a, b and c in order", tasks)
+	}
+	if !tasks[0].Started.Equal(epoch) || !tasks[1].Started.Equal(epoch.Add(time.Second)) {
+		t.Errorf("start times %v and %v, want the clock's time at registration", tasks[0].Started, tasks[1].Started)
+	}
+
+	// A finished task leaves the list without being cancelled
+	doneB()
+	doneB()
+	if !store.CancelTask(a) || store.CancelTask(a) || store.CancelTask(b) {
+		t.Error("CancelTask found a task twice, or a finished one")
+	}
+	if len(cancelled) != 1 || cancelled[0] != "a" {
+		t.Errorf("cancelled %v, want only a", cancelled)
+	}
+	if tasks := store.Tasks(); len(tasks) != 1 || tasks[0].ID != c {
+		t.Errorf("Tasks = %+v, want only c", tasks)
+	}
+
+	fake("d")
+	if n := store.CancelAllTasks(); n != 2 || len(store.Tasks()) != 0 {
+		t.Errorf("CancelAllTasks cancelled %d, leaving %+v; want 2 and none", n, store.Tasks())
+	}
+	if want := []string{"a", "c", "d"}; fmt.Sprint(cancelled) != fmt.Sprint(want) {
+		t.Errorf("cancelled %v, want %v", cancelled, want)
+	}
+}
+
+func TestCancelDebouncedTask(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	store := NewStoreWithOptions(Reduce, State{},
+		WithClock[State, AppAction](clock),
+		WithMiddleware(DebounceMiddleware[State, AppAction](clock, time.Second)))
+
+	store.Dispatch(IncrementAction{})
+	store.Dispatch(SetTargetAction{Target: 5})
+	tasks := store.Tasks()
+	if len(tasks) != 2 || tasks[0].Name != "debounced "+ActionName(IncrementAction{}) {
+		t.Fatalf("Tasks = %+v, want the two held actions", tasks)
+	}
+
+	// Cancelling drops the held action; the other one still lands
+	store.CancelTask(tasks[0].ID)
+	clock.Advance(time.Second)
+	if got := store.GetState(); got.Count != 0 || got.Target != 5 {
+		t.Errorf("state = %+v, want the increment dropped and the target set", got)
+	}
+	if len(store.Tasks()) != 0 || clock.Pending() != 0 {
+		t.Errorf("Tasks = %+v with %d timers pending after everything ran", store.Tasks(), clock.Pending())
+	}
+
+	store.Dispatch(IncrementAction{})
+	store.CancelAllTasks()
+	clock.Advance(time.Hour)
+	if got := store.GetState().Count; got != 0 {
+		t.Errorf("Count = %d after cancelling everything, want 0", got)
+	}
+}
@@ -0,0 +1,69 @@
+package counterstore
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
+)
+
+// FileTee Middleware writes every action it forwards to w as one encoded
+// JSON line, giving a log LoadFromTeeLog can replay. Unlike a Journal it
+// keeps no snapshots, so the log grows with every dispatch.
+func FileTeeMiddleware(w io.Writer, onError func(error)) Middleware[State, AppAction] {
+	if onError == nil {
+		onError = func(err error) { log.Printf("tee: %v", err) }
+	}
+	var mu sync.Mutex
+	return func(store *Store[State, AppAction], next Dispatch[AppAction]) Dispatch[AppAction] {
+		return func(action AppAction) error {
+			if err := next(action); err != nil {
+				return err
+			}
+			data, err := EncodeAction(action)
+			if err != nil {
+				onError(err)
+				return nil
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if _, err := w.Write(append(data, '\n')); err != nil {
+				onError(err)
+			}
+			return nil
+		}
+	}
+}
+
+// LoadFromTeeLog rebuilds a store by folding the actions logged at path over
+// initial. Blank lines are skipped; a line that fails to decode is reported
+// with its line number.
+func LoadFromTeeLog(path string, reducer Reducer[State, AppAction], initial State) (*Store[State, AppAction], error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	state := initial
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		action, err := DecodeAction(text)
+		if err != nil {
+			return nil, fmt.Errorf("tee log %s line %d: %w", path, line, err)
+		}
+		state = reducer(state, action)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("tee log %s: %w", path, err)
+	}
+	return NewStore(reducer, state), nil
+}
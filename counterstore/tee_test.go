@@ -0,0 +1,45 @@
+package counterstore
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadFromTeeLog(t *testing.T) {
+	var log bytes.Buffer
+	live := NewStore(Reduce, State{}, FileTeeMiddleware(&log, func(err error) { t.Errorf("tee: %v", err) }))
+	live.Dispatch(SetTargetAction{Target: 12})
+	live.Dispatch(AddAmountAction{Amount: 5})
+	live.Dispatch(IncrementAction{})
+	live.Dispatch(NegateAction{})
+
+	// Blank lines between records are skipped
+	data := strings.ReplaceAll(log.String(), "\n", "\n\n")
+	path := filepath.Join(t.TempDir(), "tee.jsonl")
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := LoadFromTeeLog(path, Reduce, State{})
+	if err != nil {
+		t.Fatalf("LoadFromTeeLog: %v", err)
+	}
+	got, want := store.GetState(), live.GetState()
+	if got.Count != want.Count || got.Target != want.Target || got.Status != want.Status {
+		t.Errorf("rebuilt %+v, want %+v", got, want)
+	}
+}
+
+func TestLoadFromTeeLogDecodeError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tee.jsonl")
+	data := `{"type":"Increment"}` + "\n\n" + `{"type":"Nope"}` + "\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadFromTeeLog(path, Reduce, State{}); err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("LoadFromTeeLog error = %v, want one naming line 3", err)
+	}
+}
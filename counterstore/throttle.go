@@ -0,0 +1,35 @@
+package counterstore
+
+import (
+	"sync"
+	"time"
+)
+
+// Throttle Middleware forwards the first action of each type and drops
+// further actions of that type until interval has passed since it. A
+// wrapped action, such as an annotated one, goes by the type it wraps. A nil
+// clock means the wall clock.
+func ThrottleMiddleware[S StateProvider[S], A Action[S]](clock Clock, interval time.Duration) Middleware[S, A] {
+	clock = orRealClock(clock)
+	return func(store *Store[S, A], next Dispatch[A]) Dispatch[A] {
+		var mu sync.Mutex
+		lastForwarded := map[string]time.Time{}
+
+		return func(action A) error {
+			key := actionKey(action)
+			now := clock.Now()
+
+			mu.Lock()
+			last, seen := lastForwarded[key]
+			if seen && now.Sub(last) < interval {
+				mu.Unlock()
+				store.ReportBlocked("throttled")
+				return nil
+			}
+			lastForwarded[key] = now
+			mu.Unlock()
+
+			return next(action)
+		}
+	}
+}
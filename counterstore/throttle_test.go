@@ -0,0 +1,60 @@
+package counterstore
+
+import (
+	"testing"
+	"time"
+)
+
+func TestThrottleMiddleware(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	store := NewStore(Reduce, State{}, ThrottleMiddleware[State, AppAction](clock, time.Second))
+
+	store.Dispatch(IncrementAction{})
+	store.Dispatch(IncrementAction{})
+	clock.Advance(999 * time.Millisecond)
+	store.Dispatch(IncrementAction{})
+	if got := store.GetState().Count; got != 1 {
+		t.Fatalf("Count = %d within the interval, want 1", got)
+	}
+
+	// Other action types have their own interval
+	store.Dispatch(DecrementAction{})
+	if got := store.GetState().Count; got != 0 {
+		t.Errorf("Count = %d, want the decrement forwarded", got)
+	}
+
+	clock.Advance(time.Millisecond)
+	store.Dispatch(IncrementAction{})
+	if got := store.GetState().Count; got != 1 {
+		t.Errorf("Count = %d after the interval, want the increment forwarded", got)
+	}
+}
+
+func TestThrottleMiddlewareAnnotated(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	store := NewStore(Reduce, State{}, ThrottleMiddleware[State, AppAction](clock, time.Second))
+	meta := map[string]string{"source": "test"}
+
+	// Annotated actions of different types are throttled apart
+	store.Dispatch(WithMeta(AddAmountAction{Amount: 10}, meta))
+	store.Dispatch(WithMeta(DecrementAction{}, meta))
+	if got := store.GetState().Count; got != 9 {
+		t.Fatalf("Count = %d, want both annotated actions forwarded", got)
+	}
+	// and as the same type as when bare
+	store.Dispatch(AddAmountAction{Amount: 10})
+	if got := store.GetState().Count; got != 9 {
+		t.Errorf("Count = %d, want the bare add throttled", got)
+	}
+}
+
+func TestThrottleMiddlewareReportsBlocked(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	store := NewStore(Reduce, State{}, ThrottleMiddleware[State, AppAction](clock, time.Second))
+	store.Dispatch(IncrementAction{})
+
+	result := store.DispatchWithResult(IncrementAction{})
+	if result.Applied || result.Reason != "throttled" {
+		t.Errorf("result = %+v, want blocked as throttled", result)
+	}
+}
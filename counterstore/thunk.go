@@ -0,0 +1,114 @@
+package counterstore
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+)
+
+// Thunk is an action that performs side effects and dispatches other actions
+// instead of changing state itself. ThunkMiddleware runs it; should it reach
+// the reducer anyway, it leaves state unchanged.
+//
+// A thunk sets either Run or, when it needs the store's random source or a
+// context for cancellation, RunContext. Context is the one RunContext gets;
+// it defaults to context.Background and is usually set by middleware such as
+// CancelPreviousMiddleware.
+type Thunk[S StateProvider[S], A Action[S]] struct {
+	Run        func(dispatch Dispatch[A], getState func() S)
+	RunContext func(ctx ThunkContext[S, A])
+	Context    context.Context
+}
+
+// ThunkContext is what a thunk runs with. Rand is the store's seeded random
+// source, so a replay with the same seed makes the same choices; it is shared
+// with RollMiddleware and other thunks and safe for concurrent use. Context is
+// cancelled when the thunk's work is no longer wanted; work the thunk starts
+// in the background should stop when it is done.
+type ThunkContext[S StateProvider[S], A Action[S]] struct {
+	Dispatch Dispatch[A]
+	GetState func() S
+	Rand     *rand.Rand
+	Context  context.Context
+}
+
+func (t Thunk[S, A]) Apply(s S) S {
+	return s
+}
+
+// Thunk Middleware runs thunks instead of passing them down the chain. Actions
+// they dispatch re-enter the store from the top, so they see all middleware.
+func ThunkMiddleware[S StateProvider[S], A Action[S]](store *Store[S, A], next Dispatch[A]) Dispatch[A] {
+	return func(action A) error {
+		thunk, ok := any(action).(Thunk[S, A])
+		if !ok {
+			return next(action)
+		}
+		if thunk.RunContext != nil {
+			ctx := thunk.Context
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			thunk.RunContext(ThunkContext[S, A]{Dispatch: store.Dispatch, GetState: store.GetState, Rand: store.rand, Context: ctx})
+		} else if thunk.Run != nil {
+			thunk.Run(store.Dispatch, store.GetState)
+		}
+		return nil
+	}
+}
+
+// CancelPrevious Middleware gives each thunk a context and, when a thunk
+// arrives whose group key matches one still running, cancels the running
+// thunk's context first, so only the latest of a group keeps running. The
+// context is also cancelled once its thunk returns, and the thunk is one of
+// the store's tasks until then; cancelling the task cancels the context. An
+// empty key opts a thunk out. Install it outside ThunkMiddleware; other
+// actions pass straight through.
+func CancelPreviousMiddleware[S StateProvider[S], A Action[S]](group func(A) string) Middleware[S, A] {
+	return func(store *Store[S, A], next Dispatch[A]) Dispatch[A] {
+		// inFlight is the running thunk of a group, known by its task ID
+		type inFlight struct {
+			id     uint64
+			cancel context.CancelFunc
+		}
+		var mu sync.Mutex
+		running := map[string]inFlight{}
+
+		return func(action A) error {
+			thunk, ok := any(action).(Thunk[S, A])
+			if !ok {
+				return next(action)
+			}
+			key := group(action)
+			if key == "" {
+				return next(action)
+			}
+
+			parent := thunk.Context
+			if parent == nil {
+				parent = context.Background()
+			}
+			ctx, cancel := context.WithCancel(parent)
+			id, done := store.RegisterTask("thunk "+key, cancel)
+			defer func() {
+				mu.Lock()
+				if running[key].id == id {
+					delete(running, key)
+				}
+				mu.Unlock()
+				done()
+				cancel()
+			}()
+
+			mu.Lock()
+			if previous, ok := running[key]; ok {
+				previous.cancel()
+			}
+			running[key] = inFlight{id: id, cancel: cancel}
+			mu.Unlock()
+
+			thunk.Context = ctx
+			return next(any(thunk).(A))
+		}
+	}
+}
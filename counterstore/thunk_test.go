@@ -0,0 +1,122 @@
+package counterstore
+
+import (
+	"context"
+	"testing"
+)
+
+func TestThunkMiddleware(t *testing.T) {
+	store := NewStoreWithOptions(Reduce, State{Count: 2},
+		WithRandSeed[State, AppAction](1),
+		WithMiddleware(ThunkMiddleware[State, AppAction]),
+	)
+	var sawCount int
+	store.Dispatch(Thunk[State, AppAction]{Run: func(dispatch Dispatch[AppAction], getState func() State) {
+		dispatch(IncrementAction{})
+		sawCount = getState().Count
+		dispatch(IncrementAction{})
+	}})
+	if sawCount != 3 || store.GetState().Count != 4 {
+		t.Errorf("thunk saw %d, final Count %d; want 3 and 4", sawCount, store.GetState().Count)
+	}
+
+	var ctx ThunkContext[State, AppAction]
+	store.Dispatch(Thunk[State, AppAction]{RunContext: func(c ThunkContext[State, AppAction]) { ctx = c }})
+	if ctx.Rand == nil || ctx.Context == nil || ctx.Context.Err() != nil {
+		t.Errorf("RunContext got %+v, want a random source and a live context", ctx)
+	}
+}
+
+func TestCancelPreviousMiddleware(t *testing.T) {
+	group := func(action AppAction) string {
+		if _, ok := action.(Thunk[State, AppAction]); ok {
+			return "load"
+		}
+		return ""
+	}
+	store := NewStore(Reduce, State{}, CancelPreviousMiddleware[State, AppAction](group), ThunkMiddleware[State, AppAction])
+
+	// The first load starts a second while it is still running
+	var loads []context.Context
+	var firstErr, secondErr error
+	var tasks []TaskInfo
+	var load Thunk[State, AppAction]
+	load = Thunk[State, AppAction]{RunContext: func(ctx ThunkContext[State, AppAction]) {
+		loads = append(loads, ctx.Context)
+		if len(loads) == 1 {
+			if ctx.Context.Err() != nil {
+				t.Error("first load cancelled before a second started")
+			}
+			store.Dispatch(load)
+			firstErr = ctx.Context.Err()
+			return
+		}
+		secondErr = ctx.Context.Err()
+		tasks = store.Tasks()
+	}}
+
+	store.Dispatch(load)
+	if firstErr != context.Canceled {
+		t.Errorf("first load's context err = %v after the second started, want cancelled", firstErr)
+	}
+	if secondErr != nil {
+		t.Errorf("the latest load was cancelled: %v", secondErr)
+	}
+	if len(tasks) != 2 || tasks[0].Name != "thunk load" {
+		t.Errorf("tasks while both ran = %+v, want both loads", tasks)
+	}
+
+	// Once they return, their contexts are released and their tasks done
+	if loads[1].Err() == nil {
+		t.Error("the latest load's context outlived it")
+	}
+	if got := store.Tasks(); len(got) != 0 {
+		t.Errorf("tasks after the loads returned = %+v", got)
+	}
+
+	// Other actions pass through untouched
+	store.Dispatch(IncrementAction{})
+	if got := store.GetState().Count; got != 1 {
+		t.Errorf("Count = %d, want 1", got)
+	}
+}
+
+func TestCancelPreviousKeepsGroupsApart(t *testing.T) {
+	// Each thunk names its group in its context
+	group := func(action AppAction) string {
+		if thunk, ok := action.(Thunk[State, AppAction]); ok && thunk.Context != nil {
+			return thunk.Context.Value(groupKey{}).(string)
+		}
+		return ""
+	}
+	store := NewStore(Reduce, State{}, CancelPreviousMiddleware[State, AppAction](group), ThunkMiddleware[State, AppAction])
+
+	// a starts b, which starts another a; each records its context error once
+	// the thunk it started has returned, so the inner a records first
+	errs := map[string][]error{}
+	thunkIn := func(name string, then func()) Thunk[State, AppAction] {
+		return Thunk[State, AppAction]{
+			Context: context.WithValue(context.Background(), groupKey{}, name),
+			RunContext: func(ctx ThunkContext[State, AppAction]) {
+				if then != nil {
+					then()
+				}
+				errs[name] = append(errs[name], ctx.Context.Err())
+			},
+		}
+	}
+	store.Dispatch(thunkIn("a", func() {
+		store.Dispatch(thunkIn("b", func() {
+			store.Dispatch(thunkIn("a", nil))
+		}))
+	}))
+
+	if errs["a"][0] != nil || errs["a"][1] == nil {
+		t.Errorf("a errors = %v, want the inner a to cancel the outer one", errs["a"])
+	}
+	if errs["b"][0] != nil {
+		t.Error("a load in another group cancelled b")
+	}
+}
+
+type groupKey struct{}
@@ -0,0 +1,57 @@
+package counterstore
+
+import (
+	"sync"
+	"time"
+)
+
+// TimelineEntry records when an action was dispatched and how long it took.
+// Type is the action's ActionName, looking through annotations and the like.
+type TimelineEntry struct {
+	Type      string
+	At        time.Time
+	DurMicros int64
+}
+
+// Timeline is auxiliary state holding the dispatch timeline, kept outside
+// State so it never ends up in snapshots or diffs
+type Timeline struct {
+	mu      sync.Mutex
+	clock   Clock
+	entries []TimelineEntry
+}
+
+// NewTimeline creates an empty timeline reading time from clock, or the
+// wall clock when clock is nil
+func NewTimeline(clock Clock) *Timeline {
+	return &Timeline{clock: orRealClock(clock)}
+}
+
+// Entries returns a copy of the recorded entries in dispatch order
+func (t *Timeline) Entries() []TimelineEntry {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]TimelineEntry(nil), t.entries...)
+}
+
+func (t *Timeline) append(entry TimelineEntry) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.entries = append(t.entries, entry)
+}
+
+// Timeline Middleware appends an entry per dispatched action to timeline
+func TimelineMiddleware[S StateProvider[S], A Action[S]](timeline *Timeline) Middleware[S, A] {
+	return func(store *Store[S, A], next Dispatch[A]) Dispatch[A] {
+		return func(action A) error {
+			start := timeline.clock.Now()
+			err := next(action)
+			timeline.append(TimelineEntry{
+				Type:      actionKey(action),
+				At:        start,
+				DurMicros: timeline.clock.Now().Sub(start).Microseconds(),
+			})
+			return err
+		}
+	}
+}
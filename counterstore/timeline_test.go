@@ -0,0 +1,53 @@
+package counterstore
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimelineMiddleware(t *testing.T) {
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	clock := NewFakeClock(start)
+	timeline := NewTimeline(clock)
+
+	// slow makes every reducer run take 250µs on the fake clock
+	slow := func(store *Store[State, AppAction], next Dispatch[AppAction]) Dispatch[AppAction] {
+		return func(action AppAction) error {
+			clock.Advance(250 * time.Microsecond)
+			return next(action)
+		}
+	}
+	store := NewStore(Reduce, State{}, TimelineMiddleware[State, AppAction](timeline), slow)
+
+	store.Dispatch(IncrementAction{})
+	clock.Advance(time.Second)
+	store.Dispatch(DecrementAction{})
+	store.Dispatch(ResetAction{})
+
+	want := []TimelineEntry{
+		{Type: "Increment", At: start, DurMicros: 250},
+		{Type: "Decrement", At: start.Add(time.Second + 250*time.Microsecond), DurMicros: 250},
+		{Type: "Reset", At: start.Add(time.Second + 500*time.Microsecond), DurMicros: 250},
+	}
+	got := timeline.Entries()
+	if len(got) != len(want) {
+		t.Fatalf("%d entries, want %d: %v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i].Type != want[i].Type || !got[i].At.Equal(want[i].At) || got[i].DurMicros != want[i].DurMicros {
+			t.Errorf("entry %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestTimelineEntriesIsACopy(t *testing.T) {
+	timeline := NewTimeline(NewFakeClock(time.Unix(0, 0)))
+	store := NewStore(Reduce, State{}, TimelineMiddleware[State, AppAction](timeline))
+	store.Dispatch(IncrementAction{})
+
+	entries := timeline.Entries()
+	entries[0].Type = "changed"
+	if got := timeline.Entries()[0].Type; got != "Increment" {
+		t.Errorf("Entries shares storage with the timeline: Type = %q", got)
+	}
+}
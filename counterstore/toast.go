@@ -0,0 +1,128 @@
+package counterstore
+
+import (
+	"log"
+	"time"
+)
+
+// defaultToastTTL is how long a toast stays up when its action gives no TTL
+const defaultToastTTL = 3 * time.Second
+
+// Toast is a transient notification, shown until ExpiresAt
+type Toast struct {
+	Text      string
+	ExpiresAt time.Time
+}
+
+// addToast returns toasts with a new one expiring ttl after now. It never
+// modifies the slice it is given.
+func addToast(toasts []Toast, text string, now time.Time, ttl time.Duration) []Toast {
+	if ttl <= 0 {
+		ttl = defaultToastTTL
+	}
+	next := make([]Toast, 0, len(toasts)+1)
+	next = append(next, toasts...)
+	return append(next, Toast{Text: text, ExpiresAt: now.Add(ttl)})
+}
+
+// expireToasts returns the toasts still showing at now, keeping their order.
+// It never modifies the slice it is given.
+func expireToasts(toasts []Toast, now time.Time) []Toast {
+	var next []Toast
+	for _, t := range toasts {
+		if now.Before(t.ExpiresAt) {
+			next = append(next, t)
+		}
+	}
+	return next
+}
+
+// ShowToastAction shows Text for TTL from At. ToastMiddleware stamps At with
+// its clock when it is left zero.
+type ShowToastAction struct {
+	Text string
+	TTL  time.Duration
+	At   time.Time
+}
+
+func (a ShowToastAction) Undoable() bool { return false }
+
+func (a ShowToastAction) Apply(s State) State {
+	state := s.Copy()
+	state.Toasts = addToast(state.Toasts, a.Text, a.At, a.TTL)
+	return state
+}
+
+// ExpireToastsAction drops the toasts that have expired by Now
+type ExpireToastsAction struct {
+	Now time.Time
+}
+
+func (a ExpireToastsAction) Undoable() bool { return false }
+
+func (a ExpireToastsAction) Apply(s State) State {
+	state := s.Copy()
+	state.Toasts = expireToasts(state.Toasts, a.Now)
+	return state
+}
+
+// ToastRule picks the toast, if any, to show after action took the state
+// from prev to next; "" means none
+type ToastRule func(action AppAction, prev, next State) string
+
+// DefaultToastRule announces resets and reaching a non-zero target
+func DefaultToastRule(action AppAction, prev, next State) string {
+	switch action.(type) {
+	case ResetAction:
+		return "Counter reset"
+	case ConfirmResetAction:
+		if prev.UI.ConfirmPending {
+			return "Counter reset"
+		}
+	}
+	if next.Target != 0 && next.Count == next.Target && prev.Count != prev.Target {
+		return "Target reached"
+	}
+	return ""
+}
+
+// Toast Middleware raises the toasts rule asks for, stamps ShowToastActions
+// with clock's time, and dispatches an ExpireToastsAction when each toast's
+// time is up. A nil clock means the wall clock.
+func ToastMiddleware(clock Clock, rule ToastRule) Middleware[State, AppAction] {
+	clock = orRealClock(clock)
+	return func(store *Store[State, AppAction], next Dispatch[AppAction]) Dispatch[AppAction] {
+		expire := func() {
+			if err := store.Dispatch(ExpireToastsAction{Now: clock.Now()}); err != nil {
+				log.Printf("expire toasts: %v", err)
+			}
+		}
+
+		return func(action AppAction) error {
+			if show, ok := action.(ShowToastAction); ok {
+				if show.At.IsZero() {
+					show.At = clock.Now()
+				}
+				if show.TTL <= 0 {
+					show.TTL = defaultToastTTL
+				}
+				if err := next(show); err != nil {
+					return err
+				}
+				clock.AfterFunc(show.TTL, expire)
+				return nil
+			}
+
+			prev := store.GetState()
+			if err := next(action); err != nil {
+				return err
+			}
+			if rule != nil {
+				if text := rule(action, prev, store.GetState()); text != "" {
+					return store.Dispatch(ShowToastAction{Text: text})
+				}
+			}
+			return nil
+		}
+	}
+}
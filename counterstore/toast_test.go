@@ -0,0 +1,89 @@
+package counterstore
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAddAndExpireToasts(t *testing.T) {
+	var toasts []Toast
+	toasts = addToast(toasts, "a", epoch, time.Second)
+	toasts = addToast(toasts, "b", epoch, 3*time.Second)
+	withDefault := addToast(toasts, "c", epoch, 0)
+
+	if len(toasts) != 2 {
+		t.Fatalf("addToast modified its input: %v", toasts)
+	}
+	if got := withDefault[2].ExpiresAt; !got.Equal(epoch.Add(defaultToastTTL)) {
+		t.Errorf("zero TTL expires at %v, want the default TTL", got)
+	}
+
+	tests := []struct {
+		at   time.Duration
+		want []string
+	}{
+		{0, []string{"a", "b", "c"}},
+		{999 * time.Millisecond, []string{"a", "b", "c"}},
+		{time.Second, []string{"b", "c"}},
+		{3 * time.Second, nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, toast := range expireToasts(withDefault, epoch.Add(tt.at)) {
+			got = append(got, toast.Text)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("at +%v showing %v, want %v", tt.at, got, tt.want)
+		}
+	}
+	if len(withDefault) != 3 {
+		t.Error("expireToasts modified its input")
+	}
+}
+
+func TestToastMiddleware(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	store := NewStore(Reduce, State{Count: 3}, ToastMiddleware(clock, DefaultToastRule))
+
+	store.Dispatch(ShowToastAction{Text: "hello", TTL: 2 * time.Second})
+	clock.Advance(time.Second)
+	store.Dispatch(ResetAction{})
+
+	toasts := store.GetState().Toasts
+	if len(toasts) != 2 || toasts[0].Text != "hello" || toasts[1].Text != "Counter reset" {
+		t.Fatalf("toasts = %+v", toasts)
+	}
+	if !toasts[1].ExpiresAt.Equal(epoch.Add(time.Second + defaultToastTTL)) {
+		t.Errorf("rule toast expires at %v", toasts[1].ExpiresAt)
+	}
+
+	clock.Advance(time.Second)
+	if toasts := store.GetState().Toasts; len(toasts) != 1 || toasts[0].Text != "Counter reset" {
+		t.Errorf("after the first TTL: %+v", toasts)
+	}
+	clock.Advance(defaultToastTTL)
+	if toasts := store.GetState().Toasts; len(toasts) != 0 {
+		t.Errorf("after every TTL: %+v", toasts)
+	}
+}
+
+func TestDefaultToastRule(t *testing.T) {
+	tests := []struct {
+		name       string
+		action     AppAction
+		prev, next State
+		want       string
+	}{
+		{"reset", ResetAction{}, State{Count: 4}, State{}, "Counter reset"},
+		{"target reached", IncrementAction{}, State{Count: 4, Target: 5}, State{Count: 5, Target: 5}, "Target reached"},
+		{"already at target", SetTargetAction{Target: 5}, State{Count: 5, Target: 5}, State{Count: 5, Target: 5}, ""},
+		{"no target", DecrementAction{}, State{Count: 1}, State{}, ""},
+		{"plain increment", IncrementAction{}, State{Count: 1, Target: 5}, State{Count: 2, Target: 5}, ""},
+	}
+	for _, tt := range tests {
+		if got := DefaultToastRule(tt.action, tt.prev, tt.next); got != tt.want {
+			t.Errorf("%s: %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package counterstore
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// TraceMetaKey is the Annotated metadata key TraceMiddleware puts an
+// action's trace ID under
+const TraceMetaKey = "trace"
+
+type traceKey struct{}
+
+// WithTraceID returns a copy of ctx carrying the trace ID id
+func WithTraceID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, traceKey{}, id)
+}
+
+// TraceID returns the trace ID ctx carries, or "" without one
+func TraceID(ctx context.Context) string {
+	id, _ := ctx.Value(traceKey{}).(string)
+	return id
+}
+
+// ActionTraceID returns the trace ID an action carries in its metadata, or
+// for a thunk in its Context, or "" without one
+func ActionTraceID(action AppAction) string {
+	if thunk, ok := action.(Thunk[State, AppAction]); ok {
+		if thunk.Context == nil {
+			return ""
+		}
+		return TraceID(thunk.Context)
+	}
+	if m, ok := action.(Metadata); ok {
+		return m.Metadata()[TraceMetaKey]
+	}
+	return ""
+}
+
+// newTraceID returns a random 16-digit hex trace ID
+func newTraceID() string {
+	var b [8]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// Trace Middleware gives each top-level dispatch a trace ID from newID, or
+// a random one when newID is nil, and passes it on to everything the
+// dispatch causes. Actions go on annotated with the ID under TraceMetaKey,
+// so LoggingMiddleware and ProvenanceMiddleware report it. Thunks keep their
+// type: their Context carries the ID, for TraceID, and every action they
+// dispatch inherits it, so a user action can be correlated with all its
+// downstream effects. An action dispatched already carrying an ID keeps it.
+// Install it outermost, outside ThunkMiddleware.
+func TraceMiddleware(newID func() string) Middleware[State, AppAction] {
+	if newID == nil {
+		newID = newTraceID
+	}
+	return func(store *Store[State, AppAction], next Dispatch[AppAction]) Dispatch[AppAction] {
+		return func(action AppAction) error {
+			id := ActionTraceID(action)
+			if id == "" {
+				id = newID()
+			}
+			if thunk, ok := action.(Thunk[State, AppAction]); ok {
+				return next(traceThunk(thunk, id))
+			}
+			return next(withTrace(action, id))
+		}
+	}
+}
+
+// traceThunk returns thunk with id in its context and on every action it
+// dispatches
+func traceThunk(thunk Thunk[State, AppAction], id string) Thunk[State, AppAction] {
+	thunk = withThunkTrace(thunk, id)
+
+	traced := func(dispatch Dispatch[AppAction]) Dispatch[AppAction] {
+		return func(action AppAction) error {
+			return dispatch(withTrace(action, id))
+		}
+	}
+	if run := thunk.Run; run != nil {
+		thunk.Run = func(dispatch Dispatch[AppAction], getState func() State) {
+			run(traced(dispatch), getState)
+		}
+	}
+	if run := thunk.RunContext; run != nil {
+		thunk.RunContext = func(ctx ThunkContext[State, AppAction]) {
+			ctx.Dispatch = traced(ctx.Dispatch)
+			run(ctx)
+		}
+	}
+	return thunk
+}
+
+// withThunkTrace returns thunk with its Context carrying id
+func withThunkTrace(thunk Thunk[State, AppAction], id string) Thunk[State, AppAction] {
+	parent := thunk.Context
+	if parent == nil {
+		parent = context.Background()
+	}
+	if TraceID(parent) != id {
+		thunk.Context = WithTraceID(parent, id)
+	}
+	return thunk
+}
+
+// withTrace marks action with the trace ID id: a thunk in its Context,
+// since ThunkMiddleware would not recognize it annotated, and any other
+// action in its metadata, added to that of an action already annotated
+func withTrace(action AppAction, id string) AppAction {
+	if thunk, ok := action.(Thunk[State, AppAction]); ok {
+		return withThunkTrace(thunk, id)
+	}
+	annotated, ok := action.(Annotated)
+	if !ok {
+		return WithMeta(action, map[string]string{TraceMetaKey: id})
+	}
+	if annotated.Meta[TraceMetaKey] == id {
+		return annotated
+	}
+	annotated = WithMeta(annotated.AppAction, annotated.Meta)
+	annotated.Meta[TraceMetaKey] = id
+	return annotated
+}
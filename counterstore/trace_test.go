@@ -0,0 +1,99 @@
+package counterstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"testing"
+)
+
+// sequentialIDs returns trace IDs t1, t2 and so on
+func sequentialIDs() func() string {
+	n := 0
+	return func() string {
+		n++
+		return fmt.Sprintf("t%d", n)
+	}
+}
+
+func TestTraceMiddlewareThunkChildren(t *testing.T) {
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+
+	var sink MemoryAuditSink
+	var seen []string
+	store := NewStore(Reduce, State{},
+		TraceMiddleware(sequentialIDs()),
+		ThunkMiddleware[State, AppAction],
+		LoggingMiddleware[State, AppAction],
+		ProvenanceMiddleware(&sink, nil),
+	)
+
+	store.Dispatch(IncrementAction{})
+	logged.Reset()
+	store.Dispatch(Thunk[State, AppAction]{RunContext: func(ctx ThunkContext[State, AppAction]) {
+		seen = append(seen, TraceID(ctx.Context))
+		ctx.Dispatch(AddAmountAction{Amount: 2})
+		// A nested thunk inherits the trace too
+		ctx.Dispatch(Thunk[State, AppAction]{Run: func(dispatch Dispatch[AppAction], getState func() State) {
+			dispatch(IncrementAction{})
+		}})
+		ctx.Dispatch(SetTargetAction{Target: 9})
+	}})
+
+	if len(seen) != 1 || seen[0] != "t2" {
+		t.Errorf("thunk saw trace IDs %v, want t2", seen)
+	}
+
+	// Every log entry of the thunk's dispatch carries its trace ID
+	entries := regexp.MustCompile(`Meta: map\[[^\]]*\]`).FindAllString(logged.String(), -1)
+	if len(entries) != 3 {
+		t.Fatalf("%d log entries with metadata, want 3:\n%s", len(entries), logged.String())
+	}
+	for _, entry := range entries {
+		if entry != "Meta: map[trace:t2]" {
+			t.Errorf("log entry %q, want the parent trace t2", entry)
+		}
+	}
+
+	var traces []string
+	for _, entry := range sink.Entries() {
+		traces = append(traces, entry.TraceID)
+	}
+	if want := []string{"t1", "t2", "t2", "t2"}; fmt.Sprint(traces) != fmt.Sprint(want) {
+		t.Errorf("audit trace IDs = %v, want %v", traces, want)
+	}
+	if got := store.GetState(); got.Count != 4 || got.Target != 9 {
+		t.Errorf("state = %+v, want Count 4 and Target 9", got)
+	}
+}
+
+func TestTraceMiddlewareKeepsExistingID(t *testing.T) {
+	var sink MemoryAuditSink
+	store := NewStore(Reduce, State{}, TraceMiddleware(sequentialIDs()), ThunkMiddleware[State, AppAction], ProvenanceMiddleware(&sink, nil))
+
+	store.Dispatch(WithMeta(IncrementAction{}, map[string]string{TraceMetaKey: "upstream", "source": "net"}))
+	var inThunk string
+	store.Dispatch(Thunk[State, AppAction]{
+		Context: WithTraceID(context.Background(), "request-7"),
+		RunContext: func(ctx ThunkContext[State, AppAction]) {
+			inThunk = TraceID(ctx.Context)
+			ctx.Dispatch(IncrementAction{})
+		},
+	})
+
+	entries := sink.Entries()
+	if len(entries) != 2 || entries[0].TraceID != "upstream" || entries[1].TraceID != "request-7" {
+		t.Errorf("audit entries = %+v, want traces upstream and request-7", entries)
+	}
+	if inThunk != "request-7" {
+		t.Errorf("thunk context trace = %q, want request-7", inThunk)
+	}
+	if meta := ActionTraceID(IncrementAction{}); meta != "" {
+		t.Errorf("ActionTraceID of an untraced action = %q, want empty", meta)
+	}
+}
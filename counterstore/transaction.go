@@ -0,0 +1,34 @@
+package counterstore
+
+import "fmt"
+
+// TransactionAction applies Steps in order as one action. The store
+// validates every step against the state the steps before it produce, so if
+// any step fails none of them apply.
+type TransactionAction struct {
+	Steps []AppAction
+}
+
+func (a TransactionAction) Apply(s State) State {
+	for _, step := range a.Steps {
+		s = step.Apply(s)
+	}
+	return s
+}
+
+func (a TransactionAction) Validate(s State) error {
+	for i, step := range a.Steps {
+		if v, ok := step.(Validator[State]); ok {
+			if err := v.Validate(s); err != nil {
+				return fmt.Errorf("transaction step %d (%s): %w", i+1, ActionName(step), err)
+			}
+		}
+		s = step.Apply(s)
+	}
+	return nil
+}
+
+// Undoable makes the transaction an undo step if any of its steps would be
+func (a TransactionAction) Undoable() bool {
+	return anyUndoable(a.Steps)
+}
@@ -0,0 +1,58 @@
+package counterstore
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTransactionAllPass(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 2, Flags: map[string]bool{FlagNoNegatives: true}})
+	notified := 0
+	store.Subscribe(func() { notified++ })
+
+	err := store.Dispatch(TransactionAction{Steps: []AppAction{
+		AddAmountAction{Amount: 3}, DecrementAction{}, SetTargetAction{Target: 9},
+	}})
+	if err != nil {
+		t.Fatalf("Dispatch: %v", err)
+	}
+	if got := store.GetState(); got.Count != 4 || got.Target != 9 {
+		t.Errorf("state = %+v, want Count 4, Target 9", got)
+	}
+	if notified != 1 {
+		t.Errorf("%d notifications, want 1", notified)
+	}
+	if !store.Undo() || store.GetState().Count != 2 || store.GetState().Target != 0 {
+		t.Errorf("one Undo left %+v, want the state before the transaction", store.GetState())
+	}
+}
+
+func TestTransactionStepFails(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 1, Flags: map[string]bool{FlagNoNegatives: true}})
+	notified := 0
+	store.Subscribe(func() { notified++ })
+
+	// The second step is only invalid against the state the first leaves
+	err := store.Dispatch(TransactionAction{Steps: []AppAction{
+		DecrementAction{}, DecrementAction{}, SetTargetAction{Target: 9},
+	}})
+	if !errors.Is(err, ErrNegativeCount) || !strings.Contains(err.Error(), "step 2 (Decrement)") {
+		t.Fatalf("Dispatch error = %v, want step 2 rejected", err)
+	}
+	if got := store.GetState(); got.Count != 1 || got.Target != 0 {
+		t.Errorf("state = %+v, want it untouched", got)
+	}
+	if notified != 0 || store.CanUndo() {
+		t.Errorf("aborted transaction notified %d times, undo %v", notified, store.CanUndo())
+	}
+}
+
+func TestTransactionUndoable(t *testing.T) {
+	if (TransactionAction{Steps: []AppAction{ToggleMinimalAction{}}}).Undoable() {
+		t.Error("a transaction of non-undoable steps is undoable")
+	}
+	if !(TransactionAction{Steps: []AppAction{ToggleMinimalAction{}, IncrementAction{}}}).Undoable() {
+		t.Error("a transaction with an undoable step is not undoable")
+	}
+}
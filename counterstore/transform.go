@@ -0,0 +1,32 @@
+package counterstore
+
+import "errors"
+
+// errTransformDropped tells dispatchInternal a transform dropped the action,
+// so there is nothing to notify about
+var errTransformDropped = errors.New("action dropped by transform")
+
+// AddTransform registers fn to rewrite actions just before they reach the
+// reducer, after the whole middleware chain has run. Transforms run in the
+// order they were added, each seeing the previous one's result and the
+// current state; returning the action unchanged leaves it alone, and
+// returning nil drops it, so nothing is committed and Dispatch returns nil.
+// Transforms run under the store's lock, like the reducer, and must not call
+// back into the store.
+func (s *Store[S, A]) AddTransform(fn func(action A, state S) A) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.transforms = append(s.transforms, fn)
+}
+
+// transform runs the registered transforms over action against state and
+// reports false if one dropped it. Callers must hold s.mu.
+func (s *Store[S, A]) transform(action A, state S) (A, bool) {
+	for _, fn := range s.transforms {
+		action = fn(action, state)
+		if any(action) == nil {
+			return action, false
+		}
+	}
+	return action, true
+}
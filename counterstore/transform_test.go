@@ -0,0 +1,73 @@
+package counterstore
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAddTransformChain(t *testing.T) {
+	var reduced []AppAction
+	store := NewStore(func(state State, action AppAction) State {
+		reduced = append(reduced, action)
+		return Reduce(state, action)
+	}, State{Target: 10})
+	reduced = nil
+
+	var order []string
+	// clamp keeps SetCountAction within the target
+	store.AddTransform(func(action AppAction, state State) AppAction {
+		order = append(order, "clamp")
+		if set, ok := action.(SetCountAction); ok && set.Value > state.Target {
+			return SetCountAction{Value: state.Target}
+		}
+		return action
+	})
+	// double sees the clamped action and doubles any SetCountAction
+	store.AddTransform(func(action AppAction, state State) AppAction {
+		order = append(order, "double")
+		if set, ok := action.(SetCountAction); ok {
+			return SetCountAction{Value: set.Value * 2}
+		}
+		return action
+	})
+
+	store.Dispatch(SetCountAction{Value: 99})
+	store.Dispatch(IncrementAction{})
+
+	want := []AppAction{SetCountAction{Value: 20}, IncrementAction{}}
+	if !reflect.DeepEqual(reduced, want) {
+		t.Errorf("reducer saw %v, want %v", reduced, want)
+	}
+	if wantOrder := []string{"clamp", "double", "clamp", "double"}; !reflect.DeepEqual(order, wantOrder) {
+		t.Errorf("transforms ran as %v, want %v", order, wantOrder)
+	}
+	if got := store.GetState().Count; got != 21 {
+		t.Errorf("Count = %d, want 21", got)
+	}
+}
+
+func TestAddTransformDrops(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 3})
+	store.AddTransform(func(action AppAction, state State) AppAction {
+		if _, ok := action.(ResetAction); ok {
+			return nil
+		}
+		return action
+	})
+	laterRan := false
+	store.AddTransform(func(action AppAction, state State) AppAction {
+		laterRan = true
+		return action
+	})
+	version := store.Version()
+
+	if err := store.Dispatch(ResetAction{}); err != nil {
+		t.Errorf("dropped dispatch returned %v, want nil", err)
+	}
+	if got := store.GetState().Count; got != 3 || store.Version() != version || store.CanUndo() {
+		t.Errorf("dropped reset committed: Count %d, CanUndo %v", got, store.CanUndo())
+	}
+	if laterRan {
+		t.Error("a transform after the dropping one still ran")
+	}
+}
@@ -0,0 +1,23 @@
+package counterstore
+
+// UIState is the UI-only slice of State: whether the help overlay is open,
+// whether a reset is waiting for confirmation, whether the window has gone
+// idle, whether the stopwatch is running and the dwell recorded at each
+// count. All of it is transient, and no transient field lives outside it.
+// Actions that set it are not undoable, undo and redo keep its current
+// value, State.Hash ignores it, and it is left out of State's JSON, so
+// sessions, journals and state files never persist it and loading one
+// leaves it at its zero value.
+type UIState struct {
+	ShowHelp       bool
+	ConfirmPending bool
+	Idle           bool
+	Stopwatch      bool
+	Dwell          map[int]int
+}
+
+// Copy returns an independent copy of the UI state
+func (u UIState) Copy() UIState {
+	u.Dwell = copyDwell(u.Dwell)
+	return u
+}
@@ -0,0 +1,100 @@
+package counterstore
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// transientActions set every field of the UI state
+var transientActions = []AppAction{
+	SetHelpAction{Visible: true},
+	RequestResetAction{},
+	SetIdleAction{Idle: true},
+	StartStopwatchAction{},
+	RecordDwellAction{Value: 3, Amount: 2},
+}
+
+func TestTransientUIStaysOutOfHistory(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 3})
+	for _, action := range transientActions {
+		store.Dispatch(action)
+	}
+	if store.CanUndo() {
+		t.Fatalf("%d undo steps after only transient actions, want none", store.HistoryLen())
+	}
+
+	// Undoing a real step keeps the UI as it is now
+	store.Dispatch(IncrementAction{})
+	store.Dispatch(StopStopwatchAction{})
+	store.Dispatch(SetHelpAction{Visible: false})
+	want := store.GetState().UI
+	if !store.Undo() {
+		t.Fatal("nothing to undo")
+	}
+	got := store.GetState()
+	if got.Count != 3 {
+		t.Errorf("Count after undo = %d, want 3", got.Count)
+	}
+	if got.UI.ShowHelp != want.ShowHelp || got.UI.ConfirmPending != want.ConfirmPending ||
+		got.UI.Idle != want.Idle || got.UI.Stopwatch != want.Stopwatch || got.UI.Dwell[3] != 2 {
+		t.Errorf("UI after undo = %+v, want the current %+v", got.UI, want)
+	}
+	store.Redo()
+	if got := store.GetState(); got.Count != 4 || got.UI.Stopwatch || got.UI.Dwell[3] != 2 {
+		t.Errorf("after redo: Count %d, UI %+v", got.Count, got.UI)
+	}
+}
+
+func TestUICopyIsIndependent(t *testing.T) {
+	state := State{UI: UIState{Dwell: map[int]int{1: 1}}}
+	copied := state.Copy()
+	copied.UI.Dwell[1] = 9
+	if state.UI.Dwell[1] != 1 {
+		t.Error("a copy shares the dwell map")
+	}
+	kept := State{}.KeepTransient(state)
+	kept.UI.Dwell[1] = 9
+	if state.UI.Dwell[1] != 1 {
+		t.Error("KeepTransient shares the dwell map")
+	}
+}
+
+func TestJSONPersisterSkipsUI(t *testing.T) {
+	storage := NewMemoryStorage(JSONSerializer{})
+	store := NewStore(Reduce, State{Count: 5}, PersistMiddleware(storage, func(err error) { t.Error(err) }))
+	for _, action := range transientActions {
+		store.Dispatch(action)
+	}
+	store.Dispatch(IncrementAction{})
+	if got := store.GetState().UI; !got.ShowHelp || !got.Stopwatch {
+		t.Fatalf("UI = %+v, want the transient actions applied", got)
+	}
+
+	data, err := JSONSerializer{}.Marshal(store.GetState())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatal(err)
+	}
+	for name := range fields {
+		for _, transient := range []string{"UI", "ShowHelp", "ConfirmPending", "Idle", "Stopwatch", "Dwell"} {
+			if strings.EqualFold(name, transient) {
+				t.Errorf("JSON holds the transient %s: %s", name, data)
+			}
+		}
+	}
+
+	loaded, err := storage.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Count != 6 {
+		t.Errorf("loaded Count = %d, want 6", loaded.Count)
+	}
+	if ui := loaded.UI; ui.ShowHelp || ui.ConfirmPending || ui.Idle || ui.Stopwatch || ui.Dwell != nil {
+		t.Errorf("loaded UI = %+v, want it zero", ui)
+	}
+}
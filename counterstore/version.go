@@ -0,0 +1,114 @@
+package counterstore
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// versionLimit is the number of past versions ChangedSince can compare against
+const versionLimit = defaultHistoryLimit
+
+// versionedState is a committed state and the version it was committed as
+type versionedState[S any] struct {
+	version uint64
+	state   S
+}
+
+// ErrVersionUnavailable is wrapped by ChangedSince for a version that is no
+// longer, or not yet, kept
+var ErrVersionUnavailable = errors.New("version unavailable")
+
+// recordVersion bumps the version for a newly committed state and keeps it
+// for ChangedSince. The store's initial state is version 0. Callers must
+// hold s.mu.
+func (s *Store[S, A]) recordVersion(state S) {
+	if len(s.versions) > 0 {
+		s.version++
+	}
+	s.versions = append(s.versions, versionedState[S]{version: s.version, state: state})
+	if len(s.versions) > versionLimit {
+		s.versions = s.versions[len(s.versions)-versionLimit:]
+	}
+}
+
+// Version returns the number of changes committed since the store was
+// created. Every dispatch, undo, redo or restore bumps it, even when the
+// resulting state is equal to the previous one; in FrameCommitMode each
+// CommitFrame with changes bumps it once.
+func (s *Store[S, A]) Version() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.version
+}
+
+// Dirty returns the current version and whether it has moved since the last
+// call, so a render loop polling once per frame sees any number of changes
+// in between as one. The store's initial state is not dirty.
+func (s *Store[S, A]) Dirty() (uint64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	dirty := s.version != s.polled
+	s.polled = s.version
+	return s.version, dirty
+}
+
+// ChangedSince reports, for each named selector, whether its output for the
+// state at version v differs from its output now. Only the most recent
+// versionLimit versions are kept; older ones return ErrVersionUnavailable.
+func (s *Store[S, A]) ChangedSince(v uint64, selectors map[string]func(S) any) (map[string]bool, error) {
+	s.mu.RLock()
+	current := s.state
+	var then S
+	found := false
+	if len(s.versions) > 0 {
+		oldest := s.versions[0].version
+		if v >= oldest && v <= s.version {
+			then, found = s.versions[v-oldest].state, true
+		}
+	}
+	s.mu.RUnlock()
+
+	if !found {
+		return nil, fmt.Errorf("changed since %d: %w", v, ErrVersionUnavailable)
+	}
+	changed := make(map[string]bool, len(selectors))
+	for name, selector := range selectors {
+		changed[name] = !reflect.DeepEqual(selector(then), selector(current))
+	}
+	return changed, nil
+}
+
+// versionedState returns a copy of the committed state together with its
+// version, read under one lock so the two agree
+func (s *Store[S, A]) versionedState() (S, uint64) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.committedLocked().Copy(), s.version
+}
+
+// SetStateIfVersion is SetState guarded by the version: it replaces the
+// state only while Version is still expected, so a server push cannot
+// clobber local changes made since the caller last looked. It reports
+// whether the state was replaced, along with the version afterwards; on a
+// mismatch that is the current version, for the caller to rebase onto. A
+// state the invariant rejects is not applied and comes back as an
+// *InvariantViolation.
+func (s *Store[S, A]) SetStateIfVersion(expected uint64, state S) (bool, uint64, error) {
+	s.mu.Lock()
+	if s.version != expected {
+		version := s.version
+		s.mu.Unlock()
+		return false, version, nil
+	}
+	err := s.replaceState(state)
+	version := s.version
+	s.mu.Unlock()
+	if err != nil {
+		return false, version, err
+	}
+
+	s.changed()
+	s.settle()
+	return true, version, nil
+}
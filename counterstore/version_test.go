@@ -0,0 +1,160 @@
+package counterstore
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+var changeSelectors = map[string]func(State) any{
+	"count":  func(s State) any { return s.Count },
+	"target": func(s State) any { return s.Target },
+	"flags":  func(s State) any { return s.Flags },
+}
+
+func TestChangedSince(t *testing.T) {
+	store := NewStore(Reduce, State{})
+	if got := store.Version(); got != 0 {
+		t.Fatalf("initial Version = %d, want 0", got)
+	}
+	store.Dispatch(IncrementAction{})
+	v1 := store.Version()
+	store.Dispatch(SetTargetAction{Target: 5})
+	store.Dispatch(SetCountAction{Value: 1}) // equal state, still a version
+	if got := store.Version(); got != 3 {
+		t.Fatalf("Version = %d, want 3", got)
+	}
+
+	tests := []struct {
+		since uint64
+		want  map[string]bool
+	}{
+		{0, map[string]bool{"count": true, "target": true, "flags": false}},
+		{v1, map[string]bool{"count": false, "target": true, "flags": false}},
+		{3, map[string]bool{"count": false, "target": false, "flags": false}},
+	}
+	for _, tt := range tests {
+		got, err := store.ChangedSince(tt.since, changeSelectors)
+		if err != nil {
+			t.Fatalf("ChangedSince(%d): %v", tt.since, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ChangedSince(%d) = %v, want %v", tt.since, got, tt.want)
+		}
+	}
+
+	if _, err := store.ChangedSince(4, changeSelectors); !errors.Is(err, ErrVersionUnavailable) {
+		t.Errorf("a future version: err %v, want ErrVersionUnavailable", err)
+	}
+}
+
+func TestChangedSinceForgottenVersion(t *testing.T) {
+	store := NewStore(Reduce, State{})
+	for i := 0; i < versionLimit+3; i++ {
+		store.Dispatch(IncrementAction{})
+	}
+	if _, err := store.ChangedSince(0, changeSelectors); !errors.Is(err, ErrVersionUnavailable) {
+		t.Errorf("a dropped version: err %v, want ErrVersionUnavailable", err)
+	}
+	oldest := store.Version() - versionLimit + 1
+	if got, err := store.ChangedSince(oldest, changeSelectors); err != nil || !got["count"] {
+		t.Errorf("oldest kept version: %v, %v", got, err)
+	}
+}
+
+func TestVersionBumpsOnUndoAndRedo(t *testing.T) {
+	store := NewStore(Reduce, State{})
+	store.Dispatch(IncrementAction{})
+	store.Undo()
+	store.Redo()
+	if got := store.Version(); got != 3 {
+		t.Errorf("Version = %d, want a bump for the dispatch, the undo and the redo", got)
+	}
+	if _, dirty := store.Dirty(); !dirty {
+		t.Error("Dirty false after changes")
+	}
+	if _, dirty := store.Dirty(); dirty {
+		t.Error("Dirty true twice for the same changes")
+	}
+}
+
+func TestSetStateIfVersion(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 1})
+	notified := 0
+	store.Subscribe(func() { notified++ })
+	expected := store.Version()
+
+	ok, version, err := store.SetStateIfVersion(expected, State{Count: 10, Target: 20})
+	if !ok || err != nil || version != expected+1 || version != store.Version() {
+		t.Fatalf("matching SetStateIfVersion = %v, %d, %v; want applied at %d", ok, version, err, expected+1)
+	}
+	if got := store.GetState(); got.Count != 10 || got.Target != 20 || notified != 1 {
+		t.Errorf("after applying: %+v with %d notifications", got, notified)
+	}
+	// Like SetState, the replacement is an undo step
+	if !store.Undo() || store.GetState().Count != 1 {
+		t.Errorf("Count after undo = %d, want 1", store.GetState().Count)
+	}
+}
+
+func TestSetStateIfVersionStale(t *testing.T) {
+	store := NewStore(Reduce, State{Count: 1})
+	stale := store.Version()
+	store.Dispatch(IncrementAction{})
+	notified := 0
+	store.Subscribe(func() { notified++ })
+
+	ok, version, err := store.SetStateIfVersion(stale, State{Count: 10})
+	if ok || err != nil || version != store.Version() || version == stale {
+		t.Errorf("stale SetStateIfVersion = %v, %d, %v; want rejected with the current version %d", ok, version, err, store.Version())
+	}
+	if store.GetState().Count != 2 || notified != 0 {
+		t.Errorf("a stale push changed the state: Count %d, %d notifications", store.GetState().Count, notified)
+	}
+
+	// The caller rebases onto the version it was given
+	if ok, _, _ := store.SetStateIfVersion(version, State{Count: 10}); !ok || store.GetState().Count != 10 {
+		t.Error("rebased SetStateIfVersion was not applied")
+	}
+}
+
+func TestSetStateIfVersionInvariant(t *testing.T) {
+	store := NewStoreWithOptions(Reduce, State{Count: 1}, WithInvariant[State, AppAction](minZero, nil))
+	expected := store.Version()
+	ok, version, err := store.SetStateIfVersion(expected, State{Count: -5})
+	var iv *InvariantViolation[State]
+	if ok || version != expected || !errors.As(err, &iv) {
+		t.Errorf("SetStateIfVersion of a bad state = %v, %d, %v; want an *InvariantViolation at %d", ok, version, err, expected)
+	}
+	if store.GetState().Count != 1 {
+		t.Errorf("Count = %d, want 1", store.GetState().Count)
+	}
+}
+
+func TestDirtyCoalescesBetweenPolls(t *testing.T) {
+	store := NewStore(Reduce, State{})
+	if _, dirty := store.Dirty(); dirty {
+		t.Error("the initial state is dirty")
+	}
+
+	store.Dispatch(IncrementAction{})
+	store.Dispatch(SetTargetAction{Target: 4})
+	store.Dispatch(AddAmountAction{Amount: 3})
+	version, dirty := store.Dirty()
+	if !dirty || version != store.Version() || version != 3 {
+		t.Errorf("Dirty after three dispatches = %d, %v; want 3, true", version, dirty)
+	}
+	if again, dirty := store.Dirty(); dirty || again != version {
+		t.Errorf("second poll = %d, %v; want %d, false", again, dirty, version)
+	}
+
+	// A rejected dispatch changes no version, so nothing is dirty
+	store.Dispatch(RepeatAction{Inner: IncrementAction{}, Times: -1})
+	if _, dirty := store.Dirty(); dirty {
+		t.Error("dirty after a rejected dispatch")
+	}
+	store.Dispatch(DecrementAction{})
+	if version, dirty := store.Dirty(); !dirty || version != 4 {
+		t.Errorf("Dirty after one more dispatch = %d, %v; want 4, true", version, dirty)
+	}
+}
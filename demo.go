@@ -0,0 +1,21 @@
+package main
+
+import (
+	"time"
+
+	"gio-redux-example/counterstore"
+)
+
+// demoSteps is the sequence -demo plays, paced for a screen recording
+var demoSteps = []counterstore.DemoStep{
+	{Delay: time.Second, Caption: "A Redux-style counter in Gio"},
+	{Action: counterstore.IncrementAction{}, Delay: 2 * time.Second, Caption: "Increment"},
+	{Action: counterstore.IncrementAction{}, Delay: 700 * time.Millisecond},
+	{Action: counterstore.IncrementAction{}, Delay: 700 * time.Millisecond},
+	{Action: counterstore.AddAmountAction{Amount: 10}, Delay: 1500 * time.Millisecond, Caption: "Shift+Up adds 10"},
+	{Action: counterstore.DecrementAction{}, Delay: 1500 * time.Millisecond, Caption: "Decrement"},
+	{Action: counterstore.NegateAction{}, Delay: 1500 * time.Millisecond, Caption: "Negate"},
+	{Action: counterstore.SetPaletteAction{Palette: counterstore.PaletteColorBlind}, Delay: 1500 * time.Millisecond, Caption: "Colour-blind palette"},
+	{Action: counterstore.SetPaletteAction{Palette: counterstore.PaletteDefault}, Delay: 1500 * time.Millisecond},
+	{Action: counterstore.ResetAction{}, Delay: 1500 * time.Millisecond},
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"gio-redux-example/counterstore"
+)
+
+// diceSides is the die the Roll button throws
+const diceSides = 6
+
+// recentRolls is how many rolls the dice panel lists
+const recentRolls = 8
+
+// Roll throws a die with sides sides, setting the count to the roll
+func (v *ViewModel) Roll(sides int) {
+	v.dispatchWithFeedback(counterstore.RollAction{Sides: sides})
+}
+
+// RollLine describes the last roll and the ones before it, newest first
+func (v *ViewModel) RollLine() string {
+	rolls := v.store.GetStateFast().Rolls
+	if len(rolls) == 0 {
+		return "No rolls yet"
+	}
+	recent := make([]string, 0, recentRolls-1)
+	for i := len(rolls) - 2; i >= 0 && len(recent) < recentRolls-1; i-- {
+		recent = append(recent, strconv.Itoa(rolls[i]))
+	}
+	line := fmt.Sprintf("Rolled %d", rolls[len(rolls)-1])
+	if len(recent) > 0 {
+		line += " · before: " + strings.Join(recent, " ")
+	}
+	return line
+}
+
+// DicePanel has the button rolling a die and shows the recent rolls
+type DicePanel struct {
+	theme      *material.Theme
+	rollButton widget.Clickable
+}
+
+func (p *DicePanel) Layout(gtx layout.Context, vm *ViewModel) layout.Dimensions {
+	if p.rollButton.Clicked(gtx) {
+		vm.Roll(diceSides)
+	}
+
+	return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+		layout.Rigid(material.Button(p.theme, &p.rollButton, fmt.Sprintf("Roll d%d", diceSides)).Layout),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(6)}.Layout),
+		layout.Rigid(material.Body1(p.theme, vm.RollLine()).Layout),
+	)
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"testing"
+
+	"gio-redux-example/counterstore"
+)
+
+func TestViewModelRollLine(t *testing.T) {
+	draws := []int{2, 5, 0}
+	intn := func(n int) int {
+		draw := draws[0]
+		draws = draws[1:]
+		return draw
+	}
+	store := counterstore.NewStore(counterstore.Reduce, counterstore.State{}, counterstore.RollMiddleware(intn))
+	vm := NewViewModel(store)
+
+	if got := vm.RollLine(); got != "No rolls yet" {
+		t.Errorf("RollLine = %q before rolling", got)
+	}
+	vm.Roll(diceSides)
+	if got := vm.RollLine(); got != "Rolled 3" {
+		t.Errorf("RollLine = %q, want Rolled 3", got)
+	}
+	vm.Roll(diceSides)
+	vm.Roll(diceSides)
+	if got, want := vm.RollLine(), "Rolled 1 · before: 6 3"; got != want {
+		t.Errorf("RollLine = %q, want %q", got, want)
+	}
+	if got := store.GetState().Count; got != 1 {
+		t.Errorf("Count = %d, want the last roll", got)
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"image"
+	"sort"
+
+	"gioui.org/layout"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+)
+
+// maxDwellBars is how many counts the dwell chart shows at most
+const maxDwellBars = 24
+
+// DwellBar is one bar of the dwell chart
+type DwellBar struct {
+	Count int
+	Dwell int
+}
+
+// DwellBars returns the counts with the most dwell, at most maxDwellBars
+// of them, in ascending order of count
+func (v *ViewModel) DwellBars() []DwellBar {
+	dwell := v.store.GetStateFast().UI.Dwell
+	bars := make([]DwellBar, 0, len(dwell))
+	for count, n := range dwell {
+		bars = append(bars, DwellBar{Count: count, Dwell: n})
+	}
+	sort.Slice(bars, func(i, j int) bool {
+		if bars[i].Dwell != bars[j].Dwell {
+			return bars[i].Dwell > bars[j].Dwell
+		}
+		return bars[i].Count < bars[j].Count
+	})
+	bars = bars[:min(len(bars), maxDwellBars)]
+	sort.Slice(bars, func(i, j int) bool { return bars[i].Count < bars[j].Count })
+	return bars
+}
+
+// DwellPalette returns the active palette, whose cold and hot colours the
+// dwell chart is drawn in
+func (v *ViewModel) DwellPalette() palette {
+	return paletteFor(v.store.GetStateFast().Palette)
+}
+
+// layoutDwellChart fills a bar per count, as tall as its share of the
+// largest dwell, in a small box: the most visited count in the palette's hot
+// colour and the rest in its cold one
+func layoutDwellChart(gtx layout.Context, bars []DwellBar, p palette) layout.Dimensions {
+	size := image.Pt(gtx.Dp(unit.Dp(160)), gtx.Dp(unit.Dp(32)))
+	if len(bars) == 0 {
+		return layout.Dimensions{Size: size}
+	}
+
+	top := 0
+	for _, bar := range bars {
+		top = max(top, bar.Dwell)
+	}
+	width := size.X / len(bars)
+	gap := min(gtx.Dp(unit.Dp(1)), width/2)
+	hottest := false
+	for i, bar := range bars {
+		h := size.Y * bar.Dwell / max(top, 1)
+		rect := image.Rect(i*width, size.Y-h, (i+1)*width-gap, size.Y)
+		fill := p.Cold
+		if bar.Dwell == top && !hottest {
+			fill, hottest = p.Hot, true
+		}
+		paint.FillShape(gtx.Ops, fill, clip.Rect(rect).Op())
+	}
+	return layout.Dimensions{Size: size}
+}
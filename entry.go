@@ -0,0 +1,190 @@
+package main
+
+import (
+	"errors"
+	"image/color"
+	"strconv"
+	"strings"
+
+	"gioui.org/io/event"
+	"gioui.org/io/key"
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+)
+
+var errNotANumber = errors.New("not a whole number")
+
+// parseCountInput parses user-entered text as a count
+func parseCountInput(text string) (int, error) {
+	value, err := strconv.Atoi(strings.TrimSpace(text))
+	if err != nil {
+		return 0, errNotANumber
+	}
+	return value, nil
+}
+
+// EntryPhase is the phase of the numeric entry overlay
+type EntryPhase int
+
+const (
+	EntryClosed EntryPhase = iota
+	EntryOpen
+	EntrySubmitting
+)
+
+// EntryMachine is the numeric entry state machine, kept free of rendering so
+// its transitions can be exercised on their own:
+//
+//	closed --Open--> open --Submit(valid)--> submitting --Finish--> closed
+//	open --Submit(invalid)--> open (with Err set)
+//	any --Cancel--> closed
+type EntryMachine struct {
+	Phase EntryPhase
+	Text  string
+	Err   string
+}
+
+// Open starts an entry with the given initial text; it is a no-op unless closed
+func (m *EntryMachine) Open(initial string) bool {
+	if m.Phase != EntryClosed {
+		return false
+	}
+	m.Phase = EntryOpen
+	m.Text = initial
+	m.Err = ""
+	return true
+}
+
+// Submit parses text and moves to submitting on success, returning the value.
+// Invalid input keeps the entry open and records the error.
+func (m *EntryMachine) Submit(text string) (int, bool) {
+	if m.Phase != EntryOpen {
+		return 0, false
+	}
+	m.Text = text
+	value, err := parseCountInput(text)
+	if err != nil {
+		m.Err = err.Error()
+		return 0, false
+	}
+	m.Phase = EntrySubmitting
+	m.Err = ""
+	return value, true
+}
+
+// Finish closes the entry once a submitted value has been dispatched
+func (m *EntryMachine) Finish() {
+	if m.Phase == EntrySubmitting {
+		m.Phase = EntryClosed
+		m.Text = ""
+	}
+}
+
+// Cancel closes the entry from any phase, discarding the input
+func (m *EntryMachine) Cancel() {
+	m.Phase = EntryClosed
+	m.Text = ""
+	m.Err = ""
+}
+
+// digitFilters match the keys that open the entry overlay
+var digitFilters = func() []event.Filter {
+	filters := make([]event.Filter, 0, 10)
+	for d := '0'; d <= '9'; d++ {
+		filters = append(filters, key.Filter{Name: key.Name(string(d))})
+	}
+	return filters
+}()
+
+// EntryOverlay is the View sub-component for typing a count
+type EntryOverlay struct {
+	theme    *material.Theme
+	editor   widget.Editor
+	machine  EntryMachine
+	onSubmit func(value int)
+
+	errorColor color.NRGBA
+}
+
+func NewEntryOverlay(theme *material.Theme, onSubmit func(value int)) *EntryOverlay {
+	return &EntryOverlay{
+		theme:    theme,
+		editor:   widget.Editor{SingleLine: true, Submit: true, Filter: "-0123456789"},
+		onSubmit: onSubmit,
+	}
+}
+
+// Open shows the overlay seeded with initial and focuses its editor
+func (o *EntryOverlay) Open(gtx layout.Context, initial string) {
+	if !o.machine.Open(initial) {
+		return
+	}
+	o.editor.SetText(initial)
+	o.editor.SetCaret(len(initial), len(initial))
+	gtx.Execute(key.FocusCmd{Tag: &o.editor})
+}
+
+func (o *EntryOverlay) close(gtx layout.Context) {
+	o.machine.Cancel()
+	gtx.Execute(key.FocusCmd{Tag: nil})
+}
+
+// Active reports whether the overlay is open and owns keyboard input
+func (o *EntryOverlay) Active() bool {
+	return o.machine.Phase != EntryClosed
+}
+
+// Layout handles the overlay's input and draws it above the main layout,
+// painting errors in vm's palette. It draws nothing while closed.
+func (o *EntryOverlay) Layout(gtx layout.Context, vm *ViewModel) layout.Dimensions {
+	if o.machine.Phase == EntryClosed {
+		return layout.Dimensions{}
+	}
+
+	for {
+		ev, ok := o.editor.Update(gtx)
+		if !ok {
+			break
+		}
+		if _, ok := ev.(widget.SubmitEvent); ok {
+			if value, ok := o.machine.Submit(o.editor.Text()); ok {
+				o.onSubmit(value)
+				o.machine.Finish()
+				gtx.Execute(key.FocusCmd{Tag: nil})
+			}
+		}
+	}
+	for {
+		ev, ok := gtx.Event(key.Filter{Name: key.NameEscape})
+		if !ok {
+			break
+		}
+		if e, ok := ev.(key.Event); ok && e.State == key.Press {
+			o.close(gtx)
+		}
+	}
+	if o.machine.Phase == EntryClosed {
+		return layout.Dimensions{}
+	}
+
+	o.errorColor = vm.ErrorColor()
+	return layoutModal(gtx, o.theme, o, o.layoutForm)
+}
+
+func (o *EntryOverlay) layoutForm(gtx layout.Context) layout.Dimensions {
+	gtx.Constraints.Min.X = gtx.Dp(unit.Dp(160))
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(material.Caption(o.theme, "Set count (Enter to apply, Esc to cancel)").Layout),
+		layout.Rigid(material.Editor(o.theme, &o.editor, "0").Layout),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if o.machine.Err == "" {
+				return layout.Dimensions{}
+			}
+			label := material.Caption(o.theme, o.machine.Err)
+			label.Color = o.errorColor
+			return label.Layout(gtx)
+		}),
+	)
+}
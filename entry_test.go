@@ -0,0 +1,90 @@
+package main
+
+import "testing"
+
+func TestEntryMachineSubmit(t *testing.T) {
+	var m EntryMachine
+	if !m.Open("4") {
+		t.Fatal("Open from closed failed")
+	}
+	if m.Phase != EntryOpen || m.Text != "4" {
+		t.Fatalf("after Open: %+v", m)
+	}
+	if m.Open("5") {
+		t.Error("Open while open succeeded")
+	}
+
+	value, ok := m.Submit(" 42 ")
+	if !ok || value != 42 {
+		t.Fatalf("Submit = %d, %v; want 42, true", value, ok)
+	}
+	if m.Phase != EntrySubmitting {
+		t.Fatalf("after Submit: phase %d, want submitting", m.Phase)
+	}
+	if _, ok := m.Submit("43"); ok {
+		t.Error("Submit while submitting succeeded")
+	}
+
+	m.Finish()
+	if m.Phase != EntryClosed || m.Text != "" {
+		t.Errorf("after Finish: %+v", m)
+	}
+}
+
+func TestEntryMachineInvalidInput(t *testing.T) {
+	var m EntryMachine
+	m.Open("")
+
+	if _, ok := m.Submit("abc"); ok {
+		t.Fatal("Submit of non-number succeeded")
+	}
+	if m.Phase != EntryOpen || m.Err != errNotANumber.Error() || m.Text != "abc" {
+		t.Fatalf("after invalid Submit: %+v", m)
+	}
+
+	if value, ok := m.Submit("-7"); !ok || value != -7 {
+		t.Fatalf("Submit(-7) = %d, %v", value, ok)
+	}
+	if m.Err != "" {
+		t.Errorf("Err = %q after valid Submit", m.Err)
+	}
+}
+
+func TestEntryMachineCancel(t *testing.T) {
+	for _, phase := range []EntryPhase{EntryClosed, EntryOpen, EntrySubmitting} {
+		m := EntryMachine{Phase: phase, Text: "12", Err: "x"}
+		m.Cancel()
+		if m != (EntryMachine{}) {
+			t.Errorf("Cancel from phase %d left %+v", phase, m)
+		}
+	}
+}
+
+func TestEntryMachineFinishOnlyFromSubmitting(t *testing.T) {
+	m := EntryMachine{Phase: EntryOpen, Text: "3"}
+	m.Finish()
+	if m.Phase != EntryOpen || m.Text != "3" {
+		t.Errorf("Finish while open changed the machine: %+v", m)
+	}
+}
+
+func TestParseCountInput(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+		err  error
+	}{
+		{"42", 42, nil},
+		{"  -3\t", -3, nil},
+		{"+8", 8, nil},
+		{"", 0, errNotANumber},
+		{"4.5", 0, errNotANumber},
+		{"99999999999999999999", 0, errNotANumber},
+	}
+	for _, tt := range tests {
+		got, err := parseCountInput(tt.text)
+		if got != tt.want || err != tt.err {
+			t.Errorf("parseCountInput(%q) = %d, %v; want %d, %v", tt.text, got, err, tt.want, tt.err)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"gioui.org/layout"
+	"gioui.org/widget/material"
+
+	"gio-redux-example/counterstore"
+)
+
+// dispatchWithFeedback dispatches action and remembers, for Feedback, why it
+// was blocked, or that it was not
+func (v *ViewModel) dispatchWithFeedback(action counterstore.AppAction) {
+	result := v.store.DispatchWithResult(action)
+	feedback := ""
+	if !result.Applied {
+		feedback = "Blocked: " + result.Reason
+	}
+	v.mu.Lock()
+	v.feedback = feedback
+	v.mu.Unlock()
+}
+
+// Feedback describes the outcome of the last button or key press, or is
+// empty when it went through
+func (v *ViewModel) Feedback() string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.feedback
+}
+
+// layoutFeedback shows why the last press was blocked, if it was
+func (v *View) layoutFeedback(gtx layout.Context) layout.Dimensions {
+	text := v.viewModel.Feedback()
+	if text == "" {
+		return layout.Dimensions{}
+	}
+	label := material.Caption(v.theme, text)
+	label.Color = v.viewModel.ErrorColor()
+	return label.Layout(gtx)
+}
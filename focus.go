@@ -0,0 +1,81 @@
+package main
+
+import (
+	"image"
+
+	"gioui.org/layout"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+)
+
+// focusTarget is a counter button Tab can move the keyboard focus to
+type focusTarget int
+
+const (
+	// noFocus is no button focused, as at startup
+	noFocus focusTarget = iota - 1
+	focusIncrement
+	focusDecrement
+	focusReset
+	// focusTargets is the number of focusable buttons
+	focusTargets
+)
+
+// advanceFocus returns the target of n that Tab moves the focus to from
+// current, or Shift+Tab when backward, in left-to-right order and wrapping
+// around at either end. With nothing focused Tab focuses the first target
+// and Shift+Tab the last.
+func advanceFocus(current focusTarget, n int, backward bool) focusTarget {
+	if n <= 0 {
+		return noFocus
+	}
+	if current < 0 || int(current) >= n {
+		if backward {
+			return focusTarget(n - 1)
+		}
+		return 0
+	}
+	step := 1
+	if backward {
+		step = n - 1
+	}
+	return focusTarget((int(current) + step) % n)
+}
+
+// activateFocused presses the focused button, as Enter and Space do. It
+// reports false when nothing is focused; a disabled button is not pressed.
+func (v *View) activateFocused() bool {
+	switch v.focus {
+	case focusIncrement:
+		if v.viewModel.CanIncrement() {
+			v.viewModel.Incre()
+		}
+	case focusDecrement:
+		if v.viewModel.CanDecrement() {
+			v.viewModel.Decre()
+		}
+	case focusReset:
+		v.viewModel.Reset()
+	default:
+		return false
+	}
+	return true
+}
+
+// layoutFocusable lays out w, drawing the focus ring around it when target
+// has the keyboard focus
+func (v *View) layoutFocusable(gtx layout.Context, target focusTarget, w layout.Widget) layout.Dimensions {
+	dims := w(gtx)
+	if v.focus != target {
+		return dims
+	}
+	width := gtx.Dp(unit.Dp(2))
+	rect := image.Rectangle{Min: image.Pt(-width, -width), Max: dims.Size.Add(image.Pt(width, width))}
+	ring := clip.Stroke{
+		Path:  clip.UniformRRect(rect, gtx.Dp(unit.Dp(6))).Path(gtx.Ops),
+		Width: float32(width),
+	}
+	paint.FillShape(gtx.Ops, v.theme.Fg, ring.Op())
+	return dims
+}
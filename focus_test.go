@@ -0,0 +1,80 @@
+package main
+
+import (
+	"testing"
+
+	"gio-redux-example/counterstore"
+)
+
+func TestAdvanceFocusOrder(t *testing.T) {
+	n := int(focusTargets)
+	var forward, backward []focusTarget
+	current := noFocus
+	for i := 0; i < n+1; i++ {
+		current = advanceFocus(current, n, false)
+		forward = append(forward, current)
+	}
+	current = noFocus
+	for i := 0; i < n+1; i++ {
+		current = advanceFocus(current, n, true)
+		backward = append(backward, current)
+	}
+
+	wantForward := []focusTarget{focusIncrement, focusDecrement, focusReset, focusIncrement}
+	wantBackward := []focusTarget{focusReset, focusDecrement, focusIncrement, focusReset}
+	for i := range wantForward {
+		if forward[i] != wantForward[i] {
+			t.Fatalf("Tab cycles through %v, want %v", forward, wantForward)
+		}
+		if backward[i] != wantBackward[i] {
+			t.Fatalf("Shift+Tab cycles through %v, want %v", backward, wantBackward)
+		}
+	}
+}
+
+func TestAdvanceFocusEdges(t *testing.T) {
+	tests := []struct {
+		current  focusTarget
+		n        int
+		backward bool
+		want     focusTarget
+	}{
+		{focusIncrement, 0, false, noFocus},
+		{noFocus, -1, true, noFocus},
+		{focusIncrement, 1, false, focusIncrement},
+		{focusIncrement, 1, true, focusIncrement},
+		// A target beyond n, as after buttons went away, starts over
+		{focusReset, 2, false, focusIncrement},
+		{focusReset, 2, true, focusDecrement},
+	}
+	for _, tt := range tests {
+		if got := advanceFocus(tt.current, tt.n, tt.backward); got != tt.want {
+			t.Errorf("advanceFocus(%d, %d, %v) = %d, want %d", tt.current, tt.n, tt.backward, got, tt.want)
+		}
+	}
+}
+
+func TestActivateFocused(t *testing.T) {
+	vm, store := newTestViewModel(counterstore.State{Count: 0, Flags: map[string]bool{counterstore.FlagNoNegatives: true}})
+	v := &View{viewModel: vm, focus: noFocus}
+	if v.activateFocused() {
+		t.Error("activated with nothing focused")
+	}
+
+	v.focus = focusIncrement
+	v.activateFocused()
+	v.activateFocused()
+	if got := store.GetState().Count; got != 2 {
+		t.Errorf("Count = %d after activating increment twice, want 2", got)
+	}
+	v.focus = focusReset
+	v.activateFocused()
+	// A disabled button is focused but not pressed
+	v.focus = focusDecrement
+	if !v.activateFocused() {
+		t.Error("activating a disabled button reported nothing focused")
+	}
+	if got := store.GetState().Count; got != 0 {
+		t.Errorf("Count = %d after reset and a refused decrement, want 0", got)
+	}
+}
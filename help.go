@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+
+	"gioui.org/layout"
+	"gioui.org/widget/material"
+)
+
+// Shortcut is one row of the keyboard cheat-sheet
+type Shortcut struct {
+	Keys        string
+	Description string
+}
+
+// shortcuts lists the keyboard shortcuts handled by View.handleKeys
+var shortcuts = []Shortcut{
+	{Keys: "↑", Description: "Increment"},
+	{Keys: "↓", Description: "Decrement"},
+	{Keys: "Shift+↑↓", Description: "Add or subtract 10"},
+	{Keys: "Ctrl+↑↓", Description: "Add or subtract 100"},
+	{Keys: "R", Description: "Reset to zero"},
+	{Keys: "F", Description: "Show only the count, or everything again"},
+	{Keys: "Space", Description: "Hold to increment rapidly, or press the focused button"},
+	{Keys: "Tab", Description: "Focus the next button; Shift+Tab the previous one"},
+	{Keys: "Enter", Description: "Press the focused button"},
+	{Keys: "0–9", Description: "Type a count"},
+	{Keys: "?", Description: "Show or hide this help"},
+	{Keys: "Esc", Description: "Close the open overlay, clear the focus or leave minimal mode"},
+}
+
+// HelpLines returns the cheat-sheet text, one shortcut per line
+func HelpLines() []string {
+	lines := make([]string, 0, len(shortcuts))
+	for _, sc := range shortcuts {
+		lines = append(lines, fmt.Sprintf("%-8s %s", sc.Keys, sc.Description))
+	}
+	return lines
+}
+
+// HelpOverlay is the View component showing the shortcut cheat-sheet
+type HelpOverlay struct {
+	theme *material.Theme
+}
+
+// Layout draws the cheat-sheet above the main layout when visible
+func (h *HelpOverlay) Layout(gtx layout.Context, visible bool) layout.Dimensions {
+	if !visible {
+		return layout.Dimensions{}
+	}
+	return layoutModal(gtx, h.theme, h, func(gtx layout.Context) layout.Dimensions {
+		lines := HelpLines()
+		children := make([]layout.FlexChild, 0, len(lines)+1)
+		children = append(children, layout.Rigid(material.Subtitle1(h.theme, "Keyboard shortcuts").Layout))
+		for _, line := range lines {
+			children = append(children, layout.Rigid(material.Body2(h.theme, line).Layout))
+		}
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+	})
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHelpLines(t *testing.T) {
+	lines := HelpLines()
+	if len(lines) != len(shortcuts) {
+		t.Fatalf("%d lines for %d shortcuts", len(lines), len(shortcuts))
+	}
+	if want := "↑        Increment"; lines[0] != want {
+		t.Errorf("first line = %q, want %q", lines[0], want)
+	}
+	for i, sc := range shortcuts {
+		if !strings.HasPrefix(lines[i], sc.Keys) || !strings.HasSuffix(lines[i], " "+sc.Description) {
+			t.Errorf("line %d = %q, want %q then %q", i, lines[i], sc.Keys, sc.Description)
+		}
+	}
+
+	var keys []string
+	for _, sc := range shortcuts {
+		keys = append(keys, sc.Keys)
+	}
+	for _, want := range []string{"↑", "↓", "R", "?", "Esc"} {
+		if !strings.Contains(strings.Join(keys, "\n"), want) {
+			t.Errorf("no shortcut listed for %q", want)
+		}
+	}
+}
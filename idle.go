@@ -0,0 +1,51 @@
+package main
+
+import (
+	"image"
+	"image/color"
+
+	"gioui.org/io/event"
+	"gioui.org/io/pointer"
+	"gioui.org/layout"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+)
+
+// idleDim is the veil drawn over the window while it is idle
+var idleDim = color.NRGBA{A: 0x99}
+
+// Idle reports whether the window has gone idle and should be dimmed
+func (v *ViewModel) Idle() bool {
+	return v.store.GetStateFast().UI.Idle
+}
+
+// Activity reports user input, waking the window if it is idle
+func (v *ViewModel) Activity() {
+	if v.idle != nil {
+		v.idle.Activity()
+	}
+}
+
+// trackActivity reports pointer input anywhere in the window as activity,
+// letting the events pass through to the widgets underneath
+func (v *View) trackActivity(gtx layout.Context) {
+	for {
+		_, ok := gtx.Event(pointer.Filter{Target: &v.activityTag, Kinds: pointer.Move | pointer.Press})
+		if !ok {
+			break
+		}
+		v.viewModel.Activity()
+	}
+	defer pointer.PassOp{}.Push(gtx.Ops).Pop()
+	defer clip.Rect(image.Rectangle{Max: gtx.Constraints.Max}).Push(gtx.Ops).Pop()
+	event.Op(gtx.Ops, &v.activityTag)
+}
+
+// layoutIdle dims everything drawn so far while the window is idle
+func (v *View) layoutIdle(gtx layout.Context) {
+	if !v.viewModel.Idle() {
+		return
+	}
+	defer clip.Rect(image.Rectangle{Max: gtx.Constraints.Max}).Push(gtx.Ops).Pop()
+	paint.Fill(gtx.Ops, idleDim)
+}
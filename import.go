@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"gio-redux-example/counterstore"
+)
+
+// maxShownCounts is how many imported counts ImportPanel lists
+const maxShownCounts = 8
+
+// ImportCounts reads the -import CSV file and replaces the imported counts
+// with its values; a file that cannot be read or parsed shows a toast
+func (v *ViewModel) ImportCounts() {
+	values, err := readCountsCSV(v.importPath)
+	if err != nil {
+		v.store.Dispatch(counterstore.ShowToastAction{Text: err.Error()})
+		return
+	}
+	v.store.Dispatch(counterstore.ImportCountsAction{Values: values})
+}
+
+func readCountsCSV(path string) ([]int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return counterstore.ParseCountsCSV(f)
+}
+
+// ImportedCounts returns the counts last imported
+func (v *ViewModel) ImportedCounts() []int {
+	return v.store.GetStateFast().Counts
+}
+
+// ImportPanel offers an Import button reading the -import file, disabled
+// without one, and lists the counts imported so far
+type ImportPanel struct {
+	theme        *material.Theme
+	importButton widget.Clickable
+}
+
+func (p *ImportPanel) Layout(gtx layout.Context, vm *ViewModel) layout.Dimensions {
+	if vm.importPath == "" {
+		gtx = gtx.Disabled()
+	}
+	if p.importButton.Clicked(gtx) {
+		vm.ImportCounts()
+	}
+
+	return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+		layout.Rigid(material.Button(p.theme, &p.importButton, "Import").Layout),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(6)}.Layout),
+		layout.Rigid(material.Caption(p.theme, formatCounts(vm.ImportedCounts())).Layout),
+	)
+}
+
+// formatCounts lists counts, eliding all but the first maxShownCounts
+func formatCounts(counts []int) string {
+	if len(counts) == 0 {
+		return "No counts imported"
+	}
+	shown := counts[:min(len(counts), maxShownCounts)]
+	parts := make([]string, len(shown))
+	for i, count := range shown {
+		parts[i] = strconv.Itoa(count)
+	}
+	list := strings.Join(parts, ", ")
+	if len(counts) > len(shown) {
+		list += fmt.Sprintf(" … (%d in all)", len(counts))
+	}
+	return list
+}
@@ -0,0 +1,37 @@
+package main
+
+import "sync"
+
+// Invalidator coalesces change notifications into at most one window
+// invalidation per frame: the first change after a frame invalidates, and
+// later ones are absorbed until Frame reports the next frame has started.
+type Invalidator struct {
+	mu         sync.Mutex
+	invalidate func()
+	pending    bool
+}
+
+func NewInvalidator(invalidate func()) *Invalidator {
+	return &Invalidator{invalidate: invalidate}
+}
+
+// Changed requests a redraw unless one is already pending
+func (i *Invalidator) Changed() {
+	i.mu.Lock()
+	if i.pending {
+		i.mu.Unlock()
+		return
+	}
+	i.pending = true
+	i.mu.Unlock()
+
+	i.invalidate()
+}
+
+// Frame marks the pending redraw as started; call it on each frame event
+// before reading state, so changes made during layout invalidate again
+func (i *Invalidator) Frame() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.pending = false
+}
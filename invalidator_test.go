@@ -0,0 +1,52 @@
+package main
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestInvalidatorOncePerFrame(t *testing.T) {
+	invalidations := 0
+	inv := NewInvalidator(func() { invalidations++ })
+
+	for i := 0; i < 10; i++ {
+		inv.Changed()
+	}
+	if invalidations != 1 {
+		t.Fatalf("10 changes in one frame invalidated %d times, want 1", invalidations)
+	}
+
+	inv.Frame()
+	inv.Frame()
+	if invalidations != 1 {
+		t.Errorf("a frame with no changes invalidated")
+	}
+	inv.Changed()
+	inv.Changed()
+	if invalidations != 2 {
+		t.Errorf("changes in the next frame invalidated %d times in total, want 2", invalidations)
+	}
+}
+
+func TestInvalidatorConcurrentChanges(t *testing.T) {
+	var mu sync.Mutex
+	invalidations := 0
+	inv := NewInvalidator(func() {
+		mu.Lock()
+		invalidations++
+		mu.Unlock()
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			inv.Changed()
+		}()
+	}
+	wg.Wait()
+	if invalidations != 1 {
+		t.Errorf("concurrent changes invalidated %d times, want 1", invalidations)
+	}
+}
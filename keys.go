@@ -0,0 +1,32 @@
+package main
+
+import "gioui.org/io/key"
+
+// arrowMagnitudes are the steps the arrow keys take under each modifier
+const (
+	arrowStep      = 1
+	arrowShiftStep = 10
+	arrowCtrlStep  = 100
+)
+
+// arrowDelta maps an arrow key and its modifiers to the change in count:
+// 1 plain, 10 with Shift and 100 with Ctrl, which wins when both are held.
+// Up is positive and Down negative; any other key reports false.
+func arrowDelta(name key.Name, mods key.Modifiers) (int, bool) {
+	var sign int
+	switch name {
+	case key.NameUpArrow:
+		sign = 1
+	case key.NameDownArrow:
+		sign = -1
+	default:
+		return 0, false
+	}
+	switch {
+	case mods.Contain(key.ModCtrl):
+		return sign * arrowCtrlStep, true
+	case mods.Contain(key.ModShift):
+		return sign * arrowShiftStep, true
+	}
+	return sign * arrowStep, true
+}
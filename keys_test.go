@@ -0,0 +1,68 @@
+package main
+
+import (
+	"testing"
+
+	"gioui.org/io/key"
+
+	"gio-redux-example/counterstore"
+)
+
+func TestArrowDelta(t *testing.T) {
+	tests := []struct {
+		name  key.Name
+		mods  key.Modifiers
+		want  int
+		wantK bool
+	}{
+		{key.NameUpArrow, 0, 1, true},
+		{key.NameDownArrow, 0, -1, true},
+		{key.NameUpArrow, key.ModShift, 10, true},
+		{key.NameDownArrow, key.ModShift, -10, true},
+		{key.NameUpArrow, key.ModCtrl, 100, true},
+		{key.NameDownArrow, key.ModCtrl, -100, true},
+		{key.NameUpArrow, key.ModCtrl | key.ModShift, 100, true},
+		{key.NameDownArrow, key.ModAlt, -1, true},
+		{key.NameLeftArrow, 0, 0, false},
+		{"A", key.ModShift, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := arrowDelta(tt.name, tt.mods)
+		if got != tt.want || ok != tt.wantK {
+			t.Errorf("arrowDelta(%q, %v) = %d, %v; want %d, %v", tt.name, tt.mods, got, ok, tt.want, tt.wantK)
+		}
+	}
+}
+
+func TestArrowStepsDispatch(t *testing.T) {
+	rec := counterstore.NewRecorder(nil)
+	store := counterstore.NewStore(counterstore.Reduce, counterstore.State{}, counterstore.RecorderMiddleware(rec))
+	vm := NewViewModel(store)
+
+	for _, mods := range []key.Modifiers{0, key.ModShift, key.ModCtrl} {
+		delta, _ := arrowDelta(key.NameUpArrow, mods)
+		vm.Step(delta)
+	}
+	delta, _ := arrowDelta(key.NameDownArrow, 0)
+	vm.Step(delta)
+
+	if got := store.GetState().Count; got != 110 {
+		t.Errorf("Count = %d, want 1 + 10 + 100 - 1", got)
+	}
+	// Plain arrows keep dispatching increments and decrements
+	want := []counterstore.AppAction{
+		counterstore.IncrementAction{},
+		counterstore.AddAmountAction{Amount: 10},
+		counterstore.AddAmountAction{Amount: 100},
+		counterstore.DecrementAction{},
+	}
+	actions := rec.Actions()
+	if len(actions) != len(want) {
+		t.Fatalf("recorded %d actions, want %d", len(actions), len(want))
+	}
+	for i := range want {
+		if actions[i].Action != want[i] {
+			t.Errorf("action %d = %v, want %v", i, actions[i].Action, want[i])
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Labels holds the user-visible strings of the counter controls. Empty
+// button labels fall back to English.
+type Labels struct {
+	Increment string
+	Decrement string
+	Reset     string
+	// GroupSeparator separates thousands in the displayed count; empty
+	// means none
+	GroupSeparator string
+}
+
+// EnglishLabels is the default label set
+var EnglishLabels = Labels{
+	Increment:      "Increment",
+	Decrement:      "Decrement",
+	Reset:          "Reset",
+	GroupSeparator: ",",
+}
+
+// FrenchLabels localizes the controls for French, grouping thousands with a
+// narrow no-break space
+var FrenchLabels = Labels{
+	Increment:      "Incrémenter",
+	Decrement:      "Décrémenter",
+	Reset:          "Réinitialiser",
+	GroupSeparator: "\u202f",
+}
+
+// labelSets are the label sets selectable with -lang
+var labelSets = map[string]Labels{
+	"en": EnglishLabels,
+	"fr": FrenchLabels,
+}
+
+// resolveLabels fills the empty fields of l from EnglishLabels
+func resolveLabels(l Labels) Labels {
+	if l.Increment == "" {
+		l.Increment = EnglishLabels.Increment
+	}
+	if l.Decrement == "" {
+		l.Decrement = EnglishLabels.Decrement
+	}
+	if l.Reset == "" {
+		l.Reset = EnglishLabels.Reset
+	}
+	return l
+}
+
+// FormatCount formats n with its digits grouped in thousands by
+// GroupSeparator, e.g. "-1,234,567"
+func (l Labels) FormatCount(n int) string {
+	digits := strconv.Itoa(n)
+	sign := ""
+	if n < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	if l.GroupSeparator == "" || len(digits) <= 3 {
+		return sign + digits
+	}
+
+	var b strings.Builder
+	b.WriteString(sign)
+	head := len(digits) % 3
+	if head == 0 {
+		head = 3
+	}
+	b.WriteString(digits[:head])
+	for i := head; i < len(digits); i += 3 {
+		b.WriteString(l.GroupSeparator)
+		b.WriteString(digits[i : i+3])
+	}
+	return b.String()
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"testing"
+
+	"gioui.org/widget/material"
+
+	"gio-redux-example/counterstore"
+)
+
+func TestResolveLabels(t *testing.T) {
+	if got := resolveLabels(Labels{}); got != (Labels{Increment: "Increment", Decrement: "Decrement", Reset: "Reset"}) {
+		t.Errorf("resolveLabels of nothing = %+v", got)
+	}
+	partial := Labels{Increment: "Plus", GroupSeparator: "."}
+	want := Labels{Increment: "Plus", Decrement: "Decrement", Reset: "Reset", GroupSeparator: "."}
+	if got := resolveLabels(partial); got != want {
+		t.Errorf("resolveLabels(%+v) = %+v, want %+v", partial, got, want)
+	}
+	if got := resolveLabels(FrenchLabels); got != FrenchLabels {
+		t.Errorf("resolveLabels changed a complete set: %+v", got)
+	}
+}
+
+func TestViewUsesLabels(t *testing.T) {
+	vm, _ := newTestViewModel(counterstore.State{})
+	th := material.NewTheme()
+
+	if got := NewView(vm, th, FrenchLabels).Labels(); got != FrenchLabels {
+		t.Errorf("French view labels = %+v", got)
+	}
+	if got := NewView(vm, th, Labels{Reset: "Zero"}).Labels(); got.Reset != "Zero" || got.Increment != "Increment" {
+		t.Errorf("partial view labels = %+v", got)
+	}
+}
+
+func TestFormatCount(t *testing.T) {
+	tests := []struct {
+		labels Labels
+		n      int
+		want   string
+	}{
+		{EnglishLabels, 0, "0"},
+		{EnglishLabels, 999, "999"},
+		{EnglishLabels, 1000, "1,000"},
+		{EnglishLabels, -1234567, "-1,234,567"},
+		{EnglishLabels, 123456, "123,456"},
+		{FrenchLabels, 1234567, "1 234 567"},
+		{FrenchLabels, -12, "-12"},
+		{Labels{}, 1234567, "1234567"},
+	}
+	for _, tt := range tests {
+		if got := tt.labels.FormatCount(tt.n); got != tt.want {
+			t.Errorf("FormatCount(%d) with %q = %q, want %q", tt.n, tt.labels.GroupSeparator, got, tt.want)
+		}
+	}
+}
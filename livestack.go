@@ -0,0 +1,59 @@
+package main
+
+import (
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+)
+
+// liveRateLimit is the rate, and burst, the live stack panel applies when
+// rate limiting is switched on
+const liveRateLimit = 5
+
+// StackPanel is the View component switching logging, profiling and rate
+// limiting on and off while the app runs
+type StackPanel struct {
+	theme     *material.Theme
+	logging   widget.Bool
+	profiling widget.Bool
+	rateLimit widget.Bool
+}
+
+func (p *StackPanel) Layout(gtx layout.Context, vm *ViewModel) layout.Dimensions {
+	cfg := vm.StackConfig()
+	changed := false
+	if p.logging.Update(gtx) {
+		cfg.Logging = p.logging.Value
+		changed = true
+	}
+	if p.profiling.Update(gtx) {
+		cfg.Profiler = nil
+		if p.profiling.Value {
+			cfg.Profiler = vm.profiler
+		}
+		changed = true
+	}
+	if p.rateLimit.Update(gtx) {
+		cfg.RateLimit, cfg.RateBurst = 0, 0
+		if p.rateLimit.Value {
+			cfg.RateLimit, cfg.RateBurst = liveRateLimit, liveRateLimit
+		}
+		changed = true
+	}
+	if changed {
+		vm.SetStackConfig(cfg)
+	}
+
+	p.logging.Value = cfg.Logging
+	p.profiling.Value = cfg.Profiler != nil
+	p.rateLimit.Value = cfg.RateLimit > 0
+
+	return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+		layout.Rigid(material.CheckBox(p.theme, &p.logging, "Logging").Layout),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(12)}.Layout),
+		layout.Rigid(material.CheckBox(p.theme, &p.profiling, "Profiling").Layout),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(12)}.Layout),
+		layout.Rigid(material.CheckBox(p.theme, &p.rateLimit, "Rate limit").Layout),
+	)
+}
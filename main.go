@@ -1,219 +1,597 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"image/color"
 	"log"
+	"math"
+	"net/http"
 	"sync"
+	"time"
 
 	"gioui.org/app"
 	"gioui.org/font"
+	"gioui.org/io/event"
+	"gioui.org/io/key"
+	"gioui.org/io/semantic"
 	"gioui.org/layout"
 	"gioui.org/op"
+	"gioui.org/op/clip"
 	"gioui.org/unit"
 	"gioui.org/widget"
 	"gioui.org/widget/material"
+
+	"gio-redux-example/counterstore"
 )
 
-// StateProvider interface for state types
-type StateProvider[T any] interface {
-	Copy() T
-}
+// ViewModel
+type ViewModel struct {
+	store    *counterstore.Store[counterstore.State, counterstore.AppAction]
+	bigStore *counterstore.Store[counterstore.BigState, counterstore.BigAction]
+
+	timeline *counterstore.Timeline
+	recent   *counterstore.RecentCounts
+	average  *counterstore.SetAverage
+	recorder *counterstore.Recorder
+	profiler *counterstore.Profiler
+	clock    counterstore.Clock
+
+	stack           counterstore.StackConfig
+	extraMiddleware []counterstore.Middleware[counterstore.State, counterstore.AppAction]
+
+	mu          sync.Mutex
+	lastChanges map[string]counterstore.Change
+	lastLatency time.Duration
+	metrics     []MetricsRow
+	display     Tween
+	colorRange  ColorRange
+	status      string
+	statusSince time.Time
+	feedback    string
 
-// State
-type State struct {
-	Count int
+	confirmReset bool
+	importPath   string
+
+	idle  *counterstore.IdleWatch
+	burst *counterstore.Burst
+
+	autoIncrement      *counterstore.Burst
+	autoIncrementDone  func()
+	autoIncrementEvery time.Duration
 }
 
-func (s State) Copy() State {
-	return State{
-		Count: s.Count,
+func NewViewModel(store *counterstore.Store[counterstore.State, counterstore.AppAction]) *ViewModel {
+	count := float64(store.GetState().Count)
+	return &ViewModel{
+		store:      store,
+		clock:      counterstore.RealClock{},
+		display:    Tween{From: count, To: count, Duration: displayEase},
+		colorRange: defaultColorRange,
+
+		autoIncrementEvery: defaultAutoIncrementEvery,
 	}
 }
 
-// Action
-type Action[S any] interface {
-	Apply(s S) S
+// Incre moves the count up by the step size
+func (v *ViewModel) Incre() {
+	v.dispatchWithFeedback(v.stepAction(1))
+}
+
+// Decre moves the count down by the step size
+func (v *ViewModel) Decre() {
+	v.dispatchWithFeedback(v.stepAction(-1))
 }
 
-// IncrementAction
-type IncrementAction struct{}
+func (v *ViewModel) CountLabel() string {
+	return fmt.Sprintf("%d", v.store.GetStateFast().Count)
+}
 
-func (a IncrementAction) Apply(s State) State {
-	state := s.Copy()
-	state.Count++
-	return state
+// DisplayValue is the count as currently shown, easing towards the count in
+// State whenever it changes
+func (v *ViewModel) DisplayValue() float64 {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	now := v.clock.Now()
+	if count := float64(v.store.GetStateFast().Count); count != v.display.To {
+		v.display = v.display.Retarget(now, count)
+	}
+	return v.display.Value(now)
 }
 
-// DecrementAction
-type DecrementAction struct{}
+// DisplaySettled reports whether DisplayValue has caught up with State
+func (v *ViewModel) DisplaySettled() bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.display.Settled(v.clock.Now()) && float64(v.store.GetStateFast().Count) == v.display.To
+}
 
-func (a DecrementAction) Apply(s State) State {
-	state := s.Copy()
-	state.Count--
-	return state
+// BigLabel formats the arbitrary-precision count, or "" when no big store is
+// attached
+func (v *ViewModel) BigLabel() string {
+	if v.bigStore == nil {
+		return ""
+	}
+	return v.bigStore.GetState().Count.String()
 }
 
-// Reducer type
-type Reducer[S any, A Action[S]] func(state S, action A) S
+func (v *ViewModel) Paused() bool {
+	return v.store.Paused()
+}
 
-// Middleware type
-type Middleware[S StateProvider[S], A Action[S]] func(store *Store[S, A], next Dispatch[A]) Dispatch[A]
-type Dispatch[A any] func(action A)
+func (v *ViewModel) SetPaused(paused bool) {
+	if paused {
+		v.store.Pause()
+	} else {
+		v.store.Resume()
+	}
+}
 
-// Logging Middleware
-func LoggingMiddleware[S StateProvider[S], A Action[S]](store *Store[S, A], next Dispatch[A]) Dispatch[A] {
-	return func(action A) {
-		prevState := store.GetState()
-		log.Printf("Action dispatched: %T, Previous State: %+v", action, prevState)
-		next(action)
-		newState := store.GetState()
-		log.Printf("Action dispatched: %T, New State: %+v", action, newState)
+// PendingLines names the actions queued while paused, oldest first
+func (v *ViewModel) PendingLines() []string {
+	pending := v.store.PendingActions()
+	lines := make([]string, len(pending))
+	for i, action := range pending {
+		lines[i] = fmt.Sprintf("%d. %s", i+1, counterstore.ActionName(action))
 	}
+	return lines
 }
 
-// Store
-type Store[S StateProvider[S], A Action[S]] struct {
-	mu          sync.RWMutex
-	state       S
-	reducer     Reducer[S, A]
-	middleware  []Middleware[S, A]
-	dispatch    Dispatch[A]
-	subscribers []func()
+// StackConfig returns the configuration of the store's switchable middleware
+func (v *ViewModel) StackConfig() counterstore.StackConfig {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.stack
+}
+
+// SetStackConfig rebuilds the store's middleware from cfg, followed by the
+// fixed middleware the app always runs
+func (v *ViewModel) SetStackConfig(cfg counterstore.StackConfig) {
+	v.mu.Lock()
+	v.stack = cfg
+	middleware := append(counterstore.BuildStack[counterstore.State, counterstore.AppAction](cfg), v.extraMiddleware...)
+	v.mu.Unlock()
+
+	v.store.SetMiddleware(middleware)
 }
 
-func NewStore[S StateProvider[S], A Action[S]](
-	reducer Reducer[S, A],
-	initialState S,
-	middleware ...Middleware[S, A],
-) *Store[S, A] {
-	store := &Store[S, A]{
-		state:       initialState,
-		reducer:     reducer,
-		middleware:  middleware,
-		subscribers: []func(){},
+// Words spells the count out for screen readers
+func (v *ViewModel) Words() string {
+	return numberWords(v.store.GetStateFast().Count)
+}
+
+// Toasts returns the notifications currently showing, oldest first
+func (v *ViewModel) Toasts() []counterstore.Toast {
+	return v.store.GetStateFast().Toasts
+}
+
+// NewPlayback starts a playback of the dispatches recorded so far
+func (v *ViewModel) NewPlayback() *counterstore.Playback {
+	if v.recorder == nil {
+		return counterstore.NewPlayback(nil)
 	}
+	return counterstore.NewPlayback(v.recorder.Actions())
+}
 
-	store.dispatch = store.applyMiddleware(store.dispatchInternal())
-	return store
+// JumpTo shows state without dispatching anything, for time travel
+func (v *ViewModel) JumpTo(state counterstore.State) {
+	v.store.JumpTo(state)
 }
 
-func (s *Store[S, A]) dispatchInternal() Dispatch[A] {
-	return func(action A) {
-		s.mu.Lock()
-		s.state = s.reducer(s.state, action)
-		s.mu.Unlock()
+// LabelColor returns the count's colour on the configured colour scale, in
+// the active palette
+func (v *ViewModel) LabelColor() color.NRGBA {
+	v.mu.Lock()
+	r := v.colorRange
+	v.mu.Unlock()
+	state := v.store.GetStateFast()
+	return scaleColor(state.Count, r, paletteFor(state.Palette))
+}
 
-		// Notify subscribers after state update
-		s.mu.RLock()
-		subscribers := s.subscribers
-		s.mu.RUnlock()
+// ErrorColor returns the colour errors are painted in, in the active palette
+func (v *ViewModel) ErrorColor() color.NRGBA {
+	return paletteFor(v.store.GetStateFast().Palette).Error
+}
 
-		for _, sub := range subscribers {
-			sub()
-		}
+// ColorBlind reports whether the colour-blind-safe palette is active
+func (v *ViewModel) ColorBlind() bool {
+	return v.store.GetStateFast().Palette == counterstore.PaletteColorBlind
+}
+
+// SetColorBlind switches between the default and colour-blind-safe palettes
+func (v *ViewModel) SetColorBlind(enabled bool) {
+	p := counterstore.PaletteDefault
+	if enabled {
+		p = counterstore.PaletteColorBlind
 	}
+	v.store.Dispatch(counterstore.SetPaletteAction{Palette: p})
 }
 
-func (s *Store[S, A]) applyMiddleware(dispatch Dispatch[A]) Dispatch[A] {
-	// Apply in reverse order so first middleware is outermost
-	for i := len(s.middleware) - 1; i >= 0; i-- {
-		dispatch = s.middleware[i](s, dispatch)
+// SetColorRange sets the span of counts the label's colour scale covers
+func (v *ViewModel) SetColorRange(r ColorRange) {
+	v.mu.Lock()
+	v.colorRange = r
+	v.mu.Unlock()
+}
+
+// Reset zeroes the count, or with confirmation on only asks for it to be
+// zeroed until ConfirmReset
+func (v *ViewModel) Reset() {
+	if v.confirmReset {
+		v.store.Dispatch(counterstore.RequestResetAction{})
+		return
 	}
-	return dispatch
+	v.store.Dispatch(counterstore.ResetAction{})
 }
 
-func (s *Store[S, A]) Dispatch(action A) {
-	s.dispatch(action)
+// ConfirmPending reports whether a reset is waiting to be confirmed
+func (v *ViewModel) ConfirmPending() bool {
+	return v.store.GetStateFast().UI.ConfirmPending
 }
 
-func (s *Store[S, A]) GetState() S {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-	return s.state.Copy()
+func (v *ViewModel) ConfirmReset() {
+	v.store.Dispatch(counterstore.ConfirmResetAction{})
 }
 
-func (s *Store[S, A]) Subscribe(fn func()) func() {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	s.subscribers = append(s.subscribers, fn)
+func (v *ViewModel) CancelReset() {
+	v.store.Dispatch(counterstore.CancelResetAction{})
+}
 
-	// Return unsubscribe function
-	index := len(s.subscribers) - 1
-	return func() {
-		s.mu.Lock()
-		defer s.mu.Unlock()
-		if index < len(s.subscribers) {
-			s.subscribers = append(s.subscribers[:index], s.subscribers[index+1:]...)
-		}
+// Step moves the count by delta: single steps go through Incre and Decre,
+// larger ones dispatch an AddAmountAction
+func (v *ViewModel) Step(delta int) {
+	switch delta {
+	case 1:
+		v.Incre()
+	case -1:
+		v.Decre()
+	default:
+		v.dispatchWithFeedback(counterstore.AddAmountAction{Amount: delta})
 	}
 }
 
-// AppAction is the union type for all actions in this app
-type AppAction interface {
-	Action[State]
+// CanIncrement reports whether an increment would be accepted, which it is
+// unless the count is at the top of its range in error overflow mode
+func (v *ViewModel) CanIncrement() bool {
+	return v.canStep(1)
 }
 
-// Reducer function
-func reduce(state State, action AppAction) State {
-	return action.Apply(state)
+// CanDecrement reports whether a decrement would be accepted; it is refused
+// at zero under the no-negatives policy, and at the bottom of the range in
+// error overflow mode
+func (v *ViewModel) CanDecrement() bool {
+	return v.canStep(-1)
 }
 
-// ViewModel
-type ViewModel struct {
-	store *Store[State, AppAction]
+// NoNegatives reports whether the no-negatives policy is on
+func (v *ViewModel) NoNegatives() bool {
+	return v.store.GetStateFast().Flags[counterstore.FlagNoNegatives]
 }
 
-func NewViewModel(store *Store[State, AppAction]) *ViewModel {
-	return &ViewModel{
-		store: store,
+func (v *ViewModel) SetNoNegatives(enabled bool) {
+	v.store.Dispatch(counterstore.SetFlagAction{Name: counterstore.FlagNoNegatives, Enabled: enabled})
+}
+
+// Countdown reports whether the count is counting down to zero by itself
+func (v *ViewModel) Countdown() bool {
+	return v.store.GetStateFast().Countdown
+}
+
+func (v *ViewModel) ToggleCountdown() {
+	v.store.Dispatch(counterstore.ToggleCountdownAction{})
+}
+
+func (v *ViewModel) Negate() {
+	v.dispatchWithFeedback(counterstore.NegateAction{})
+}
+
+func (v *ViewModel) SetCount(value int) {
+	v.store.Dispatch(counterstore.SetCountAction{Value: value})
+}
+
+func (v *ViewModel) PresentationMode() bool {
+	return v.store.GetStateFast().PresentationMode
+}
+
+func (v *ViewModel) SetPresentationMode(enabled bool) {
+	v.store.Dispatch(counterstore.SetPresentationModeAction{Enabled: enabled})
+}
+
+func (v *ViewModel) AlwaysOnTop() bool {
+	return v.store.GetStateFast().AlwaysOnTop
+}
+
+func (v *ViewModel) ToggleAlwaysOnTop() {
+	v.store.Dispatch(counterstore.ToggleAlwaysOnTopAction{})
+}
+
+func (v *ViewModel) ShowHelp() bool {
+	return v.store.GetStateFast().UI.ShowHelp
+}
+
+func (v *ViewModel) SetHelp(visible bool) {
+	v.store.Dispatch(counterstore.SetHelpAction{Visible: visible})
+}
+
+// ClipboardText is the text the Copy button puts on the clipboard
+func (v *ViewModel) ClipboardText() string {
+	return v.CountLabel()
+}
+
+// Preview explains what action would do to the current state
+func (v *ViewModel) Preview(action counterstore.AppAction) string {
+	return counterstore.Explain(v.store.GetStateFast(), action)
+}
+
+// SetLastChanges stores the change map of the most recent dispatch
+func (v *ViewModel) SetLastChanges(changes map[string]counterstore.Change) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.lastChanges = changes
+}
+
+// ChangeLines returns the "before → after" lines for the most recent dispatch
+func (v *ViewModel) ChangeLines() []string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return counterstore.FormatChanges(v.lastChanges)
+}
+
+// WatchProfiler keeps LastDispatchLatency and MetricsRows up to date from p
+// on every change
+func (v *ViewModel) WatchProfiler(p *counterstore.Profiler) func() {
+	return v.store.Subscribe(func() {
+		latency := p.Last()
+		rows := BuildMetricsRows(p.Stats())
+		v.mu.Lock()
+		v.lastLatency = latency
+		v.metrics = rows
+		v.mu.Unlock()
+	})
+}
+
+// MetricsRows returns the per-action metrics as of the last change
+func (v *ViewModel) MetricsRows() []MetricsRow {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.metrics
+}
+
+// LastDispatchLatency returns how long the last action spent in middleware
+// and the reducer
+func (v *ViewModel) LastDispatchLatency() time.Duration {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.lastLatency
+}
+
+// Average returns the running average of the values set with SetCount
+func (v *ViewModel) Average() float64 {
+	if v.average == nil {
+		return 0
 	}
+	return v.average.Average().Mean()
 }
 
-func (v *ViewModel) Incre() {
-	v.store.Dispatch(IncrementAction{})
+// Recent returns the most recent distinct counts, oldest first
+func (v *ViewModel) Recent() []int {
+	if v.recent == nil {
+		return nil
+	}
+	return v.recent.Values()
 }
 
-func (v *ViewModel) Decre() {
-	v.store.Dispatch(DecrementAction{})
+// Rate returns the net count change per second over the trailing window
+func (v *ViewModel) Rate(window time.Duration) float64 {
+	if v.recorder == nil {
+		return 0
+	}
+	return counterstore.NetRate(v.recorder.Actions(), v.clock.Now(), window)
 }
 
-func (v *ViewModel) CountLabel() string {
-	return fmt.Sprintf("%d", v.store.GetState().Count)
+// TimelineLines returns the last n timeline entries formatted for display,
+// most recent first
+func (v *ViewModel) TimelineLines(n int) []string {
+	if v.timeline == nil {
+		return nil
+	}
+	entries := v.timeline.Entries()
+	lines := []string{}
+	for i := len(entries) - 1; i >= 0 && len(lines) < n; i-- {
+		e := entries[i]
+		lines = append(lines, fmt.Sprintf("%s  %s  %dµs", e.At.Format("15:04:05.000"), e.Type, e.DurMicros))
+	}
+	return lines
 }
 
 func main() {
+	debugAddr := flag.String("debug-addr", "", "serve expvar metrics at /debug/vars on this address")
+	autoReset := flag.Duration("auto-reset", 0, "reset the counter after this long without any action; 0 disables")
+	lang := flag.String("lang", "en", "language of the controls: en or fr")
+	colorMin := flag.Int("color-min", defaultColorRange.Min, "count painted at the cold end of the label's colour scale")
+	colorMax := flag.Int("color-max", defaultColorRange.Max, "count painted at the hot end of the label's colour scale")
+	confirmReset := flag.Bool("confirm-reset", true, "ask for confirmation before resetting the counter")
+	script := flag.String("script", "", "dispatch the action commands in this file, or - for standard input, as they are read")
+	idleDim := flag.Duration("idle-dim", 0, "dim the window after this long without input; 0 disables")
+	mirror := flag.String("mirror", "", "keep this file holding the current state as JSON, for other programs to watch")
+	importCSV := flag.String("import", "", "CSV file of counts the Import button reads")
+	minimalFullscreen := flag.Bool("minimal-fullscreen", false, "go fullscreen in the minimal layout")
+	demo := flag.Bool("demo", false, "play a scripted demo of the controls, for screen recordings")
+	frameCommits := flag.Bool("frame-commits", false, "notify subscribers of state changes once per frame rather than per action")
+	autoIncrementEvery := flag.Duration("auto-increment-every", defaultAutoIncrementEvery, "how often the demo bot increments the count")
+	bench := flag.Int("bench", 0, "dispatch this many actions through a few headless stacks, print their throughput and exit")
+	flag.Parse()
+
+	if *bench > 0 {
+		runBenchmarks(*bench)
+		return
+	}
+
+	labels, ok := labelSets[*lang]
+	if !ok {
+		log.Fatalf("unknown -lang %q", *lang)
+	}
+
+	if *debugAddr != "" {
+		go func() {
+			log.Println(http.ListenAndServe(*debugAddr, nil))
+		}()
+	}
+
 	go func() {
 		w := &app.Window{}
 		w.Option(
 			app.Title("Counter App"),
-			app.Size(unit.Dp(400), unit.Dp(200)),
+			app.Size(unit.Dp(420), unit.Dp(480)),
 			app.MinSize(unit.Dp(300), unit.Dp(100)),
 		)
-		if err := run(w); err != nil {
+		if err := run(w, appConfig{
+			autoReset:         *autoReset,
+			labels:            labels,
+			colorRange:        ColorRange{Min: *colorMin, Max: *colorMax},
+			script:            *script,
+			confirmReset:      *confirmReset,
+			demo:              *demo,
+			mirror:            *mirror,
+			importCSV:         *importCSV,
+			minimalFullscreen: *minimalFullscreen,
+			idleDim:           *idleDim,
+			autoIncrement:     *autoIncrementEvery,
+			frameCommits:      *frameCommits,
+		}); err != nil {
 			log.Fatal(err)
 		}
 	}()
 	app.Main()
 }
 
-func run(w *app.Window) error {
+// mirrorInterval is how often -mirror rewrites its file at most
+const mirrorInterval = 100 * time.Millisecond
+
+// appConfig is the command-line configuration run starts the app with
+type appConfig struct {
+	autoReset         time.Duration
+	labels            Labels
+	colorRange        ColorRange
+	script            string
+	confirmReset      bool
+	demo              bool
+	mirror            string
+	importCSV         string
+	minimalFullscreen bool
+	idleDim           time.Duration
+	autoIncrement     time.Duration
+	frameCommits      bool
+}
+
+func run(w *app.Window, cfg appConfig) error {
 	th := material.NewTheme()
-	store := NewStore(reduce, State{Count: 0}, LoggingMiddleware[State, AppAction])
-	viewModel := NewViewModel(store)
+	var viewModel *ViewModel
+	timeline := counterstore.NewTimeline(nil)
+	profiler := counterstore.NewProfiler(nil)
+	recent := counterstore.NewRecentCounts(20)
+	average := &counterstore.SetAverage{}
+	autoReset := counterstore.NewAutoReset(nil, cfg.autoReset)
+	defer autoReset.Close()
+	idle := counterstore.NewIdleWatch(nil, cfg.idleDim)
+	defer idle.Close()
+	countdown := counterstore.NewCountdown(nil, func() {
+		viewModel.store.Dispatch(counterstore.ShowToastAction{Text: "Countdown finished"})
+	})
+	defer countdown.Close()
+	stopwatch := counterstore.NewStopwatch(nil)
+	defer stopwatch.Close()
+	recorder := counterstore.NewRecorder(nil)
+	stack := counterstore.StackConfig{
+		Logging:  true,
+		Profiler: profiler,
+	}
+	extra := []counterstore.Middleware[counterstore.State, counterstore.AppAction]{
+		counterstore.RollMiddleware(nil),
+		counterstore.TimelineMiddleware[counterstore.State, counterstore.AppAction](timeline),
+		counterstore.RecentMiddleware(recent),
+		counterstore.AverageMiddleware(average),
+		counterstore.RecorderMiddleware(recorder),
+		counterstore.ExpvarMiddleware[counterstore.State, counterstore.AppAction]("counter"),
+		counterstore.ToastMiddleware(nil, counterstore.DefaultToastRule),
+		autoReset.Middleware(),
+		idle.Middleware(),
+		countdown.Middleware(),
+		stopwatch.Middleware(),
+		counterstore.DwellMiddleware(counterstore.DwellMillis, nil),
+		counterstore.DiffMiddleware[counterstore.State, counterstore.AppAction](func(changes map[string]counterstore.Change) {
+			viewModel.SetLastChanges(changes)
+		}),
+	}
+	if cfg.mirror != "" {
+		extra = append(extra, counterstore.StateMirrorMiddleware(nil, cfg.mirror, mirrorInterval, nil))
+	}
+	opts := []counterstore.StoreOption[counterstore.State, counterstore.AppAction]{
+		counterstore.WithMiddleware(append(counterstore.BuildStack[counterstore.State, counterstore.AppAction](stack), extra...)...),
+	}
+	if cfg.frameCommits {
+		opts = append(opts,
+			counterstore.WithNotifyMode[counterstore.State, counterstore.AppAction](counterstore.FrameCommitMode),
+			counterstore.WithFrameRequest[counterstore.State, counterstore.AppAction](w.Invalidate),
+		)
+	}
+	store := counterstore.NewStoreWithOptions(counterstore.Reduce, counterstore.State{Count: 0}, opts...)
+	viewModel = NewViewModel(store)
+	viewModel.stack = stack
+	viewModel.extraMiddleware = extra
+	viewModel.profiler = profiler
+	viewModel.timeline = timeline
+	viewModel.recent = recent
+	viewModel.average = average
+	viewModel.SetColorRange(cfg.colorRange)
+	viewModel.recorder = recorder
+	viewModel.WatchProfiler(profiler)
+	viewModel.WatchStatus()
+	viewModel.confirmReset = cfg.confirmReset
+	viewModel.importPath = cfg.importCSV
+	viewModel.idle = idle
+	viewModel.burst = counterstore.NewBurst(nil, burstInterval, func() {
+		store.Dispatch(counterstore.IncrementAction{})
+	})
+	defer viewModel.burst.Close()
+	viewModel.autoIncrementEvery = cfg.autoIncrement
+	defer viewModel.StopAutoIncrement()
+	if cfg.script != "" {
+		go pipeScript(store, cfg.script)
+	}
 
 	var ops op.Ops
-	view := NewView(viewModel, th)
+	view := NewView(viewModel, th, cfg.labels)
+	stopPresenting := viewModel.Present(view, view.labels.FormatCount)
+	defer stopPresenting()
 
-	// Subscribe to store changes and invalidate window
-	store.Subscribe(func() {
-		w.Invalidate()
-	})
+	var title titleBinding
+	title.apply(w, store.GetState().Count)
+
+	// Subscribe to store changes and invalidate window, once per frame
+	invalidator := NewInvalidator(w.Invalidate)
+	store.Subscribe(invalidator.Changed)
+	bindAlwaysOnTop(w, store)
+	if cfg.minimalFullscreen {
+		bindFullscreen(w, store)
+	}
+	if cfg.demo {
+		stopDemo := counterstore.RunDemo(store, demoSteps, nil)
+		defer stopDemo()
+	}
 
 	for {
 		switch e := w.Event().(type) {
 		case app.DestroyEvent:
 			return e.Err
 		case app.FrameEvent:
+			// Commit first, so the notification it sends finds this frame
+			// already pending and does not ask for another
+			store.CommitFrame()
+			invalidator.Frame()
+			if _, dirty := store.Dirty(); dirty {
+				title.apply(w, store.GetState().Count)
+			}
 			gtx := app.NewContext(&ops, e)
 			view.Layout(gtx)
 			e.Frame(gtx.Ops)
@@ -226,43 +604,459 @@ type View struct {
 	theme           *material.Theme
 	incrementButton widget.Clickable
 	decrementButton widget.Clickable
+	resetButton     widget.Clickable
+	negateButton    widget.Clickable
+	labels          Labels
+	largeToggle     widget.Bool
+	pinToggle       widget.Bool
+	pauseToggle     widget.Bool
+	noNegToggle     widget.Bool
+	countdownToggle widget.Bool
+	paletteToggle   widget.Bool
+	flushButton     widget.Clickable
+	entry           *EntryOverlay
+	help            HelpOverlay
+	metrics         *MetricsPanel
+	clipboard       ClipboardButtons
+	stackPanel      StackPanel
+	scoreboard      Scoreboard
+	stopwatch       StopwatchPanel
+	money           MoneyPanel
+	presets         PresetPanel
+	autoIncrement   AutoIncrementPanel
+	dice            DicePanel
+	importPanel     ImportPanel
+	bookmarks       *BookmarkPanel
+	notes           *NotePanel
+	categories      *CategoryPanel
+	countBinding    *CountBinding
+	playback        PlaybackPanel
+	confirmation    ResetConfirmation
+
+	presented   presented
+	activityTag bool
+	focus       focusTarget
 }
 
-func NewView(vm *ViewModel, theme *material.Theme) *View {
+// NewView builds the view with the given control labels; empty ones fall
+// back to English
+func NewView(vm *ViewModel, theme *material.Theme, labels Labels) *View {
 	return &View{
 		viewModel:       vm,
 		theme:           theme,
 		incrementButton: widget.Clickable{},
 		decrementButton: widget.Clickable{},
+		labels:          resolveLabels(labels),
+		entry:           NewEntryOverlay(theme, vm.SetCount),
+		help:            HelpOverlay{theme: theme},
+		metrics:         NewMetricsPanel(theme),
+		clipboard:       ClipboardButtons{theme: theme},
+		stackPanel:      StackPanel{theme: theme},
+		scoreboard:      Scoreboard{theme: theme},
+		stopwatch:       StopwatchPanel{theme: theme},
+		money:           MoneyPanel{theme: theme},
+		presets:         PresetPanel{theme: theme},
+		autoIncrement:   AutoIncrementPanel{theme: theme},
+		dice:            DicePanel{theme: theme},
+		importPanel:     ImportPanel{theme: theme},
+		bookmarks:       NewBookmarkPanel(theme),
+		notes:           NewNotePanel(theme),
+		categories:      NewCategoryPanel(theme),
+		countBinding:    NewCountBinding(theme),
+		playback:        PlaybackPanel{theme: theme},
+		confirmation:    ResetConfirmation{theme: theme},
+		focus:           noFocus,
 	}
 }
 
+// Labels returns the labels the view renders its controls with
+func (v *View) Labels() Labels {
+	return v.labels
+}
+
 func (v *View) Layout(gtx layout.Context) layout.Dimensions {
+	v.trackActivity(gtx)
+	v.handleKeys(gtx)
+	var dims layout.Dimensions
+	switch v.viewModel.ViewLayout() {
+	case minimalLayout:
+		dims = v.layoutMinimal(gtx)
+	default:
+		dims = v.layoutFull(gtx)
+	}
+	v.entry.Layout(gtx, v.viewModel)
+	layoutToasts(gtx, v.theme, v.viewModel.Toasts())
+	v.confirmation.Layout(gtx, v.viewModel)
+	v.help.Layout(gtx, v.viewModel.ShowHelp())
+	v.layoutIdle(gtx)
+	return dims
+}
+
+var (
+	helpFilters = []event.Filter{
+		key.Filter{Name: "?", Optional: key.ModShift},
+		key.Filter{Name: "/", Required: key.ModShift},
+	}
+	shortcutFilters = []event.Filter{
+		key.Filter{Name: key.NameUpArrow, Optional: key.ModShift | key.ModCtrl},
+		key.Filter{Name: key.NameDownArrow, Optional: key.ModShift | key.ModCtrl},
+		key.Filter{Name: "R"},
+		key.Filter{Name: "F"},
+		key.Filter{Name: key.NameSpace},
+		key.Filter{Name: key.NameTab, Optional: key.ModShift},
+	}
+)
+
+// handleKeys processes the window-level key shortcuts. While a reset awaits
+// confirmation Enter confirms and Esc cancels it; while the help overlay is
+// open only the keys closing it are handled; while the entry overlay is open
+// it owns the keyboard. In the minimal layout Esc also returns to the full
+// one. Tab and Shift+Tab move the focus ring between the counter buttons,
+// Enter and Space press the focused one and Esc clears the focus.
+func (v *View) handleKeys(gtx layout.Context) {
+	if v.entry.Active() {
+		return
+	}
+
+	showHelp := v.viewModel.ShowHelp()
+	filters := append([]event.Filter{}, helpFilters...)
+	if v.viewModel.ConfirmPending() {
+		filters = append(filters, key.Filter{Name: key.NameEscape}, key.Filter{Name: key.NameReturn})
+	} else if showHelp {
+		filters = append(filters, key.Filter{Name: key.NameEscape})
+	} else {
+		filters = append(filters, shortcutFilters...)
+		filters = append(filters, digitFilters...)
+		if v.viewModel.Minimal() || v.focus != noFocus {
+			filters = append(filters, key.Filter{Name: key.NameEscape})
+		}
+		if v.focus != noFocus {
+			filters = append(filters, key.Filter{Name: key.NameReturn})
+		}
+	}
+	if v.viewModel.ConfirmPending() || showHelp {
+		// The space key's release is no longer delivered
+		v.viewModel.StopBurst()
+	}
+
+	for {
+		ev, ok := gtx.Event(filters...)
+		if !ok {
+			break
+		}
+		e, ok := ev.(key.Event)
+		if ok && e.Name == key.NameSpace && e.State == key.Release {
+			v.viewModel.StopBurst()
+		}
+		if !ok || e.State != key.Press {
+			continue
+		}
+		v.viewModel.Activity()
+		switch e.Name {
+		case "?", "/":
+			v.viewModel.SetHelp(!showHelp)
+		case key.NameEscape:
+			switch {
+			case v.viewModel.ConfirmPending():
+				v.viewModel.CancelReset()
+			case showHelp:
+				v.viewModel.SetHelp(false)
+			case v.focus != noFocus:
+				v.focus = noFocus
+			case v.viewModel.Minimal():
+				v.viewModel.ToggleMinimal()
+			}
+		case key.NameReturn:
+			if v.viewModel.ConfirmPending() || !v.activateFocused() {
+				v.viewModel.ConfirmReset()
+			}
+		case key.NameTab:
+			v.focus = advanceFocus(v.focus, int(focusTargets), e.Modifiers.Contain(key.ModShift))
+		case key.NameUpArrow, key.NameDownArrow:
+			delta, _ := arrowDelta(e.Name, e.Modifiers)
+			v.viewModel.Step(delta)
+		case "R":
+			v.viewModel.Reset()
+		case "F":
+			v.viewModel.ToggleMinimal()
+		case key.NameSpace:
+			if !v.activateFocused() {
+				v.viewModel.StartBurst()
+			}
+		default:
+			v.entry.Open(gtx, string(e.Name))
+		}
+	}
+}
+
+func (v *View) layoutFull(gtx layout.Context) layout.Dimensions {
 	return layout.Center.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
 		return layout.Flex{
-			Axis:      layout.Horizontal,
+			Axis:      layout.Vertical,
 			Alignment: layout.Middle,
-			Spacing:   layout.SpaceEvenly,
 		}.Layout(gtx,
+			layout.Rigid(v.layoutCounter),
+			layout.Rigid(v.layoutStatus),
+			layout.Rigid(v.layoutFeedback),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return v.countBinding.Layout(gtx, v.viewModel)
+			}),
+			layout.Rigid(v.layoutPreview),
+			layout.Rigid(v.layoutLatency),
+			layout.Rigid(v.layoutRate),
+			layout.Rigid(v.layoutAverage),
+			layout.Rigid(v.layoutNextTen),
+			layout.Rigid(v.layoutToggles),
+			layout.Rigid(v.layoutPending),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return v.stackPanel.Layout(gtx, v.viewModel)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return v.scoreboard.Layout(gtx, v.viewModel)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return v.stopwatch.Layout(gtx, v.viewModel)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return v.money.Layout(gtx, v.viewModel)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return v.presets.Layout(gtx, v.viewModel)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return v.autoIncrement.Layout(gtx, v.viewModel)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return v.dice.Layout(gtx, v.viewModel)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return v.importPanel.Layout(gtx, v.viewModel)
+			}),
 			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-				if v.incrementButton.Clicked(gtx) {
-					v.viewModel.Incre()
-				}
-				return material.Button(v.theme, &v.incrementButton, "Increment").Layout(gtx)
+				return v.bookmarks.Layout(gtx, v.viewModel)
 			}),
-			layout.Rigid(layout.Spacer{Width: unit.Dp(20)}.Layout),
 			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-				label := material.Body1(v.theme, v.viewModel.CountLabel())
-				label.Font.Weight = font.Bold
-				return label.Layout(gtx)
+				return v.notes.Layout(gtx, v.viewModel)
 			}),
-			layout.Rigid(layout.Spacer{Width: unit.Dp(20)}.Layout),
 			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-				if v.decrementButton.Clicked(gtx) {
-					v.viewModel.Decre()
-				}
-				return material.Button(v.theme, &v.decrementButton, "Decrement").Layout(gtx)
+				return v.categories.Layout(gtx, v.viewModel)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return v.playback.Layout(gtx, v.viewModel)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return v.clipboard.Layout(gtx, v.viewModel)
+			}),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layoutSparkline(gtx, v.viewModel.Recent(), v.theme.ContrastBg)
+			}),
+			layout.Rigid(layout.Spacer{Height: unit.Dp(6)}.Layout),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layoutDwellChart(gtx, v.viewModel.DwellBars(), v.viewModel.DwellPalette())
+			}),
+			layout.Rigid(layout.Spacer{Height: unit.Dp(12)}.Layout),
+			layout.Rigid(v.layoutChanges),
+			layout.Rigid(layout.Spacer{Height: unit.Dp(12)}.Layout),
+			layout.Rigid(v.layoutTimeline),
+			layout.Rigid(layout.Spacer{Height: unit.Dp(12)}.Layout),
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return v.metrics.Layout(gtx, v.viewModel.MetricsRows())
 			}),
 		)
 	})
 }
+
+func (v *View) layoutCounter(gtx layout.Context) layout.Dimensions {
+	return layout.Flex{
+		Axis:      layout.Horizontal,
+		Alignment: layout.Middle,
+		Spacing:   layout.SpaceEvenly,
+	}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if !v.viewModel.CanIncrement() {
+				gtx = gtx.Disabled()
+			}
+			if v.incrementButton.Clicked(gtx) {
+				v.viewModel.Incre()
+			}
+			return v.layoutFocusable(gtx, focusIncrement, material.Button(v.theme, &v.incrementButton, v.labels.Increment).Layout)
+		}),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(20)}.Layout),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			text, _ := v.shown()
+			if !v.viewModel.DisplaySettled() {
+				text = v.labels.FormatCount(int(math.Round(v.viewModel.DisplayValue())))
+				gtx.Execute(op.InvalidateCmd{})
+			}
+			label := countLabel(v.theme, v.viewModel.PresentationMode(), text)
+			label.Color = v.viewModel.LabelColor()
+			return layoutSemanticLabel(gtx, v.viewModel.Words(), label.Layout)
+		}),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(20)}.Layout),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if !v.viewModel.CanDecrement() {
+				gtx = gtx.Disabled()
+			}
+			if v.decrementButton.Clicked(gtx) {
+				v.viewModel.Decre()
+			}
+			return v.layoutFocusable(gtx, focusDecrement, material.Button(v.theme, &v.decrementButton, v.labels.Decrement).Layout)
+		}),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(20)}.Layout),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if v.resetButton.Clicked(gtx) {
+				v.viewModel.Reset()
+			}
+			return v.layoutFocusable(gtx, focusReset, material.Button(v.theme, &v.resetButton, v.labels.Reset).Layout)
+		}),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(20)}.Layout),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if v.negateButton.Clicked(gtx) {
+				v.viewModel.Negate()
+			}
+			return material.Button(v.theme, &v.negateButton, "±").Layout(gtx)
+		}),
+	)
+}
+
+// layoutSemanticLabel lays out w with label as its accessibility label in
+// place of the text it draws
+func layoutSemanticLabel(gtx layout.Context, label string, w layout.Widget) layout.Dimensions {
+	macro := op.Record(gtx.Ops)
+	dims := w(gtx)
+	call := macro.Stop()
+
+	defer clip.Rect{Max: dims.Size}.Push(gtx.Ops).Pop()
+	semantic.LabelOp(label).Add(gtx.Ops)
+	call.Add(gtx.Ops)
+	return dims
+}
+
+// countLabel styles the count, large in presentation mode and bold either way
+func countLabel(th *material.Theme, presentation bool, text string) material.LabelStyle {
+	label := material.Body1(th, text)
+	if presentation {
+		label = material.H4(th, text)
+	}
+	label.Font.Weight = font.Bold
+	return label
+}
+
+// layoutToggles renders the checkboxes bound to the display flags in State
+func (v *View) layoutToggles(gtx layout.Context) layout.Dimensions {
+	if v.largeToggle.Update(gtx) {
+		v.viewModel.SetPresentationMode(v.largeToggle.Value)
+	}
+	if v.pinToggle.Update(gtx) {
+		v.viewModel.ToggleAlwaysOnTop()
+	}
+	if v.pauseToggle.Update(gtx) {
+		v.viewModel.SetPaused(v.pauseToggle.Value)
+	}
+	if v.noNegToggle.Update(gtx) {
+		v.viewModel.SetNoNegatives(v.noNegToggle.Value)
+	}
+	if v.countdownToggle.Update(gtx) {
+		v.viewModel.ToggleCountdown()
+	}
+	if v.paletteToggle.Update(gtx) {
+		v.viewModel.SetColorBlind(v.paletteToggle.Value)
+	}
+	v.largeToggle.Value = v.viewModel.PresentationMode()
+	v.pinToggle.Value = v.viewModel.AlwaysOnTop()
+	v.pauseToggle.Value = v.viewModel.Paused()
+	v.noNegToggle.Value = v.viewModel.NoNegatives()
+	v.countdownToggle.Value = v.viewModel.Countdown()
+	v.paletteToggle.Value = v.viewModel.ColorBlind()
+
+	return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+		layout.Rigid(material.CheckBox(v.theme, &v.largeToggle, "Large").Layout),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(12)}.Layout),
+		layout.Rigid(material.CheckBox(v.theme, &v.pinToggle, "Pin on top").Layout),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(12)}.Layout),
+		layout.Rigid(material.CheckBox(v.theme, &v.pauseToggle, "Pause").Layout),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(12)}.Layout),
+		layout.Rigid(material.CheckBox(v.theme, &v.noNegToggle, "No negatives").Layout),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(12)}.Layout),
+		layout.Rigid(material.CheckBox(v.theme, &v.countdownToggle, "Countdown").Layout),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(12)}.Layout),
+		layout.Rigid(material.CheckBox(v.theme, &v.paletteToggle, "Colour-blind palette").Layout),
+	)
+}
+
+// layoutPending lists the actions queued while paused, with a button
+// flushing them; it is empty when the store is running
+func (v *View) layoutPending(gtx layout.Context) layout.Dimensions {
+	if v.flushButton.Clicked(gtx) {
+		v.viewModel.SetPaused(false)
+	}
+	if !v.viewModel.Paused() {
+		return layout.Dimensions{}
+	}
+
+	lines := v.viewModel.PendingLines()
+	if len(lines) == 0 {
+		lines = []string{"(no queued actions)"}
+	}
+	return layout.Flex{Axis: layout.Vertical, Alignment: layout.Middle}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return v.layoutLines(gtx, lines)
+		}),
+		layout.Rigid(material.Button(v.theme, &v.flushButton, "Flush").Layout),
+	)
+}
+
+// layoutLatency shows how long the last action took to process
+func (v *View) layoutLatency(gtx layout.Context) layout.Dimensions {
+	text := fmt.Sprintf("last action took %dµs", v.viewModel.LastDispatchLatency().Microseconds())
+	return material.Caption(v.theme, text).Layout(gtx)
+}
+
+// rateWindow is the trailing window of the rate display
+const rateWindow = 5 * time.Second
+
+// layoutRate shows the net change per second, redrawing while it is decaying
+func (v *View) layoutRate(gtx layout.Context) layout.Dimensions {
+	rate := v.viewModel.Rate(rateWindow)
+	if rate != 0 {
+		gtx.Execute(op.InvalidateCmd{At: gtx.Now.Add(time.Second / 4)})
+	}
+	text := fmt.Sprintf("%+.1f/s over %s", rate, rateWindow)
+	return material.Caption(v.theme, text).Layout(gtx)
+}
+
+// layoutAverage shows the running average of the values set by entry
+func (v *View) layoutAverage(gtx layout.Context) layout.Dimensions {
+	text := fmt.Sprintf("average set value %.2f", v.viewModel.Average())
+	return material.Caption(v.theme, text).Layout(gtx)
+}
+
+// layoutPreview explains the effect of the hovered button's action
+func (v *View) layoutPreview(gtx layout.Context) layout.Dimensions {
+	text := " "
+	switch {
+	case v.incrementButton.Hovered():
+		text = v.viewModel.Preview(counterstore.IncrementAction{})
+	case v.decrementButton.Hovered():
+		text = v.viewModel.Preview(counterstore.DecrementAction{})
+	}
+	return material.Caption(v.theme, text).Layout(gtx)
+}
+
+// layoutChanges renders the diff of the last action, one line per field
+func (v *View) layoutChanges(gtx layout.Context) layout.Dimensions {
+	return v.layoutLines(gtx, v.viewModel.ChangeLines())
+}
+
+// layoutTimeline renders the most recent dispatches with their durations
+func (v *View) layoutTimeline(gtx layout.Context) layout.Dimensions {
+	return v.layoutLines(gtx, v.viewModel.TimelineLines(5))
+}
+
+func (v *View) layoutLines(gtx layout.Context, lines []string) layout.Dimensions {
+	children := make([]layout.FlexChild, 0, len(lines))
+	for _, line := range lines {
+		children = append(children, layout.Rigid(material.Caption(v.theme, line).Layout))
+	}
+	return layout.Flex{Axis: layout.Vertical, Alignment: layout.Middle}.Layout(gtx, children...)
+}
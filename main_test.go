@@ -0,0 +1,294 @@
+package main
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"gioui.org/font"
+	"gioui.org/widget/material"
+
+	"gio-redux-example/counterstore"
+)
+
+func newTestViewModel(state counterstore.State) (*ViewModel, *counterstore.Store[counterstore.State, counterstore.AppAction]) {
+	store := counterstore.NewStore(counterstore.Reduce, state)
+	return NewViewModel(store), store
+}
+
+func TestCountLabel(t *testing.T) {
+	th := material.NewTheme()
+	tests := []struct {
+		presentation bool
+		want         material.LabelStyle
+	}{
+		{false, material.Body1(th, "7")},
+		{true, material.H4(th, "7")},
+	}
+	for _, tt := range tests {
+		got := countLabel(th, tt.presentation, "7")
+		if got.TextSize != tt.want.TextSize {
+			t.Errorf("presentation %v: text size %v, want %v", tt.presentation, got.TextSize, tt.want.TextSize)
+		}
+		if got.Font.Weight != font.Bold {
+			t.Errorf("presentation %v: weight %v, want bold", tt.presentation, got.Font.Weight)
+		}
+		if got.Text != "7" {
+			t.Errorf("presentation %v: text %q", tt.presentation, got.Text)
+		}
+	}
+}
+
+func TestViewModelPresentationMode(t *testing.T) {
+	vm, store := newTestViewModel(counterstore.State{})
+	vm.SetPresentationMode(true)
+	if !vm.PresentationMode() || !store.GetState().PresentationMode {
+		t.Fatal("SetPresentationMode(true) did not reach the state")
+	}
+	vm.SetPresentationMode(false)
+	if vm.PresentationMode() {
+		t.Error("SetPresentationMode(false) left it on")
+	}
+}
+
+func TestViewModelLastDispatchLatency(t *testing.T) {
+	clock := counterstore.NewFakeClock(time.Unix(0, 0))
+	profiler := counterstore.NewProfiler(clock)
+	// slow makes the reducer side of every dispatch take 1234µs
+	slow := func(store *counterstore.Store[counterstore.State, counterstore.AppAction], next counterstore.Dispatch[counterstore.AppAction]) counterstore.Dispatch[counterstore.AppAction] {
+		return func(action counterstore.AppAction) error {
+			clock.Advance(1234 * time.Microsecond)
+			return next(action)
+		}
+	}
+	store := counterstore.NewStore(counterstore.Reduce, counterstore.State{},
+		counterstore.ProfilerMiddleware[counterstore.State, counterstore.AppAction](profiler), slow)
+	vm := NewViewModel(store)
+	vm.WatchProfiler(profiler)
+
+	if got := vm.LastDispatchLatency(); got != 0 {
+		t.Errorf("latency before any dispatch = %v", got)
+	}
+	store.Dispatch(counterstore.IncrementAction{})
+	if got := vm.LastDispatchLatency(); got != 1234*time.Microsecond {
+		t.Errorf("LastDispatchLatency = %v, want 1.234ms", got)
+	}
+}
+
+func TestViewModelRate(t *testing.T) {
+	clock := counterstore.NewFakeClock(time.Unix(0, 0))
+	recorder := counterstore.NewRecorder(clock)
+	store := counterstore.NewStore(counterstore.Reduce, counterstore.State{}, counterstore.RecorderMiddleware(recorder))
+	vm := NewViewModel(store)
+	vm.clock = clock
+
+	if got := vm.Rate(5 * time.Second); got != 0 {
+		t.Errorf("Rate before any recorder = %v", got)
+	}
+	vm.recorder = recorder
+	if got := vm.Rate(5 * time.Second); got != 0 {
+		t.Errorf("Rate of an empty history = %v", got)
+	}
+
+	for i := 0; i < 10; i++ {
+		store.Dispatch(counterstore.IncrementAction{})
+		clock.Advance(500 * time.Millisecond)
+	}
+	if got := vm.Rate(5 * time.Second); got != 2 {
+		t.Errorf("Rate over 5s = %v, want 2", got)
+	}
+	if got := vm.Rate(time.Second); got != 2 {
+		t.Errorf("Rate over 1s = %v, want 2", got)
+	}
+}
+
+func TestViewModelBigLabel(t *testing.T) {
+	vm, _ := newTestViewModel(counterstore.State{})
+	if got := vm.BigLabel(); got != "" {
+		t.Errorf("BigLabel with no big store = %q", got)
+	}
+	vm.bigStore = counterstore.NewBigStore()
+	vm.bigStore.Dispatch(counterstore.BigAddAction{Amount: big.NewInt(math.MaxInt64)})
+	vm.bigStore.Dispatch(counterstore.BigIncrementAction{})
+	if got := vm.BigLabel(); got != "9223372036854775808" {
+		t.Errorf("BigLabel = %q", got)
+	}
+}
+
+func TestViewModelPendingLines(t *testing.T) {
+	vm, store := newTestViewModel(counterstore.State{})
+	if got := vm.PendingLines(); len(got) != 0 {
+		t.Errorf("PendingLines while running = %q", got)
+	}
+	vm.SetPaused(true)
+	store.Dispatch(counterstore.IncrementAction{})
+	store.Dispatch(counterstore.AddAmountAction{Amount: 3})
+
+	want := []string{"1. Increment", "2. AddAmount"}
+	if got := vm.PendingLines(); !reflect.DeepEqual(got, want) {
+		t.Errorf("PendingLines = %q, want %q", got, want)
+	}
+	vm.SetPaused(false)
+	if got := vm.PendingLines(); len(got) != 0 || store.GetState().Count != 4 {
+		t.Errorf("after flushing: lines %q, Count %d", got, store.GetState().Count)
+	}
+}
+
+func TestViewModelAverage(t *testing.T) {
+	average := &counterstore.SetAverage{}
+	store := counterstore.NewStore(counterstore.Reduce, counterstore.State{}, counterstore.AverageMiddleware(average))
+	vm := NewViewModel(store)
+	if got := vm.Average(); got != 0 {
+		t.Errorf("Average with nothing attached = %v", got)
+	}
+
+	vm.average = average
+	if got := vm.Average(); got != 0 {
+		t.Errorf("Average before any SetCount = %v, want 0", got)
+	}
+	for _, value := range []int{2, 7, 3} {
+		store.Dispatch(counterstore.SetCountAction{Value: value})
+		store.Dispatch(counterstore.IncrementAction{})
+	}
+	if got := vm.Average(); got != 4 {
+		t.Errorf("Average = %v, want 4", got)
+	}
+}
+
+func TestViewModelSetStackConfig(t *testing.T) {
+	vm, store := newTestViewModel(counterstore.State{Count: 3})
+	forwarded := 0
+	vm.extraMiddleware = []counterstore.Middleware[counterstore.State, counterstore.AppAction]{
+		func(store *counterstore.Store[counterstore.State, counterstore.AppAction], next counterstore.Dispatch[counterstore.AppAction]) counterstore.Dispatch[counterstore.AppAction] {
+			return func(action counterstore.AppAction) error {
+				forwarded++
+				return next(action)
+			}
+		},
+	}
+
+	clock := counterstore.NewFakeClock(time.Unix(0, 0))
+	vm.SetStackConfig(counterstore.StackConfig{Clock: clock, RateLimit: 1, RateBurst: 1})
+	if got := vm.StackConfig(); got.RateLimit != 1 {
+		t.Errorf("StackConfig = %+v", got)
+	}
+	store.Dispatch(counterstore.IncrementAction{})
+	store.Dispatch(counterstore.IncrementAction{})
+	if got := store.GetState().Count; got != 4 {
+		t.Errorf("Count = %d, want the state kept and the second increment rate limited", got)
+	}
+	if forwarded != 1 {
+		t.Errorf("fixed middleware saw %d actions, want 1", forwarded)
+	}
+}
+
+func TestViewModelNegate(t *testing.T) {
+	vm, store := newTestViewModel(counterstore.State{Count: 7})
+	vm.Negate()
+	if got := store.GetState().Count; got != -7 {
+		t.Errorf("Count after Negate = %d, want -7", got)
+	}
+	vm.Negate()
+	if got := vm.CountLabel(); got != "7" {
+		t.Errorf("label after negating twice = %q, want 7", got)
+	}
+}
+
+func TestViewModelCanStep(t *testing.T) {
+	noNegatives := map[string]bool{counterstore.FlagNoNegatives: true}
+	tests := []struct {
+		name           string
+		state          counterstore.State
+		canInc, canDec bool
+	}{
+		{"zero, policy off", counterstore.State{}, true, true},
+		{"zero, policy on", counterstore.State{Flags: noNegatives}, true, false},
+		{"one, policy on", counterstore.State{Count: 1, Flags: noNegatives}, true, true},
+		{"negative, policy off", counterstore.State{Count: -4}, true, true},
+		{"top in error mode", counterstore.State{Count: math.MaxInt, Overflow: counterstore.OverflowError}, false, true},
+		{"bottom in error mode", counterstore.State{Count: math.MinInt, Overflow: counterstore.OverflowError}, true, false},
+	}
+	for _, tt := range tests {
+		vm, _ := newTestViewModel(tt.state)
+		if got := vm.CanIncrement(); got != tt.canInc {
+			t.Errorf("%s: CanIncrement = %v, want %v", tt.name, got, tt.canInc)
+		}
+		if got := vm.CanDecrement(); got != tt.canDec {
+			t.Errorf("%s: CanDecrement = %v, want %v", tt.name, got, tt.canDec)
+		}
+	}
+
+	// Turning the policy on at zero disables the decrement straight away
+	vm, _ := newTestViewModel(counterstore.State{})
+	vm.SetNoNegatives(true)
+	if vm.CanDecrement() {
+		t.Error("CanDecrement true after enabling no-negatives at zero")
+	}
+}
+
+func TestViewModelResetConfirmation(t *testing.T) {
+	vm, store := newTestViewModel(counterstore.State{Count: 3})
+	vm.confirmReset = true
+	vm.Reset()
+	if !vm.ConfirmPending() || store.GetState().Count != 3 {
+		t.Fatalf("Reset with confirmation on: pending %v, Count %d", vm.ConfirmPending(), store.GetState().Count)
+	}
+	vm.CancelReset()
+	if vm.ConfirmPending() || store.GetState().Count != 3 {
+		t.Fatalf("after CancelReset: pending %v, Count %d", vm.ConfirmPending(), store.GetState().Count)
+	}
+	vm.Reset()
+	vm.ConfirmReset()
+	if vm.ConfirmPending() || store.GetState().Count != 0 {
+		t.Errorf("after ConfirmReset: pending %v, Count %d", vm.ConfirmPending(), store.GetState().Count)
+	}
+
+	vm.confirmReset = false
+	store.Dispatch(counterstore.SetCountAction{Value: 4})
+	vm.Reset()
+	if vm.ConfirmPending() || store.GetState().Count != 0 {
+		t.Errorf("Reset with confirmation off: pending %v, Count %d", vm.ConfirmPending(), store.GetState().Count)
+	}
+}
+
+func TestViewModelCurrentNote(t *testing.T) {
+	vm, store := newTestViewModel(counterstore.State{Count: 4})
+	if got := vm.CurrentNote(); got != "" {
+		t.Errorf("CurrentNote without notes = %q, want empty", got)
+	}
+	vm.SetNote("four")
+	store.Dispatch(counterstore.IncrementAction{})
+	if got := vm.CurrentNote(); got != "" {
+		t.Errorf("CurrentNote at 5 = %q, want empty", got)
+	}
+	store.Dispatch(counterstore.DecrementAction{})
+	if got := vm.CurrentNote(); got != "four" {
+		t.Errorf("CurrentNote back at 4 = %q, want four", got)
+	}
+	vm.SetNote("")
+	if got := vm.CurrentNote(); got != "" || len(store.GetState().Notes) != 0 {
+		t.Errorf("CurrentNote after clearing = %q with notes %v", got, store.GetState().Notes)
+	}
+}
+
+func TestViewModelCategoryTotal(t *testing.T) {
+	vm, _ := newTestViewModel(counterstore.State{})
+	if err := vm.AddCategory("b"); err != nil {
+		t.Fatal(err)
+	}
+	vm.StepCategory("a", 1)
+	vm.StepCategory("a", 1)
+	vm.StepCategory("b", -1)
+	vm.StepCategory("c", 1)
+	if got := vm.Categories(); !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Errorf("Categories = %v, want [a b c]", got)
+	}
+	if got := vm.Category("a"); got != 2 {
+		t.Errorf("Category(a) = %d, want 2", got)
+	}
+	if got := vm.CategoryTotal(); got != 2 {
+		t.Errorf("CategoryTotal = %d, want 2", got)
+	}
+}
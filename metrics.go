@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"gio-redux-example/counterstore"
+)
+
+// MetricsRow is one formatted line of the metrics panel
+type MetricsRow struct {
+	Action  string
+	Count   string
+	Average string
+}
+
+// BuildMetricsRows turns profiler stats into display rows, most dispatched
+// action first and ties broken by name
+func BuildMetricsRows(stats map[string]counterstore.ActionStats) []MetricsRow {
+	keys := make([]string, 0, len(stats))
+	for k := range stats {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		ci, cj := stats[keys[i]].Count, stats[keys[j]].Count
+		if ci != cj {
+			return ci > cj
+		}
+		return keys[i] < keys[j]
+	})
+
+	rows := make([]MetricsRow, 0, len(keys))
+	for _, k := range keys {
+		st := stats[k]
+		rows = append(rows, MetricsRow{
+			Action:  k[strings.LastIndexByte(k, '.')+1:],
+			Count:   fmt.Sprintf("%d", st.Count),
+			Average: fmt.Sprintf("%.1fµs", float64(st.Average().Nanoseconds())/1e3),
+		})
+	}
+	return rows
+}
+
+// MetricsPanel is the View component listing per-action metrics
+type MetricsPanel struct {
+	theme  *material.Theme
+	toggle widget.Clickable
+	list   widget.List
+	open   bool
+}
+
+func NewMetricsPanel(theme *material.Theme) *MetricsPanel {
+	return &MetricsPanel{
+		theme: theme,
+		list:  widget.List{List: layout.List{Axis: layout.Vertical}},
+	}
+}
+
+// Layout draws the toggle button and, when open, the rows below it
+func (p *MetricsPanel) Layout(gtx layout.Context, rows []MetricsRow) layout.Dimensions {
+	if p.toggle.Clicked(gtx) {
+		p.open = !p.open
+	}
+	label := "Show metrics"
+	if p.open {
+		label = "Hide metrics"
+	}
+
+	return layout.Flex{Axis: layout.Vertical, Alignment: layout.Middle}.Layout(gtx,
+		layout.Rigid(material.Button(p.theme, &p.toggle, label).Layout),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if !p.open {
+				return layout.Dimensions{}
+			}
+			gtx.Constraints.Max.Y = gtx.Dp(unit.Dp(120))
+			gtx.Constraints.Max.X = min(gtx.Constraints.Max.X, gtx.Dp(unit.Dp(320)))
+			return material.List(p.theme, &p.list).Layout(gtx, len(rows), func(gtx layout.Context, i int) layout.Dimensions {
+				row := rows[i]
+				return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+					layout.Flexed(2, material.Body2(p.theme, row.Action).Layout),
+					layout.Flexed(1, material.Body2(p.theme, row.Count).Layout),
+					layout.Flexed(1, material.Body2(p.theme, row.Average).Layout),
+				)
+			})
+		}),
+	)
+}
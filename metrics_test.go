@@ -0,0 +1,31 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"gio-redux-example/counterstore"
+)
+
+func TestBuildMetricsRows(t *testing.T) {
+	stats := map[string]counterstore.ActionStats{
+		"counterstore.ResetAction":     {Count: 2, Total: 3 * time.Microsecond},
+		"counterstore.IncrementAction": {Count: 5, Total: 12500 * time.Nanosecond},
+		"counterstore.DecrementAction": {Count: 2, Total: 1 * time.Microsecond},
+	}
+	want := []MetricsRow{
+		{Action: "IncrementAction", Count: "5", Average: "2.5µs"},
+		{Action: "DecrementAction", Count: "2", Average: "0.5µs"},
+		{Action: "ResetAction", Count: "2", Average: "1.5µs"},
+	}
+	if got := BuildMetricsRows(stats); !reflect.DeepEqual(got, want) {
+		t.Errorf("BuildMetricsRows =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestBuildMetricsRowsEmpty(t *testing.T) {
+	if got := BuildMetricsRows(nil); len(got) != 0 {
+		t.Errorf("BuildMetricsRows(nil) = %v, want no rows", got)
+	}
+}
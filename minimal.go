@@ -0,0 +1,82 @@
+package main
+
+import (
+	"math"
+	"sync"
+
+	"gioui.org/app"
+	"gioui.org/layout"
+	"gioui.org/op"
+
+	"gio-redux-example/counterstore"
+)
+
+// Minimal reports whether only the count is shown
+func (v *ViewModel) Minimal() bool {
+	return v.store.GetStateFast().Minimal
+}
+
+// ToggleMinimal switches between the full and the minimal layout
+func (v *ViewModel) ToggleMinimal() {
+	v.store.Dispatch(counterstore.ToggleMinimalAction{})
+}
+
+// viewLayout is the layout the view draws its main content with
+type viewLayout int
+
+const (
+	fullLayout viewLayout = iota
+	minimalLayout
+)
+
+// chooseLayout picks the layout for the app's state: the minimal one in
+// minimal mode, the full one with all controls otherwise
+func chooseLayout(state counterstore.State) viewLayout {
+	if state.Minimal {
+		return minimalLayout
+	}
+	return fullLayout
+}
+
+// ViewLayout returns the layout to draw the current state with
+func (v *ViewModel) ViewLayout() viewLayout {
+	return chooseLayout(v.store.GetStateFast())
+}
+
+// layoutMinimal draws only the count, large and centred, for kiosks and
+// presentations
+func (v *View) layoutMinimal(gtx layout.Context) layout.Dimensions {
+	return layout.Center.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		text, _ := v.shown()
+		if !v.viewModel.DisplaySettled() {
+			text = v.labels.FormatCount(int(math.Round(v.viewModel.DisplayValue())))
+			gtx.Execute(op.InvalidateCmd{})
+		}
+		label := countLabel(v.theme, true, text)
+		label.Color = v.viewModel.LabelColor()
+		return layoutSemanticLabel(gtx, v.viewModel.Words(), label.Layout)
+	})
+}
+
+// bindFullscreen makes the window fullscreen while State.Minimal is set and
+// windowed again once it is cleared
+func bindFullscreen(w *app.Window, store *counterstore.Store[counterstore.State, counterstore.AppAction]) func() {
+	var mu sync.Mutex
+	prev := store.GetState().Minimal
+
+	return store.Subscribe(func() {
+		next := store.GetState().Minimal
+
+		mu.Lock()
+		changed := prev != next
+		prev = next
+		mu.Unlock()
+
+		switch {
+		case changed && next:
+			w.Option(app.Fullscreen.Option())
+		case changed:
+			w.Option(app.Windowed.Option())
+		}
+	})
+}
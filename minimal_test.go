@@ -0,0 +1,44 @@
+package main
+
+import (
+	"testing"
+
+	"gio-redux-example/counterstore"
+)
+
+func TestChooseLayout(t *testing.T) {
+	tests := []struct {
+		name  string
+		state counterstore.State
+		want  viewLayout
+	}{
+		{"default", counterstore.State{}, fullLayout},
+		{"minimal", counterstore.State{Minimal: true}, minimalLayout},
+		{"presentation mode alone", counterstore.State{PresentationMode: true}, fullLayout},
+		{"minimal in presentation mode", counterstore.State{Minimal: true, PresentationMode: true}, minimalLayout},
+	}
+	for _, tt := range tests {
+		if got := chooseLayout(tt.state); got != tt.want {
+			t.Errorf("%s: chooseLayout = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestViewModelToggleMinimal(t *testing.T) {
+	vm, store := newTestViewModel(counterstore.State{Count: 3})
+	vm.ToggleMinimal()
+	if !vm.Minimal() || vm.ViewLayout() != minimalLayout {
+		t.Fatal("ToggleMinimal did not switch to the minimal layout")
+	}
+	// Entering minimal mode is not an undo step
+	if store.CanUndo() {
+		t.Error("ToggleMinimal added an undo step")
+	}
+	vm.ToggleMinimal()
+	if vm.Minimal() || vm.ViewLayout() != fullLayout {
+		t.Error("a second ToggleMinimal did not restore the full layout")
+	}
+	if got := store.GetState().Count; got != 3 {
+		t.Errorf("Count = %d, want 3 untouched", got)
+	}
+}
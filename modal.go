@@ -0,0 +1,38 @@
+package main
+
+import (
+	"image/color"
+
+	"gioui.org/io/event"
+	"gioui.org/layout"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+)
+
+// layoutModal dims everything drawn so far, swallows pointer input aimed at
+// it on behalf of tag, and draws content in a bordered panel in the centre
+func layoutModal(gtx layout.Context, th *material.Theme, tag event.Tag, content layout.Widget) layout.Dimensions {
+	size := gtx.Constraints.Max
+	area := clip.Rect{Max: size}.Push(gtx.Ops)
+	event.Op(gtx.Ops, tag)
+	paint.Fill(gtx.Ops, color.NRGBA{A: 0x80})
+	area.Pop()
+
+	layout.Center.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return widget.Border{Color: th.Fg, Width: unit.Dp(1)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+			return layout.Stack{}.Layout(gtx,
+				layout.Expanded(func(gtx layout.Context) layout.Dimensions {
+					paint.FillShape(gtx.Ops, th.Bg, clip.Rect{Max: gtx.Constraints.Min}.Op())
+					return layout.Dimensions{Size: gtx.Constraints.Min}
+				}),
+				layout.Stacked(func(gtx layout.Context) layout.Dimensions {
+					return layout.UniformInset(unit.Dp(12)).Layout(gtx, content)
+				}),
+			)
+		})
+	})
+	return layout.Dimensions{Size: size}
+}
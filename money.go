@@ -0,0 +1,51 @@
+package main
+
+import (
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"gio-redux-example/counterstore"
+)
+
+// Money formats the count as an amount in cents, such as $12.34
+func (v *ViewModel) Money() string {
+	return counterstore.FormatCents(v.store.GetStateFast().Count)
+}
+
+// AddCents adds cents to the count read as money
+func (v *ViewModel) AddCents(cents int) {
+	v.dispatchWithFeedback(counterstore.AddCentsAction{Cents: cents})
+}
+
+// MoneyPanel shows the count as money with buttons adding a cent or a
+// dollar and taking a dollar off
+type MoneyPanel struct {
+	theme       *material.Theme
+	centButton  widget.Clickable
+	plusButton  widget.Clickable
+	minusButton widget.Clickable
+}
+
+func (p *MoneyPanel) Layout(gtx layout.Context, vm *ViewModel) layout.Dimensions {
+	if p.centButton.Clicked(gtx) {
+		vm.AddCents(1)
+	}
+	if p.plusButton.Clicked(gtx) {
+		vm.AddCents(100)
+	}
+	if p.minusButton.Clicked(gtx) {
+		vm.AddCents(-100)
+	}
+
+	return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+		layout.Rigid(material.Body1(p.theme, vm.Money()).Layout),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(6)}.Layout),
+		layout.Rigid(material.Button(p.theme, &p.centButton, "+1¢").Layout),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(6)}.Layout),
+		layout.Rigid(material.Button(p.theme, &p.plusButton, "+$1").Layout),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(6)}.Layout),
+		layout.Rigid(material.Button(p.theme, &p.minusButton, "−$1").Layout),
+	)
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"testing"
+
+	"gio-redux-example/counterstore"
+)
+
+func TestViewModelMoney(t *testing.T) {
+	vm, store := newTestViewModel(counterstore.State{Count: -30})
+	if got := vm.Money(); got != "-$0.30" {
+		t.Errorf("Money = %q, want -$0.30", got)
+	}
+	vm.AddCents(100)
+	vm.AddCents(1)
+	if got := vm.Money(); got != "$0.71" {
+		t.Errorf("Money = %q, want $0.71", got)
+	}
+	vm.AddCents(-100)
+	if got, want := vm.Money(), "-$0.29"; got != want || store.GetState().Count != -29 {
+		t.Errorf("Money = %q at Count %d, want %s", got, store.GetState().Count, want)
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+
+	"gioui.org/layout"
+	"gioui.org/widget/material"
+)
+
+// toNextTen returns how many increments take count to the next multiple of
+// ten above it, or 0 when count is already one. Negative counts count up
+// towards zero the same way, so -3 is 3 from 0 and -13 is 3 from -10.
+func toNextTen(count int) int {
+	r := count % 10
+	if r > 0 {
+		return 10 - r
+	}
+	return -r
+}
+
+// ToNextTen returns how many increments reach the next multiple of ten
+func (v *ViewModel) ToNextTen() int {
+	return toNextTen(v.store.GetStateFast().Count)
+}
+
+// layoutNextTen hints how far the next multiple of ten is, and nothing when
+// the count is on one
+func (v *View) layoutNextTen(gtx layout.Context) layout.Dimensions {
+	n := v.viewModel.ToNextTen()
+	if n == 0 {
+		return layout.Dimensions{}
+	}
+	return material.Caption(v.theme, fmt.Sprintf("%d to next 10", n)).Layout(gtx)
+}
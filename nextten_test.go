@@ -0,0 +1,42 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"gio-redux-example/counterstore"
+)
+
+func TestToNextTen(t *testing.T) {
+	tests := []struct{ count, want int }{
+		{0, 0},
+		{1, 9},
+		{7, 3},
+		{10, 0},
+		{19, 1},
+		{-3, 3},
+		{-10, 0},
+		{-13, 3},
+		{-19, 9},
+		{math.MaxInt, 3},
+		{math.MinInt, 8},
+	}
+	for _, tt := range tests {
+		if got := toNextTen(tt.count); got != tt.want {
+			t.Errorf("toNextTen(%d) = %d, want %d", tt.count, got, tt.want)
+		}
+	}
+}
+
+func TestViewModelToNextTen(t *testing.T) {
+	vm, store := newTestViewModel(counterstore.State{Count: 27})
+	if got := vm.ToNextTen(); got != 3 {
+		t.Errorf("ToNextTen at 27 = %d, want 3", got)
+	}
+	for i := 0; i < 3; i++ {
+		store.Dispatch(counterstore.IncrementAction{})
+	}
+	if got := vm.ToNextTen(); got != 0 {
+		t.Errorf("ToNextTen at 30 = %d, want 0", got)
+	}
+}
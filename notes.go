@@ -0,0 +1,66 @@
+package main
+
+import (
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"gio-redux-example/counterstore"
+)
+
+// CurrentNote returns the note attached to the current count, or "" when it
+// has none
+func (v *ViewModel) CurrentNote() string {
+	state := v.store.GetStateFast()
+	return state.Notes[state.Count]
+}
+
+// SetNote attaches text to the current count; empty text removes its note
+func (v *ViewModel) SetNote(text string) {
+	count := v.store.GetStateFast().Count
+	v.store.Dispatch(counterstore.SetNoteAction{Count: count, Text: text})
+}
+
+// NotePanel shows the current count's note with an editor to change it;
+// submitting an empty note removes it
+type NotePanel struct {
+	theme      *material.Theme
+	editor     widget.Editor
+	saveButton widget.Clickable
+}
+
+func NewNotePanel(theme *material.Theme) *NotePanel {
+	return &NotePanel{theme: theme, editor: widget.Editor{SingleLine: true, Submit: true}}
+}
+
+func (p *NotePanel) Layout(gtx layout.Context, vm *ViewModel) layout.Dimensions {
+	save := p.saveButton.Clicked(gtx)
+	for {
+		ev, ok := p.editor.Update(gtx)
+		if !ok {
+			break
+		}
+		if _, ok := ev.(widget.SubmitEvent); ok {
+			save = true
+		}
+	}
+	if save {
+		vm.SetNote(strings.TrimSpace(p.editor.Text()))
+		p.editor.SetText("")
+	}
+
+	return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			gtx.Constraints.Min.X = gtx.Dp(unit.Dp(120))
+			gtx.Constraints.Max.X = gtx.Constraints.Min.X
+			return material.Editor(p.theme, &p.editor, "Note").Layout(gtx)
+		}),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(6)}.Layout),
+		layout.Rigid(material.Button(p.theme, &p.saveButton, "Set note").Layout),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(12)}.Layout),
+		layout.Rigid(material.Body2(p.theme, vm.CurrentNote()).Layout),
+	)
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"image/color"
+
+	"gio-redux-example/counterstore"
+)
+
+// palette is the set of colours that carry meaning in the UI
+type palette struct {
+	// Cold and Hot are the ends of the count's colour scale
+	Cold, Hot color.NRGBA
+	// Error paints validation and paste errors
+	Error color.NRGBA
+}
+
+// palettes maps each counterstore.Palette to its colours. The colour-blind
+// one uses the Okabe-Ito blue, orange and vermilion, which stay apart under
+// the common forms of colour blindness.
+var palettes = map[counterstore.Palette]palette{
+	counterstore.PaletteDefault: {
+		Cold:  color.NRGBA{R: 0x21, G: 0x65, B: 0xd8, A: 0xff},
+		Hot:   color.NRGBA{R: 0xd3, G: 0x2f, B: 0x2f, A: 0xff},
+		Error: color.NRGBA{R: 0xc0, A: 0xff},
+	},
+	counterstore.PaletteColorBlind: {
+		Cold:  color.NRGBA{R: 0x00, G: 0x72, B: 0xb2, A: 0xff},
+		Hot:   color.NRGBA{R: 0xe6, G: 0x9f, B: 0x00, A: 0xff},
+		Error: color.NRGBA{R: 0xd5, G: 0x5e, B: 0x00, A: 0xff},
+	},
+}
+
+// paletteFor returns the colours of p, falling back to the default palette
+// for unknown values
+func paletteFor(p counterstore.Palette) palette {
+	if colors, ok := palettes[p]; ok {
+		return colors
+	}
+	return palettes[counterstore.PaletteDefault]
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"testing"
+
+	"gio-redux-example/counterstore"
+)
+
+func TestPaletteFor(t *testing.T) {
+	if got := paletteFor(counterstore.PaletteColorBlind); got != palettes[counterstore.PaletteColorBlind] {
+		t.Errorf("paletteFor(color-blind) = %+v", got)
+	}
+	if got := paletteFor(counterstore.Palette(99)); got != palettes[counterstore.PaletteDefault] {
+		t.Errorf("paletteFor(unknown) = %+v, want the default palette", got)
+	}
+	// The colour-blind palette must actually differ wherever colour means something
+	def, cb := palettes[counterstore.PaletteDefault], palettes[counterstore.PaletteColorBlind]
+	if def.Cold == cb.Cold || def.Hot == cb.Hot || def.Error == cb.Error {
+		t.Errorf("palettes share colours: %+v and %+v", def, cb)
+	}
+}
+
+func TestViewModelPalette(t *testing.T) {
+	vm, store := newTestViewModel(counterstore.State{Count: 100})
+	def, cb := palettes[counterstore.PaletteDefault], palettes[counterstore.PaletteColorBlind]
+
+	if vm.ColorBlind() || vm.ErrorColor() != def.Error || vm.LabelColor() != def.Hot || vm.DwellPalette() != def {
+		t.Fatalf("default palette not in use: error %v, label %v", vm.ErrorColor(), vm.LabelColor())
+	}
+
+	vm.SetColorBlind(true)
+	if !vm.ColorBlind() || store.GetState().Palette != counterstore.PaletteColorBlind {
+		t.Fatal("SetColorBlind(true) did not switch the palette")
+	}
+	if vm.ErrorColor() != cb.Error || vm.LabelColor() != cb.Hot || vm.DwellPalette() != cb {
+		t.Errorf("colour-blind palette not in use: error %v, label %v", vm.ErrorColor(), vm.LabelColor())
+	}
+	if store.CanUndo() {
+		t.Error("switching palette became an undo step")
+	}
+
+	vm.SetColorBlind(false)
+	if vm.ColorBlind() || vm.ErrorColor() != def.Error {
+		t.Error("SetColorBlind(false) did not switch back")
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"log"
+	"sync"
+
+	"gioui.org/app"
+
+	"gio-redux-example/counterstore"
+)
+
+// alwaysOnTopOption builds the window option that pins the window above
+// others. Gio v0.9 exposes no such option on any platform, so it is nil and
+// pinning degrades to a logged warning; set it where a platform gains support.
+var alwaysOnTopOption func(enabled bool) app.Option
+
+// pinDecision is what to do with the window after a state change
+type pinDecision int
+
+const (
+	pinNone pinDecision = iota
+	pinApply
+	pinUnsupported
+)
+
+// decidePin maps an AlwaysOnTop transition to a window action: nothing if
+// the flag did not change, otherwise apply it or warn when unsupported
+func decidePin(prev, next, supported bool) pinDecision {
+	switch {
+	case prev == next:
+		return pinNone
+	case !supported:
+		return pinUnsupported
+	}
+	return pinApply
+}
+
+// bindAlwaysOnTop keeps the window's pinned state in line with State.AlwaysOnTop
+func bindAlwaysOnTop(w *app.Window, store *counterstore.Store[counterstore.State, counterstore.AppAction]) func() {
+	var mu sync.Mutex
+	prev := store.GetState().AlwaysOnTop
+
+	return store.Subscribe(func() {
+		next := store.GetState().AlwaysOnTop
+
+		mu.Lock()
+		decision := decidePin(prev, next, alwaysOnTopOption != nil)
+		prev = next
+		mu.Unlock()
+
+		switch decision {
+		case pinApply:
+			w.Option(alwaysOnTopOption(next))
+		case pinUnsupported:
+			log.Printf("always-on-top is not supported on this platform; ignoring AlwaysOnTop=%v", next)
+		}
+	})
+}
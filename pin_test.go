@@ -0,0 +1,22 @@
+package main
+
+import "testing"
+
+func TestDecidePin(t *testing.T) {
+	tests := []struct {
+		prev, next, supported bool
+		want                  pinDecision
+	}{
+		{false, false, true, pinNone},
+		{true, true, false, pinNone},
+		{false, true, true, pinApply},
+		{true, false, true, pinApply},
+		{false, true, false, pinUnsupported},
+		{true, false, false, pinUnsupported},
+	}
+	for _, tt := range tests {
+		if got := decidePin(tt.prev, tt.next, tt.supported); got != tt.want {
+			t.Errorf("decidePin(%v, %v, %v) = %d, want %d", tt.prev, tt.next, tt.supported, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"gio-redux-example/counterstore"
+)
+
+// PlaybackPanel scrubs and replays the recorded session: the slider jumps
+// to any recorded step and Play steps through them at the recorded pace.
+// Stop leaves playback and returns to the session's latest state.
+type PlaybackPanel struct {
+	theme      *material.Theme
+	playback   *counterstore.Playback
+	slider     widget.Float
+	playButton widget.Clickable
+	stopButton widget.Clickable
+}
+
+func (p *PlaybackPanel) Layout(gtx layout.Context, vm *ViewModel) layout.Dimensions {
+	now := gtx.Now
+
+	if p.playButton.Clicked(gtx) {
+		if p.playback != nil && p.playback.Mode() == counterstore.PlaybackPlaying {
+			p.playback.Pause()
+		} else {
+			if p.playback == nil || p.playback.Mode() == counterstore.PlaybackStopped {
+				p.playback = vm.NewPlayback()
+			}
+			p.playback.Play(now)
+			vm.JumpTo(p.playback.StateAt(p.playback.Position()))
+		}
+	}
+	if p.stopButton.Clicked(gtx) && p.playback != nil {
+		vm.JumpTo(p.playback.StateAt(p.playback.Len()))
+		p.playback = nil
+	}
+
+	p.slider.Update(gtx)
+	switch {
+	case p.slider.Dragging():
+		if p.playback == nil {
+			p.playback = vm.NewPlayback()
+		}
+		pos := int(math.Round(float64(p.slider.Value) * float64(p.playback.Len())))
+		if p.playback.Mode() != counterstore.PlaybackScrubbing || pos != p.playback.Position() {
+			p.playback.Scrub(pos)
+			vm.JumpTo(p.playback.StateAt(pos))
+		}
+	case p.playback != nil && p.playback.Mode() == counterstore.PlaybackScrubbing:
+		p.playback.EndScrub(now)
+	}
+
+	if p.playback != nil {
+		if p.playback.Advance(now) {
+			vm.JumpTo(p.playback.StateAt(p.playback.Position()))
+		}
+		if due, ok := p.playback.NextDue(); ok {
+			gtx.Execute(op.InvalidateCmd{At: due})
+		}
+		if !p.slider.Dragging() && p.playback.Len() > 0 {
+			p.slider.Value = float32(p.playback.Position()) / float32(p.playback.Len())
+		}
+	}
+
+	playLabel := "Play"
+	status := "live"
+	if p.playback != nil {
+		if p.playback.Mode() == counterstore.PlaybackPlaying {
+			playLabel = "Pause"
+		}
+		status = fmt.Sprintf("%s %d/%d", p.playback.Mode(), p.playback.Position(), p.playback.Len())
+	}
+
+	return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+		layout.Rigid(material.Button(p.theme, &p.playButton, playLabel).Layout),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(8)}.Layout),
+		layout.Rigid(material.Button(p.theme, &p.stopButton, "Stop").Layout),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(8)}.Layout),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			gtx.Constraints.Min.X = gtx.Dp(unit.Dp(160))
+			gtx.Constraints.Max.X = gtx.Constraints.Min.X
+			return material.Slider(p.theme, &p.slider).Layout(gtx)
+		}),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(8)}.Layout),
+		layout.Rigid(material.Caption(p.theme, status).Layout),
+	)
+}
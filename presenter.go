@@ -0,0 +1,75 @@
+package main
+
+import (
+	"strconv"
+	"sync"
+)
+
+// Presenter is what the ViewModel pushes its output to. The Gio View is one;
+// another front end, such as a terminal UI, can drive the same ViewModel by
+// implementing it.
+type Presenter interface {
+	// ShowCount displays the formatted count
+	ShowCount(text string)
+	// ShowStatus displays the status message, or clears it when text is empty
+	ShowStatus(text string)
+}
+
+// Present pushes the count, formatted with format, and the status message
+// to p right away and after every state change that alters them. A nil
+// format prints the count in plain decimal. Calls run on the dispatching
+// goroutine. It returns the function that stops presenting.
+func (v *ViewModel) Present(p Presenter, format func(int) string) func() {
+	if format == nil {
+		format = strconv.Itoa
+	}
+	var mu sync.Mutex
+	lastCount, lastStatus := "", ""
+	first := true
+
+	push := func() {
+		state := v.store.GetStateFast()
+		count := format(state.Count)
+
+		mu.Lock()
+		countChanged := first || count != lastCount
+		statusChanged := first || state.Status != lastStatus
+		lastCount, lastStatus, first = count, state.Status, false
+		mu.Unlock()
+
+		if countChanged {
+			p.ShowCount(count)
+		}
+		if statusChanged {
+			p.ShowStatus(state.Status)
+		}
+	}
+	push()
+	return v.store.Subscribe(push)
+}
+
+// presented is the part of the View the ViewModel pushes to
+type presented struct {
+	mu     sync.Mutex
+	count  string
+	status string
+}
+
+func (v *View) ShowCount(text string) {
+	v.presented.mu.Lock()
+	v.presented.count = text
+	v.presented.mu.Unlock()
+}
+
+func (v *View) ShowStatus(text string) {
+	v.presented.mu.Lock()
+	v.presented.status = text
+	v.presented.mu.Unlock()
+}
+
+// shown returns the count and status last presented to the View
+func (v *View) shown() (count, status string) {
+	v.presented.mu.Lock()
+	defer v.presented.mu.Unlock()
+	return v.presented.count, v.presented.status
+}
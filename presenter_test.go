@@ -0,0 +1,62 @@
+package main
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"gio-redux-example/counterstore"
+)
+
+// fakePresenter records everything pushed to it
+type fakePresenter struct {
+	counts   []string
+	statuses []string
+}
+
+func (p *fakePresenter) ShowCount(text string)  { p.counts = append(p.counts, text) }
+func (p *fakePresenter) ShowStatus(text string) { p.statuses = append(p.statuses, text) }
+
+func TestPresentPushesFormattedCount(t *testing.T) {
+	vm, store := newTestViewModel(counterstore.State{Count: 7})
+	p := &fakePresenter{}
+	stop := vm.Present(p, func(n int) string { return "#" + strconv.Itoa(n) })
+
+	store.Dispatch(counterstore.IncrementAction{})
+	// A change that leaves the count alone pushes no count
+	store.Dispatch(counterstore.SetTargetAction{Target: 20})
+	store.Dispatch(counterstore.AddAmountAction{Amount: 2})
+
+	if want := []string{"#7", "#8", "#10"}; !reflect.DeepEqual(p.counts, want) {
+		t.Errorf("counts pushed = %v, want %v", p.counts, want)
+	}
+	if len(p.statuses) == 0 {
+		t.Fatal("no status pushed when presenting started")
+	}
+
+	stop()
+	store.Dispatch(counterstore.IncrementAction{})
+	if got := p.counts[len(p.counts)-1]; got != "#10" {
+		t.Errorf("count %q pushed after stopping", got)
+	}
+}
+
+func TestPresentDefaultFormat(t *testing.T) {
+	vm, store := newTestViewModel(counterstore.State{Count: -3})
+	p := &fakePresenter{}
+	vm.Present(p, nil)
+	store.Dispatch(counterstore.DecrementAction{})
+	if want := []string{"-3", "-4"}; !reflect.DeepEqual(p.counts, want) {
+		t.Errorf("counts pushed = %v, want %v", p.counts, want)
+	}
+}
+
+func TestViewShowsPresented(t *testing.T) {
+	var v View
+	var _ Presenter = &v
+	v.ShowCount("12")
+	v.ShowStatus("above target")
+	if count, status := v.shown(); count != "12" || status != "above target" {
+		t.Errorf("shown = %q, %q; want 12 and above target", count, status)
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"gio-redux-example/counterstore"
+)
+
+// PresetName is the name of the current preset
+func (v *ViewModel) PresetName() string {
+	return v.store.GetStateFast().CurrentPreset().Name
+}
+
+// NextPreset switches to the next preset and applies its settings
+func (v *ViewModel) NextPreset() {
+	counterstore.CyclePreset(v.store)
+}
+
+// stepAction is the action for one step up (sign 1) or down (sign -1) at
+// the current step size
+func (v *ViewModel) stepAction(sign int) counterstore.AppAction {
+	step := v.store.GetStateFast().Step()
+	switch {
+	case step == 1 && sign > 0:
+		return counterstore.IncrementAction{}
+	case step == 1:
+		return counterstore.DecrementAction{}
+	}
+	return counterstore.AddAmountAction{Amount: sign * step}
+}
+
+// canStep reports whether the step stepAction(sign) returns would be
+// accepted
+func (v *ViewModel) canStep(sign int) bool {
+	validator, ok := v.stepAction(sign).(counterstore.Validator[counterstore.State])
+	return !ok || validator.Validate(v.store.GetStateFast()) == nil
+}
+
+// PresetPanel shows the current preset with a button moving to the next
+type PresetPanel struct {
+	theme      *material.Theme
+	nextButton widget.Clickable
+}
+
+func (p *PresetPanel) Layout(gtx layout.Context, vm *ViewModel) layout.Dimensions {
+	if p.nextButton.Clicked(gtx) {
+		vm.NextPreset()
+	}
+
+	return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+		layout.Rigid(material.Body1(p.theme, fmt.Sprintf("Preset: %s", vm.PresetName())).Layout),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(6)}.Layout),
+		layout.Rigid(material.Button(p.theme, &p.nextButton, "Next preset").Layout),
+	)
+}
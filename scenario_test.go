@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"gio-redux-example/counterstore"
+)
+
+// ScenarioStep is one line of an acceptance scenario: dispatch Action, then
+// expect the count label to read WantLabel
+type ScenarioStep struct {
+	Action    counterstore.AppAction
+	WantLabel string
+}
+
+// CounterScenario is a sample scenario covering the built-in counter actions
+var CounterScenario = []ScenarioStep{
+	{Action: counterstore.IncrementAction{}, WantLabel: "1"},
+	{Action: counterstore.IncrementAction{}, WantLabel: "2"},
+	{Action: counterstore.DecrementAction{}, WantLabel: "1"},
+	{Action: counterstore.SetCountAction{Value: 42}, WantLabel: "42"},
+	{Action: counterstore.DecrementAction{}, WantLabel: "41"},
+}
+
+// RunScenario plays steps against a fresh store and ViewModel, checking the
+// label after every step. The first mismatch fails t with the step number,
+// the action and the label before and after it.
+func RunScenario(t testing.TB, steps []ScenarioStep) {
+	t.Helper()
+
+	store := counterstore.NewStore(counterstore.Reduce, counterstore.State{})
+	viewModel := NewViewModel(store)
+
+	for i, step := range steps {
+		before := viewModel.CountLabel()
+		if err := store.Dispatch(step.Action); err != nil {
+			t.Fatalf("step %d (%s): %v", i+1, counterstore.ActionName(step.Action), err)
+		}
+		if got := viewModel.CountLabel(); got != step.WantLabel {
+			t.Fatalf("step %d (%s): label went %q → %q, want %q",
+				i+1, counterstore.ActionName(step.Action), before, got, step.WantLabel)
+		}
+	}
+}
+
+func TestCounterScenario(t *testing.T) {
+	RunScenario(t, CounterScenario)
+}
+
+// recordingTB is a testing.TB catching the failure RunScenario reports
+type recordingTB struct {
+	testing.TB
+	failure string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Fatalf(format string, args ...any) {
+	r.failure = fmt.Sprintf(format, args...)
+	runtime.Goexit()
+}
+
+func TestRunScenarioReportsFailingStep(t *testing.T) {
+	tb := &recordingTB{TB: t}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		RunScenario(tb, []ScenarioStep{
+			{Action: counterstore.IncrementAction{}, WantLabel: "1"},
+			{Action: counterstore.IncrementAction{}, WantLabel: "3"},
+		})
+	}()
+	<-done
+
+	want := `step 2 (Increment): label went "1" → "2", want "3"`
+	if tb.failure != want {
+		t.Errorf("failure = %q, want %q", tb.failure, want)
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"gio-redux-example/counterstore"
+)
+
+// playerNames labels the players of the two-player score
+var playerNames = [...]string{counterstore.PlayerA: "A", counterstore.PlayerB: "B"}
+
+// Scores returns both players' scores and the player on turn
+func (v *ViewModel) Scores() (a, b, turn int) {
+	state := v.store.GetStateFast()
+	return state.ScoreA, state.ScoreB, state.Turn
+}
+
+// Score adds delta to player's score; it is rejected off turn
+func (v *ViewModel) Score(player, delta int) {
+	v.store.Dispatch(counterstore.ScoreAction{Player: player, Delta: delta})
+}
+
+// CanScore reports whether player is on turn and so may score
+func (v *ViewModel) CanScore(player int) bool {
+	return counterstore.ScoreAction{Player: player}.Validate(v.store.GetStateFast()) == nil
+}
+
+// PassTurn hands the turn to the other player
+func (v *ViewModel) PassTurn() {
+	v.store.Dispatch(counterstore.PassTurnAction{})
+}
+
+// Scoreboard shows the two-player score with a +1 button per player, only
+// the one on turn enabled, and a button passing the turn
+type Scoreboard struct {
+	theme      *material.Theme
+	scoreA     widget.Clickable
+	scoreB     widget.Clickable
+	passButton widget.Clickable
+}
+
+func (b *Scoreboard) Layout(gtx layout.Context, vm *ViewModel) layout.Dimensions {
+	if b.passButton.Clicked(gtx) {
+		vm.PassTurn()
+	}
+	scoreA, scoreB, turn := vm.Scores()
+
+	player := func(p, score int, button *widget.Clickable) layout.FlexChild {
+		return layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if !vm.CanScore(p) {
+				gtx = gtx.Disabled()
+			}
+			if button.Clicked(gtx) {
+				vm.Score(p, 1)
+			}
+			label := material.Body1(b.theme, fmt.Sprintf("%s: %d", playerNames[p], score))
+			if p == turn {
+				label.Font.Weight = font.Bold
+			}
+			return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+				layout.Rigid(label.Layout),
+				layout.Rigid(layout.Spacer{Width: unit.Dp(6)}.Layout),
+				layout.Rigid(material.Button(b.theme, button, "+1").Layout),
+			)
+		})
+	}
+
+	return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+		player(counterstore.PlayerA, scoreA, &b.scoreA),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(12)}.Layout),
+		player(counterstore.PlayerB, scoreB, &b.scoreB),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(12)}.Layout),
+		layout.Rigid(material.Caption(b.theme, fmt.Sprintf("%s to play", playerNames[turn])).Layout),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(6)}.Layout),
+		layout.Rigid(material.Button(b.theme, &b.passButton, "Pass").Layout),
+	)
+}
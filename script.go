@@ -0,0 +1,27 @@
+package main
+
+import (
+	"io"
+	"log"
+	"os"
+
+	"gio-redux-example/counterstore"
+)
+
+// pipeScript feeds the action commands in path, or standard input for "-",
+// into store, logging and skipping bad lines
+func pipeScript(store *counterstore.Store[counterstore.State, counterstore.AppAction], path string) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			log.Printf("script: %v", err)
+			return
+		}
+		defer f.Close()
+		r = f
+	}
+	if err := counterstore.PipeActionsWithPolicy(store, r, counterstore.PipeSkipErrors, nil); err != nil {
+		log.Printf("script %s: %v", path, err)
+	}
+}
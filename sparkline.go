@@ -0,0 +1,44 @@
+package main
+
+import (
+	"image"
+	"image/color"
+
+	"gioui.org/f32"
+	"gioui.org/layout"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+)
+
+// layoutSparkline strokes values as a line scaled to fill a small box
+func layoutSparkline(gtx layout.Context, values []int, col color.NRGBA) layout.Dimensions {
+	size := image.Pt(gtx.Dp(unit.Dp(160)), gtx.Dp(unit.Dp(32)))
+	if len(values) < 2 {
+		return layout.Dimensions{Size: size}
+	}
+
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+	span := float32(hi - lo)
+	if span == 0 {
+		span = 1
+	}
+
+	w, h := float32(size.X-1), float32(size.Y-1)
+	var path clip.Path
+	path.Begin(gtx.Ops)
+	for i, v := range values {
+		pt := f32.Pt(float32(i)*w/float32(len(values)-1), h-float32(v-lo)*h/span)
+		if i == 0 {
+			path.MoveTo(pt)
+		} else {
+			path.LineTo(pt)
+		}
+	}
+	stroke := clip.Stroke{Path: path.End(), Width: float32(gtx.Dp(unit.Dp(1.5)))}
+	paint.FillShape(gtx.Ops, col, stroke.Op())
+	return layout.Dimensions{Size: size}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"image/color"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/widget/material"
+)
+
+const (
+	// statusHold is how long a status message stays fully visible
+	statusHold = 3 * time.Second
+	// statusFade is how long it then takes to fade out
+	statusFade = time.Second
+)
+
+// statusAlpha is the opacity of a status message shown at since, as of now:
+// opaque for statusHold, then fading linearly to transparent over statusFade
+func statusAlpha(since, now time.Time) float64 {
+	age := now.Sub(since)
+	switch {
+	case age < statusHold:
+		return 1
+	case age >= statusHold+statusFade:
+		return 0
+	}
+	return 1 - float64(age-statusHold)/float64(statusFade)
+}
+
+// WatchStatus notes when State.Status last changed, so the View can fade the
+// message out from then
+func (v *ViewModel) WatchStatus() func() {
+	v.mu.Lock()
+	v.status = v.store.GetState().Status
+	v.statusSince = v.clock.Now()
+	v.mu.Unlock()
+
+	return v.store.Subscribe(func() {
+		status := v.store.GetStateFast().Status
+		v.mu.Lock()
+		if status != v.status {
+			v.status = status
+			v.statusSince = v.clock.Now()
+		}
+		v.mu.Unlock()
+	})
+}
+
+// Status returns the status message and its current opacity; the text is
+// empty once it has faded out or been cleared
+func (v *ViewModel) Status() (string, float64) {
+	v.mu.Lock()
+	status, since := v.status, v.statusSince
+	v.mu.Unlock()
+
+	alpha := statusAlpha(since, v.clock.Now())
+	if status == "" || alpha == 0 {
+		return "", 0
+	}
+	return status, alpha
+}
+
+// layoutStatus draws the status message below the count, redrawing each
+// frame while it fades
+func (v *View) layoutStatus(gtx layout.Context) layout.Dimensions {
+	_, text := v.shown()
+	_, alpha := v.viewModel.Status()
+	if text == "" || alpha == 0 {
+		return layout.Dimensions{}
+	}
+	gtx.Execute(op.InvalidateCmd{})
+
+	label := material.Caption(v.theme, text)
+	label.Color = fadeColor(label.Color, alpha)
+	return label.Layout(gtx)
+}
+
+// fadeColor scales c's alpha by alpha
+func fadeColor(c color.NRGBA, alpha float64) color.NRGBA {
+	c.A = uint8(float64(c.A) * alpha)
+	return c
+}
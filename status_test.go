@@ -0,0 +1,59 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"gio-redux-example/counterstore"
+)
+
+func TestStatusAlpha(t *testing.T) {
+	since := time.Unix(100, 0)
+	tests := []struct {
+		age  time.Duration
+		want float64
+	}{
+		{0, 1},
+		{statusHold - time.Millisecond, 1},
+		{statusHold, 1},
+		{statusHold + statusFade/4, 0.75},
+		{statusHold + statusFade/2, 0.5},
+		{statusHold + statusFade, 0},
+		{time.Hour, 0},
+	}
+	for _, tt := range tests {
+		if got := statusAlpha(since, since.Add(tt.age)); got != tt.want {
+			t.Errorf("statusAlpha at %v = %v, want %v", tt.age, got, tt.want)
+		}
+	}
+}
+
+func TestViewModelStatusFades(t *testing.T) {
+	vm, store := newTestViewModel(counterstore.State{Count: 5})
+	clock := counterstore.NewFakeClock(time.Unix(0, 0))
+	vm.clock = clock
+	defer vm.WatchStatus()()
+
+	if text, _ := vm.Status(); text != "" {
+		t.Fatalf("Status before any message = %q", text)
+	}
+	store.Dispatch(counterstore.ResetAction{})
+	if text, alpha := vm.Status(); text != counterstore.StatusReset || alpha != 1 {
+		t.Fatalf("Status after reset = %q, %v", text, alpha)
+	}
+	clock.Advance(statusHold + statusFade/2)
+	if text, alpha := vm.Status(); text != counterstore.StatusReset || alpha != 0.5 {
+		t.Errorf("Status mid-fade = %q, %v; want half faded", text, alpha)
+	}
+	clock.Advance(statusFade)
+	if text, alpha := vm.Status(); text != "" || alpha != 0 {
+		t.Errorf("Status after fading = %q, %v; want gone", text, alpha)
+	}
+
+	// A new message starts the fade over
+	store.Dispatch(counterstore.ResetAction{})
+	store.Dispatch(counterstore.SetStatusAction{Text: "again"})
+	if text, alpha := vm.Status(); text != "again" || alpha != 1 {
+		t.Errorf("Status after a new message = %q, %v", text, alpha)
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"gio-redux-example/counterstore"
+)
+
+// Stopwatch reports whether the count is accumulating elapsed milliseconds
+func (v *ViewModel) Stopwatch() bool {
+	return v.store.GetStateFast().UI.Stopwatch
+}
+
+// StopwatchTime formats the count as stopwatch time
+func (v *ViewModel) StopwatchTime() string {
+	return counterstore.FormatStopwatch(v.store.GetStateFast().Count)
+}
+
+// ToggleStopwatch starts the stopwatch when stopped and stops it when running
+func (v *ViewModel) ToggleStopwatch() {
+	if v.Stopwatch() {
+		v.store.Dispatch(counterstore.StopStopwatchAction{})
+		return
+	}
+	v.store.Dispatch(counterstore.StartStopwatchAction{})
+}
+
+// StopwatchPanel shows the count as stopwatch time with start/stop and reset
+// buttons
+type StopwatchPanel struct {
+	theme       *material.Theme
+	startButton widget.Clickable
+	resetButton widget.Clickable
+}
+
+func (p *StopwatchPanel) Layout(gtx layout.Context, vm *ViewModel) layout.Dimensions {
+	if p.startButton.Clicked(gtx) {
+		vm.ToggleStopwatch()
+	}
+	if p.resetButton.Clicked(gtx) {
+		vm.Reset()
+	}
+	label := "Start"
+	if vm.Stopwatch() {
+		label = "Stop"
+	}
+
+	return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+		layout.Rigid(material.Body1(p.theme, vm.StopwatchTime()).Layout),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(6)}.Layout),
+		layout.Rigid(material.Button(p.theme, &p.startButton, label).Layout),
+		layout.Rigid(layout.Spacer{Width: unit.Dp(6)}.Layout),
+		layout.Rigid(material.Button(p.theme, &p.resetButton, "Reset").Layout),
+	)
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+
+	"gioui.org/app"
+)
+
+// windowTitle formats the count for the OS window title
+func windowTitle(count int) string {
+	return fmt.Sprintf("Counter: %d", count)
+}
+
+// titleBinding keeps the window title in line with State.Count. The event
+// loop applies it on frames where Store.Dirty reports a change, rather than
+// on every store notification.
+type titleBinding struct {
+	current string
+}
+
+// apply sets the window title for count if it is out of date; call it from
+// the window's event loop
+func (b *titleBinding) apply(w *app.Window, count int) {
+	if title, ok := b.update(count); ok {
+		w.Option(app.Title(title))
+	}
+}
+
+// update records the title for count, returning it and true when it differs
+// from the one last applied
+func (b *titleBinding) update(count int) (string, bool) {
+	title := windowTitle(count)
+	if title == b.current {
+		return "", false
+	}
+	b.current = title
+	return title, true
+}
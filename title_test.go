@@ -0,0 +1,38 @@
+package main
+
+import "testing"
+
+func TestWindowTitle(t *testing.T) {
+	tests := []struct {
+		count int
+		want  string
+	}{
+		{0, "Counter: 0"},
+		{7, "Counter: 7"},
+		{-12, "Counter: -12"},
+	}
+	for _, tt := range tests {
+		if got := windowTitle(tt.count); got != tt.want {
+			t.Errorf("windowTitle(%d) = %q, want %q", tt.count, got, tt.want)
+		}
+	}
+}
+
+func TestTitleBindingSkipsRedundantUpdates(t *testing.T) {
+	var b titleBinding
+	var applied []string
+	for _, count := range []int{3, 3, 4, 4, 4, 3} {
+		if title, ok := b.update(count); ok {
+			applied = append(applied, title)
+		}
+	}
+	want := []string{"Counter: 3", "Counter: 4", "Counter: 3"}
+	if len(applied) != len(want) {
+		t.Fatalf("applied %q, want %q", applied, want)
+	}
+	for i := range want {
+		if applied[i] != want[i] {
+			t.Errorf("update %d applied %q, want %q", i, applied[i], want[i])
+		}
+	}
+}
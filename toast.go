@@ -0,0 +1,55 @@
+package main
+
+import (
+	"image"
+
+	"gioui.org/layout"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget/material"
+
+	"gio-redux-example/counterstore"
+)
+
+// layoutToasts stacks toasts in the bottom-right corner, oldest on top
+func layoutToasts(gtx layout.Context, th *material.Theme, toasts []counterstore.Toast) layout.Dimensions {
+	if len(toasts) == 0 {
+		return layout.Dimensions{}
+	}
+
+	children := make([]layout.FlexChild, 0, 2*len(toasts))
+	for _, toast := range toasts {
+		children = append(children,
+			layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+				return layoutToast(gtx, th, toast.Text)
+			}),
+			layout.Rigid(layout.Spacer{Height: unit.Dp(6)}.Layout),
+		)
+	}
+
+	gtx.Constraints.Min = gtx.Constraints.Max
+	return layout.UniformInset(unit.Dp(12)).Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.SE.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+			gtx.Constraints.Min = image.Point{}
+			return layout.Flex{Axis: layout.Vertical, Alignment: layout.End}.Layout(gtx, children...)
+		})
+	})
+}
+
+func layoutToast(gtx layout.Context, th *material.Theme, text string) layout.Dimensions {
+	return layout.Stack{}.Layout(gtx,
+		layout.Expanded(func(gtx layout.Context) layout.Dimensions {
+			rect := clip.UniformRRect(image.Rectangle{Max: gtx.Constraints.Min}, gtx.Dp(unit.Dp(4)))
+			paint.FillShape(gtx.Ops, th.ContrastBg, rect.Op(gtx.Ops))
+			return layout.Dimensions{Size: gtx.Constraints.Min}
+		}),
+		layout.Stacked(func(gtx layout.Context) layout.Dimensions {
+			return layout.Inset{Top: unit.Dp(6), Bottom: unit.Dp(6), Left: unit.Dp(10), Right: unit.Dp(10)}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+				label := material.Body2(th, text)
+				label.Color = th.ContrastFg
+				return label.Layout(gtx)
+			})
+		}),
+	)
+}
@@ -0,0 +1,46 @@
+package main
+
+import "time"
+
+// displayEase is how long the displayed count takes to catch up with State
+const displayEase = 250 * time.Millisecond
+
+// easeOutCubic maps a time fraction to eased progress, starting fast and
+// settling gently; fractions outside [0, 1] are clamped
+func easeOutCubic(t float64) float64 {
+	switch {
+	case t <= 0:
+		return 0
+	case t >= 1:
+		return 1
+	}
+	inv := 1 - t
+	return 1 - inv*inv*inv
+}
+
+// Tween eases a value from From to To over Duration starting at Start
+type Tween struct {
+	From, To float64
+	Start    time.Time
+	Duration time.Duration
+}
+
+// Value returns the eased value at now
+func (t Tween) Value(now time.Time) float64 {
+	if t.Settled(now) {
+		return t.To
+	}
+	fraction := float64(now.Sub(t.Start)) / float64(t.Duration)
+	return t.From + (t.To-t.From)*easeOutCubic(fraction)
+}
+
+// Settled reports whether the tween has reached To by now
+func (t Tween) Settled(now time.Time) bool {
+	return t.Duration <= 0 || !now.Before(t.Start.Add(t.Duration))
+}
+
+// Retarget starts a new tween towards to from wherever t is at now, so a
+// change arriving mid-animation carries on smoothly instead of jumping
+func (t Tween) Retarget(now time.Time, to float64) Tween {
+	return Tween{From: t.Value(now), To: to, Start: now, Duration: t.Duration}
+}
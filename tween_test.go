@@ -0,0 +1,84 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"gio-redux-example/counterstore"
+)
+
+func TestEaseOutCubic(t *testing.T) {
+	tests := []struct {
+		t, want float64
+	}{
+		{-0.5, 0},
+		{0, 0},
+		{0.25, 0.578125},
+		{0.5, 0.875},
+		{0.75, 0.984375},
+		{1, 1},
+		{1.5, 1},
+	}
+	for _, tt := range tests {
+		if got := easeOutCubic(tt.t); math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("easeOutCubic(%v) = %v, want %v", tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestTween(t *testing.T) {
+	start := time.Unix(0, 0)
+	tw := Tween{From: 0, To: 8, Start: start, Duration: 100 * time.Millisecond}
+
+	if got := tw.Value(start); got != 0 {
+		t.Errorf("Value at start = %v, want 0", got)
+	}
+	if got := tw.Value(start.Add(50 * time.Millisecond)); got != 7 {
+		t.Errorf("Value halfway = %v, want 7", got)
+	}
+	if tw.Settled(start.Add(99 * time.Millisecond)) {
+		t.Error("Settled before the duration elapsed")
+	}
+	if !tw.Settled(start.Add(100*time.Millisecond)) || tw.Value(start.Add(time.Second)) != 8 {
+		t.Error("not settled on To after the duration")
+	}
+
+	// Retargeting mid-way carries on from the current value
+	next := tw.Retarget(start.Add(50*time.Millisecond), 10)
+	if next.From != 7 || next.To != 10 || next.Duration != tw.Duration {
+		t.Errorf("Retarget = %+v", next)
+	}
+	if (Tween{To: 3}).Value(start) != 3 {
+		t.Error("a zero-duration tween is not settled on To")
+	}
+}
+
+func TestViewModelDisplayValue(t *testing.T) {
+	clock := counterstore.NewFakeClock(time.Unix(0, 0))
+	vm, store := newTestViewModel(counterstore.State{Count: 2})
+	vm.clock = clock
+
+	if got := vm.DisplayValue(); got != 2 || !vm.DisplaySettled() {
+		t.Fatalf("initial DisplayValue = %v, settled %v", got, vm.DisplaySettled())
+	}
+
+	store.Dispatch(counterstore.SetCountAction{Value: 10})
+	if got := vm.DisplayValue(); got != 2 {
+		t.Errorf("DisplayValue right after the change = %v, want 2", got)
+	}
+	if vm.DisplaySettled() {
+		t.Error("settled right after the change")
+	}
+	clock.Advance(displayEase / 2)
+	if got := vm.DisplayValue(); got != 9 {
+		t.Errorf("DisplayValue halfway = %v, want 9", got)
+	}
+	clock.Advance(displayEase / 2)
+	if got := vm.DisplayValue(); got != 10 || !vm.DisplaySettled() {
+		t.Errorf("DisplayValue at the end = %v, settled %v", got, vm.DisplaySettled())
+	}
+	if got := store.GetState().Count; got != 10 {
+		t.Errorf("Count in State = %d, want 10", got)
+	}
+}
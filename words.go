@@ -0,0 +1,63 @@
+package main
+
+import "strings"
+
+var (
+	smallWords = []string{
+		"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+		"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen",
+		"seventeen", "eighteen", "nineteen",
+	}
+	tensWords  = []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+	scaleWords = []string{"", "thousand", "million", "billion", "trillion", "quadrillion", "quintillion"}
+)
+
+// numberWords spells n out in English, e.g. "negative twelve" or
+// "one thousand two hundred thirty-four", covering the whole int range
+func numberWords(n int) string {
+	if n == 0 {
+		return smallWords[0]
+	}
+
+	// Work on the magnitude as uint64 so math.MinInt does not overflow
+	magnitude := uint64(n)
+	if n < 0 {
+		magnitude = -magnitude
+	}
+
+	var groups []string
+	for scale := 0; magnitude > 0; scale++ {
+		if group := magnitude % 1000; group > 0 {
+			words := hundredsWords(int(group))
+			if scaleWords[scale] != "" {
+				words += " " + scaleWords[scale]
+			}
+			groups = append([]string{words}, groups...)
+		}
+		magnitude /= 1000
+	}
+
+	words := strings.Join(groups, " ")
+	if n < 0 {
+		return "negative " + words
+	}
+	return words
+}
+
+// hundredsWords spells out 1 to 999
+func hundredsWords(n int) string {
+	var parts []string
+	if n >= 100 {
+		parts = append(parts, smallWords[n/100]+" hundred")
+		n %= 100
+	}
+	switch {
+	case n >= 20 && n%10 != 0:
+		parts = append(parts, tensWords[n/10]+"-"+smallWords[n%10])
+	case n >= 20:
+		parts = append(parts, tensWords[n/10])
+	case n > 0:
+		parts = append(parts, smallWords[n])
+	}
+	return strings.Join(parts, " ")
+}
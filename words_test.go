@@ -0,0 +1,52 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"gio-redux-example/counterstore"
+)
+
+func TestNumberWords(t *testing.T) {
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{0, "zero"},
+		{1, "one"},
+		{-1, "negative one"},
+		{-12, "negative twelve"},
+		{19, "nineteen"},
+		{20, "twenty"},
+		{21, "twenty-one"},
+		{99, "ninety-nine"},
+		{100, "one hundred"},
+		{101, "one hundred one"},
+		{110, "one hundred ten"},
+		{999, "nine hundred ninety-nine"},
+		{1000, "one thousand"},
+		{1001, "one thousand one"},
+		{1234, "one thousand two hundred thirty-four"},
+		{1000000, "one million"},
+		{2000300, "two million three hundred"},
+		{-7654321, "negative seven million six hundred fifty-four thousand three hundred twenty-one"},
+		{math.MaxInt64, "nine quintillion two hundred twenty-three quadrillion three hundred seventy-two trillion thirty-six billion eight hundred fifty-four million seven hundred seventy-five thousand eight hundred seven"},
+		{math.MinInt64, "negative nine quintillion two hundred twenty-three quadrillion three hundred seventy-two trillion thirty-six billion eight hundred fifty-four million seven hundred seventy-five thousand eight hundred eight"},
+	}
+	for _, tt := range tests {
+		if got := numberWords(tt.n); got != tt.want {
+			t.Errorf("numberWords(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestViewModelWords(t *testing.T) {
+	vm, store := newTestViewModel(counterstore.State{Count: -12})
+	if got := vm.Words(); got != "negative twelve" {
+		t.Errorf("Words = %q", got)
+	}
+	store.Dispatch(counterstore.SetCountAction{Value: 20})
+	if got := vm.Words(); got != "twenty" {
+		t.Errorf("Words after SetCount = %q", got)
+	}
+}